@@ -43,7 +43,7 @@ func TestNewConfiguration(t *testing.T) {
 			warnings: nil,
 			errors: []string{
 				"identity_validation: reset_password: option 'jwt_secret' is required when the reset password functionality isn't disabled",
-				"authentication_backend: you must ensure either the 'file' or 'ldap' authentication backend is configured",
+				"authentication_backend: you must ensure one of the 'file', 'ldap', or 'sql' authentication backend is configured",
 				"access_control: 'default_policy' option 'deny' is invalid: when no rules are specified it must be 'two_factor' or 'one_factor'",
 				"session: option 'cookies' is required",
 				"storage: option 'encryption_key' is required",
@@ -14,7 +14,7 @@ func ProvidersStartupCheck(ctx Context, log bool) (err error) {
 }
 
 // ServiceRunAll runs all services given a context.
-func ServiceRunAll(ctx Context) (err error) {
+func ServiceRunAll(ctx ServiceContext) (err error) {
 	if ctx == nil {
 		return fmt.Errorf("no context provided")
 	}
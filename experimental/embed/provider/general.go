@@ -39,11 +39,12 @@ func NewAuthorizer(config *schema.Configuration) *authorization.Authorizer {
 	return authorization.NewAuthorizer(config)
 }
 
-// NewSession creates a new *session.Provider given a valid configuration.
+// NewSession creates a new *session.Provider given a valid configuration. The storage is only required when the
+// storage session provider is configured.
 //
 // Warning: This method may panic if the provided configuration isn't validated.
-func NewSession(config *schema.Configuration, caCertPool *x509.CertPool) *session.Provider {
-	return session.NewProvider(config.Session, caCertPool)
+func NewSession(config *schema.Configuration, caCertPool *x509.CertPool, storage storage.SessionDataProvider) *session.Provider {
+	return session.NewProvider(config.Session, caCertPool, storage)
 }
 
 // NewRegulator creates a new *regulation.Regulator given a valid configuration.
@@ -0,0 +1,231 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ChainUserProvider is a provider which routes each user to the first backend in an ordered chain which owns them.
+type ChainUserProvider struct {
+	links    []chainUserProviderLink
+	backends map[string]UserProvider
+}
+
+type chainUserProviderLink struct {
+	name      string
+	provider  UserProvider
+	usernames []regexp.Regexp
+	domains   []string
+}
+
+// NewChainUserProvider creates a new instance of ChainUserProvider given the chain configuration and the providers
+// for each of the configured backends keyed by their name.
+func NewChainUserProvider(config []schema.AuthenticationBackendChain, backends map[string]UserProvider) (p *ChainUserProvider) {
+	p = &ChainUserProvider{
+		links:    make([]chainUserProviderLink, len(config)),
+		backends: backends,
+	}
+
+	for i, link := range config {
+		p.links[i] = chainUserProviderLink{
+			name:      link.Backend,
+			provider:  backends[link.Backend],
+			usernames: link.Usernames,
+			domains:   make([]string, len(link.Domains)),
+		}
+
+		for j, domain := range link.Domains {
+			p.links[i].domains[j] = "@" + strings.ToLower(strings.TrimPrefix(domain, "@"))
+		}
+	}
+
+	return p
+}
+
+// Backend returns the provider for a configured backend given its name.
+func (p *ChainUserProvider) Backend(name string) (provider UserProvider) {
+	return p.backends[name]
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *ChainUserProvider) StartupCheck() (err error) {
+	for _, link := range p.links {
+		if link.provider == nil {
+			return fmt.Errorf("error initializing the chained authentication backend: the '%s' backend is not configured", link.name)
+		}
+
+		if err = link.provider.StartupCheck(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close implements the UserProvider interface.
+func (p *ChainUserProvider) Close() (err error) {
+	errs := make([]error, 0, len(p.links))
+
+	for _, link := range p.links {
+		if link.provider == nil {
+			continue
+		}
+
+		if err = link.provider.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckUserPassword checks if provided password matches for the given user using the backend which owns the user.
+func (p *ChainUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return false, err
+	}
+
+	return provider.CheckUserPassword(username, password)
+}
+
+// CheckUserPasswordWithStatus checks if provided password matches for the given user using the backend which owns the
+// user, and reports the password status if that backend is able to provide one.
+func (p *ChainUserProvider) CheckUserPasswordWithStatus(username string, password string) (valid bool, status *PasswordStatus, err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return false, nil, err
+	}
+
+	if statusProvider, ok := provider.(PasswordStatusUserProvider); ok {
+		return statusProvider.CheckUserPasswordWithStatus(username, password)
+	}
+
+	valid, err = provider.CheckUserPassword(username, password)
+
+	return valid, nil, err
+}
+
+// GetDetails retrieves the details of the given user from the backend which owns the user.
+func (p *ChainUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return nil, err
+	}
+
+	return provider.GetDetails(username)
+}
+
+// GetDetailsExtended retrieves the extended details of the given user from the backend which owns the user.
+func (p *ChainUserProvider) GetDetailsExtended(username string) (details *UserDetailsExtended, err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return nil, err
+	}
+
+	return provider.GetDetailsExtended(username)
+}
+
+// UpdatePassword updates the password of the given user in the backend which owns the user.
+func (p *ChainUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return err
+	}
+
+	return provider.UpdatePassword(username, newPassword)
+}
+
+// ChangePassword changes the password of the given user in the backend which owns the user.
+func (p *ChainUserProvider) ChangePassword(username string, oldPassword string, newPassword string) (err error) {
+	var provider UserProvider
+
+	if provider, err = p.owner(username); err != nil {
+		return err
+	}
+
+	return provider.ChangePassword(username, oldPassword, newPassword)
+}
+
+// GetGroups implements the GroupsUserProvider interface by merging the groups of every backend in the chain.
+func (p *ChainUserProvider) GetGroups() (groups []string, err error) {
+	visited := map[string]struct{}{}
+
+	for _, link := range p.links {
+		if _, ok := visited[link.name]; ok {
+			continue
+		}
+
+		visited[link.name] = struct{}{}
+
+		lister, ok := link.provider.(GroupsUserProvider)
+		if !ok {
+			return nil, fmt.Errorf("the '%s' backend does not support listing groups", link.name)
+		}
+
+		var values []string
+
+		if values, err = lister.GetGroups(); err != nil {
+			return nil, fmt.Errorf("error occurred getting the groups from the '%s' backend: %w", link.name, err)
+		}
+
+		for _, group := range values {
+			if !utils.IsStringInSlice(group, groups) {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups, nil
+}
+
+func (p *ChainUserProvider) owner(username string) (provider UserProvider, err error) {
+	for _, link := range p.links {
+		if link.matches(username) {
+			return link.provider, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no authentication backend owns the user '%s'", ErrUserNotFound, username)
+}
+
+func (l chainUserProviderLink) matches(username string) bool {
+	if len(l.usernames) == 0 && len(l.domains) == 0 {
+		return true
+	}
+
+	for _, pattern := range l.usernames {
+		if pattern.MatchString(username) {
+			return true
+		}
+	}
+
+	lower := strings.ToLower(username)
+
+	for _, domain := range l.domains {
+		if strings.HasSuffix(lower, domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	_ UserProvider               = (*ChainUserProvider)(nil)
+	_ PasswordStatusUserProvider = (*ChainUserProvider)(nil)
+	_ GroupsUserProvider         = (*ChainUserProvider)(nil)
+)
@@ -0,0 +1,239 @@
+package authentication
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+type chainTestUserProvider struct {
+	name    string
+	calls   []string
+	startup error
+	closed  bool
+}
+
+func (p *chainTestUserProvider) StartupCheck() (err error) {
+	return p.startup
+}
+
+func (p *chainTestUserProvider) CheckUserPassword(username string, _ string) (valid bool, err error) {
+	p.calls = append(p.calls, "CheckUserPassword:"+username)
+
+	return true, nil
+}
+
+func (p *chainTestUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	p.calls = append(p.calls, "GetDetails:"+username)
+
+	return &UserDetails{Username: username, DisplayName: p.name}, nil
+}
+
+func (p *chainTestUserProvider) GetDetailsExtended(username string) (details *UserDetailsExtended, err error) {
+	p.calls = append(p.calls, "GetDetailsExtended:"+username)
+
+	return &UserDetailsExtended{UserDetails: &UserDetails{Username: username, DisplayName: p.name}}, nil
+}
+
+func (p *chainTestUserProvider) UpdatePassword(username string, _ string) (err error) {
+	p.calls = append(p.calls, "UpdatePassword:"+username)
+
+	return nil
+}
+
+func (p *chainTestUserProvider) ChangePassword(username string, _ string, _ string) (err error) {
+	p.calls = append(p.calls, "ChangePassword:"+username)
+
+	return nil
+}
+
+func (p *chainTestUserProvider) Close() (err error) {
+	p.closed = true
+
+	return nil
+}
+
+func TestChainUserProviderRouting(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   []schema.AuthenticationBackendChain
+		username string
+		expected string
+		err      string
+	}{
+		{
+			"ShouldRouteUsernamePatternToFirstBackend",
+			[]schema.AuthenticationBackendChain{
+				{Backend: BackendFile, Usernames: []regexp.Regexp{*regexp.MustCompile(`^svc-`)}},
+				{Backend: BackendLDAP},
+			},
+			"svc-backup",
+			BackendFile,
+			"",
+		},
+		{
+			"ShouldRouteUnmatchedUserToCatchAll",
+			[]schema.AuthenticationBackendChain{
+				{Backend: BackendFile, Usernames: []regexp.Regexp{*regexp.MustCompile(`^svc-`)}},
+				{Backend: BackendLDAP},
+			},
+			"john",
+			BackendLDAP,
+			"",
+		},
+		{
+			"ShouldRouteDomainSuffixCaseInsensitive",
+			[]schema.AuthenticationBackendChain{
+				{Backend: BackendFile, Domains: []string{"example.com"}},
+				{Backend: BackendLDAP, Domains: []string{"corp.example.com"}},
+			},
+			"John@CORP.example.com",
+			BackendLDAP,
+			"",
+		},
+		{
+			"ShouldNotMatchPartialDomain",
+			[]schema.AuthenticationBackendChain{
+				{Backend: BackendFile, Domains: []string{"example.com"}},
+			},
+			"john@badexample.com",
+			"",
+			"user not found: no authentication backend owns the user 'john@badexample.com'",
+		},
+		{
+			"ShouldReturnNotFoundWithoutOwner",
+			[]schema.AuthenticationBackendChain{
+				{Backend: BackendFile, Usernames: []regexp.Regexp{*regexp.MustCompile(`^svc-`)}},
+			},
+			"john",
+			"",
+			"user not found: no authentication backend owns the user 'john'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backends := map[string]*chainTestUserProvider{
+				BackendFile: {name: BackendFile},
+				BackendLDAP: {name: BackendLDAP},
+			}
+
+			provider := NewChainUserProvider(tc.config, map[string]UserProvider{
+				BackendFile: backends[BackendFile],
+				BackendLDAP: backends[BackendLDAP],
+			})
+
+			details, err := provider.GetDetails(tc.username)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.True(t, errors.Is(err, ErrUserNotFound))
+				assert.Nil(t, details)
+
+				_, err = provider.CheckUserPassword(tc.username, "password")
+				assert.EqualError(t, err, tc.err)
+
+				assert.EqualError(t, provider.UpdatePassword(tc.username, "password"), tc.err)
+				assert.EqualError(t, provider.ChangePassword(tc.username, "old", "new"), tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, details.DisplayName)
+
+			valid, err := provider.CheckUserPassword(tc.username, "password")
+			assert.NoError(t, err)
+			assert.True(t, valid)
+
+			extended, err := provider.GetDetailsExtended(tc.username)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, extended.DisplayName)
+
+			assert.NoError(t, provider.UpdatePassword(tc.username, "password"))
+			assert.NoError(t, provider.ChangePassword(tc.username, "old", "new"))
+
+			assert.Len(t, backends[tc.expected].calls, 5)
+
+			for name, backend := range backends {
+				if name != tc.expected {
+					assert.Empty(t, backend.calls)
+				}
+			}
+		})
+	}
+}
+
+type chainTestGroupsUserProvider struct {
+	*chainTestUserProvider
+
+	groups []string
+	err    error
+}
+
+func (p *chainTestGroupsUserProvider) GetGroups() (groups []string, err error) {
+	return p.groups, p.err
+}
+
+func TestChainUserProviderGetGroups(t *testing.T) {
+	file := &chainTestGroupsUserProvider{chainTestUserProvider: &chainTestUserProvider{name: BackendFile}, groups: []string{"dev", "admins"}}
+	ldap := &chainTestGroupsUserProvider{chainTestUserProvider: &chainTestUserProvider{name: BackendLDAP}, groups: []string{"users", "dev"}}
+
+	provider := NewChainUserProvider([]schema.AuthenticationBackendChain{
+		{Backend: BackendFile, Domains: []string{"example.com"}},
+		{Backend: BackendLDAP, Domains: []string{"example.org"}},
+		{Backend: BackendFile},
+	}, map[string]UserProvider{BackendFile: file, BackendLDAP: ldap})
+
+	groups, err := provider.GetGroups()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins", "dev", "users"}, groups)
+
+	ldap.err = errors.New("bad connection")
+
+	groups, err = provider.GetGroups()
+
+	assert.EqualError(t, err, "error occurred getting the groups from the 'ldap' backend: bad connection")
+	assert.Nil(t, groups)
+
+	provider = NewChainUserProvider([]schema.AuthenticationBackendChain{
+		{Backend: BackendFile, Domains: []string{"example.com"}},
+		{Backend: BackendSQL},
+	}, map[string]UserProvider{BackendFile: file, BackendSQL: &chainTestUserProvider{name: BackendSQL}})
+
+	groups, err = provider.GetGroups()
+
+	assert.EqualError(t, err, "the 'sql' backend does not support listing groups")
+	assert.Nil(t, groups)
+}
+
+func TestChainUserProviderStartupCheckAndClose(t *testing.T) {
+	file, ldap := &chainTestUserProvider{name: BackendFile}, &chainTestUserProvider{name: BackendLDAP}
+
+	provider := NewChainUserProvider([]schema.AuthenticationBackendChain{
+		{Backend: BackendFile, Domains: []string{"example.com"}},
+		{Backend: BackendLDAP},
+	}, map[string]UserProvider{BackendFile: file, BackendLDAP: ldap})
+
+	assert.NoError(t, provider.StartupCheck())
+	assert.Equal(t, file, provider.Backend(BackendFile))
+	assert.Nil(t, provider.Backend(BackendSQL))
+
+	ldap.startup = errors.New("bad connection")
+
+	assert.EqualError(t, provider.StartupCheck(), "bad connection")
+
+	assert.NoError(t, provider.Close())
+	assert.True(t, file.closed)
+	assert.True(t, ldap.closed)
+
+	provider = NewChainUserProvider([]schema.AuthenticationBackendChain{{Backend: BackendSQL}}, map[string]UserProvider{})
+
+	assert.EqualError(t, provider.StartupCheck(), "error initializing the chained authentication backend: the 'sql' backend is not configured")
+}
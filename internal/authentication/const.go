@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"errors"
+	"fmt"
 
 	"golang.org/x/text/encoding/unicode"
 )
@@ -75,13 +76,52 @@ const (
 	//
 	// OID Reference: https://oidref.com/1.2.840.113556.1.4.2066
 	ldapOIDControlMsftServerPolicyHintsDeprecated = "1.2.840.113556.1.4.2066"
+
+	// LDAP Control OID: Password Policy for LDAP Directories.
+	//
+	// See the linked documents for more information.
+	//
+	// Draft: https://datatracker.ietf.org/doc/html/draft-behera-ldap-password-policy-10
+	//
+	// OID Reference: https://oidref.com/1.3.6.1.4.1.42.2.27.8.5.1
+	ldapOIDControlPasswordPolicy = "1.3.6.1.4.1.42.2.27.8.5.1"
 )
 
 const (
-	ldapAttributeUnicodePwd   = "unicodePwd"
-	ldapAttributeUserPassword = "userPassword"
+	ldapAttributeUnicodePwd         = "unicodePwd"
+	ldapAttributeUserPassword       = "userPassword"
+	ldapAttributeUserAccountControl = "userAccountControl"
+	ldapAttributePwdLastSet         = "pwdLastSet"
+	ldapAttributeShadowLastChange   = "shadowLastChange"
 )
 
+const (
+	ldapUserAccountControlAccountDisable = 0x2
+)
+
+const (
+	// ldapActiveDirectoryBindDataPasswordMustChange is the Active Directory bind diagnostic data value which indicates
+	// the credentials were valid but the user must change their password before they can bind.
+	ldapActiveDirectoryBindDataPasswordMustChange = "data 773"
+)
+
+// ldapPasswordPolicyDiagnostics maps the diagnostic messages returned by servers which do not respond with the password
+// policy control to the error they represent. The messages are matched case-insensitively and include the wording used
+// by OpenLDAP, 389 Directory Server / FreeIPA, and Samba Active Directory Domain Controllers.
+var ldapPasswordPolicyDiagnostics = []struct {
+	message string
+	err     error
+}{
+	{"password is too short", ErrPasswordTooShort},
+	{"password must be at least", ErrPasswordTooShort},
+	{"password is too young to change", ErrPasswordTooYoung},
+	{"within password minimum age", ErrPasswordTooYoung},
+	{"password is in history", ErrPasswordInHistory},
+	{"password in history", ErrPasswordInHistory},
+	{"password was already used", ErrPasswordInHistory},
+	{"password has expired", ErrPasswordExpired},
+}
+
 const (
 	ldapBaseObjectFilter = "(objectClass=*)"
 )
@@ -122,6 +162,9 @@ var (
 	// ErrUserNotFound indicates the user wasn't found in the authentication backend.
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrUserDisabled indicates the user was found in the authentication backend but their account is disabled.
+	ErrUserDisabled = errors.New("user is disabled")
+
 	// ErrWatcherNoContent is returned when the file is empty.
 	ErrWatcherNoContent = errors.New("no file content")
 
@@ -137,6 +180,20 @@ var (
 	// ErrPasswordWeak is returned when the password provided does not meet the password policy requirements.
 	ErrPasswordWeak = errors.New("your supplied password does not meet the password policy requirements")
 
+	// ErrPasswordTooShort is returned when the server side password policy rejects the password as too short.
+	ErrPasswordTooShort = fmt.Errorf("%w: the password is too short", ErrPasswordWeak)
+
+	// ErrPasswordInHistory is returned when the server side password policy rejects the password as it's in the password
+	// history of the user.
+	ErrPasswordInHistory = fmt.Errorf("%w: the password is in the password history", ErrPasswordWeak)
+
+	// ErrPasswordTooYoung is returned when the server side password policy rejects the change as the current password was
+	// changed too recently.
+	ErrPasswordTooYoung = errors.New("the password was changed too recently to be changed again")
+
+	// ErrPasswordExpired is returned when the server side password policy indicates the password has expired.
+	ErrPasswordExpired = errors.New("the password has expired")
+
 	// ErrAuthenticationFailed is returned when authentication of a user fails.
 	ErrAuthenticationFailed = errors.New("authentication failed")
 
@@ -157,6 +214,13 @@ var (
 	encodingUTF16LittleEndian = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
 )
 
+// Authentication backend names.
+const (
+	BackendFile = "file"
+	BackendLDAP = "ldap"
+	BackendSQL  = "sql"
+)
+
 // Attribute value type strings.
 const (
 	ValueTypeString  = "string"
@@ -28,6 +28,9 @@ type FileUserProvider struct {
 	database      FileUserProviderDatabase
 	mutex         sync.Mutex
 	timeoutReload time.Time
+
+	// current contains the encoded digests which are known to match the configured hash parameters.
+	current map[string]struct{}
 }
 
 // NewFileUserProvider creates a new instance of FileUserProvider.
@@ -36,6 +39,7 @@ func NewFileUserProvider(config *schema.AuthenticationBackendFile) (provider *Fi
 		config:        config,
 		timeoutReload: time.Now().Add(-1 * time.Second),
 		database:      NewFileUserDatabase(config.Path, config.Search.Email, config.Search.CaseInsensitive, getExtra(config)),
+		current:       map[string]struct{}{},
 	}
 }
 
@@ -82,19 +86,118 @@ func (p *FileUserProvider) Close() (err error) {
 	return nil
 }
 
-// CheckUserPassword checks if provided password matches for the given user.
+// CheckUserPassword checks if provided password matches for the given user. If the password matches but the stored
+// digest was not produced with the currently configured hash parameters the password is rehashed and saved.
 func (p *FileUserProvider) CheckUserPassword(username string, password string) (match bool, err error) {
 	var details FileUserDatabaseUserDetails
 
+	if match, details, err = p.checkUserPassword(username, password); err != nil || !match {
+		return match, err
+	}
+
+	p.rehashUserPassword(details, password)
+
+	return true, nil
+}
+
+func (p *FileUserProvider) checkUserPassword(username string, password string) (match bool, details FileUserDatabaseUserDetails, err error) {
 	if details, err = p.database.GetUserDetails(username); err != nil {
-		return false, err
+		return false, details, err
 	}
 
 	if details.Disabled {
-		return false, ErrUserNotFound
+		return false, details, ErrUserDisabled
+	}
+
+	if match, err = details.Password.MatchAdvanced(password); err != nil {
+		return false, details, err
+	}
+
+	return match, details, nil
+}
+
+// rehashUserPassword replaces the digest of a user after a successful password check when the digest does not match
+// the configured hash parameters. Failures are logged but never fail the authentication attempt.
+func (p *FileUserProvider) rehashUserPassword(details FileUserDatabaseUserDetails, password string) {
+	if p.hash == nil || !details.Password.Valid() {
+		return
+	}
+
+	encoded := details.Password.Encode()
+
+	if !p.isRehashRequired(details.Password, encoded, password) {
+		return
+	}
+
+	log := logging.Logger().WithField("username", details.Username)
+
+	digest, err := p.hash.Hash(password)
+	if err != nil {
+		log.WithError(err).Warn("Error occurred rehashing the password of user with the configured password hashing parameters")
+
+		return
+	}
+
+	// The mutex is held for the entire update so the write can't race with a reload triggered by the file watcher.
+	p.mutex.Lock()
+
+	defer p.mutex.Unlock()
+
+	var current FileUserDatabaseUserDetails
+
+	if current, err = p.database.GetUserDetails(details.Username); err != nil || !current.Password.Valid() || current.Password.Encode() != encoded {
+		log.Debug("Skipping the rehash of the password of user as the user was modified while the password was being rehashed")
+
+		return
+	}
+
+	current.Password = schema.NewPasswordDigest(digest)
+
+	p.database.SetUserDetails(current.Username, &current)
+
+	p.setTimeoutReload(time.Now())
+
+	if err = p.database.Save(); err != nil {
+		log.WithError(err).Warn("Error occurred saving the authentication database after rehashing the password of user")
+
+		return
+	}
+
+	p.current[digest.Encode()] = struct{}{}
+
+	log.Info("Successfully rehashed the password of user with the configured password hashing parameters")
+}
+
+// isRehashRequired returns true if the digest wasn't produced with the configured hash parameters. This is determined
+// by hashing the password using the salt from the digest and comparing the result, which is then cached so this only
+// happens once per digest. As reusing the salt hides a change to the salt length it's compared separately.
+func (p *FileUserProvider) isRehashRequired(digest *schema.PasswordDigest, encoded, password string) (required bool) {
+	p.mutex.Lock()
+
+	_, ok := p.current[encoded]
+
+	p.mutex.Unlock()
+
+	if ok {
+		return false
+	}
+
+	if length := getFileCryptoSaltLength(p.config.Password); length != 0 && len(digest.Salt()) != length {
+		return true
+	}
+
+	candidate, err := p.hash.HashWithSalt(password, digest.Salt())
+	if err != nil || candidate.Encode() != encoded {
+		return true
 	}
 
-	return details.Password.MatchAdvanced(password)
+	p.mutex.Lock()
+
+	p.current[encoded] = struct{}{}
+
+	p.mutex.Unlock()
+
+	return false
 }
 
 // GetDetails retrieve the groups a user belongs to.
@@ -106,7 +209,7 @@ func (p *FileUserProvider) GetDetails(username string) (details *UserDetails, er
 	}
 
 	if d.Disabled {
-		return nil, ErrUserNotFound
+		return nil, ErrUserDisabled
 	}
 
 	return d.ToUserDetails(), nil
@@ -121,12 +224,17 @@ func (p *FileUserProvider) GetDetailsExtended(username string) (details *UserDet
 	}
 
 	if d.Disabled {
-		return nil, ErrUserNotFound
+		return nil, ErrUserDisabled
 	}
 
 	return d.ToExtendedUserDetails(), nil
 }
 
+// GetGroups implements the GroupsUserProvider interface.
+func (p *FileUserProvider) GetGroups() (groups []string, err error) {
+	return p.database.GetGroups(), nil
+}
+
 // UpdatePassword update the password of the given user.
 func (p *FileUserProvider) UpdatePassword(username string, newPassword string) (err error) {
 	var details FileUserDatabaseUserDetails
@@ -136,7 +244,7 @@ func (p *FileUserProvider) UpdatePassword(username string, newPassword string) (
 	}
 
 	if details.Disabled {
-		return ErrUserNotFound
+		return ErrUserDisabled
 	}
 
 	var digest algorithm.Digest
@@ -145,13 +253,17 @@ func (p *FileUserProvider) UpdatePassword(username string, newPassword string) (
 		return err
 	}
 
+	now := time.Now()
+
 	details.Password = schema.NewPasswordDigest(digest)
+	details.PasswordLastChanged = &now
+	details.PasswordChangeRequired = false
 
 	p.database.SetUserDetails(details.Username, &details)
 
 	p.mutex.Lock()
 
-	p.setTimeoutReload(time.Now())
+	p.setTimeoutReload(now)
 
 	p.mutex.Unlock()
 
@@ -171,7 +283,7 @@ func (p *FileUserProvider) ChangePassword(username string, oldPassword string, n
 	}
 
 	if details.Disabled {
-		return ErrUserNotFound
+		return ErrUserDisabled
 	}
 
 	if strings.TrimSpace(newPassword) == "" {
@@ -182,7 +294,7 @@ func (p *FileUserProvider) ChangePassword(username string, oldPassword string, n
 		return ErrPasswordWeak
 	}
 
-	oldPasswordCorrect, err := p.CheckUserPassword(username, oldPassword)
+	oldPasswordCorrect, _, err := p.checkUserPassword(username, oldPassword)
 	if err != nil {
 		return ErrAuthenticationFailed
 	}
@@ -197,13 +309,17 @@ func (p *FileUserProvider) ChangePassword(username string, oldPassword string, n
 		return fmt.Errorf("%w : %v", ErrOperationFailed, err)
 	}
 
+	now := time.Now()
+
 	details.Password = schema.NewPasswordDigest(digest)
+	details.PasswordLastChanged = &now
+	details.PasswordChangeRequired = false
 
 	p.database.SetUserDetails(details.Username, &details)
 
 	p.mutex.Lock()
 
-	p.setTimeoutReload(time.Now())
+	p.setTimeoutReload(now)
 
 	p.mutex.Unlock()
 
@@ -226,6 +342,12 @@ func (p *FileUserProvider) StartupCheck() (err error) {
 		return err
 	}
 
+	p.mutex.Lock()
+
+	p.current = map[string]struct{}{}
+
+	p.mutex.Unlock()
+
 	if p.database == nil {
 		p.database = NewFileUserDatabase(p.config.Path, p.config.Search.Email, p.config.Search.CaseInsensitive, getExtra(p.config))
 	}
@@ -290,6 +412,23 @@ func NewFileCryptoHashFromConfig(config schema.AuthenticationBackendFilePassword
 	return hash, nil
 }
 
+// getFileCryptoSaltLength returns the configured salt length of the algorithm, or 0 if the algorithm has a fixed salt
+// length.
+func getFileCryptoSaltLength(config schema.AuthenticationBackendFilePassword) (length int) {
+	switch config.Algorithm {
+	case hashArgon2, "":
+		return config.Argon2.SaltLength
+	case hashSHA2Crypt:
+		return config.SHA2Crypt.SaltLength
+	case hashPBKDF2:
+		return config.PBKDF2.SaltLength
+	case hashScrypt:
+		return config.Scrypt.SaltLength
+	default:
+		return 0
+	}
+}
+
 func checkDatabase(path string) (err error) {
 	if _, err = os.Stat(path); os.IsNotExist(err) {
 		if err = os.WriteFile(path, userYAMLTemplate, 0600); err != nil {
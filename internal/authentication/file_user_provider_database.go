@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/go-crypt/crypt"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/expression"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // FileUserProviderDatabase is the interface implemented by the file user provider databases.
@@ -23,6 +26,7 @@ type FileUserProviderDatabase interface {
 	Load() (err error)
 	GetUserDetails(username string) (user FileUserDatabaseUserDetails, err error)
 	SetUserDetails(username string, details *FileUserDatabaseUserDetails)
+	GetGroups() (groups []string)
 }
 
 // NewFileUserDatabase creates a new FileUserDatabase.
@@ -194,6 +198,25 @@ func (m *FileUserDatabase) GetUserDetails(username string) (user FileUserDatabas
 	return user, ErrUserNotFound
 }
 
+// GetGroups returns the sorted list of unique groups which are assigned to at least one user.
+func (m *FileUserDatabase) GetGroups() (groups []string) {
+	m.RLock()
+
+	defer m.RUnlock()
+
+	for _, details := range m.Users {
+		for _, group := range details.Groups {
+			if !utils.IsStringInSlice(group, groups) {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups
+}
+
 // SetUserDetails sets the FileUserDatabaseUserDetails for a given user.
 func (m *FileUserDatabase) SetUserDetails(username string, details *FileUserDatabaseUserDetails) {
 	if details == nil {
@@ -207,6 +230,22 @@ func (m *FileUserDatabase) SetUserDetails(username string, details *FileUserData
 	m.Users[username] = *details
 }
 
+// DeleteUserDetails removes the FileUserDatabaseUserDetails for a given user where the username must be the users actual
+// username.
+func (m *FileUserDatabase) DeleteUserDetails(username string) (err error) {
+	m.Lock()
+
+	defer m.Unlock()
+
+	if _, ok := m.Users[username]; !ok {
+		return ErrUserNotFound
+	}
+
+	delete(m.Users, username)
+
+	return nil
+}
+
 // ToDatabaseModel converts the FileUserDatabase into the FileDatabaseModel for saving.
 func (m *FileUserDatabase) ToDatabaseModel() (model *FileDatabaseModel) {
 	model = &FileDatabaseModel{
@@ -246,6 +285,9 @@ type FileUserDatabaseUserDetails struct {
 	Groups         []string               `json:"groups" jsonschema:"title=Groups" jsonschema_description:"The groups list for the user."`
 	Disabled       bool                   `json:"disabled" jsonschema:"default=false,title=Disabled" jsonschema_description:"The disabled status for the user."`
 
+	PasswordLastChanged    *time.Time `json:"password_last_changed,omitempty" jsonschema:"title=Password Last Changed" jsonschema_description:"The time the password for the user was last changed."`
+	PasswordChangeRequired bool       `json:"password_change_required" jsonschema:"default=false,title=Password Change Required" jsonschema_description:"Requires the user to change their password at next login."`
+
 	Address *FileUserDatabaseUserDetailsAddressModel `json:"address,omitempty" jsonschema:"title=Address" jsonschema_description:"The address for the user."`
 
 	Extra map[string]any `json:"extra" jsonschema:"title=Extra" jsonschema_description:"The extra attributes for the user."`
@@ -268,12 +310,19 @@ func (m FileUserDatabaseUserDetails) ToUserDetails() (details *UserDetails) {
 		emails = append(emails, m.Email)
 	}
 
-	return &UserDetails{
-		Username:    m.Username,
-		DisplayName: m.DisplayName,
-		Emails:      emails,
-		Groups:      m.Groups,
+	details = &UserDetails{
+		Username:               m.Username,
+		DisplayName:            m.DisplayName,
+		Emails:                 emails,
+		Groups:                 m.Groups,
+		PasswordChangeRequired: m.PasswordChangeRequired,
 	}
+
+	if m.PasswordLastChanged != nil {
+		details.PasswordLastChanged = *m.PasswordLastChanged
+	}
+
+	return details
 }
 
 // ToExtendedUserDetails converts FileUserDatabaseUserDetails into a *UserDetailsExtended.
@@ -328,6 +377,9 @@ func (m FileUserDatabaseUserDetails) ToUserDetailsModel() (model FileDatabaseUse
 		Groups:         m.Groups,
 		Address:        m.Address,
 		Extra:          m.Extra,
+
+		PasswordLastChanged:    m.PasswordLastChanged,
+		PasswordChangeRequired: m.PasswordChangeRequired,
 	}
 
 	if m.Website != nil {
@@ -441,6 +493,9 @@ type FileDatabaseUserDetailsModel struct {
 	PhoneExtension string   `yaml:"phone_extension"`
 	Disabled       bool     `yaml:"disabled"`
 
+	PasswordLastChanged    *time.Time `yaml:"password_last_changed,omitempty"`
+	PasswordChangeRequired bool       `yaml:"password_change_required"`
+
 	Address *FileUserDatabaseUserDetailsAddressModel `yaml:"address"`
 
 	Extra map[string]any `yaml:"extra"`
@@ -536,6 +591,9 @@ func (m FileDatabaseUserDetailsModel) ToDatabaseUserDetailsModel(username string
 		Groups:         m.Groups,
 		Address:        m.Address,
 		Extra:          m.Extra,
+
+		PasswordLastChanged:    m.PasswordLastChanged,
+		PasswordChangeRequired: m.PasswordChangeRequired,
 	}
 
 	if m.Website != "" {
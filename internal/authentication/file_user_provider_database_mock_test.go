@@ -38,6 +38,20 @@ func (m *MockFileUserDatabase) EXPECT() *MockFileUserDatabaseMockRecorder {
 	return m.recorder
 }
 
+// GetGroups mocks base method.
+func (m *MockFileUserDatabase) GetGroups() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroups")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetGroups indicates an expected call of GetGroups.
+func (mr *MockFileUserDatabaseMockRecorder) GetGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockFileUserDatabase)(nil).GetGroups))
+}
+
 // GetUserDetails mocks base method.
 func (m *MockFileUserDatabase) GetUserDetails(username string) (FileUserDatabaseUserDetails, error) {
 	m.ctrl.T.Helper()
@@ -217,6 +217,32 @@ func TestFileUserDatabaseGetUserDetails(t *testing.T) {
 	}
 }
 
+func TestFileUserDatabaseDeleteUserDetails(t *testing.T) {
+	database := NewFileUserDatabase("", false, false, nil)
+
+	database.SetUserDetails("john", &FileUserDatabaseUserDetails{Username: "john", DisplayName: "John Doe"})
+
+	assert.ErrorIs(t, database.DeleteUserDetails("harry"), ErrUserNotFound)
+	assert.NoError(t, database.DeleteUserDetails("john"))
+	assert.ErrorIs(t, database.DeleteUserDetails("john"), ErrUserNotFound)
+
+	_, err := database.GetUserDetails("john")
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestFileUserDatabaseGetGroups(t *testing.T) {
+	database := NewFileUserDatabase("", false, false, nil)
+
+	assert.Nil(t, database.GetGroups())
+
+	database.SetUserDetails("john", &FileUserDatabaseUserDetails{Username: "john", Groups: []string{"dev", "admins"}})
+	database.SetUserDetails("harry", &FileUserDatabaseUserDetails{Username: "harry", Groups: []string{"dev"}})
+	database.SetUserDetails("bob", &FileUserDatabaseUserDetails{Username: "bob"})
+
+	assert.Equal(t, []string{"admins", "dev"}, database.GetGroups())
+}
+
 func TestFileUserDatabaseShouldNotDeadlockOnSave(t *testing.T) {
 	const (
 		concurrency = 8
@@ -284,6 +284,41 @@ func TestShouldRetrieveUserDetails(t *testing.T) {
 	})
 }
 
+func TestShouldRetrieveUserDetailsPasswordAge(t *testing.T) {
+	WithDatabase(t, UserDatabaseContentPasswordAge, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		provider := NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		details, err := provider.GetDetails("john")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), details.PasswordLastChanged)
+		assert.False(t, details.PasswordChangeRequired)
+
+		details, err = provider.GetDetails("harry")
+		require.NoError(t, err)
+		assert.True(t, details.PasswordLastChanged.IsZero())
+		assert.True(t, details.PasswordChangeRequired)
+
+		before := time.Now().Add(-time.Second)
+
+		require.NoError(t, provider.UpdatePassword("harry", "newpassword"))
+
+		// Reset the provider to force a read from disk.
+		provider = NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		details, err = provider.GetDetails("harry")
+		require.NoError(t, err)
+		assert.True(t, details.PasswordLastChanged.After(before))
+		assert.False(t, details.PasswordChangeRequired)
+	})
+}
+
 func TestShouldErrOnUserDetailsNoUser(t *testing.T) {
 	WithDatabase(t, UserDatabaseContent, func(path string) {
 		config := DefaultFileAuthenticationBackendConfiguration
@@ -299,11 +334,11 @@ func TestShouldErrOnUserDetailsNoUser(t *testing.T) {
 
 		details, err = provider.GetDetails("dis")
 		assert.Nil(t, details)
-		assert.Equal(t, err, ErrUserNotFound)
+		assert.Equal(t, err, ErrUserDisabled)
 
 		extended, err := provider.GetDetailsExtended("dis")
 		assert.Nil(t, extended)
-		assert.Equal(t, err, ErrUserNotFound)
+		assert.Equal(t, err, ErrUserDisabled)
 	})
 }
 
@@ -401,7 +436,138 @@ func TestShouldErrOnUpdatePasswordNoUser(t *testing.T) {
 		assert.NoError(t, provider.StartupCheck())
 
 		assert.Equal(t, provider.UpdatePassword("nousers", "newpassword"), ErrUserNotFound)
-		assert.Equal(t, provider.UpdatePassword("dis", "example"), ErrUserNotFound)
+		assert.Equal(t, provider.UpdatePassword("dis", "example"), ErrUserDisabled)
+	})
+}
+
+func TestShouldRehashPasswordOnSuccessfulCheck(t *testing.T) {
+	WithDatabase(t, UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		provider := NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		ok, err := provider.CheckUserPassword("harry", "wrong_password")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		db := NewFileUserDatabase(path, false, false, nil)
+		require.NoError(t, db.Load())
+
+		assert.True(t, strings.HasPrefix(db.Users["harry"].Password.Encode(), "$6$"))
+
+		ok, err = provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, db.Load())
+
+		encoded := db.Users["harry"].Password.Encode()
+
+		assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=3,p=4$"))
+		assert.True(t, db.Users["harry"].Password.Match("password"))
+
+		ok, err = provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, db.Load())
+
+		assert.Equal(t, encoded, db.Users["harry"].Password.Encode())
+	})
+}
+
+func TestShouldNotRehashPasswordWhenParametersMatch(t *testing.T) {
+	WithDatabase(t, UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		config.Password.Algorithm = "sha2crypt"
+		config.Password.SHA2Crypt.Iterations = 500000
+
+		provider := NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		db := NewFileUserDatabase(path, false, false, nil)
+		require.NoError(t, db.Load())
+
+		encoded := db.Users["harry"].Password.Encode()
+
+		ok, err := provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, db.Load())
+
+		assert.Equal(t, encoded, db.Users["harry"].Password.Encode())
+	})
+}
+
+func TestShouldRehashPasswordWhenSaltLengthDiffers(t *testing.T) {
+	WithDatabase(t, UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		config.Password.Algorithm = "sha2crypt"
+		config.Password.SHA2Crypt.Iterations = 500000
+		config.Password.SHA2Crypt.SaltLength = 8
+
+		provider := NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		db := NewFileUserDatabase(path, false, false, nil)
+		require.NoError(t, db.Load())
+
+		encoded := db.Users["harry"].Password.Encode()
+
+		assert.Len(t, db.Users["harry"].Password.Salt(), 16)
+
+		ok, err := provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, db.Load())
+
+		assert.NotEqual(t, encoded, db.Users["harry"].Password.Encode())
+		assert.True(t, strings.HasPrefix(db.Users["harry"].Password.Encode(), "$6$rounds=500000$"))
+		assert.Len(t, db.Users["harry"].Password.Salt(), 8)
+		assert.True(t, db.Users["harry"].Password.Match("password"))
+	})
+}
+
+func TestShouldNotFailCheckWhenRehashSaveFails(t *testing.T) {
+	WithDatabase(t, UserDatabaseContent, func(path string) {
+		db := NewFileUserDatabase(path, false, false, nil)
+		assert.NoError(t, db.Load())
+
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		provider := NewFileUserProvider(&config)
+
+		assert.NoError(t, provider.StartupCheck())
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mock := NewMockFileUserDatabase(ctrl)
+
+		provider.database = mock
+
+		details, _ := db.GetUserDetails("harry")
+
+		gomock.InOrder(
+			mock.EXPECT().GetUserDetails("harry").Return(details, nil),
+			mock.EXPECT().GetUserDetails("harry").Return(details, nil),
+			mock.EXPECT().SetUserDetails("harry", gomock.Any()),
+			mock.EXPECT().Save().Return(fmt.Errorf("failed to mock save")),
+		)
+
+		ok, err := provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
 	})
 }
 
@@ -433,6 +599,12 @@ func TestFileUserProviderShouldNotDeadlockOnUpdatePassword(t *testing.T) {
 		}
 	}
 
+	check := func(provider *FileUserProvider) {
+		for i := 0; i < iterations; i++ {
+			_, _ = provider.CheckUserPassword("john", "apple123")
+		}
+	}
+
 	testCases := []struct {
 		name    string
 		workers []func(provider *FileUserProvider)
@@ -449,6 +621,10 @@ func TestFileUserProviderShouldNotDeadlockOnUpdatePassword(t *testing.T) {
 			"ShouldNotDeadlockWithConcurrentUpdatesReloadsAndReads",
 			[]func(provider *FileUserProvider){update, reload, details},
 		},
+		{
+			"ShouldNotDeadlockWithConcurrentUpdatesReloadsAndChecks",
+			[]func(provider *FileUserProvider){update, reload, check},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -523,7 +699,7 @@ func TestShouldChangePassword(t *testing.T) {
 			"dis",
 			"password",
 			"newpassword",
-			"user not found",
+			"user is disabled",
 		},
 		{
 			"ShouldErrEmptyNewPassword",
@@ -735,7 +911,7 @@ func TestShouldNotAllowLoginOfDisabledUsers(t *testing.T) {
 		ok, err := provider.CheckUserPassword("dis", "password")
 
 		assert.False(t, ok)
-		assert.EqualError(t, err, "user not found")
+		assert.EqualError(t, err, "user is disabled")
 	})
 }
 
@@ -1138,6 +1314,25 @@ users:
       - dev
 `)
 
+var UserDatabaseContentPasswordAge = []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "{CRYPT}$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+    email: john.doe@authelia.com
+    password_last_changed: 2024-01-02T03:04:05Z
+    groups:
+      - admins
+      - dev
+
+  harry:
+    displayname: "Harry Potter"
+    password: "{CRYPT}$6$rounds=500000$jgiCMRyGXzoqpxS3$w2pJeZnnH8bwW3zzvoMWtTRfQYsHbWbD/hquuQ5vUeIyl9gdwBIt6RWk2S6afBA0DPakbeWgD/4SZPiS0hYtU/"
+    email: harry.potter@authelia.com
+    password_change_required: true
+    groups: []
+`)
+
 var UserDatabaseContentExtra = []byte(`
 users:
   john:
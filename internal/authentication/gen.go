@@ -8,3 +8,4 @@ package authentication
 //go:generate mockgen -write_package_comment=false -package authentication -destination ldap_client_factory_mock_test.go -mock_names LDAPClientFactory=MockLDAPClientFactory github.com/authelia/authelia/v4/internal/authentication LDAPClientFactory
 //go:generate mockgen -write_package_comment=false -package authentication -destination file_user_provider_database_mock_test.go -mock_names FileUserProviderDatabase=MockFileUserDatabase github.com/authelia/authelia/v4/internal/authentication FileUserProviderDatabase
 //go:generate mockgen -write_package_comment=false -package authentication -destination file_user_provider_hash_mock_test.go -mock_names Hash=MockHash github.com/go-crypt/crypt/algorithm Hash
+//go:generate mockgen -write_package_comment=false -package authentication -destination sql_user_provider_storage_mock_test.go -mock_names AuthenticationUserProvider=MockSQLUserStorage github.com/authelia/authelia/v4/internal/storage AuthenticationUserProvider
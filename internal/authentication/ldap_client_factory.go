@@ -577,6 +577,8 @@ func ldapDialBindOpts(log *logrus.Entry, config *schema.AuthenticationBackendLDA
 	switch {
 	case options.Password == "" && options.PermitUnauthenticatedBind:
 		err = client.UnauthenticatedBind(options.Username)
+	case options.PasswordPolicy != nil && client.Discovery().Controls.PwdPolicy:
+		err = ldapBindPasswordPolicy(client, options.Username, options.Password, options.PasswordPolicy)
 	default:
 		err = client.Bind(options.Username, options.Password)
 	}
@@ -589,3 +591,19 @@ func ldapDialBindOpts(log *logrus.Entry, config *schema.AuthenticationBackendLDA
 
 	return client, nil
 }
+
+func ldapBindPasswordPolicy(client LDAPExtendedClient, username, password string, policy *ldap.ControlBeheraPasswordPolicy) (err error) {
+	var result *ldap.SimpleBindResult
+
+	request := ldap.NewSimpleBindRequest(username, password, []ldap.Control{ldap.NewControlBeheraPasswordPolicy()})
+
+	result, err = client.SimpleBind(request)
+
+	if result != nil {
+		if control := getControlPasswordPolicy(result.Controls); control != nil {
+			*policy = *control
+		}
+	}
+
+	return err
+}
@@ -1,5 +1,9 @@
 package authentication
 
+import (
+	"github.com/go-ldap/ldap/v3"
+)
+
 // LDAPClientFactoryOptions represents the options used when obtaining an LDAP client.
 type LDAPClientFactoryOptions struct {
 	Address  string
@@ -7,6 +11,9 @@ type LDAPClientFactoryOptions struct {
 	Password string
 
 	PermitUnauthenticatedBind bool
+
+	// PasswordPolicy receives the password policy response control from the bind when the server supports it.
+	PasswordPolicy *ldap.ControlBeheraPasswordPolicy
 }
 
 // LDAPClientFactoryOption is a function which configures the LDAPClientFactoryOptions.
@@ -39,3 +46,11 @@ func WithPermitUnauthenticatedBind(permit bool) func(*LDAPClientFactoryOptions)
 		settings.PermitUnauthenticatedBind = permit
 	}
 }
+
+// WithPasswordPolicy returns an LDAPClientFactoryOption which requests the password policy control during the bind
+// when the server supports it, and stores the response control in the provided value.
+func WithPasswordPolicy(policy *ldap.ControlBeheraPasswordPolicy) func(*LDAPClientFactoryOptions) {
+	return func(settings *LDAPClientFactoryOptions) {
+		settings.PasswordPolicy = policy
+	}
+}
@@ -1,7 +1,11 @@
 package authentication
 
 import (
+	"errors"
+	"strings"
+
 	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
 )
 
 type controlMsftServerPolicyHints struct {
@@ -34,3 +38,70 @@ func (c *controlMsftServerPolicyHints) Encode() (packet *ber.Packet) {
 func (c *controlMsftServerPolicyHints) String() string {
 	return "Enforce the password history length constraint (MS-SAMR section 3.1.1.7.1) during password set: " + c.GetControlType()
 }
+
+// getControlPasswordPolicy returns the password policy response control from a list of response controls if present.
+func getControlPasswordPolicy(controls []ldap.Control) (control *ldap.ControlBeheraPasswordPolicy) {
+	for _, c := range controls {
+		if policy, ok := c.(*ldap.ControlBeheraPasswordPolicy); ok {
+			return policy
+		}
+	}
+
+	return nil
+}
+
+// getControlsFromError decodes the response controls from the packet of an *ldap.Error. This is necessary as the
+// client does not decode the response controls of unsuccessful modify or extended operations.
+func getControlsFromError(err error) (controls []ldap.Control) {
+	var e *ldap.Error
+
+	if !errors.As(err, &e) || e.Packet == nil || len(e.Packet.Children) < 3 {
+		return nil
+	}
+
+	for _, child := range e.Packet.Children[2].Children {
+		control, errDecode := ldap.DecodeControl(child)
+		if errDecode != nil {
+			continue
+		}
+
+		controls = append(controls, control)
+	}
+
+	return controls
+}
+
+// getPasswordPolicyError returns the error which represents the server side password policy violation described by
+// the password policy response control or the diagnostic message of an error, or nil if it doesn't describe one.
+func getPasswordPolicyError(err error) error {
+	if policy := getControlPasswordPolicy(getControlsFromError(err)); policy != nil {
+		switch policy.Error {
+		case ldap.BeheraPasswordTooShort:
+			return ErrPasswordTooShort
+		case ldap.BeheraPasswordInHistory:
+			return ErrPasswordInHistory
+		case ldap.BeheraPasswordTooYoung:
+			return ErrPasswordTooYoung
+		case ldap.BeheraPasswordExpired:
+			return ErrPasswordExpired
+		case ldap.BeheraInsufficientPasswordQuality:
+			return ErrPasswordWeak
+		}
+	}
+
+	var e *ldap.Error
+
+	if !errors.As(err, &e) || e.Err == nil {
+		return nil
+	}
+
+	message := strings.ToLower(e.Err.Error())
+
+	for _, diagnostic := range ldapPasswordPolicyDiagnostics {
+		if strings.Contains(message, diagnostic.message) {
+			return diagnostic.err
+		}
+	}
+
+	return nil
+}
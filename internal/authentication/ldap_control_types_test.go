@@ -1,9 +1,15 @@
 package authentication
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestControlMsftServerPolicyHints(t *testing.T) {
@@ -15,3 +21,169 @@ func TestControlMsftServerPolicyHints(t *testing.T) {
 	assert.Equal(t, "Enforce the password history length constraint (MS-SAMR section 3.1.1.7.1) during password set: 1.2.840.113556.1.4.2239", ct.String())
 	assert.NotNil(t, ct.Encode())
 }
+
+func TestGetPasswordPolicyError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     error
+		expected error
+	}{
+		{
+			"ShouldMapControlTooShort",
+			newTestPasswordPolicyError(t, ldap.BeheraPasswordTooShort, "Password fails quality checking policy"),
+			ErrPasswordTooShort,
+		},
+		{
+			"ShouldMapControlInHistory",
+			newTestPasswordPolicyError(t, ldap.BeheraPasswordInHistory, "Password fails quality checking policy"),
+			ErrPasswordInHistory,
+		},
+		{
+			"ShouldMapControlTooYoung",
+			newTestPasswordPolicyError(t, ldap.BeheraPasswordTooYoung, "Password fails quality checking policy"),
+			ErrPasswordTooYoung,
+		},
+		{
+			"ShouldMapControlExpired",
+			newTestPasswordPolicyError(t, ldap.BeheraPasswordExpired, ""),
+			ErrPasswordExpired,
+		},
+		{
+			"ShouldMapControlInsufficientQuality",
+			newTestPasswordPolicyError(t, ldap.BeheraInsufficientPasswordQuality, "Password fails quality checking policy"),
+			ErrPasswordWeak,
+		},
+		{
+			"ShouldFallbackToDiagnosticWhenControlHasOtherError",
+			newTestPasswordPolicyError(t, ldap.BeheraPasswordModNotAllowed, "Password is in history of old passwords"),
+			ErrPasswordInHistory,
+		},
+		{
+			"ShouldMapDiagnosticSambaTooShort",
+			&ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("0000052D: Constraint violation - check_password_restrictions: the password is too short. It should be equal or longer than 7 characters!")},
+			ErrPasswordTooShort,
+		},
+		{
+			"ShouldMapDiagnosticSambaInHistory",
+			&ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("0000052D: Constraint violation - check_password_restrictions: the password was already used (in history)!")},
+			ErrPasswordInHistory,
+		},
+		{
+			"ShouldMapDiagnosticOpenLDAPTooYoung",
+			&ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("Password is too young to change")},
+			ErrPasswordTooYoung,
+		},
+		{
+			"ShouldMapDiagnostic389MinimumLength",
+			&ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("invalid password syntax - password must be at least 8 characters long")},
+			ErrPasswordTooShort,
+		},
+		{
+			"ShouldMapWrappedError",
+			fmt.Errorf("error occurred: %w", &ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("password in history")}),
+			ErrPasswordInHistory,
+		},
+		{
+			"ShouldNotMapUnknownDiagnostic",
+			&ldap.Error{ResultCode: ldap.LDAPResultConstraintViolation, Err: errors.New("0000052D: SvcErr: DSID-031A126C, problem 5003 (WILL_NOT_PERFORM), data 0")},
+			nil,
+		},
+		{
+			"ShouldNotMapOtherErrors",
+			errors.New("connection reset"),
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := getPasswordPolicyError(tc.have)
+
+			if tc.expected == nil {
+				assert.NoError(t, actual)
+			} else {
+				assert.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestShouldPasswordPolicyErrorsBeWeakWhereApplicable(t *testing.T) {
+	assert.ErrorIs(t, ErrPasswordTooShort, ErrPasswordWeak)
+	assert.ErrorIs(t, ErrPasswordInHistory, ErrPasswordWeak)
+	assert.NotErrorIs(t, ErrPasswordTooYoung, ErrPasswordWeak)
+	assert.NotErrorIs(t, ErrPasswordExpired, ErrPasswordWeak)
+}
+
+func TestLDAPBindPasswordPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	defer ctrl.Finish()
+
+	client := NewMockLDAPClient(ctrl)
+
+	client.EXPECT().
+		SimpleBind(gomock.Any()).
+		DoAndReturn(func(request *ldap.SimpleBindRequest) (*ldap.SimpleBindResult, error) {
+			require.Len(t, request.Controls, 1)
+			assert.Equal(t, ldapOIDControlPasswordPolicy, request.Controls[0].GetControlType())
+			assert.Equal(t, "cn=john,dc=example,dc=com", request.Username)
+
+			return &ldap.SimpleBindResult{Controls: []ldap.Control{&ldap.ControlBeheraPasswordPolicy{Expire: -1, Grace: 2, Error: -1}}}, nil
+		})
+
+	policy := ldap.NewControlBeheraPasswordPolicy()
+
+	assert.NoError(t, ldapBindPasswordPolicy(client, "cn=john,dc=example,dc=com", "password", policy))
+	assert.Equal(t, int64(2), policy.Grace)
+
+	client.EXPECT().
+		SimpleBind(gomock.Any()).
+		Return(&ldap.SimpleBindResult{Controls: []ldap.Control{&ldap.ControlBeheraPasswordPolicy{Expire: -1, Grace: -1, Error: ldap.BeheraPasswordExpired}}}, &ldap.Error{ResultCode: ldap.LDAPResultInvalidCredentials, Err: errors.New("")})
+
+	policy = ldap.NewControlBeheraPasswordPolicy()
+
+	assert.Error(t, ldapBindPasswordPolicy(client, "cn=john,dc=example,dc=com", "password", policy))
+	assert.Equal(t, int8(ldap.BeheraPasswordExpired), policy.Error)
+
+	client.EXPECT().
+		SimpleBind(gomock.Any()).
+		Return(nil, errors.New("network error"))
+
+	policy = ldap.NewControlBeheraPasswordPolicy()
+
+	assert.EqualError(t, ldapBindPasswordPolicy(client, "cn=john,dc=example,dc=com", "password", policy), "network error")
+	assert.Equal(t, int64(-1), policy.Grace)
+	assert.Equal(t, int8(-1), policy.Error)
+}
+
+func newTestPasswordPolicyError(t *testing.T, code int8, message string) error {
+	value := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "PasswordPolicyResponseValue")
+
+	field := ber.Encode(ber.ClassContext, ber.TypePrimitive, 1, nil, "error")
+	field.Data.WriteByte(byte(code))
+
+	value.AppendChild(field)
+
+	control := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	control.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ldapOIDControlPasswordPolicy, "Control Type"))
+	control.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, string(value.Bytes()), "Control Value"))
+
+	controls := ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+	controls.AppendChild(control)
+
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationModifyResponse, nil, "Modify Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(ldap.LDAPResultConstraintViolation), "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(1), "Message ID"))
+	envelope.AppendChild(response)
+	envelope.AppendChild(controls)
+
+	packet, err := ber.DecodePacketErr(envelope.Bytes())
+	require.NoError(t, err)
+
+	return ldap.GetLDAPError(packet)
+}
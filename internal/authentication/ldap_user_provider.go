@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -82,13 +83,21 @@ func NewLDAPUserProviderWithFactory(config *schema.AuthenticationBackendLDAP, di
 
 // CheckUserPassword checks if provided password matches for the given user.
 func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	valid, _, err = p.CheckUserPasswordWithStatus(username, password)
+
+	return valid, err
+}
+
+// CheckUserPasswordWithStatus checks if provided password matches for the given user and reports the password status
+// from the password policy response control when the server provides one.
+func (p *LDAPUserProvider) CheckUserPasswordWithStatus(username string, password string) (valid bool, status *PasswordStatus, err error) {
 	var (
 		client, uclient LDAPExtendedClient
 		profile         *ldapUserProfile
 	)
 
 	if client, err = p.factory.GetClient(WithPermitUnauthenticatedBind(p.config.PermitUnauthenticatedBind)); err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	defer func() {
@@ -98,11 +107,24 @@ func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (
 	}()
 
 	if profile, err = p.getUserProfile(client, username); err != nil {
-		return false, err
+		return false, nil, err
 	}
 
-	if uclient, err = p.factory.GetClient(WithUsername(profile.DN), WithPassword(password)); err != nil {
-		return false, fmt.Errorf("authentication failed. Cause: %w", err)
+	policy := ldap.NewControlBeheraPasswordPolicy()
+
+	if uclient, err = p.factory.GetClient(WithUsername(profile.DN), WithPassword(password), WithPasswordPolicy(policy)); err != nil {
+		// Active Directory refuses to bind users who must change their password even though the credentials are valid,
+		// this is only treated as a successful check when the profile reflects the required change so the user is
+		// forced through the password change flow.
+		if profile.PasswordChangeRequired && isPasswordMustChangeBindError(err) {
+			return true, nil, nil
+		}
+
+		if policy.Error == ldap.BeheraPasswordExpired {
+			return false, nil, fmt.Errorf("authentication failed. Cause: %w: %w", ErrPasswordExpired, err)
+		}
+
+		return false, nil, fmt.Errorf("authentication failed. Cause: %w", err)
 	}
 
 	defer func() {
@@ -111,7 +133,11 @@ func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (
 		}
 	}()
 
-	return true, nil
+	if policy.Grace >= 0 {
+		status = &PasswordStatus{GraceLoginsRemaining: int(policy.Grace)}
+	}
+
+	return true, status, nil
 }
 
 // GetDetails retrieve the groups a user belongs to.
@@ -144,10 +170,12 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 	}
 
 	return &UserDetails{
-		Username:    profile.Username,
-		DisplayName: profile.DisplayName,
-		Emails:      profile.Emails,
-		Groups:      groups,
+		Username:               profile.Username,
+		DisplayName:            profile.DisplayName,
+		Emails:                 profile.Emails,
+		Groups:                 groups,
+		PasswordLastChanged:    profile.PasswordLastChanged,
+		PasswordChangeRequired: profile.PasswordChangeRequired,
 	}, nil
 }
 
@@ -192,10 +220,12 @@ func (p *LDAPUserProvider) GetDetailsExtended(username string) (details *UserDet
 		PhoneExtension: profile.PhoneExtension,
 		Address:        profile.Address,
 		UserDetails: &UserDetails{
-			Username:    profile.Username,
-			DisplayName: profile.DisplayName,
-			Emails:      profile.Emails,
-			Groups:      groups,
+			Username:               profile.Username,
+			DisplayName:            profile.DisplayName,
+			Emails:                 profile.Emails,
+			Groups:                 groups,
+			PasswordLastChanged:    profile.PasswordLastChanged,
+			PasswordChangeRequired: profile.PasswordChangeRequired,
 		},
 		Extra: profile.Extra,
 	}
@@ -240,6 +270,49 @@ func (p *LDAPUserProvider) GetDetailsExtended(username string) (details *UserDet
 	return details, nil
 }
 
+// GetGroups implements the GroupsUserProvider interface. The groups are found by performing a group search with every
+// placeholder of the groups filter which refers to a specific user replaced by a wildcard.
+func (p *LDAPUserProvider) GetGroups() (groups []string, err error) {
+	var client LDAPExtendedClient
+
+	if client, err = p.factory.GetClient(WithPermitUnauthenticatedBind(p.config.PermitUnauthenticatedBind)); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := p.factory.ReleaseClient(client); err != nil {
+			p.log.WithError(err).Warn("Error occurred releasing the LDAP client")
+		}
+	}()
+
+	request := ldap.NewSearchRequest(
+		p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, p.resolveGroupsFilterAll(), p.groupsAttributes, nil,
+	)
+
+	p.log.
+		WithField("base_dn", request.BaseDN).
+		WithField("filter", request.Filter).
+		WithField("attributes", request.Attributes).
+		Trace("Performing search for all groups")
+
+	var result *ldap.SearchResult
+
+	if result, err = p.search(client, request); err != nil {
+		return nil, fmt.Errorf("unable to retrieve groups. Cause: %w", err)
+	}
+
+	for _, entry := range result.Entries {
+		if group := p.getUserGroupFromEntry(entry); len(group) != 0 && !utils.IsStringInSlice(group, groups) {
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups, nil
+}
+
 // UpdatePassword update the password of the given user.
 func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error) {
 	var (
@@ -262,6 +335,10 @@ func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error)
 	}
 
 	if err = p.setPassword(client, profile, username, "", password); err != nil {
+		if errPolicy := getPasswordPolicyError(err); errPolicy != nil {
+			return fmt.Errorf("unable to update password. Cause: %w: %w", errPolicy, err)
+		}
+
 		return fmt.Errorf("unable to update password. Cause: %w", err)
 	}
 
@@ -291,6 +368,10 @@ func (p *LDAPUserProvider) ChangePassword(username, oldPassword string, newPassw
 
 	userPasswordOk, err := p.CheckUserPassword(username, oldPassword)
 	if err != nil {
+		if errors.Is(err, ErrPasswordExpired) {
+			return err
+		}
+
 		errorCode := getLDAPResultCode(err)
 		if errorCode == ldap.LDAPResultInvalidCredentials {
 			return ErrIncorrectPassword
@@ -308,6 +389,10 @@ func (p *LDAPUserProvider) ChangePassword(username, oldPassword string, newPassw
 	}
 
 	if err = p.setPassword(client, profile, username, oldPassword, newPassword); err != nil {
+		if errPolicy := getPasswordPolicyError(err); errPolicy != nil {
+			return fmt.Errorf("%w: %v", errPolicy, err)
+		}
+
 		if errorCode := getLDAPResultCode(err); errorCode != -1 {
 			switch errorCode {
 			case ldap.LDAPResultInvalidCredentials,
@@ -339,6 +424,10 @@ func (p *LDAPUserProvider) setPassword(client LDAPExtendedClient, profile *ldapU
 		controls = append(controls, &controlMsftServerPolicyHints{ldapOIDControlMsftServerPolicyHintsDeprecated})
 	}
 
+	if client.Discovery().Controls.PwdPolicy {
+		controls = append(controls, ldap.NewControlBeheraPasswordPolicy())
+	}
+
 	switch {
 	case p.config.Implementation == schema.LDAPImplementationActiveDirectory:
 		var value string
@@ -505,6 +594,18 @@ func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, entry
 		return nil, fmt.Errorf("user '%s' must have a distinguished name but the result returned an empty distinguished name", username)
 	}
 
+	if isDisabledFromEntry(entry, p.config.Attributes.Disabled) {
+		return nil, ErrUserDisabled
+	}
+
+	if userProfile.PasswordLastChanged, userProfile.PasswordChangeRequired, err = getPasswordLastChangedFromEntry(entry, p.config.Attributes.PasswordLastChanged); err != nil {
+		return nil, fmt.Errorf("user '%s' has an invalid value for attribute '%s': %w", username, p.config.Attributes.PasswordLastChanged, err)
+	}
+
+	if isPasswordChangeRequiredFromEntry(entry, p.config.Attributes.PasswordChangeRequired) {
+		userProfile.PasswordChangeRequired = true
+	}
+
 	return &userProfile, nil
 }
 
@@ -616,6 +717,8 @@ func (p *LDAPUserProvider) getUserGroups(client LDAPExtendedClient, username str
 		return p.getUserGroupsRequestFilter(client, username, profile, request)
 	case "memberof":
 		return p.getUserGroupsRequestMemberOf(client, username, profile, request)
+	case "recursive":
+		return p.getUserGroupsRequestRecursive(client, username, profile, request)
 	default:
 		return nil, fmt.Errorf("could not perform group search with mode '%s' as it's unknown", p.config.GroupSearchMode)
 	}
@@ -672,6 +775,90 @@ func (p *LDAPUserProvider) getUserGroupsRequestMemberOf(client LDAPExtendedClien
 	return groups, nil
 }
 
+func (p *LDAPUserProvider) getUserGroupsRequestRecursive(client LDAPExtendedClient, username string, _ *ldapUserProfile, request *ldap.SearchRequest) (groups []string, err error) {
+	var result *ldap.SearchResult
+
+	if result, err = p.search(client, request); err != nil {
+		return nil, fmt.Errorf("unable to retrieve groups of user '%s'. Cause: %w", username, err)
+	}
+
+	visited := map[string]struct{}{}
+
+	groups, pending := p.getUserGroupsFromEntriesRecursive(result.Entries, visited, groups)
+
+	for depth := 1; len(pending) != 0; depth++ {
+		if depth > p.config.GroupSearchMaxDepth {
+			p.log.
+				WithField("username", username).
+				WithField("max_depth", p.config.GroupSearchMaxDepth).
+				WithField("mode", "recursive").
+				Debug("Skipping the remaining parent group searches as the maximum depth has been reached")
+
+			break
+		}
+
+		var next []*ldap.Entry
+
+		for _, entry := range pending {
+			group := p.getUserGroupFromEntry(entry)
+
+			request = ldap.NewSearchRequest(
+				p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+				0, 0, false, p.resolveGroupsFilter(group, &ldapUserProfile{DN: entry.DN, Username: group}), p.groupsAttributes, nil,
+			)
+
+			p.log.
+				WithField("dn", entry.DN).
+				WithField("filter", request.Filter).
+				WithField("depth", depth).
+				WithField("mode", "recursive").
+				Trace("Performing parent group search")
+
+			if result, err = p.search(client, request); err != nil {
+				return nil, fmt.Errorf("unable to retrieve parent groups of group '%s' for user '%s'. Cause: %w", entry.DN, username, err)
+			}
+
+			var parents []*ldap.Entry
+
+			groups, parents = p.getUserGroupsFromEntriesRecursive(result.Entries, visited, groups)
+
+			next = append(next, parents...)
+		}
+
+		pending = next
+	}
+
+	return groups, nil
+}
+
+// getUserGroupsFromEntriesRecursive appends the names of every group entry which has not already been visited to the
+// groups and returns the newly visited entries so their parents can be resolved. Distinguished names are compared
+// case-insensitively which prevents cyclic group memberships from being expanded more than once.
+func (p *LDAPUserProvider) getUserGroupsFromEntriesRecursive(entries []*ldap.Entry, visited map[string]struct{}, groups []string) (result []string, pending []*ldap.Entry) {
+	for _, entry := range entries {
+		key := strings.ToLower(entry.DN)
+
+		if _, ok := visited[key]; ok {
+			p.log.
+				WithField("dn", entry.DN).
+				WithField("mode", "recursive").
+				Trace("Skipping Group as it has already been resolved")
+
+			continue
+		}
+
+		visited[key] = struct{}{}
+
+		if group := p.getUserGroupFromEntry(entry); len(group) != 0 {
+			groups = append(groups, group)
+		}
+
+		pending = append(pending, entry)
+	}
+
+	return groups, pending
+}
+
 func (p *LDAPUserProvider) getUserGroupFromEntry(entry *ldap.Entry) string {
 attributes:
 	for _, attr := range entry.Attributes {
@@ -777,6 +964,22 @@ func (p *LDAPUserProvider) resolveGroupsFilter(input string, profile *ldapUserPr
 	return filter
 }
 
+// resolveGroupsFilterAll returns the groups filter with the placeholders which refer to a specific user replaced by
+// filters which match any value so the filter matches every group.
+func (p *LDAPUserProvider) resolveGroupsFilterAll() (filter string) {
+	filter = strings.NewReplacer(
+		ldapPlaceholderInput, "*",
+		ldapPlaceholderUsername, "*",
+		ldapPlaceholderDistinguishedName, "*",
+		ldapPlaceholderMemberOfDistinguishedName, "(objectClass=*)",
+		ldapPlaceholderMemberOfRelativeDistinguishedName, "(objectClass=*)",
+	).Replace(p.config.GroupsFilter)
+
+	p.log.Tracef("Computed groups filter for all groups is %s", filter)
+
+	return filter
+}
+
 func (p *LDAPUserProvider) modify(client LDAPExtendedClient, modifyRequest *ldap.ModifyRequest) (err error) {
 	var result *ldap.ModifyResult
 	if result, err = client.ModifyWithResult(modifyRequest); err != nil {
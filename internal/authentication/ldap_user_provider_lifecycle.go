@@ -113,6 +113,21 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		}
 	}
 
+	if len(p.config.Attributes.Disabled) != 0 && !utils.IsStringInSlice(p.config.Attributes.Disabled, p.usersAttributes) {
+		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.Disabled)
+		p.usersAttributesExtended = append(p.usersAttributesExtended, p.config.Attributes.Disabled)
+	}
+
+	if len(p.config.Attributes.PasswordLastChanged) != 0 && !utils.IsStringInSlice(p.config.Attributes.PasswordLastChanged, p.usersAttributes) {
+		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.PasswordLastChanged)
+		p.usersAttributesExtended = append(p.usersAttributesExtended, p.config.Attributes.PasswordLastChanged)
+	}
+
+	if len(p.config.Attributes.PasswordChangeRequired) != 0 && !utils.IsStringInSlice(p.config.Attributes.PasswordChangeRequired, p.usersAttributes) {
+		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.PasswordChangeRequired)
+		p.usersAttributesExtended = append(p.usersAttributesExtended, p.config.Attributes.PasswordChangeRequired)
+	}
+
 	attributesExtended := []string{
 		p.config.Attributes.GivenName,
 		p.config.Attributes.MiddleName,
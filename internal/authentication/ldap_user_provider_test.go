@@ -1800,6 +1800,74 @@ func TestLDAPUserProvider_GetDetails_ShouldReturnOnUserError(t *testing.T) {
 	assert.EqualError(t, err, "cannot find user DN of user 'john'. Cause: failed to search")
 }
 
+func TestLDAPUserProvider_GetDetails_ShouldReturnUserDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &schema.AuthenticationBackendLDAP{
+		Address:  testLDAPAddress,
+		User:     "cn=admin,dc=example,dc=com",
+		Password: "password",
+		Attributes: schema.AuthenticationBackendLDAPAttributes{
+			Username:    "uid",
+			Mail:        "mail",
+			DisplayName: "displayName",
+			MemberOf:    "memberOf",
+			Disabled:    "userAccountControl",
+		},
+		UsersFilter:       "uid={input}",
+		AdditionalUsersDN: "ou=users",
+		BaseDN:            "dc=example,dc=com",
+		PermitReferrals:   true,
+	}
+
+	mockDialer := NewMockLDAPClientDialer(ctrl)
+
+	mockClient := NewMockLDAPClient(ctrl)
+
+	dialURL := mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389", gomock.Any()).Return(mockClient, nil)
+
+	setTimeout := mockClient.EXPECT().SetTimeout(gomock.Eq(time.Second * 0))
+
+	dseSearch := NewRootDSESearchRequest(mockClient, nil)
+
+	provider := NewLDAPUserProviderWithFactory(config, false, NewStandardLDAPClientFactory(config, nil, mockDialer))
+
+	assert.Equal(t, []string{"uid", "mail", "displayName", "memberOf", "userAccountControl"}, provider.usersAttributes)
+
+	clientBind := mockClient.EXPECT().
+		Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+		Return(nil)
+
+	clientClose := mockClient.EXPECT().Close()
+
+	searchProfile := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				{
+					DN: "uid=john,dc=example,dc=com",
+					Attributes: []*ldap.EntryAttribute{
+						{
+							Name:   "uid",
+							Values: []string{"john"},
+						},
+						{
+							Name:   "userAccountControl",
+							Values: []string{"514"},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	gomock.InOrder(dialURL, setTimeout, dseSearch, clientBind, searchProfile, clientClose)
+
+	details, err := provider.GetDetails("john")
+	assert.Nil(t, details)
+	assert.ErrorIs(t, err, ErrUserDisabled)
+}
+
 func TestLDAPUserProvider_GetDetailsExtendedShouldReturnOnBindError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2315,6 +2383,122 @@ func TestShouldReturnUsernameFromLDAPSearchModeMemberOfRDN(t *testing.T) {
 	assert.Equal(t, details.Username, "John")
 }
 
+func TestShouldReturnNestedGroupsFromLDAPSearchModeRecursive(t *testing.T) {
+	testCases := []struct {
+		name     string
+		depth    int
+		expected []string
+	}{
+		{"ShouldResolveAllParentsAndStopOnCycle", 5, []string{"developers", "engineering", "staff"}},
+		{"ShouldStopAtMaximumDepth", 1, []string{"developers", "engineering"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := &schema.AuthenticationBackendLDAP{
+				Address:  testLDAPAddress,
+				User:     "cn=admin,dc=example,dc=com",
+				Password: "password",
+				Attributes: schema.AuthenticationBackendLDAPAttributes{
+					Username:    "uid",
+					Mail:        "mail",
+					DisplayName: "displayName",
+					GroupName:   "cn",
+				},
+				GroupSearchMode:     "recursive",
+				GroupSearchMaxDepth: tc.depth,
+				UsersFilter:         "uid={input}",
+				GroupsFilter:        "(&(member={dn})(objectClass=groupOfNames))",
+				AdditionalUsersDN:   "ou=users",
+				BaseDN:              "dc=example,dc=com",
+			}
+
+			mockDialer := NewMockLDAPClientDialer(ctrl)
+
+			mockClient := NewMockLDAPClient(ctrl)
+
+			dialURL := mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389", gomock.Any()).Return(mockClient, nil)
+
+			setTimeout := mockClient.EXPECT().SetTimeout(gomock.Eq(time.Second * 0))
+
+			dseSearch := NewRootDSESearchRequest(mockClient, nil)
+
+			provider := NewLDAPUserProviderWithFactory(config, false, NewStandardLDAPClientFactory(config, nil, mockDialer))
+
+			clientBind := mockClient.EXPECT().
+				Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+				Return(nil)
+
+			clientClose := mockClient.EXPECT().Close()
+
+			searchProfile := mockClient.EXPECT().
+				Search(gomock.Any()).
+				Return(&ldap.SearchResult{
+					Entries: []*ldap.Entry{
+						{
+							DN: "uid=john,ou=users,dc=example,dc=com",
+							Attributes: []*ldap.EntryAttribute{
+								{
+									Name:   "displayName",
+									Values: []string{"John Doe"},
+								},
+								{
+									Name:   "mail",
+									Values: []string{"john@example.com"},
+								},
+								{
+									Name:   "uid",
+									Values: []string{"john"},
+								},
+							},
+						},
+					},
+				}, nil)
+
+			newRequest := func(dn string) *ldap.SearchRequest {
+				return ldap.NewSearchRequest(
+					provider.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+					0, 0, false, fmt.Sprintf("(&(member=%s)(objectClass=groupOfNames))", dn), provider.groupsAttributes, nil,
+				)
+			}
+
+			searchGroups := mockClient.EXPECT().
+				Search(newRequest("uid=john,ou=users,dc=example,dc=com")).
+				Return(createGroupSearchResultModeFilterWithDN("cn", []string{"developers"}, []string{"cn=developers,ou=groups,dc=example,dc=com"}), nil)
+
+			// The engineering group is a member of the staff group and the developers group, the latter of which results in
+			// a cycle which must not be expanded again.
+			searchParents := mockClient.EXPECT().
+				Search(newRequest("cn=developers,ou=groups,dc=example,dc=com")).
+				Return(createGroupSearchResultModeFilterWithDN("cn", []string{"engineering"}, []string{"cn=engineering,ou=groups,dc=example,dc=com"}), nil)
+
+			calls := []any{dialURL, setTimeout, dseSearch, clientBind, searchProfile, searchGroups, searchParents}
+
+			if tc.depth > 1 {
+				calls = append(calls,
+					mockClient.EXPECT().
+						Search(newRequest("cn=engineering,ou=groups,dc=example,dc=com")).
+						Return(createGroupSearchResultModeFilterWithDN("cn", []string{"staff", "developers"}, []string{"cn=staff,ou=groups,dc=example,dc=com", "CN=Developers,OU=groups,DC=example,DC=com"}), nil),
+					mockClient.EXPECT().
+						Search(newRequest("cn=staff,ou=groups,dc=example,dc=com")).
+						Return(createGroupSearchResultModeFilterWithDN("cn", []string{"engineering"}, []string{"cn=engineering,ou=groups,dc=example,dc=com"}), nil),
+				)
+			}
+
+			gomock.InOrder(append(calls, clientClose)...)
+
+			details, err := provider.GetDetails("john")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, details.Groups)
+			assert.Equal(t, "john", details.Username)
+		})
+	}
+}
+
 func TestShouldReturnUsernameFromLDAPSearchModeMemberOfDN(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -6766,6 +6950,111 @@ func TestShouldCheckInvalidUserPassword(t *testing.T) {
 	require.EqualError(t, err, "authentication failed. Cause: error occurred performing bind: invalid username or password")
 }
 
+func TestShouldCheckUserPasswordMustChange(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pwdLastSet string
+		expected   bool
+		err        string
+	}{
+		{
+			"ShouldBeValidWhenPasswordChangeRequired",
+			"0",
+			true,
+			"",
+		},
+		{
+			"ShouldBeInvalidWhenPasswordChangeNotRequired",
+			"132707080110000000",
+			false,
+			"authentication failed. Cause: error occurred performing bind: LDAP Result Code 49 \"Invalid Credentials\": 80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 773, v4563",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := &schema.AuthenticationBackendLDAP{
+				Address:  testLDAPAddress,
+				User:     "cn=admin,dc=example,dc=com",
+				Password: "password",
+				Attributes: schema.AuthenticationBackendLDAPAttributes{
+					Username:            "uid",
+					Mail:                "mail",
+					DisplayName:         "displayName",
+					MemberOf:            "memberOf",
+					PasswordLastChanged: "pwdLastSet",
+				},
+				UsersFilter:       "uid={input}",
+				AdditionalUsersDN: "ou=users",
+				BaseDN:            "dc=example,dc=com",
+			}
+
+			mockDialer := NewMockLDAPClientDialer(ctrl)
+
+			mockClient := NewMockLDAPClient(ctrl)
+
+			mockUserClient := NewMockLDAPClient(ctrl)
+
+			dseSearch := NewRootDSESearchRequest(mockClient, nil)
+
+			dseUserSearch := NewRootDSESearchRequest(mockUserClient, nil)
+
+			provider := NewLDAPUserProviderWithFactory(config, false, NewStandardLDAPClientFactory(config, nil, mockDialer))
+
+			assert.Equal(t, []string{"uid", "mail", "displayName", "memberOf", "pwdLastSet"}, provider.usersAttributes)
+
+			gomock.InOrder(
+				mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389", gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().SetTimeout(gomock.Eq(time.Second*0)),
+				dseSearch,
+				mockClient.EXPECT().
+					Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+					Return(nil),
+				mockClient.EXPECT().
+					Search(gomock.Any()).
+					Return(&ldap.SearchResult{
+						Entries: []*ldap.Entry{
+							{
+								DN: "uid=test,dc=example,dc=com",
+								Attributes: []*ldap.EntryAttribute{
+									{
+										Name:   "uid",
+										Values: []string{"John"},
+									},
+									{
+										Name:   "pwdLastSet",
+										Values: []string{tc.pwdLastSet},
+									},
+								},
+							},
+						},
+					}, nil),
+				mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389", gomock.Any()).Return(mockUserClient, nil),
+				mockUserClient.EXPECT().SetTimeout(gomock.Eq(time.Second*0)),
+				dseUserSearch,
+				mockUserClient.EXPECT().
+					Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
+					Return(ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 773, v4563"))),
+				mockUserClient.EXPECT().Close(),
+				mockClient.EXPECT().Close(),
+			)
+
+			valid, err := provider.CheckUserPassword("john", "password")
+
+			assert.Equal(t, tc.expected, valid)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
 func TestShouldCallStartTLSWhenEnabled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -6892,6 +7181,87 @@ func TestShouldParseDynamicConfiguration(t *testing.T) {
 
 	assert.Equal(t, "(&(|(uid=test@example.com)(mail=test@example.com))(sAMAccountType=805306368)(!(userAccountControl:1.2.840.113556.1.4.803:=2))(!(pwdLastSet=0))(|(!(accountExpires=*))(accountExpires=0)(accountExpiresM>=133147241190000000)(accountExpiresU>=1670250519)(accountExpiresG>=20221205142839.0Z)))", provider.resolveUsersFilter("test@example.com"))
 	assert.Equal(t, "(&(|(member=cn=admin,dc=example,dc=com)(member=test@example.com)(member=test))(objectClass=group))", provider.resolveGroupsFilter("test@example.com", &ldapUserProfile{Username: "test", DN: "cn=admin,dc=example,dc=com"}))
+	assert.Equal(t, "(&(|(member=*)(member=*)(member=*))(objectClass=group))", provider.resolveGroupsFilterAll())
+}
+
+func TestShouldResolveGroupsFilterAllMemberOf(t *testing.T) {
+	provider := NewLDAPUserProviderWithFactory(
+		&schema.AuthenticationBackendLDAP{
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				DistinguishedName: "distinguishedName",
+			},
+			GroupsFilter: "(&(|{memberof:dn}{memberof:rdn})(objectClass=group))",
+			BaseDN:       "dc=example,dc=com",
+		},
+		false,
+		nil)
+
+	assert.Equal(t, "(&(|(objectClass=*)(objectClass=*))(objectClass=group))", provider.resolveGroupsFilterAll())
+}
+
+func TestShouldGetGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &schema.AuthenticationBackendLDAP{
+		Address:  testLDAPAddress,
+		User:     "cn=admin,dc=example,dc=com",
+		Password: "password",
+		Attributes: schema.AuthenticationBackendLDAPAttributes{
+			Username:    "uid",
+			Mail:        "mail",
+			DisplayName: "displayName",
+			MemberOf:    "memberOf",
+			GroupName:   "cn",
+		},
+		UsersFilter:        "uid={input}",
+		GroupsFilter:       "(&(member={dn})(objectClass=groupOfNames))",
+		AdditionalUsersDN:  "ou=users",
+		AdditionalGroupsDN: "ou=groups",
+		BaseDN:             "dc=example,dc=com",
+	}
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := NewLDAPUserProviderWithFactory(config, false, mockFactory)
+
+	gomock.InOrder(
+		mockFactory.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+		mockClient.EXPECT().
+			Search(&ldap.SearchRequest{
+				BaseDN: "ou=groups,dc=example,dc=com", Scope: ldap.ScopeWholeSubtree, DerefAliases: ldap.NeverDerefAliases,
+				Filter: "(&(member=*)(objectClass=groupOfNames))", Attributes: []string{"cn"},
+			}).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					ldap.NewEntry("cn=dev,ou=groups,dc=example,dc=com", map[string][]string{"cn": {"dev"}}),
+					ldap.NewEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{"cn": {"admins"}}),
+					ldap.NewEntry("cn=admins,ou=other,dc=example,dc=com", map[string][]string{"cn": {"admins"}}),
+					ldap.NewEntry("cn=empty,ou=groups,dc=example,dc=com", map[string][]string{}),
+				},
+			}, nil),
+		mockFactory.EXPECT().ReleaseClient(mockClient).Return(nil),
+		mockFactory.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+		mockClient.EXPECT().Search(gomock.Any()).Return(nil, &ldap.Error{ResultCode: ldap.LDAPResultBusy, Err: errors.New("busy")}),
+		mockFactory.EXPECT().ReleaseClient(mockClient).Return(nil),
+		mockFactory.EXPECT().GetClient(gomock.Any()).Return(nil, errors.New("dial failed")),
+	)
+
+	groups, err := provider.GetGroups()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins", "dev"}, groups)
+
+	groups, err = provider.GetGroups()
+
+	assert.EqualError(t, err, "unable to retrieve groups. Cause: LDAP Result Code 51 \"Busy\": busy")
+	assert.Nil(t, groups)
+
+	groups, err = provider.GetGroups()
+
+	assert.EqualError(t, err, "dial failed")
+	assert.Nil(t, groups)
 }
 
 func TestShouldCallStartTLSWithInsecureSkipVerifyWhenSkipVerifyTrue(t *testing.T) {
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	ber "github.com/go-asn1-ber/asn1-ber"
 	"github.com/go-ldap/ldap/v3"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -130,6 +132,8 @@ func ldapGetControlsDiscoveryFromLDAPEntry(attr *ldap.EntryAttribute, controls *
 			controls.MsftPwdPolHints = true
 		case ldapOIDControlMsftServerPolicyHintsDeprecated:
 			controls.MsftPwdPolHintsDeprecated = true
+		case ldapOIDControlPasswordPolicy:
+			controls.PwdPolicy = true
 		}
 	}
 }
@@ -174,6 +178,97 @@ func getValuesFromEntry(entry *ldap.Entry, attribute string) []string {
 	return entry.GetAttributeValues(attribute)
 }
 
+// isDisabledFromEntry returns true if the entry has the disabled attribute set. The userAccountControl attribute is
+// checked for the ACCOUNTDISABLE flag, all other attributes are parsed as a boolean.
+func isDisabledFromEntry(entry *ldap.Entry, attribute string) bool {
+	value := getValueFromEntry(entry, attribute)
+
+	if value == "" {
+		return false
+	}
+
+	if strings.EqualFold(attribute, ldapAttributeUserAccountControl) {
+		flags, err := strconv.ParseInt(value, 10, 64)
+
+		return err == nil && flags&ldapUserAccountControlAccountDisable != 0
+	}
+
+	disabled, err := strconv.ParseBool(value)
+
+	return err == nil && disabled
+}
+
+// getPasswordLastChangedFromEntry returns the time the password was last changed according to the entry, and if the
+// value of the attribute indicates the user must change their password at next login. The pwdLastSet attribute is
+// parsed as a Microsoft NT epoch where 0 indicates a change is required, the shadowLastChange attribute is parsed as
+// the number of days since the unix epoch where 0 indicates a change is required, and all other attributes are parsed
+// as a generalized time.
+func getPasswordLastChangedFromEntry(entry *ldap.Entry, attribute string) (changed time.Time, required bool, err error) {
+	value := getValueFromEntry(entry, attribute)
+
+	if value == "" {
+		return changed, false, nil
+	}
+
+	switch {
+	case strings.EqualFold(attribute, ldapAttributePwdLastSet):
+		var nt uint64
+
+		if nt, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return changed, false, fmt.Errorf("cannot parse '%s' with value '%s' as a microsoft nt epoch: %w", attribute, value, err)
+		}
+
+		if nt == 0 {
+			return changed, true, nil
+		}
+
+		return utils.MicrosoftNTEpochToTime(nt), false, nil
+	case strings.EqualFold(attribute, ldapAttributeShadowLastChange):
+		var days int64
+
+		if days, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return changed, false, fmt.Errorf("cannot parse '%s' with value '%s' as a number of days: %w", attribute, value, err)
+		}
+
+		if days == 0 {
+			return changed, true, nil
+		}
+
+		return time.Unix(0, 0).UTC().Add(time.Duration(days) * utils.Day), false, nil
+	default:
+		if changed, err = ber.ParseGeneralizedTime([]byte(value)); err != nil {
+			return changed, false, fmt.Errorf("cannot parse '%s' with value '%s' as a generalized time: %w", attribute, value, err)
+		}
+
+		return changed.UTC(), false, nil
+	}
+}
+
+// isPasswordChangeRequiredFromEntry returns true if the entry has the password change required attribute set.
+func isPasswordChangeRequiredFromEntry(entry *ldap.Entry, attribute string) bool {
+	value := getValueFromEntry(entry, attribute)
+
+	if value == "" {
+		return false
+	}
+
+	required, err := strconv.ParseBool(value)
+
+	return err == nil && required
+}
+
+// isPasswordMustChangeBindError returns true if the error is an Active Directory bind error which indicates the
+// credentials were valid but the user must change their password before they are permitted to bind.
+func isPasswordMustChangeBindError(err error) bool {
+	var e *ldap.Error
+
+	if !errors.As(err, &e) || e.ResultCode != ldap.LDAPResultInvalidCredentials || e.Err == nil {
+		return false
+	}
+
+	return strings.Contains(e.Err.Error(), ldapActiveDirectoryBindDataPasswordMustChange)
+}
+
 func getExtraValueFromEntry(entry *ldap.Entry, attribute string, properties schema.AuthenticationBackendLDAPAttributesAttribute) (value any, err error) {
 	if properties.MultiValued {
 		return getExtraValueMultiFromEntry(entry, attribute, properties)
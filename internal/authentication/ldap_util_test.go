@@ -1,7 +1,10 @@
 package authentication
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	ber "github.com/go-asn1-ber/asn1-ber"
 	"github.com/go-ldap/ldap/v3"
@@ -67,6 +70,12 @@ func TestLDAPGetFeatureSupportFromEntry(t *testing.T) {
 			haveExtensionOIDs: []string{},
 			expected:          LDAPDiscovery{Successful: true, Extensions: LDAPDiscoveryExtensions{OIDs: []string{}}, Controls: LDAPDiscoveryControls{MsftPwdPolHintsDeprecated: true, OIDs: []string{ldapOIDControlMsftServerPolicyHintsDeprecated}}},
 		},
+		{
+			description:       "ShouldReturnControlPasswordPolicy",
+			haveControlOIDs:   []string{ldapOIDControlPasswordPolicy},
+			haveExtensionOIDs: []string{},
+			expected:          LDAPDiscovery{Successful: true, Extensions: LDAPDiscoveryExtensions{OIDs: []string{}}, Controls: LDAPDiscoveryControls{PwdPolicy: true, OIDs: []string{ldapOIDControlPasswordPolicy}}},
+		},
 		{
 			description:       "ShouldReturnControlAll",
 			haveControlOIDs:   []string{ldapOIDControlMsftServerPolicyHints, ldapOIDControlMsftServerPolicyHintsDeprecated},
@@ -357,3 +366,201 @@ func TestFmtLDAPVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDisabledFromEntry(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attribute string
+		values    []string
+		expected  bool
+	}{
+		{
+			"ShouldNotBeDisabledWithoutAttribute",
+			"",
+			[]string{"TRUE"},
+			false,
+		},
+		{
+			"ShouldNotBeDisabledWithoutValue",
+			"nsAccountLock",
+			nil,
+			false,
+		},
+		{
+			"ShouldBeDisabledBoolean",
+			"nsAccountLock",
+			[]string{"TRUE"},
+			true,
+		},
+		{
+			"ShouldNotBeDisabledBoolean",
+			"nsAccountLock",
+			[]string{"FALSE"},
+			false,
+		},
+		{
+			"ShouldNotBeDisabledBadBoolean",
+			"nsAccountLock",
+			[]string{"abc"},
+			false,
+		},
+		{
+			"ShouldBeDisabledUserAccountControl",
+			"userAccountControl",
+			[]string{"514"},
+			true,
+		},
+		{
+			"ShouldNotBeDisabledUserAccountControl",
+			"userAccountControl",
+			[]string{"512"},
+			false,
+		},
+		{
+			"ShouldNotBeDisabledBadUserAccountControl",
+			"userAccountControl",
+			[]string{"abc"},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := ldap.NewEntry("uid=john,dc=example,dc=com", map[string][]string{})
+
+			if tc.values != nil {
+				entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(tc.attribute, tc.values))
+			}
+
+			assert.Equal(t, tc.expected, isDisabledFromEntry(entry, tc.attribute))
+		})
+	}
+}
+
+func TestGetPasswordLastChangedFromEntry(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attribute string
+		values    []string
+		expected  time.Time
+		required  bool
+		err       string
+	}{
+		{
+			"ShouldReturnZeroWithoutAttribute",
+			"",
+			[]string{"0"},
+			time.Time{},
+			false,
+			"",
+		},
+		{
+			"ShouldReturnZeroWithoutValue",
+			"pwdLastSet",
+			nil,
+			time.Time{},
+			false,
+			"",
+		},
+		{
+			"ShouldParsePwdLastSet",
+			"pwdLastSet",
+			[]string{"132707080110000000"},
+			time.Unix(1626234411, 0).UTC(),
+			false,
+			"",
+		},
+		{
+			"ShouldRequireChangePwdLastSetZero",
+			"pwdLastSet",
+			[]string{"0"},
+			time.Time{},
+			true,
+			"",
+		},
+		{
+			"ShouldErrorBadPwdLastSet",
+			"pwdLastSet",
+			[]string{"abc"},
+			time.Time{},
+			false,
+			"cannot parse 'pwdLastSet' with value 'abc' as a microsoft nt epoch: strconv.ParseUint: parsing \"abc\": invalid syntax",
+		},
+		{
+			"ShouldParseShadowLastChange",
+			"shadowLastChange",
+			[]string{"19000"},
+			time.Date(2022, time.January, 8, 0, 0, 0, 0, time.UTC),
+			false,
+			"",
+		},
+		{
+			"ShouldRequireChangeShadowLastChangeZero",
+			"shadowLastChange",
+			[]string{"0"},
+			time.Time{},
+			true,
+			"",
+		},
+		{
+			"ShouldErrorBadShadowLastChange",
+			"shadowLastChange",
+			[]string{"abc"},
+			time.Time{},
+			false,
+			"cannot parse 'shadowLastChange' with value 'abc' as a number of days: strconv.ParseInt: parsing \"abc\": invalid syntax",
+		},
+		{
+			"ShouldParseGeneralizedTime",
+			"pwdChangedTime",
+			[]string{"20240102030405Z"},
+			time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+			false,
+			"",
+		},
+		{
+			"ShouldErrorBadGeneralizedTime",
+			"pwdChangedTime",
+			[]string{"abc"},
+			time.Time{},
+			false,
+			"cannot parse 'pwdChangedTime' with value 'abc' as a generalized time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := ldap.NewEntry("uid=john,dc=example,dc=com", map[string][]string{})
+
+			if tc.values != nil {
+				entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(tc.attribute, tc.values))
+			}
+
+			changed, required, err := getPasswordLastChangedFromEntry(entry, tc.attribute)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, changed)
+				assert.Equal(t, tc.required, required)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestIsPasswordChangeRequiredFromEntry(t *testing.T) {
+	entry := ldap.NewEntry("uid=john,dc=example,dc=com", map[string][]string{"pwdReset": {"TRUE"}, "other": {"abc"}})
+
+	assert.True(t, isPasswordChangeRequiredFromEntry(entry, "pwdReset"))
+	assert.False(t, isPasswordChangeRequiredFromEntry(entry, "other"))
+	assert.False(t, isPasswordChangeRequiredFromEntry(entry, "missing"))
+	assert.False(t, isPasswordChangeRequiredFromEntry(entry, ""))
+}
+
+func TestIsPasswordMustChangeBindError(t *testing.T) {
+	assert.True(t, isPasswordMustChangeBindError(fmt.Errorf("error occurred performing bind: %w", ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 773, v4563")))))
+	assert.False(t, isPasswordMustChangeBindError(ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 52e, v4563"))))
+	assert.False(t, isPasswordMustChangeBindError(ldap.NewError(ldap.LDAPResultOperationsError, errors.New("data 773"))))
+	assert.False(t, isPasswordMustChangeBindError(errors.New("data 773")))
+}
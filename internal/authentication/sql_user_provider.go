@@ -0,0 +1,189 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// SQLUserProvider is a provider reading details from the storage provider database.
+type SQLUserProvider struct {
+	config  *schema.AuthenticationBackendSQL
+	hash    algorithm.Hash
+	storage storage.AuthenticationUserProvider
+}
+
+// NewSQLUserProvider creates a new instance of SQLUserProvider.
+func NewSQLUserProvider(config *schema.AuthenticationBackendSQL, provider storage.AuthenticationUserProvider) (p *SQLUserProvider) {
+	return &SQLUserProvider{
+		config:  config,
+		storage: provider,
+	}
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *SQLUserProvider) StartupCheck() (err error) {
+	if p.storage == nil {
+		return fmt.Errorf("error initializing the sql authentication backend: storage provider is not configured")
+	}
+
+	if p.hash, err = NewFileCryptoHashFromConfig(p.config.Password); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close implements the UserProvider interface.
+func (p *SQLUserProvider) Close() (err error) {
+	return nil
+}
+
+// CheckUserPassword checks if provided password matches for the given user.
+func (p *SQLUserProvider) CheckUserPassword(username string, password string) (match bool, err error) {
+	var user *model.AuthenticationUser
+
+	if user, err = p.getUser(context.Background(), username); err != nil {
+		return false, err
+	}
+
+	var digest *schema.PasswordDigest
+
+	if digest, err = schema.DecodePasswordDigest(user.Password); err != nil {
+		return false, fmt.Errorf("error decoding the password digest for user '%s': %w", user.Username, err)
+	}
+
+	return digest.MatchAdvanced(password)
+}
+
+// GetDetails retrieve the groups a user belongs to.
+func (p *SQLUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	return p.getDetails(context.Background(), username)
+}
+
+// GetDetailsExtended implements the UserProvider interface.
+func (p *SQLUserProvider) GetDetailsExtended(username string) (details *UserDetailsExtended, err error) {
+	var d *UserDetails
+
+	if d, err = p.getDetails(context.Background(), username); err != nil {
+		return nil, err
+	}
+
+	return &UserDetailsExtended{
+		UserDetails: d,
+	}, nil
+}
+
+// UpdatePassword update the password of the given user.
+func (p *SQLUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	ctx := context.Background()
+
+	var user *model.AuthenticationUser
+
+	if user, err = p.getUser(ctx, username); err != nil {
+		return err
+	}
+
+	return p.setPassword(ctx, user.Username, newPassword)
+}
+
+// ChangePassword implements the UserProvider interface.
+func (p *SQLUserProvider) ChangePassword(username string, oldPassword string, newPassword string) (err error) {
+	ctx := context.Background()
+
+	var user *model.AuthenticationUser
+
+	if user, err = p.getUser(ctx, username); err != nil {
+		return fmt.Errorf("%w : %v", ErrUserNotFound, err)
+	}
+
+	if strings.TrimSpace(newPassword) == "" {
+		return ErrPasswordWeak
+	}
+
+	if oldPassword == newPassword {
+		return ErrPasswordWeak
+	}
+
+	oldPasswordCorrect, err := p.CheckUserPassword(username, oldPassword)
+	if err != nil {
+		return ErrAuthenticationFailed
+	}
+
+	if !oldPasswordCorrect {
+		return ErrIncorrectPassword
+	}
+
+	if err = p.setPassword(ctx, user.Username, newPassword); err != nil {
+		return fmt.Errorf("%w : %v", ErrOperationFailed, err)
+	}
+
+	return nil
+}
+
+// GetGroups implements the GroupsUserProvider interface.
+func (p *SQLUserProvider) GetGroups() (groups []string, err error) {
+	return p.storage.LoadAuthenticationGroups(context.Background())
+}
+
+func (p *SQLUserProvider) setPassword(ctx context.Context, username, password string) (err error) {
+	var digest algorithm.Digest
+
+	if digest, err = p.hash.Hash(password); err != nil {
+		return err
+	}
+
+	return p.storage.UpdateAuthenticationUserPassword(ctx, username, digest.Encode())
+}
+
+func (p *SQLUserProvider) getDetails(ctx context.Context, username string) (details *UserDetails, err error) {
+	var user *model.AuthenticationUser
+
+	if user, err = p.getUser(ctx, username); err != nil {
+		return nil, err
+	}
+
+	var groups []string
+
+	if groups, err = p.storage.LoadAuthenticationUserGroups(ctx, user.Username); err != nil {
+		return nil, err
+	}
+
+	details = &UserDetails{
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Groups:      groups,
+	}
+
+	if user.Email.Valid && user.Email.String != "" {
+		details.Emails = []string{user.Email.String}
+	}
+
+	return details, nil
+}
+
+func (p *SQLUserProvider) getUser(ctx context.Context, username string) (user *model.AuthenticationUser, err error) {
+	user, err = p.storage.LoadAuthenticationUser(ctx, username)
+
+	if errors.Is(err, storage.ErrNoAuthenticationUser) && p.config.Search.Email && strings.Contains(username, "@") {
+		user, err = p.storage.LoadAuthenticationUserByEmail(ctx, username)
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrNoAuthenticationUser):
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, err
+	case user.Disabled:
+		return nil, ErrUserDisabled
+	default:
+		return user, nil
+	}
+}
@@ -0,0 +1,308 @@
+package authentication
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func newTestSQLUserProvider(t *testing.T, config *schema.AuthenticationBackendSQL) (provider *SQLUserProvider, mock *MockSQLUserStorage) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mock = NewMockSQLUserStorage(ctrl)
+
+	provider = NewSQLUserProvider(config, mock)
+
+	require.NoError(t, provider.StartupCheck())
+
+	return provider, mock
+}
+
+func TestSQLUserProviderStartupCheck(t *testing.T) {
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig}, nil)
+
+	assert.EqualError(t, provider.StartupCheck(), "error initializing the sql authentication backend: storage provider is not configured")
+
+	provider = NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, NewMockSQLUserStorage(gomock.NewController(t)))
+
+	assert.EqualError(t, provider.StartupCheck(), "failed to initialize hash settings: argon2 validation error: parameter is invalid: parameter 't' must be between 1 and 2147483647 but is set to '0'")
+
+	assert.NoError(t, provider.Close())
+}
+
+func TestSQLUserProviderCheckUserPassword(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *schema.AuthenticationBackendSQL
+		username string
+		password string
+		setup    func(mock *MockSQLUserStorage)
+		expected bool
+		err      string
+	}{
+		{
+			"ShouldMatch",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldNotMatch",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john",
+			"bad",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil)
+			},
+			false,
+			"",
+		},
+		{
+			"ShouldMatchByEmail",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig, Search: schema.AuthenticationBackendSQLSearch{Email: true}},
+			"john@example.com",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john@example.com").Return(nil, storage.ErrNoAuthenticationUser),
+					mock.EXPECT().LoadAuthenticationUserByEmail(gomock.Any(), "john@example.com").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil),
+				)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldNotSearchEmailWhenDisabled",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john@example.com",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john@example.com").Return(nil, storage.ErrNoAuthenticationUser)
+			},
+			false,
+			"user not found",
+		},
+		{
+			"ShouldErrDisabledUser",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password", Disabled: true}, nil)
+			},
+			false,
+			"user is disabled",
+		},
+		{
+			"ShouldErrStorage",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(nil, errors.New("bad conn"))
+			},
+			false,
+			"bad conn",
+		},
+		{
+			"ShouldErrBadDigest",
+			&schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig},
+			"john",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$bad$password"}, nil)
+			},
+			false,
+			"error decoding the password digest for user 'john': provided encoded hash has an invalid identifier: the identifier 'bad' is unknown to the decoder",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, mock := newTestSQLUserProvider(t, tc.config)
+
+			tc.setup(mock)
+
+			actual, err := provider.CheckUserPassword(tc.username, tc.password)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSQLUserProviderGetDetails(t *testing.T) {
+	provider, mock := newTestSQLUserProvider(t, &schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig})
+
+	gomock.InOrder(
+		mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", DisplayName: "John Doe", Email: sql.NullString{Valid: true, String: "john@example.com"}, Password: "$plaintext$password"}, nil),
+		mock.EXPECT().LoadAuthenticationUserGroups(gomock.Any(), "john").Return([]string{"admins", "dev"}, nil),
+		mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", DisplayName: "John Doe", Password: "$plaintext$password"}, nil),
+		mock.EXPECT().LoadAuthenticationUserGroups(gomock.Any(), "john").Return([]string{}, nil),
+		mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil),
+		mock.EXPECT().LoadAuthenticationUserGroups(gomock.Any(), "john").Return(nil, errors.New("bad conn")),
+	)
+
+	details, err := provider.GetDetails("john")
+
+	require.NoError(t, err)
+	assert.Equal(t, "john", details.Username)
+	assert.Equal(t, "John Doe", details.DisplayName)
+	assert.Equal(t, []string{"john@example.com"}, details.Emails)
+	assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+
+	extended, err := provider.GetDetailsExtended("john")
+
+	require.NoError(t, err)
+	require.NotNil(t, extended.UserDetails)
+	assert.Equal(t, "john", extended.Username)
+	assert.Empty(t, extended.Emails)
+	assert.Empty(t, extended.Groups)
+
+	details, err = provider.GetDetails("john")
+
+	assert.EqualError(t, err, "bad conn")
+	assert.Nil(t, details)
+}
+
+func TestSQLUserProviderGetGroups(t *testing.T) {
+	provider, mock := newTestSQLUserProvider(t, &schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig})
+
+	gomock.InOrder(
+		mock.EXPECT().LoadAuthenticationGroups(gomock.Any()).Return([]string{"admins", "dev"}, nil),
+		mock.EXPECT().LoadAuthenticationGroups(gomock.Any()).Return(nil, errors.New("bad conn")),
+	)
+
+	groups, err := provider.GetGroups()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins", "dev"}, groups)
+
+	groups, err = provider.GetGroups()
+
+	assert.EqualError(t, err, "bad conn")
+	assert.Nil(t, groups)
+}
+
+func TestSQLUserProviderUpdatePassword(t *testing.T) {
+	provider, mock := newTestSQLUserProvider(t, &schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig})
+
+	gomock.InOrder(
+		mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil),
+		mock.EXPECT().UpdateAuthenticationUserPassword(gomock.Any(), "john", gomock.Any()).DoAndReturn(func(_ any, _ string, password string) error {
+			digest, err := schema.DecodePasswordDigest(password)
+			require.NoError(t, err)
+
+			assert.True(t, digest.Match("example"))
+
+			return nil
+		}),
+		mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "harry").Return(nil, storage.ErrNoAuthenticationUser),
+	)
+
+	assert.NoError(t, provider.UpdatePassword("john", "example"))
+	assert.ErrorIs(t, provider.UpdatePassword("harry", "example"), ErrUserNotFound)
+}
+
+func TestSQLUserProviderChangePassword(t *testing.T) {
+	testCases := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		setup       func(mock *MockSQLUserStorage)
+		err         error
+	}{
+		{
+			"ShouldChangePassword",
+			"password",
+			"example",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil).Times(2)
+				mock.EXPECT().UpdateAuthenticationUserPassword(gomock.Any(), "john", gomock.Any()).Return(nil)
+			},
+			nil,
+		},
+		{
+			"ShouldErrWeakPassword",
+			"password",
+			" ",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil)
+			},
+			ErrPasswordWeak,
+		},
+		{
+			"ShouldErrSamePassword",
+			"password",
+			"password",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil)
+			},
+			ErrPasswordWeak,
+		},
+		{
+			"ShouldErrIncorrectPassword",
+			"bad",
+			"example",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil).Times(2)
+			},
+			ErrIncorrectPassword,
+		},
+		{
+			"ShouldErrUserNotFound",
+			"password",
+			"example",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(nil, storage.ErrNoAuthenticationUser)
+			},
+			ErrUserNotFound,
+		},
+		{
+			"ShouldErrOperationFailed",
+			"password",
+			"example",
+			func(mock *MockSQLUserStorage) {
+				mock.EXPECT().LoadAuthenticationUser(gomock.Any(), "john").Return(&model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}, nil).Times(2)
+				mock.EXPECT().UpdateAuthenticationUserPassword(gomock.Any(), "john", gomock.Any()).Return(errors.New("bad conn"))
+			},
+			ErrOperationFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, mock := newTestSQLUserProvider(t, &schema.AuthenticationBackendSQL{Password: schema.DefaultCIPasswordConfig})
+
+			tc.setup(mock)
+
+			err := provider.ChangePassword("john", tc.oldPassword, tc.newPassword)
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/sirupsen/logrus"
@@ -21,6 +22,30 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+
+	PasswordLastChanged    time.Time
+	PasswordChangeRequired bool
+}
+
+// IsPasswordChangeRequired returns true if the user has been flagged as required to change their password or if the
+// password is older than the provided maximum age. A zero PasswordLastChanged value is never considered expired as
+// the backend was unable to determine when the password was last changed.
+func (d *UserDetails) IsPasswordChangeRequired(now time.Time, maxAge time.Duration) bool {
+	if d.PasswordChangeRequired {
+		return true
+	}
+
+	if maxAge <= 0 || d.PasswordLastChanged.IsZero() {
+		return false
+	}
+
+	return now.After(d.PasswordLastChanged.Add(maxAge))
+}
+
+// PasswordStatus represents the server side password policy status of a user reported while checking their password.
+type PasswordStatus struct {
+	// GraceLoginsRemaining is the number of logins the user has remaining with their expired password.
+	GraceLoginsRemaining int
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -238,6 +263,9 @@ type ldapUserProfile struct {
 	DisplayName string
 	Username    string
 	MemberOf    []string
+
+	PasswordLastChanged    time.Time
+	PasswordChangeRequired bool
 }
 
 type ldapUserProfileExtended struct {
@@ -317,6 +345,7 @@ type LDAPDiscoveryControls struct {
 
 	MsftPwdPolHints           bool
 	MsftPwdPolHintsDeprecated bool
+	PwdPolicy                 bool
 }
 
 // String returns the string representation of the discovered control OIDs.
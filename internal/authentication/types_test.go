@@ -4,6 +4,7 @@ import (
 	"net/mail"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +38,29 @@ func TestUserDetails_Addresses(t *testing.T) {
 	assert.Equal(t, []mail.Address{{Address: "abc@123.com"}}, details.Addresses())
 }
 
+func TestUserDetails_IsPasswordChangeRequired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name     string
+		have     *UserDetails
+		maxAge   time.Duration
+		expected bool
+	}{
+		{"ShouldNotRequireWhenEmpty", &UserDetails{}, time.Hour, false},
+		{"ShouldRequireWhenFlagged", &UserDetails{PasswordChangeRequired: true}, 0, true},
+		{"ShouldNotRequireWhenMaxAgeDisabled", &UserDetails{PasswordLastChanged: now.Add(-time.Hour * 24 * 365)}, 0, false},
+		{"ShouldNotRequireWhenWithinMaxAge", &UserDetails{PasswordLastChanged: now.Add(-time.Minute)}, time.Hour, false},
+		{"ShouldRequireWhenOlderThanMaxAge", &UserDetails{PasswordLastChanged: now.Add(-time.Hour * 2)}, time.Hour, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.IsPasswordChangeRequired(now, tc.maxAge))
+		})
+	}
+}
+
 func TestLevel_String(t *testing.T) {
 	assert.Equal(t, "one_factor", OneFactor.String())
 	assert.Equal(t, "two_factor", TwoFactor.String())
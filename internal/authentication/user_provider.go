@@ -24,3 +24,18 @@ type UserProvider interface {
 
 	Close() (err error)
 }
+
+// PasswordStatusUserProvider is implemented by authentication backends which are able to report the server side
+// password policy status of a user while checking their password.
+type PasswordStatusUserProvider interface {
+	// CheckUserPasswordWithStatus is used to check if a password matches for a specific user and reports the password
+	// status if the backend provided one.
+	CheckUserPasswordWithStatus(username string, password string) (valid bool, status *PasswordStatus, err error)
+}
+
+// GroupsUserProvider is implemented by authentication backends which are able to list every group assigned to their
+// users.
+type GroupsUserProvider interface {
+	// GetGroups is used to get the list of groups assigned to at least one user.
+	GetGroups() (groups []string, err error)
+}
@@ -0,0 +1,25 @@
+package authorization
+
+import (
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// AccessControlRequiredAMR represents the RFC8176 Authentication Method Reference values of which at least one must have
+// been performed by the user for a rule to authorize a request.
+type AccessControlRequiredAMR []string
+
+// IsMet returns true if the user has performed at least one of the required Authentication Method Reference values or
+// if there are no required values.
+func (acr AccessControlRequiredAMR) IsMet(amr AuthenticationMethodsReferences) (met bool) {
+	if len(acr) == 0 {
+		return true
+	}
+
+	for _, value := range amr.MarshalRFC8176() {
+		if utils.IsStringInSlice(value, acr) {
+			return true
+		}
+	}
+
+	return false
+}
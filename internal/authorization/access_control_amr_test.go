@@ -0,0 +1,59 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessControlRequiredAMR(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     AccessControlRequiredAMR
+		amr      AuthenticationMethodsReferences
+		expected bool
+	}{
+		{
+			"ShouldBeMetWhenEmpty",
+			nil,
+			AuthenticationMethodsReferences{},
+			true,
+		},
+		{
+			"ShouldBeMetByHardwareKey",
+			AccessControlRequiredAMR{AMRHardwareSecuredKey},
+			AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnHardware: true},
+			true,
+		},
+		{
+			"ShouldNotBeMetBySoftwareKeyWhenHardwareKeyRequired",
+			AccessControlRequiredAMR{AMRHardwareSecuredKey},
+			AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnSoftware: true},
+			false,
+		},
+		{
+			"ShouldBeMetByAnyValue",
+			AccessControlRequiredAMR{AMRHardwareSecuredKey, AMROneTimePassword},
+			AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true},
+			true,
+		},
+		{
+			"ShouldBeMetByMultiFactor",
+			AccessControlRequiredAMR{AMRMultiFactorAuthentication},
+			AuthenticationMethodsReferences{UsernameAndPassword: true, Duo: true},
+			true,
+		},
+		{
+			"ShouldNotBeMetBySingleFactor",
+			AccessControlRequiredAMR{AMRMultiFactorAuthentication},
+			AuthenticationMethodsReferences{UsernameAndPassword: true},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.IsMet(tc.amr))
+		})
+	}
+}
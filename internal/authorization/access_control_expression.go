@@ -0,0 +1,90 @@
+package authorization
+
+import (
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/expression"
+)
+
+// NewAccessControlExpression creates a new AccessControlExpression rule type. If the expression can't be compiled the
+// resulting rule never matches.
+func NewAccessControlExpression(env *expression.AccessControlEnv, value string) (rule *AccessControlExpression) {
+	if value == "" {
+		return nil
+	}
+
+	rule = &AccessControlExpression{}
+
+	if env != nil {
+		rule.Program, _ = env.Compile(value)
+	}
+
+	return rule
+}
+
+// AccessControlExpression represents an ACL common expression language rule.
+type AccessControlExpression struct {
+	Program *expression.AccessControlExpression
+}
+
+// HasSubject returns true if the expression refers to the user.
+func (acl *AccessControlExpression) HasSubject() bool {
+	return acl.Program != nil && acl.Program.HasSubject()
+}
+
+// IsMatch returns true if the expression evaluates to true for the subject and object at the given time.
+func (acl *AccessControlExpression) IsMatch(subject Subject, object Object, now time.Time) (match bool) {
+	if acl.Program == nil {
+		return false
+	}
+
+	details := subject.Details
+
+	if acl.Program.HasSubject() {
+		if details = subject.GetDetails(); details == nil {
+			return false
+		}
+	}
+
+	match, _ = acl.Program.Evaluate(details, newAccessControlExpressionValues(subject, object, now))
+
+	return match
+}
+
+func newAccessControlExpressionValues(subject Subject, object Object, now time.Time) (values map[string]any) {
+	query, headers := map[string]string{}, map[string]string{}
+
+	if object.URL != nil {
+		for key, value := range object.URL.Query() {
+			if len(value) != 0 {
+				query[key] = value[0]
+			}
+		}
+	}
+
+	for key, value := range object.Header {
+		if len(value) != 0 {
+			headers[strings.ToLower(key)] = value[0]
+		}
+	}
+
+	var ip string
+
+	if subject.IP != nil {
+		ip = subject.IP.String()
+	}
+
+	return map[string]any{
+		expression.AttributeAccessControlRequest: map[string]any{
+			expression.AttributeAccessControlRequestDomain:  object.Domain,
+			expression.AttributeAccessControlRequestPath:    object.Path,
+			expression.AttributeAccessControlRequestMethod:  object.Method,
+			expression.AttributeAccessControlRequestQuery:   query,
+			expression.AttributeAccessControlRequestHeaders: headers,
+		},
+		expression.AttributeAccessControlRemoteIP: ip,
+		expression.AttributeAccessControlClientID: subject.ClientID,
+		expression.AttributeAccessControlNow:      now,
+	}
+}
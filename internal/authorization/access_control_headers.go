@@ -0,0 +1,124 @@
+package authorization
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlHeaders creates a new AccessControlHeaders rule type.
+func NewAccessControlHeaders(config [][]schema.AccessControlRuleHeader) (rules []AccessControlHeaders) {
+	if len(config) == 0 {
+		return nil
+	}
+
+	for i := 0; i < len(config); i++ {
+		var rule []ObjectMatcher
+
+		for j := 0; j < len(config[i]); j++ {
+			subRule, err := NewAccessControlHeaderObjectMatcher(config[i][j])
+			if err != nil {
+				continue
+			}
+
+			rule = append(rule, subRule)
+		}
+
+		rules = append(rules, AccessControlHeaders{Rules: rule})
+	}
+
+	return rules
+}
+
+// AccessControlHeaders represents an ACL request headers rule.
+type AccessControlHeaders struct {
+	Rules []ObjectMatcher
+}
+
+// IsMatch returns true if this rule matches the object.
+func (ach AccessControlHeaders) IsMatch(object Object) (isMatch bool) {
+	for _, rule := range ach.Rules {
+		if !rule.IsMatch(object) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewAccessControlHeaderObjectMatcher creates a new ObjectMatcher rule type from a schema.AccessControlRuleHeader.
+func NewAccessControlHeaderObjectMatcher(rule schema.AccessControlRuleHeader) (matcher ObjectMatcher, err error) {
+	key := http.CanonicalHeaderKey(rule.Key)
+
+	switch rule.Operator {
+	case operatorPresent, operatorAbsent:
+		return &AccessControlHeaderMatcherPresent{key: key, present: rule.Operator == operatorPresent}, nil
+	case operatorEqual, operatorNotEqual:
+		if value, ok := rule.Value.(string); ok {
+			return &AccessControlHeaderMatcherEqual{key: key, value: value, equal: rule.Operator == operatorEqual}, nil
+		} else {
+			return nil, fmt.Errorf("rule value is not a string and is instead %T", rule.Value)
+		}
+	case operatorPattern, operatorNotPattern:
+		if pattern, ok := rule.Value.(*regexp.Regexp); ok {
+			return &AccessControlHeaderMatcherPattern{key: key, pattern: pattern, match: rule.Operator == operatorPattern}, nil
+		} else {
+			return nil, fmt.Errorf("rule value is not a *regexp.Regexp and is instead %T", rule.Value)
+		}
+	default:
+		return nil, fmt.Errorf("invalid operator: %s", rule.Operator)
+	}
+}
+
+// AccessControlHeaderMatcherEqual is a rule type that checks the equality of a request header.
+type AccessControlHeaderMatcherEqual struct {
+	key, value string
+	equal      bool
+}
+
+// IsMatch returns true if this rule matches the object.
+func (acl AccessControlHeaderMatcherEqual) IsMatch(object Object) (isMatch bool) {
+	switch {
+	case acl.equal:
+		return object.Header.Get(acl.key) == acl.value
+	default:
+		return object.Header.Get(acl.key) != acl.value
+	}
+}
+
+// AccessControlHeaderMatcherPresent is a rule type that checks the presence of a request header.
+type AccessControlHeaderMatcherPresent struct {
+	key     string
+	present bool
+}
+
+// IsMatch returns true if this rule matches the object.
+func (acl AccessControlHeaderMatcherPresent) IsMatch(object Object) (isMatch bool) {
+	_, ok := object.Header[acl.key]
+
+	switch {
+	case acl.present:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// AccessControlHeaderMatcherPattern is a rule type that checks a request header against regex.
+type AccessControlHeaderMatcherPattern struct {
+	key     string
+	pattern *regexp.Regexp
+	match   bool
+}
+
+// IsMatch returns true if this rule matches the object.
+func (acl AccessControlHeaderMatcherPattern) IsMatch(object Object) (isMatch bool) {
+	switch {
+	case acl.match:
+		return acl.pattern.MatchString(object.Header.Get(acl.key))
+	default:
+		return !acl.pattern.MatchString(object.Header.Get(acl.key))
+	}
+}
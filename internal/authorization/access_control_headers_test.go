@@ -0,0 +1,65 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     [][]schema.AccessControlRuleHeader
+		expected []AccessControlHeaders
+	}{
+		{
+			"ShouldReturnNilWhenEmpty",
+			nil,
+			nil,
+		},
+		{
+			"ShouldCanonicalizeKey",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPresent, Key: "x-api-version"},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher{&AccessControlHeaderMatcherPresent{key: "X-Api-Version", present: true}}}},
+		},
+		{
+			"ShouldSkipInvalidTypeEqual",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorEqual, Key: "example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+		{
+			"ShouldSkipInvalidTypePattern",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPattern, Key: "example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+		{
+			"ShouldSkipInvalidOperator",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "nop", Key: "example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewAccessControlHeaders(tc.have))
+		})
+	}
+}
@@ -1,31 +1,52 @@
 package authorization
 
 import (
+	"time"
+
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
-// NewAccessControlRules converts a schema.AccessControl into an AccessControlRule slice.
-func NewAccessControlRules(config schema.AccessControl) (rules []*AccessControlRule) {
-	for i, schemaRule := range config.Rules {
-		rules = append(rules, NewAccessControlRule(i+1, schemaRule))
+// NewAccessControlRules converts the access control section of a schema.Configuration into an AccessControlRule slice.
+func NewAccessControlRules(config *schema.Configuration) (rules []*AccessControlRule) {
+	var env *expression.AccessControlEnv
+
+	for _, schemaRule := range config.AccessControl.Rules {
+		if schemaRule.Expression != "" {
+			env, _ = expression.NewAccessControlEnv(config)
+
+			break
+		}
+	}
+
+	for i, schemaRule := range config.AccessControl.Rules {
+		rules = append(rules, NewAccessControlRule(i+1, schemaRule, env))
 	}
 
 	return rules
 }
 
 // NewAccessControlRule parses a schema ACL and generates an internal ACL.
-func NewAccessControlRule(pos int, rule schema.AccessControlRule) *AccessControlRule {
+func NewAccessControlRule(pos int, rule schema.AccessControlRule, env *expression.AccessControlEnv) *AccessControlRule {
 	r := &AccessControlRule{
-		Position: pos,
-		Query:    NewAccessControlQuery(rule.Query),
-		Methods:  schemaMethodsToACL(rule.Methods),
-		Networks: AccessControlNetworks(rule.Networks),
-		Subjects: schemaSubjectsToACL(rule.Subjects),
-		Policy:   NewLevel(rule.Policy),
+		Position:    pos,
+		Query:       NewAccessControlQuery(rule.Query),
+		Headers:     NewAccessControlHeaders(rule.Headers),
+		Methods:     schemaMethodsToACL(rule.Methods),
+		Networks:    AccessControlNetworks(rule.Networks),
+		Subjects:    schemaSubjectsToACL(rule.Subjects),
+		Expression:  NewAccessControlExpression(env, rule.Expression),
+		Policy:      NewLevel(rule.Policy),
+		RequiredAMR: AccessControlRequiredAMR(rule.RequiredAMR),
+		MaxAge:      rule.MaxAge,
+
+		DisableTrustedDevices: rule.DisableTrustedDevices,
 	}
 
-	if len(r.Subjects) != 0 {
+	r.Schedule, _ = NewAccessControlSchedule(rule.Schedule)
+
+	if len(r.Subjects) != 0 || (r.Expression != nil && r.Expression.HasSubject()) {
 		r.HasSubjects = true
 	}
 
@@ -40,18 +61,32 @@ func NewAccessControlRule(pos int, rule schema.AccessControlRule) *AccessControl
 type AccessControlRule struct {
 	HasSubjects bool
 
-	Position  int
-	Domains   []AccessControlDomain
-	Resources []AccessControlResource
-	Query     []AccessControlQuery
-	Methods   []string
-	Networks  AccessControlNetworks
-	Subjects  []AccessControlSubjects
-	Policy    Level
+	Position   int
+	Domains    []AccessControlDomain
+	Resources  []AccessControlResource
+	Query      []AccessControlQuery
+	Headers    []AccessControlHeaders
+	Methods    []string
+	Networks   AccessControlNetworks
+	Subjects   []AccessControlSubjects
+	Schedule   *AccessControlSchedule
+	Expression *AccessControlExpression
+	Policy     Level
+
+	RequiredAMR AccessControlRequiredAMR
+	MaxAge      time.Duration
+
+	DisableTrustedDevices bool
 }
 
-// IsMatch returns true if all elements of an AccessControlRule match the object and subject.
-func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match bool) {
+// AllowsTrustedDevices returns true if a user who has completed the first factor from a trusted device satisfies the
+// two_factor policy of this rule. A nil rule represents the default policy which always allows trusted devices.
+func (acr *AccessControlRule) AllowsTrustedDevices() (allowed bool) {
+	return acr == nil || !acr.DisableTrustedDevices
+}
+
+// IsMatch returns true if all elements of an AccessControlRule match the object and subject at the given time.
+func (acr *AccessControlRule) IsMatch(subject Subject, object Object, now time.Time) (match bool) {
 	if !acr.MatchesDomains(subject, object) {
 		return false
 	}
@@ -64,6 +99,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesHeaders(object) {
+		return false
+	}
+
 	if !acr.MatchesMethods(object) {
 		return false
 	}
@@ -72,10 +111,18 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesSchedule(now) {
+		return false
+	}
+
 	if !acr.MatchesSubjects(subject) {
 		return false
 	}
 
+	if !acr.MatchesExpression(subject, object, now) {
+		return false
+	}
+
 	return true
 }
 
@@ -124,6 +171,21 @@ func (acr *AccessControlRule) MatchesQuery(object Object) (match bool) {
 	return false
 }
 
+// MatchesHeaders returns true if the rule matches the request headers.
+func (acr *AccessControlRule) MatchesHeaders(object Object) (match bool) {
+	if len(acr.Headers) == 0 {
+		return true
+	}
+
+	for _, headers := range acr.Headers {
+		if headers.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MatchesMethods returns true if the rule matches the method.
 func (acr *AccessControlRule) MatchesMethods(object Object) (match bool) {
 	if len(acr.Methods) == 0 {
@@ -138,6 +200,15 @@ func (acr *AccessControlRule) MatchesNetworks(subject Subject) (match bool) {
 	return acr.Networks.IsMatch(subject)
 }
 
+// MatchesSchedule returns true if the rule matches the schedule at the given time.
+func (acr *AccessControlRule) MatchesSchedule(now time.Time) (match bool) {
+	if acr.Schedule == nil {
+		return true
+	}
+
+	return acr.Schedule.IsMatch(now)
+}
+
 // MatchesSubjects returns true if the rule matches the subjects.
 func (acr *AccessControlRule) MatchesSubjects(subject Subject) (match bool) {
 	if subject.IsAnonymous() {
@@ -163,3 +234,22 @@ func (acr *AccessControlRule) MatchesSubjectExact(subject Subject) (match bool)
 
 	return false
 }
+
+// MatchesExpression returns true if the rule matches the expression. Expressions which refer to the user are considered
+// a match for anonymous users in the same way subjects are.
+func (acr *AccessControlRule) MatchesExpression(subject Subject, object Object, now time.Time) (match bool) {
+	if acr.Expression != nil && acr.Expression.HasSubject() && subject.IsAnonymous() {
+		return true
+	}
+
+	return acr.MatchesExpressionExact(subject, object, now)
+}
+
+// MatchesExpressionExact returns true if the rule matches the expression exactly.
+func (acr *AccessControlRule) MatchesExpressionExact(subject Subject, object Object, now time.Time) (match bool) {
+	if acr.Expression == nil {
+		return true
+	}
+
+	return acr.Expression.IsMatch(subject, object, now)
+}
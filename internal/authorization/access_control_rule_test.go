@@ -31,3 +31,33 @@ func TestAccessControlRule_MatchesSubjectExact(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessControlRule_AllowsTrustedDevices(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *AccessControlRule
+		expected bool
+	}{
+		{
+			"ShouldAllowDefaultPolicy",
+			nil,
+			true,
+		},
+		{
+			"ShouldAllowRule",
+			&AccessControlRule{Policy: TwoFactor},
+			true,
+		},
+		{
+			"ShouldNotAllowRuleWithTrustedDevicesDisabled",
+			&AccessControlRule{Policy: TwoFactor, DisableTrustedDevices: true},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.AllowsTrustedDevices())
+		})
+	}
+}
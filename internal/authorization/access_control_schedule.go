@@ -0,0 +1,121 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewAccessControlSchedule creates a new AccessControlSchedule rule type. If the schedule is invalid the returned rule
+// never matches and the error describes the problem.
+func NewAccessControlSchedule(config *schema.AccessControlRuleSchedule) (rule *AccessControlSchedule, err error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	rule = &AccessControlSchedule{
+		Location: time.Local,
+	}
+
+	if config.TimeZone != "" {
+		var location *time.Location
+
+		if location, err = time.LoadLocation(config.TimeZone); err != nil {
+			return rule, fmt.Errorf("option 'timezone' is invalid: %w", err)
+		}
+
+		rule.Location = location
+	}
+
+	windows := make([]AccessControlScheduleWindow, len(config.Windows))
+
+	for i, window := range config.Windows {
+		if windows[i], err = NewAccessControlScheduleWindow(window); err != nil {
+			return rule, fmt.Errorf("window #%d: %w", i+1, err)
+		}
+	}
+
+	rule.Windows = windows
+
+	return rule, nil
+}
+
+// NewAccessControlScheduleWindow creates a new AccessControlScheduleWindow from a schema.AccessControlRuleScheduleWindow.
+func NewAccessControlScheduleWindow(config schema.AccessControlRuleScheduleWindow) (window AccessControlScheduleWindow, err error) {
+	window.End = minutesInDay
+
+	if len(config.Days) == 0 {
+		for day := range window.Days {
+			window.Days[day] = true
+		}
+	}
+
+	for _, value := range config.Days {
+		day, ok := scheduleDays[strings.ToLower(value)]
+		if !ok {
+			return window, fmt.Errorf("option 'days' has an invalid value '%s'", value)
+		}
+
+		window.Days[day] = true
+	}
+
+	if config.Start != "" {
+		if window.Start, err = utils.ParseTimeOfDay(config.Start); err != nil {
+			return window, fmt.Errorf("option 'start' is invalid: %w", err)
+		}
+	}
+
+	if config.End != "" {
+		if window.End, err = utils.ParseTimeOfDay(config.End); err != nil {
+			return window, fmt.Errorf("option 'end' is invalid: %w", err)
+		}
+	}
+
+	if window.Start == window.End {
+		return window, fmt.Errorf("options 'start' and 'end' must not be the same time")
+	}
+
+	return window, nil
+}
+
+// AccessControlSchedule represents an ACL schedule rule.
+type AccessControlSchedule struct {
+	Location *time.Location
+	Windows  []AccessControlScheduleWindow
+}
+
+// IsMatch returns true if the given time is within any of the windows of this rule.
+func (acs *AccessControlSchedule) IsMatch(now time.Time) (match bool) {
+	now = now.In(acs.Location)
+
+	for _, window := range acs.Windows {
+		if window.IsMatch(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccessControlScheduleWindow represents a single window of an ACL schedule rule. The Start and End values are the
+// number of minutes since midnight, and when the End is before the Start the window spans midnight.
+type AccessControlScheduleWindow struct {
+	Days       [7]bool
+	Start, End int
+}
+
+// IsMatch returns true if the given time is within this window. The time is expected to already be in the relevant
+// location.
+func (w AccessControlScheduleWindow) IsMatch(now time.Time) (match bool) {
+	minute, day := now.Hour()*60+now.Minute(), now.Weekday()
+
+	if w.Start < w.End {
+		return w.Days[day] && minute >= w.Start && minute < w.End
+	}
+
+	// The window spans midnight so it either started today or started yesterday.
+	return (w.Days[day] && minute >= w.Start) || (w.Days[(day+6)%7] && minute < w.End)
+}
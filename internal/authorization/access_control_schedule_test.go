@@ -0,0 +1,159 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlSchedule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.AccessControlRuleSchedule
+		expected *AccessControlSchedule
+		err      string
+	}{
+		{
+			"ShouldReturnNilWhenAbsent",
+			nil,
+			nil,
+			"",
+		},
+		{
+			"ShouldParseDefaults",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{}},
+			},
+			&AccessControlSchedule{
+				Location: time.UTC,
+				Windows:  []AccessControlScheduleWindow{{Days: [7]bool{true, true, true, true, true, true, true}, Start: 0, End: 1440}},
+			},
+			"",
+		},
+		{
+			"ShouldParseWindow",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{Days: []string{"Monday", "friday"}, Start: "08:00", End: "18:30"}},
+			},
+			&AccessControlSchedule{
+				Location: time.UTC,
+				Windows:  []AccessControlScheduleWindow{{Days: [7]bool{false, true, false, false, false, true, false}, Start: 480, End: 1110}},
+			},
+			"",
+		},
+		{
+			"ShouldErrorInvalidTimeZone",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{}},
+			},
+			&AccessControlSchedule{Location: time.Local},
+			"option 'timezone' is invalid: unknown time zone Mars/Olympus_Mons",
+		},
+		{
+			"ShouldErrorInvalidDay",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{}, {Days: []string{"funday"}}},
+			},
+			&AccessControlSchedule{Location: time.UTC},
+			"window #2: option 'days' has an invalid value 'funday'",
+		},
+		{
+			"ShouldErrorInvalidStart",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{Start: "8am"}},
+			},
+			&AccessControlSchedule{Location: time.UTC},
+			"window #1: option 'start' is invalid: time of day '8am' must be in the 'HH:MM' format",
+		},
+		{
+			"ShouldErrorInvalidEnd",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{End: "25:00"}},
+			},
+			&AccessControlSchedule{Location: time.UTC},
+			"window #1: option 'end' is invalid: time of day '25:00' has an invalid hour value",
+		},
+		{
+			"ShouldErrorSameStartAndEnd",
+			&schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{Start: "10:00", End: "10:00"}},
+			},
+			&AccessControlSchedule{Location: time.UTC},
+			"window #1: options 'start' and 'end' must not be the same time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := NewAccessControlSchedule(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+
+			if tc.err != "" {
+				require.NotNil(t, actual)
+				assert.False(t, actual.IsMatch(time.Now()))
+			}
+		})
+	}
+}
+
+func TestAccessControlScheduleIsMatch(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	weekdays := &AccessControlSchedule{
+		Location: berlin,
+		Windows: []AccessControlScheduleWindow{
+			{Days: [7]bool{false, true, true, true, true, true, false}, Start: 480, End: 1080},
+		},
+	}
+
+	overnight := &AccessControlSchedule{
+		Location: time.UTC,
+		Windows: []AccessControlScheduleWindow{
+			{Days: [7]bool{false, false, false, false, false, true, false}, Start: 1320, End: 360},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		have     *AccessControlSchedule
+		now      time.Time
+		expected bool
+	}{
+		{"ShouldMatchWeekdayWithinWindow", weekdays, time.Date(2024, time.January, 1, 9, 0, 0, 0, berlin), true},
+		{"ShouldMatchWeekdayAtStart", weekdays, time.Date(2024, time.January, 1, 8, 0, 0, 0, berlin), true},
+		{"ShouldNotMatchWeekdayAtEnd", weekdays, time.Date(2024, time.January, 1, 18, 0, 0, 0, berlin), false},
+		{"ShouldNotMatchWeekdayBeforeStart", weekdays, time.Date(2024, time.January, 1, 7, 59, 0, 0, berlin), false},
+		{"ShouldNotMatchWeekend", weekdays, time.Date(2024, time.January, 6, 9, 0, 0, 0, berlin), false},
+		{"ShouldMatchUsingLocation", weekdays, time.Date(2024, time.January, 1, 7, 30, 0, 0, time.UTC), true},
+		{"ShouldNotMatchUsingLocation", weekdays, time.Date(2024, time.January, 1, 17, 30, 0, 0, time.UTC), false},
+		{"ShouldMatchOvernightStartDay", overnight, time.Date(2024, time.January, 5, 23, 0, 0, 0, time.UTC), true},
+		{"ShouldMatchOvernightNextDay", overnight, time.Date(2024, time.January, 6, 5, 59, 0, 0, time.UTC), true},
+		{"ShouldNotMatchOvernightNextDayAfterEnd", overnight, time.Date(2024, time.January, 6, 6, 0, 0, 0, time.UTC), false},
+		{"ShouldNotMatchOvernightStartDayMorning", overnight, time.Date(2024, time.January, 5, 5, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.IsMatch(tc.now))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package authorization
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/random"
+)
+
+// NewAuditLogger creates a new *AuditLogger which writes access control decisions to the configured file. The file
+// must be opened with Open before decisions are logged.
+func NewAuditLogger(config schema.AccessControlAuditLog, random random.Provider) (logger *AuditLogger) {
+	return &AuditLogger{
+		file:   logging.NewFile(config.FilePath),
+		rate:   config.SampleRate,
+		random: random,
+	}
+}
+
+// AuditLogger writes access control decisions as JSON lines to a dedicated file.
+type AuditLogger struct {
+	file   *logging.File
+	rate   float64
+	random random.Provider
+}
+
+// Open the audit log file.
+func (l *AuditLogger) Open() (err error) {
+	return l.file.Open()
+}
+
+// Reopen the audit log file, primarily to facilitate log rotation.
+func (l *AuditLogger) Reopen() (err error) {
+	return l.file.Reopen()
+}
+
+// Close the audit log file.
+func (l *AuditLogger) Close() (err error) {
+	return l.file.Close()
+}
+
+// IsSampled returns true if a decision should be written to the audit log according to the sample rate.
+func (l *AuditLogger) IsSampled() (sampled bool) {
+	switch {
+	case l.rate >= 1:
+		return true
+	case l.rate <= 0:
+		return false
+	default:
+		return l.random.Intn(auditSampleResolution) < int(l.rate*auditSampleResolution)
+	}
+}
+
+// Log writes the decision to the audit log if it's sampled.
+func (l *AuditLogger) Log(decision AuditDecision) (err error) {
+	if !l.IsSampled() {
+		return nil
+	}
+
+	var data []byte
+
+	if data, err = json.Marshal(decision); err != nil {
+		return err
+	}
+
+	_, err = l.file.Write(append(data, '\n'))
+
+	return err
+}
+
+// NewAuditDecision creates a new AuditDecision. The position is the position of the rule which matched, or 0 if the
+// default policy was applied.
+func NewAuditDecision(now time.Time, subject Subject, object Object, position int, level Level, status int) (decision AuditDecision) {
+	decision = AuditDecision{
+		Time: now,
+		Subject: AuditSubject{
+			Username: subject.Username,
+			Groups:   subject.Groups,
+			ClientID: subject.ClientID,
+		},
+		Object: AuditObject{
+			URL:    object.String(),
+			Method: object.Method,
+		},
+		Policy: level.String(),
+		Status: status,
+	}
+
+	if subject.IP != nil {
+		decision.Subject.IP = subject.IP.String()
+	}
+
+	if position > 0 {
+		decision.RulePosition = &position
+	}
+
+	return decision
+}
+
+// AuditDecision is a single access control decision written to the audit log.
+type AuditDecision struct {
+	Time         time.Time    `json:"time"`
+	Subject      AuditSubject `json:"subject"`
+	Object       AuditObject  `json:"object"`
+	RulePosition *int         `json:"rule_position"`
+	Policy       string       `json:"policy"`
+	Status       int          `json:"status"`
+}
+
+// AuditSubject is the subject of an AuditDecision.
+type AuditSubject struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	ClientID string   `json:"client_id,omitempty"`
+	IP       string   `json:"ip,omitempty"`
+}
+
+// AuditObject is the object of an AuditDecision.
+type AuditObject struct {
+	URL    string `json:"url"`
+	Method string `json:"method,omitempty"`
+}
@@ -0,0 +1,131 @@
+package authorization
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/random"
+)
+
+func TestNewAuditDecision(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+
+	targetURL, err := url.ParseRequestURI("https://app.example.com/admin")
+	require.NoError(t, err)
+
+	object := NewObject(targetURL, fasthttp.MethodPost)
+
+	testCases := []struct {
+		name     string
+		subject  Subject
+		position int
+		level    Level
+		status   int
+		expected string
+	}{
+		{
+			"ShouldIncludeRulePosition",
+			Subject{Username: "john", Groups: []string{"admins", "dev"}, IP: net.ParseIP("192.168.1.10")},
+			3,
+			TwoFactor,
+			fasthttp.StatusOK,
+			`{"time":"2023-11-14T22:13:20Z","subject":{"username":"john","groups":["admins","dev"],"ip":"192.168.1.10"},"object":{"url":"https://app.example.com/admin","method":"POST"},"rule_position":3,"policy":"two_factor","status":200}`,
+		},
+		{
+			"ShouldOmitRulePositionForDefaultPolicy",
+			Subject{ClientID: "app", IP: net.ParseIP("10.0.0.1")},
+			0,
+			Denied,
+			fasthttp.StatusForbidden,
+			`{"time":"2023-11-14T22:13:20Z","subject":{"client_id":"app","ip":"10.0.0.1"},"object":{"url":"https://app.example.com/admin","method":"POST"},"rule_position":null,"policy":"deny","status":403}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(NewAuditDecision(now, tc.subject, object, tc.position, tc.level, tc.status))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestAuditLogger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rate     float64
+		expected int
+	}{
+		{"ShouldLogAllDecisions", 1, 10},
+		{"ShouldLogNoDecisions", 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+
+			logger := NewAuditLogger(schema.AccessControlAuditLog{FilePath: path, SampleRate: tc.rate}, random.New())
+
+			require.NoError(t, logger.Open())
+
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, logger.Log(AuditDecision{Time: time.Now(), Policy: "bypass", Status: fasthttp.StatusOK}))
+			}
+
+			require.NoError(t, logger.Close())
+
+			file, err := os.Open(path)
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			scanner := bufio.NewScanner(file)
+
+			lines := 0
+
+			for scanner.Scan() {
+				var decision AuditDecision
+
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &decision))
+				assert.Equal(t, "bypass", decision.Policy)
+
+				lines++
+			}
+
+			assert.Equal(t, tc.expected, lines)
+		})
+	}
+}
+
+func TestAuditLoggerShouldSample(t *testing.T) {
+	logger := NewAuditLogger(schema.AccessControlAuditLog{SampleRate: 0.5}, random.New())
+
+	sampled := 0
+
+	for i := 0; i < 1000; i++ {
+		if logger.IsSampled() {
+			sampled++
+		}
+	}
+
+	assert.Greater(t, sampled, 0)
+	assert.Less(t, sampled, 1000)
+}
+
+func TestAuditLoggerShouldErrorWhenNotOpen(t *testing.T) {
+	logger := NewAuditLogger(schema.AccessControlAuditLog{FilePath: filepath.Join(t.TempDir(), "audit.log"), SampleRate: 1}, random.New())
+
+	assert.EqualError(t, logger.Log(AuditDecision{}), "error writing log file: file is not open")
+}
@@ -1,8 +1,13 @@
 package authorization
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/sirupsen/logrus"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 )
@@ -12,17 +17,48 @@ type Authorizer struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
 	mfa           bool
+	details       bool
+	headers       bool
+	clock         clock.Provider
+	loader        ConfigurationLoader
+	mutex         sync.RWMutex
 	log           *logrus.Logger
 }
 
+// ConfigurationLoader is a function which loads and validates the configuration the Authorizer is reloaded from.
+type ConfigurationLoader func() (config *schema.Configuration, err error)
+
 // NewAuthorizer create an instance of authorizer with a given access control config.
 func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
+	return NewAuthorizerWithClock(config, clock.New())
+}
+
+// NewAuthorizerWithClock create an instance of authorizer with a given access control config. The clock.Provider is
+// used to determine the time rules are evaluated at.
+func NewAuthorizerWithClock(config *schema.Configuration, clock clock.Provider) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
 		defaultPolicy: NewLevel(config.AccessControl.DefaultPolicy),
-		rules:         NewAccessControlRules(config.AccessControl),
+		rules:         NewAccessControlRules(config),
+		clock:         clock,
 		log:           logging.Logger(),
 	}
 
+	for _, rule := range authorizer.rules {
+		if len(rule.Headers) != 0 {
+			authorizer.headers = true
+		}
+
+		if rule.Expression == nil {
+			continue
+		}
+
+		authorizer.headers = true
+
+		if rule.Expression.HasSubject() {
+			authorizer.details = true
+		}
+	}
+
 	if authorizer.defaultPolicy == TwoFactor {
 		authorizer.mfa = true
 
@@ -42,21 +78,92 @@ func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
 	return authorizer
 }
 
+// SetConfigurationLoader sets the ConfigurationLoader used to reload the Authorizer. It must be called before the
+// Authorizer is used concurrently.
+func (p *Authorizer) SetConfigurationLoader(loader ConfigurationLoader) {
+	p.loader = loader
+}
+
+// Reload the rules from the configuration returned by the ConfigurationLoader. The rules are swapped atomically so
+// requests are evaluated either entirely against the previous rules or entirely against the new rules.
+func (p *Authorizer) Reload() (reloaded bool, err error) {
+	if p.loader == nil {
+		return false, errors.New("no configuration loader is configured")
+	}
+
+	var config *schema.Configuration
+
+	if config, err = p.loader(); err != nil {
+		return false, fmt.Errorf("failed to reload the access control configuration: %w", err)
+	}
+
+	next := NewAuthorizerWithClock(config, p.clock)
+
+	p.mutex.Lock()
+
+	previous := len(p.rules)
+
+	p.defaultPolicy, p.rules, p.mfa, p.details, p.headers = next.defaultPolicy, next.rules, next.mfa, next.details, next.headers
+
+	p.mutex.Unlock()
+
+	p.log.WithFields(map[string]any{"rules_previous": previous, "rules_current": len(next.rules), "default_policy": next.defaultPolicy.String()}).
+		Infof("Reloaded the access control rules with %d rules (%+d)", len(next.rules), len(next.rules)-previous)
+
+	return true, nil
+}
+
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
 func (p *Authorizer) IsSecondFactorEnabled() bool {
+	p.mutex.RLock()
+
+	defer p.mutex.RUnlock()
+
 	return p.mfa
 }
 
+// IsUserDetailsRequired returns true if any rule requires the Subject to include the user details to be evaluated.
+func (p *Authorizer) IsUserDetailsRequired() bool {
+	p.mutex.RLock()
+
+	defer p.mutex.RUnlock()
+
+	return p.details
+}
+
+// IsRequestHeadersRequired returns true if any rule requires the Object to include the request headers to be evaluated.
+func (p *Authorizer) IsRequestHeadersRequired() bool {
+	p.mutex.RLock()
+
+	defer p.mutex.RUnlock()
+
+	return p.headers
+}
+
 // GetRequiredLevel retrieve the required level of authorization to access the object.
 func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level) {
+	rule, level := p.GetRequiredRule(subject, object)
+
+	return rule != nil && rule.HasSubjects, level
+}
+
+// GetRequiredRule is the same as GetRequiredLevel but returns the rule which matched instead of whether it has
+// subjects, which is nil when no rule matched and the default policy was applied.
+func (p *Authorizer) GetRequiredRule(subject Subject, object Object) (rule *AccessControlRule, level Level) {
 	p.log.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
-		if rule.IsMatch(subject, object) {
+	now := p.clock.Now()
+
+	p.mutex.RLock()
+
+	defer p.mutex.RUnlock()
+
+	for _, rule = range p.rules {
+		if rule.IsMatch(subject, object, now) {
 			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method, rule.Policy)
 
-			return rule.HasSubjects, rule.Policy
+			return rule, rule.Policy
 		}
 
 		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method, rule.Policy)
@@ -64,13 +171,19 @@ func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjec
 
 	p.log.Debugf("No matching rule for subject %s and url %s (method %s) applying default policy", subject, object, object.Method)
 
-	return false, p.defaultPolicy
+	return nil, p.defaultPolicy
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
 
+	now := p.clock.Now()
+
+	p.mutex.RLock()
+
+	defer p.mutex.RUnlock()
+
 	results = make([]RuleMatchResult, len(p.rules))
 
 	for i, rule := range p.rules {
@@ -81,10 +194,15 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 			MatchDomain:        rule.MatchesDomains(subject, object),
 			MatchResources:     rule.MatchesResources(subject, object),
 			MatchQuery:         rule.MatchesQuery(object),
+			MatchHeaders:       rule.MatchesHeaders(object),
 			MatchMethods:       rule.MatchesMethods(object),
 			MatchNetworks:      rule.MatchesNetworks(subject),
+			MatchSchedule:      rule.MatchesSchedule(now),
 			MatchSubjects:      rule.MatchesSubjects(subject),
 			MatchSubjectsExact: rule.MatchesSubjectExact(subject),
+
+			MatchExpression:      rule.MatchesExpression(subject, object, now),
+			MatchExpressionExact: rule.MatchesExpressionExact(subject, object, now),
 		}
 
 		skipped = skipped || results[i].IsMatch()
@@ -1,17 +1,23 @@
 package authorization
 
 import (
+	"errors"
 	"net"
+	"net/http"
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -364,6 +370,147 @@ func (s *AuthorizerSuite) TestShouldCheckQueryPolicy() {
 	}
 }
 
+func (s *AuthorizerSuite) TestShouldCheckHeadersPolicy() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"one.example.com"},
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{
+						Operator: operatorEqual,
+						Key:      "X-Api-Version",
+						Value:    "2",
+					},
+					{
+						Operator: operatorAbsent,
+						Key:      "x-debug",
+					},
+				},
+				{
+					{
+						Operator: operatorPresent,
+						Key:      "X-Client-Cert",
+					},
+				},
+			},
+			Policy: oneFactor,
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"two.example.com"},
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{
+						Operator: operatorNotPattern,
+						Key:      "User-Agent",
+						Value:    regexp.MustCompile(`(?i)^curl/`),
+					},
+				},
+			},
+			Policy: twoFactor,
+		}).
+		Build()
+
+	s.Assert().True(tester.IsRequestHeadersRequired())
+	s.Assert().False(tester.IsUserDetailsRequired())
+
+	testCases := []struct {
+		name, requestURL string
+		header           http.Header
+		expected         Level
+	}{
+		{"ShouldAllow1FAEqualRule", "https://one.example.com/", http.Header{"X-Api-Version": []string{"2"}}, OneFactor},
+		{"ShouldDenyEqualRuleWithAbsentRuleMiss", "https://one.example.com/", http.Header{"X-Api-Version": []string{"2"}, "X-Debug": []string{"1"}}, Denied},
+		{"ShouldDenyEqualRuleMiss", "https://one.example.com/", http.Header{"X-Api-Version": []string{"1"}}, Denied},
+		{"ShouldAllow1FAPresentRule", "https://one.example.com/", http.Header{"X-Client-Cert": []string{""}}, OneFactor},
+		{"ShouldDenyNoHeaders", "https://one.example.com/", nil, Denied},
+		{"ShouldAllow2FANotPatternRule", "https://two.example.com/", http.Header{"User-Agent": []string{"Mozilla/5.0"}}, TwoFactor},
+		{"ShouldAllow2FANotPatternRuleNoHeaders", "https://two.example.com/", nil, TwoFactor},
+		{"ShouldDenyNotPatternRuleMiss", "https://two.example.com/", http.Header{"User-Agent": []string{"curl/8.0.1"}}, Denied},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			targetURL, _ := url.ParseRequestURI(tc.requestURL)
+
+			object := NewObject(targetURL, fasthttp.MethodGet)
+			object.Header = tc.header
+
+			_, level := tester.GetRequiredLevel(UserWithGroups, object)
+
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
+func TestAuthorizerShouldCheckSchedule(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:  []string{"app.example.com"},
+					Subjects: [][]string{{"group:contractors"}},
+					Policy:   twoFactor,
+					Schedule: &schema.AccessControlRuleSchedule{
+						TimeZone: "America/New_York",
+						Windows: []schema.AccessControlRuleScheduleWindow{
+							{
+								Days:  []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+								Start: "08:00",
+								End:   "18:00",
+							},
+						},
+					},
+				},
+				{
+					Domains:  []string{"app.example.com"},
+					Subjects: [][]string{{"group:contractors"}},
+					Policy:   deny,
+				},
+				{
+					Domains: []string{"app.example.com"},
+					Policy:  oneFactor,
+				},
+			},
+		},
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	clk := clock.NewFixed(time.Date(2024, time.January, 1, 9, 0, 0, 0, newYork))
+
+	authorizer := NewAuthorizerWithClock(config, clk)
+
+	contractor := Subject{Username: "alice", Groups: []string{"contractors"}, IP: net.ParseIP("10.0.0.1")}
+
+	testCases := []struct {
+		name     string
+		now      time.Time
+		subject  Subject
+		expected Level
+	}{
+		{"ShouldAllowContractorDuringWindow", time.Date(2024, time.January, 1, 9, 0, 0, 0, newYork), contractor, TwoFactor},
+		{"ShouldDenyContractorAfterWindow", time.Date(2024, time.January, 1, 18, 30, 0, 0, newYork), contractor, Denied},
+		{"ShouldDenyContractorOnWeekend", time.Date(2024, time.January, 6, 9, 0, 0, 0, newYork), contractor, Denied},
+		{"ShouldEvaluateInTimeZone", time.Date(2024, time.January, 1, 22, 59, 0, 0, time.UTC), contractor, TwoFactor},
+		{"ShouldAllowOtherUsersOutsideWindow", time.Date(2024, time.January, 6, 9, 0, 0, 0, newYork), UserWithGroups, OneFactor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clk.Set(tc.now)
+
+			targetURL, _ := url.ParseRequestURI("https://app.example.com/")
+
+			_, level := authorizer.GetRequiredLevel(tc.subject, NewObject(targetURL, fasthttp.MethodGet))
+
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
 func (s *AuthorizerSuite) TestShouldCheckRulePrecedence() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -1079,6 +1226,132 @@ func TestNewAuthorizer(t *testing.T) {
 	assert.Equal(t, "admins", group.Name)
 }
 
+func TestAuthorizerReload(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  oneFactor,
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config)
+
+	targetURL, err := url.ParseRequestURI("https://example.com")
+	require.NoError(t, err)
+
+	subject := Subject{Username: "john", IP: net.ParseIP("127.0.0.1")}
+	object := NewObject(targetURL, fasthttp.MethodGet)
+
+	reloaded, err := authorizer.Reload()
+	assert.False(t, reloaded)
+	assert.EqualError(t, err, "no configuration loader is configured")
+
+	authorizer.SetConfigurationLoader(func() (*schema.Configuration, error) {
+		return nil, errors.New("bad configuration")
+	})
+
+	reloaded, err = authorizer.Reload()
+	assert.False(t, reloaded)
+	assert.EqualError(t, err, "failed to reload the access control configuration: bad configuration")
+
+	_, level := authorizer.GetRequiredLevel(subject, object)
+	assert.Equal(t, OneFactor, level)
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+
+	authorizer.SetConfigurationLoader(func() (*schema.Configuration, error) {
+		return &schema.Configuration{
+			AccessControl: schema.AccessControl{
+				DefaultPolicy: deny,
+				Rules: []schema.AccessControlRule{
+					{
+						Domains: []string{"example.com"},
+						Policy:  twoFactor,
+					},
+					{
+						Domains: []string{"*.example.com"},
+						Policy:  bypass,
+					},
+				},
+			},
+		}, nil
+	})
+
+	reloaded, err = authorizer.Reload()
+	assert.True(t, reloaded)
+	assert.NoError(t, err)
+
+	_, level = authorizer.GetRequiredLevel(subject, object)
+	assert.Equal(t, TwoFactor, level)
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+	assert.Len(t, authorizer.GetRuleMatchResults(subject, object), 2)
+}
+
+func TestAuthorizerGetRequiredRule(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"public.example.com"},
+					Policy:  bypass,
+				},
+				{
+					Domains:     []string{"example.com"},
+					Policy:      twoFactor,
+					Subjects:    [][]string{{"group:admins"}},
+					RequiredAMR: []string{"hwk"},
+					MaxAge:      time.Minute * 15,
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config)
+
+	testCases := []struct {
+		name                string
+		subject             Subject
+		uri                 string
+		expectedPosition    int
+		expectedHasSubjects bool
+		expectedRequiredAMR AccessControlRequiredAMR
+		expectedMaxAge      time.Duration
+		expected            Level
+	}{
+		{"ShouldMatchFirstRule", Subject{}, "https://public.example.com", 1, false, nil, 0, Bypass},
+		{"ShouldMatchSecondRule", Subject{Username: "john", Groups: []string{"admins"}}, "https://example.com", 2, true, AccessControlRequiredAMR{"hwk"}, time.Minute * 15, TwoFactor},
+		{"ShouldMatchDefaultPolicy", Subject{Username: "john", Groups: []string{"dev"}}, "https://example.com", 0, false, nil, 0, Denied},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			targetURL, err := url.ParseRequestURI(tc.uri)
+			require.NoError(t, err)
+
+			rule, level := authorizer.GetRequiredRule(tc.subject, NewObject(targetURL, fasthttp.MethodGet))
+
+			assert.Equal(t, tc.expected, level)
+
+			if tc.expectedPosition == 0 {
+				assert.Nil(t, rule)
+
+				return
+			}
+
+			require.NotNil(t, rule)
+			assert.Equal(t, tc.expectedPosition, rule.Position)
+			assert.Equal(t, tc.expectedHasSubjects, rule.HasSubjects)
+			assert.Equal(t, tc.expectedRequiredAMR, rule.RequiredAMR)
+			assert.Equal(t, tc.expectedMaxAge, rule.MaxAge)
+		})
+	}
+}
+
 func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 	config := &schema.Configuration{
 		AccessControl: schema.AccessControl{
@@ -1146,3 +1419,158 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 	authorizer = NewAuthorizer(config)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
+
+func TestAuthorizerShouldCheckExpression(t *testing.T) {
+	config := &schema.Configuration{
+		AuthenticationBackend: schema.AuthenticationBackend{
+			File: &schema.AuthenticationBackendFile{
+				ExtraAttributes: map[string]schema.AuthenticationBackendExtraAttribute{
+					"department": {
+						ValueType: "string",
+					},
+				},
+			},
+		},
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:    []string{"public.example.com"},
+					Policy:     bypass,
+					Expression: "request.headers['x-api-version'] == '2' && remote_ip.startsWith('10.')",
+				},
+				{
+					Domains:    []string{"admin.example.com"},
+					Policy:     twoFactor,
+					Expression: "'admins' in groups && department == 'ops' && now.getDayOfWeek() in [1, 2, 3, 4, 5]",
+				},
+			},
+		},
+	}
+
+	monday := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+	clk := clock.NewFixed(monday)
+
+	authorizer := NewAuthorizerWithClock(config, clk)
+
+	assert.True(t, authorizer.IsRequestHeadersRequired())
+	assert.True(t, authorizer.IsUserDetailsRequired())
+
+	ops := &authentication.UserDetailsExtended{
+		UserDetails: &authentication.UserDetails{Username: "john", Groups: []string{"dev", "admins"}},
+		Extra:       map[string]any{"department": "ops"},
+	}
+
+	sales := &authentication.UserDetailsExtended{
+		UserDetails: &authentication.UserDetails{Username: "john", Groups: []string{"dev", "admins"}},
+		Extra:       map[string]any{"department": "sales"},
+	}
+
+	testCases := []struct {
+		name        string
+		now         time.Time
+		subject     Subject
+		details     *authentication.UserDetailsExtended
+		uri         string
+		header      http.Header
+		hasSubjects bool
+		expected    Level
+	}{
+		{"ShouldNotBypassWithHeaderFromOtherIP", monday, AnonymousUser, nil, "https://public.example.com/", http.Header{"X-Api-Version": []string{"2"}}, false, Denied},
+		{"ShouldBypassWithHeaderAndIP", monday, UserWithGroups, nil, "https://public.example.com/", http.Header{"X-Api-Version": []string{"2"}}, false, Bypass},
+		{"ShouldNotBypassWithoutHeader", monday, UserWithGroups, nil, "https://public.example.com/", nil, false, Denied},
+		{"ShouldMatchUserAttributesOnWeekday", monday, UserWithGroups, ops, "https://admin.example.com/", nil, true, TwoFactor},
+		{"ShouldNotMatchUserAttributesOnWeekend", saturday, UserWithGroups, ops, "https://admin.example.com/", nil, false, Denied},
+		{"ShouldNotMatchOtherUserAttributes", monday, UserWithGroups, sales, "https://admin.example.com/", nil, false, Denied},
+		{"ShouldNotMatchWithoutUserDetails", monday, UserWithGroups, nil, "https://admin.example.com/", nil, false, Denied},
+		{"ShouldPotentiallyMatchAnonymous", monday, AnonymousUser, nil, "https://admin.example.com/", nil, true, TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clk.Set(tc.now)
+
+			subject := tc.subject
+
+			if tc.details != nil {
+				subject.Details = tc.details
+			}
+
+			targetURL, _ := url.ParseRequestURI(tc.uri)
+
+			object := NewObject(targetURL, fasthttp.MethodGet)
+			object.Header = tc.header
+
+			hasSubjects, level := authorizer.GetRequiredLevel(subject, object)
+
+			assert.Equal(t, tc.hasSubjects, hasSubjects)
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
+func TestAuthorizerShouldOnlyResolveDetailsForExpressionRules(t *testing.T) {
+	config := &schema.Configuration{
+		AuthenticationBackend: schema.AuthenticationBackend{
+			File: &schema.AuthenticationBackendFile{
+				ExtraAttributes: map[string]schema.AuthenticationBackendExtraAttribute{
+					"department": {
+						ValueType: "string",
+					},
+				},
+			},
+		},
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"public.example.com"},
+					Policy:  bypass,
+				},
+				{
+					Domains:    []string{"admin.example.com"},
+					Policy:     twoFactor,
+					Expression: "department == 'ops'",
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizerWithClock(config, clock.NewFixed(time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)))
+
+	testCases := []struct {
+		name     string
+		uri      string
+		calls    int
+		expected Level
+	}{
+		{"ShouldNotResolveForRuleWithoutExpression", "https://public.example.com/", 0, Bypass},
+		{"ShouldNotResolveForDefaultPolicy", "https://other.example.com/", 0, Denied},
+		{"ShouldResolveForRuleWithExpression", "https://admin.example.com/", 1, TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+
+			subject := UserWithGroups
+			subject.DetailsFunc = func() expression.UserDetailer {
+				calls++
+
+				return &authentication.UserDetailsExtended{
+					UserDetails: &authentication.UserDetails{Username: "john", Groups: []string{"dev", "admins"}},
+					Extra:       map[string]any{"department": "ops"},
+				}
+			}
+
+			targetURL, _ := url.ParseRequestURI(tc.uri)
+
+			_, level := authorizer.GetRequiredLevel(subject, NewObject(targetURL, fasthttp.MethodGet))
+
+			assert.Equal(t, tc.expected, level)
+			assert.Equal(t, tc.calls, calls)
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package authorization
 
+import (
+	"time"
+)
+
 // Level is the type representing an authorization level.
 type Level int
 
@@ -45,6 +49,18 @@ const (
 	operatorNotPattern = "not pattern"
 )
 
+const minutesInDay = 24 * 60
+
+var scheduleDays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
 const (
 	subexpNameUser  = "User"
 	subexpNameGroup = "Group"
@@ -55,6 +71,9 @@ var (
 	IdentitySubexpNames = []string{subexpNameUser, subexpNameGroup}
 )
 
+// auditSampleResolution is the resolution the audit log sample rate is applied with.
+const auditSampleResolution = 10000
+
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (method %s, policy %s)"
 
 // Authentication Method Reference Values https://datatracker.ietf.org/doc/html/rfc8176
@@ -0,0 +1,302 @@
+package authorization
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// LintAccessControl checks the access control configuration for rules which can never match because an earlier rule
+// fully shadows them, domain_regex patterns which overlap with the domains of other rules, and named networks which are
+// not used by any rule.
+func LintAccessControl(config schema.AccessControl) (issues []LintIssue) {
+	issues = append(issues, lintRulesShadowed(config.Rules)...)
+	issues = append(issues, lintRulesDomainRegex(config.Rules)...)
+	issues = append(issues, lintNetworksUnused(config)...)
+
+	return issues
+}
+
+// LintAccessControlSubjectGroups checks the access control rules for subjects which reference a group that is not in
+// the list of groups assigned to users.
+func LintAccessControlSubjectGroups(config schema.AccessControl, groups []string) (issues []LintIssue) {
+	for i, rule := range config.Rules {
+		for _, subjects := range rule.Subjects {
+			for _, subject := range subjects {
+				if !strings.HasPrefix(subject, prefixGroup) {
+					continue
+				}
+
+				if group := strings.Trim(subject[lenPrefixGroup:], " "); !utils.IsStringInSlice(group, groups) {
+					issues = append(issues, LintIssue{Position: i + 1, Message: fmt.Sprintf("subject references the group '%s' which is not assigned to any user", group)})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// LintIssue is an issue found while linting the access control configuration.
+type LintIssue struct {
+	// Position is the position of the rule the issue relates to, or 0 if it does not relate to a specific rule.
+	Position int
+
+	Message string
+}
+
+// String returns the LintIssue as a string.
+func (i LintIssue) String() string {
+	if i.Position == 0 {
+		return i.Message
+	}
+
+	return fmt.Sprintf("rule #%d: %s", i.Position, i.Message)
+}
+
+func lintRulesShadowed(rules []schema.AccessControlRule) (issues []LintIssue) {
+	for j := range rules {
+		for i := 0; i < j; i++ {
+			if lintRuleShadows(rules[i], rules[j]) {
+				issues = append(issues, LintIssue{Position: j + 1, Message: fmt.Sprintf("rule is shadowed by rule #%d and will never match", i+1)})
+
+				break
+			}
+		}
+	}
+
+	return issues
+}
+
+func lintRulesDomainRegex(rules []schema.AccessControlRule) (issues []LintIssue) {
+	for j := range rules {
+		for i := 0; i < j; i++ {
+			for _, pattern := range rules[j].DomainsRegex {
+				if lintRegexIsInSlice(pattern.String(), rules[i].DomainsRegex) {
+					issues = append(issues, LintIssue{Position: j + 1, Message: fmt.Sprintf("domain_regex pattern '%s' is also a domain_regex pattern of rule #%d", pattern.String(), i+1)})
+				}
+
+				for _, domain := range rules[i].Domains {
+					if lintDomainIsLiteral(domain) && pattern.MatchString(strings.ToLower(domain)) {
+						issues = append(issues, LintIssue{Position: j + 1, Message: fmt.Sprintf("domain_regex pattern '%s' overlaps with the domain '%s' of rule #%d", pattern.String(), domain, i+1)})
+					}
+				}
+			}
+
+			for _, pattern := range rules[i].DomainsRegex {
+				for _, domain := range rules[j].Domains {
+					if lintDomainIsLiteral(domain) && pattern.MatchString(strings.ToLower(domain)) {
+						issues = append(issues, LintIssue{Position: j + 1, Message: fmt.Sprintf("domain '%s' overlaps with the domain_regex pattern '%s' of rule #%d", domain, pattern.String(), i+1)})
+					}
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func lintNetworksUnused(config schema.AccessControl) (issues []LintIssue) {
+	// Named networks are expanded into the networks of each rule when the configuration is loaded, so a named network
+	// is considered used when every one of its networks is present in the networks of a single rule.
+outer:
+	for _, network := range config.Networks {
+		for _, rule := range config.Rules {
+			if lintNetworksContainsAll(rule.Networks, network.Networks) {
+				continue outer
+			}
+		}
+
+		issues = append(issues, LintIssue{Message: fmt.Sprintf("network '%s' is not used by any rule", network.Name)})
+	}
+
+	return issues
+}
+
+// lintRuleShadows returns true if every request which matches the later rule is also matched by the earlier rule.
+func lintRuleShadows(earlier, later schema.AccessControlRule) (shadows bool) {
+	return lintDomainsShadow(earlier, later) &&
+		lintRegexShadows(earlier.Resources, later.Resources) &&
+		lintMethodsShadow(earlier.Methods, later.Methods) &&
+		lintNetworksShadow(earlier.Networks, later.Networks) &&
+		lintSubjectsShadow(earlier.Subjects, later.Subjects) &&
+		(len(earlier.Query) == 0 || reflect.DeepEqual(earlier.Query, later.Query)) &&
+		(len(earlier.Headers) == 0 || reflect.DeepEqual(earlier.Headers, later.Headers)) &&
+		(earlier.Schedule == nil || reflect.DeepEqual(earlier.Schedule, later.Schedule)) &&
+		(earlier.Expression == "" || earlier.Expression == later.Expression)
+}
+
+func lintDomainsShadow(earlier, later schema.AccessControlRule) (shadows bool) {
+	for _, pattern := range later.DomainsRegex {
+		if !lintRegexIsInSlice(pattern.String(), earlier.DomainsRegex) {
+			return false
+		}
+	}
+
+outer:
+	for _, domain := range later.Domains {
+		domain = strings.ToLower(domain)
+
+		for _, d := range earlier.Domains {
+			if lintDomainShadows(strings.ToLower(d), domain) {
+				continue outer
+			}
+		}
+
+		if lintDomainIsLiteral(domain) {
+			for _, pattern := range earlier.DomainsRegex {
+				if pattern.MatchString(domain) {
+					continue outer
+				}
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintDomainShadows(earlier, later string) (shadows bool) {
+	switch {
+	case earlier == later:
+		return true
+	case strings.HasPrefix(earlier, "*.") && !strings.HasPrefix(later, "{"):
+		return strings.HasSuffix(later, earlier[1:])
+	default:
+		return false
+	}
+}
+
+func lintDomainIsLiteral(domain string) (literal bool) {
+	return !strings.HasPrefix(domain, "*.") && !strings.HasPrefix(domain, "{")
+}
+
+func lintRegexShadows(earlier, later schema.AccessControlRuleRegex) (shadows bool) {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(later) == 0 {
+		return false
+	}
+
+	for _, pattern := range later {
+		if !lintRegexIsInSlice(pattern.String(), earlier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lintRegexIsInSlice(needle string, haystack schema.AccessControlRuleRegex) (inSlice bool) {
+	for _, pattern := range haystack {
+		if pattern.String() == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lintMethodsShadow(earlier, later schema.AccessControlRuleMethods) (shadows bool) {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(later) == 0 {
+		return false
+	}
+
+	for _, method := range later {
+		if !utils.IsStringInSliceFold(method, earlier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lintNetworksShadow(earlier, later []*net.IPNet) (shadows bool) {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(later) == 0 {
+		return false
+	}
+
+outer:
+	for _, l := range later {
+		lones, lbits := l.Mask.Size()
+
+		for _, e := range earlier {
+			if eones, ebits := e.Mask.Size(); ebits == lbits && eones <= lones && e.Contains(l.IP) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintNetworksContainsAll(haystack, needles []*net.IPNet) (contains bool) {
+	if len(needles) == 0 {
+		return false
+	}
+
+outer:
+	for _, needle := range needles {
+		for _, network := range haystack {
+			if network.String() == needle.String() {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintSubjectsShadow(earlier, later schema.AccessControlRuleSubjects) (shadows bool) {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(later) == 0 {
+		return false
+	}
+
+	// The subjects are a list of alternatives where each alternative must match in full, so the earlier rule shadows
+	// the later rule when every alternative of the later rule includes all the criteria of an earlier alternative.
+outer:
+	for _, l := range later {
+		for _, e := range earlier {
+			if lintSubjectIncludesAll(l, e) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintSubjectIncludesAll(haystack, needles []string) (includes bool) {
+	for _, needle := range needles {
+		if !utils.IsStringInSlice(needle, haystack) {
+			return false
+		}
+	}
+
+	return true
+}
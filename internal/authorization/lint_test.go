@@ -0,0 +1,141 @@
+package authorization
+
+import (
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestLintAccessControl(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	_, subnetwork, _ := net.ParseCIDR("10.1.0.0/16")
+	_, other, _ := net.ParseCIDR("192.168.0.0/16")
+
+	testCases := []struct {
+		name     string
+		have     schema.AccessControl
+		expected []string
+	}{
+		{
+			"ShouldNotReportDistinctRules",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"public.example.com"}, Policy: "bypass"},
+					{Domains: []string{"secure.example.com"}, Policy: "two_factor"},
+					{Domains: []string{"*.example.com"}, Policy: "one_factor"},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldReportRuleShadowedByWildcard",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"*.example.com"}, Policy: "one_factor"},
+					{Domains: []string{"secure.example.com"}, Policy: "two_factor"},
+				},
+			},
+			[]string{"rule #2: rule is shadowed by rule #1 and will never match"},
+		},
+		{
+			"ShouldReportRuleShadowedByBroaderCriteria",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Policy: "one_factor", Networks: []*net.IPNet{network}, Methods: []string{"GET", "POST"}},
+					{Domains: []string{"App.Example.com"}, Policy: "two_factor", Networks: []*net.IPNet{subnetwork}, Methods: []string{"get"}, Resources: []regexp.Regexp{*regexp.MustCompile(`^/api`)}},
+				},
+			},
+			[]string{"rule #2: rule is shadowed by rule #1 and will never match"},
+		},
+		{
+			"ShouldNotReportRuleWithNarrowerEarlierRule",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Policy: "bypass", Resources: []regexp.Regexp{*regexp.MustCompile(`^/api`)}},
+					{Domains: []string{"app.example.com"}, Policy: "two_factor", Networks: []*net.IPNet{network}},
+					{Domains: []string{"app.example.com"}, Policy: "two_factor", Networks: []*net.IPNet{other}},
+					{Domains: []string{"app.example.com"}, Policy: "one_factor"},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldReportRuleShadowedBySubjects",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Policy: "one_factor", Subjects: [][]string{{"group:admins"}, {"user:john"}}},
+					{Domains: []string{"app.example.com"}, Policy: "two_factor", Subjects: [][]string{{"group:admins", "group:dev"}}},
+					{Domains: []string{"app.example.com"}, Policy: "two_factor", Subjects: [][]string{{"group:dev"}}},
+				},
+			},
+			[]string{"rule #2: rule is shadowed by rule #1 and will never match"},
+		},
+		{
+			"ShouldNotReportRuleWithDifferentExpression",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Policy: "one_factor", Expression: "'admins' in groups"},
+					{Domains: []string{"app.example.com"}, Policy: "two_factor", Expression: "'dev' in groups"},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldReportOverlappingDomainRegex",
+			schema.AccessControl{
+				Rules: []schema.AccessControlRule{
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^app\.example\.com$`)}, Policy: "one_factor", Methods: []string{"GET"}},
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^app\.example\.com$`)}, Policy: "two_factor"},
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^(api|web)\.example\.com$`)}, Policy: "two_factor", Methods: []string{"POST"}},
+					{Domains: []string{"api.example.com"}, Policy: "deny"},
+				},
+			},
+			[]string{
+				"rule #2: domain_regex pattern '^app\\.example\\.com$' is also a domain_regex pattern of rule #1",
+				"rule #4: domain 'api.example.com' overlaps with the domain_regex pattern '^(api|web)\\.example\\.com$' of rule #3",
+			},
+		},
+		{
+			"ShouldReportUnusedNetworks",
+			schema.AccessControl{
+				Networks: []schema.AccessControlNetwork{
+					{Name: "internal", Networks: []*net.IPNet{network}},
+					{Name: "vpn", Networks: []*net.IPNet{other}},
+				},
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Policy: "one_factor", Networks: []*net.IPNet{network}},
+				},
+			},
+			[]string{"network 'vpn' is not used by any rule"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+
+			for _, issue := range LintAccessControl(tc.have) {
+				actual = append(actual, issue.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestLintAccessControlSubjectGroups(t *testing.T) {
+	config := schema.AccessControl{
+		Rules: []schema.AccessControlRule{
+			{Domains: []string{"app.example.com"}, Policy: "one_factor", Subjects: [][]string{{"group:admins"}, {"user:john"}}},
+			{Domains: []string{"app.example.com"}, Policy: "two_factor", Subjects: [][]string{{"group:dev", "group:contractors"}}},
+		},
+	}
+
+	issues := LintAccessControlSubjectGroups(config, []string{"admins", "dev"})
+
+	assert.Equal(t, []LintIssue{{Position: 2, Message: "subject references the group 'contractors' which is not assigned to any user"}}, issues)
+}
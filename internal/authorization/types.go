@@ -3,9 +3,11 @@ package authorization
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -53,6 +55,22 @@ type Subject struct {
 	Groups   []string
 	ClientID string
 	IP       net.IP
+
+	// Details are the details of the user which are only required to evaluate rule expressions that refer to the user.
+	Details expression.UserDetailer
+
+	// DetailsFunc resolves the Details when they're not set. It's only called once an expression which refers to the
+	// user is evaluated so the details are not retrieved for requests which never reach such a rule.
+	DetailsFunc func() expression.UserDetailer
+}
+
+// GetDetails returns the Details of the user, resolving them with the DetailsFunc if they're not set.
+func (s Subject) GetDetails() expression.UserDetailer {
+	if s.Details == nil && s.DetailsFunc != nil {
+		return s.DetailsFunc()
+	}
+
+	return s.Details
 }
 
 // String returns a string representation of the Subject.
@@ -72,6 +90,9 @@ type Object struct {
 	Domain string
 	Path   string
 	Method string
+
+	// Header contains the headers of the request, which are only populated when a rule requires them.
+	Header http.Header
 }
 
 // String is a string representation of the Object.
@@ -107,18 +128,24 @@ type RuleMatchResult struct {
 	MatchDomain        bool
 	MatchResources     bool
 	MatchQuery         bool
+	MatchHeaders       bool
 	MatchMethods       bool
 	MatchNetworks      bool
+	MatchSchedule      bool
 	MatchSubjects      bool
 	MatchSubjectsExact bool
+
+	MatchExpression      bool
+	MatchExpressionExact bool
 }
 
 // IsMatch returns true if all the criteria matched.
 func (r RuleMatchResult) IsMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSchedule && r.MatchSubjectsExact && r.MatchExpressionExact
 }
 
 // IsPotentialMatch returns true if the rule is potentially a match.
 func (r RuleMatchResult) IsPotentialMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSchedule && r.MatchSubjects && r.MatchExpression &&
+		!(r.MatchSubjectsExact && r.MatchExpressionExact)
 }
@@ -99,12 +99,32 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		},
 		{
 			"ShouldMatch",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, false},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, false, true, true},
 			true,
 		},
+		{
+			"ShouldMatchExpression",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, true, false},
+			true,
+		},
+		{
+			"ShouldNotMatchExpressionMiss",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, false, false, false},
+			false,
+		},
+		{
+			"ShouldNotMatchHeadersMiss",
+			RuleMatchResult{nil, true, true, true, true, false, true, true, true, true, false, true, true},
+			false,
+		},
+		{
+			"ShouldNotMatchScheduleMiss",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, false, true, false, true, true},
+			false,
+		},
 		{
 			"ShouldMatchExact",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, true},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, true, true},
 			false,
 		},
 	}
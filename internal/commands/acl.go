@@ -1,19 +1,28 @@
 package commands
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/valyala/fasthttp"
+	"go.yaml.in/yaml/v4"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func newAccessControlCommand(ctx *CmdCtx) (cmd *cobra.Command) {
@@ -28,6 +37,8 @@ func newAccessControlCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 
 	cmd.AddCommand(
 		newAccessControlCheckCommand(ctx),
+		newAccessControlLintCommand(ctx),
+		newAccessControlTestCommand(ctx),
 	)
 
 	return cmd
@@ -49,14 +60,263 @@ func newAccessControlCheckCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 
 	cmd.Flags().String("url", "", "the url of the object")
 	cmd.Flags().String("method", fasthttp.MethodGet, "the HTTP method of the object")
+	cmd.Flags().StringArray("header", nil, "a header of the object in the format 'Name: value', can be specified multiple times")
 	cmd.Flags().String("username", "", "the username of the subject")
 	cmd.Flags().StringSlice("groups", nil, "the groups of the subject")
 	cmd.Flags().String("ip", "", "the ip of the subject")
+	cmd.Flags().String("time", "", "the time to evaluate the rules at instead of the current time")
 	cmd.Flags().Bool("verbose", false, "enables verbose output")
 
 	return cmd
 }
 
+func newAccessControlLintCommand(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "lint",
+		Short:   cmdAutheliaAccessControlLintShort,
+		Long:    cmdAutheliaAccessControlLintLong,
+		Example: cmdAutheliaAccessControlLintExample,
+		PreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.HelperConfigValidateKeysRunE,
+			ctx.HelperConfigValidateRunE,
+			ctx.LoadTrustedCertificatesRunE,
+		),
+		RunE: ctx.AccessControlLintRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool("groups", false, "checks the groups referenced by subjects against the groups from the authentication backend")
+
+	return cmd
+}
+
+// AccessControlLintRunE is the RunE for the authelia access-control lint command.
+func (ctx *CmdCtx) AccessControlLintRunE(cmd *cobra.Command, _ []string) (err error) {
+	groups, err := cmd.Flags().GetBool("groups")
+	if err != nil {
+		return err
+	}
+
+	issues := authorization.LintAccessControl(ctx.config.AccessControl)
+
+	if groups {
+		var subjects []authorization.LintIssue
+
+		if subjects, err = getAccessControlLintSubjectGroupsIssues(ctx.config, ctx.trusted); err != nil {
+			return err
+		}
+
+		issues = append(issues, subjects...)
+	}
+
+	return runAccessControlLint(cmd.OutOrStdout(), issues)
+}
+
+func getAccessControlLintSubjectGroupsIssues(config *schema.Configuration, caCertPool *x509.CertPool) (issues []authorization.LintIssue, err error) {
+	var (
+		provider authentication.UserProvider
+		closer   func()
+	)
+
+	if provider, closer, err = newDebugAuthenticationProvider(config, caCertPool); err != nil {
+		return nil, err
+	}
+
+	defer closer()
+
+	lister, ok := provider.(authentication.GroupsUserProvider)
+	if !ok {
+		return nil, fmt.Errorf("error occurred checking the groups: the configured user authentication provider does not support listing groups")
+	}
+
+	if err = provider.StartupCheck(); err != nil {
+		return nil, fmt.Errorf("error occurred initializing user authentication provider: %w", err)
+	}
+
+	var groups []string
+
+	if groups, err = lister.GetGroups(); err != nil {
+		return nil, fmt.Errorf("error occurred getting the groups from the user authentication provider: %w", err)
+	}
+
+	return authorization.LintAccessControlSubjectGroups(config.AccessControl, groups), nil
+}
+
+func runAccessControlLint(w io.Writer, issues []authorization.LintIssue) (err error) {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintf(w, "Access control configuration linted successfully without issues.\n\n")
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Access control configuration linted with issues:\n\n")
+
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(w, "\t - %s\n", issue)
+	}
+
+	_, _ = fmt.Fprint(w, "\n")
+
+	return fmt.Errorf("access control lint found %d issues", len(issues))
+}
+
+func newAccessControlTestCommand(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "test <file>",
+		Short:   cmdAutheliaAccessControlTestShort,
+		Long:    cmdAutheliaAccessControlTestLong,
+		Example: cmdAutheliaAccessControlTestExample,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.ConfigValidateAccessControlRunE,
+		),
+		RunE: ctx.AccessControlTestRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String("time", "", "the time to evaluate the rules at instead of the current time")
+
+	return cmd
+}
+
+// ConfigValidateAccessControlRunE validates the definitions and access control config, including the rules, the same
+// way the server does before running commands using them. This compiles the patterns and expressions and defaults the
+// matcher operators the rules are evaluated with.
+func (ctx *CmdCtx) ConfigValidateAccessControlRunE(_ *cobra.Command, _ []string) (err error) {
+	validator.ValidateDefinitions(ctx.config, ctx.cconfig.validator)
+	validator.ValidateAccessControl(ctx.config, ctx.cconfig.validator)
+	validator.ValidateRules(ctx.config, ctx.cconfig.validator)
+
+	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
+		var (
+			i int
+			e error
+		)
+
+		for i, e = range errs {
+			if i == 0 {
+				err = e
+				continue
+			}
+
+			err = fmt.Errorf("%w, %v", err, e)
+		}
+
+		return fmt.Errorf("failed to execute command due to errors in the configuration: %w", err)
+	}
+
+	return nil
+}
+
+// AccessControlTestRunE is the RunE for the authelia access-control test command.
+func (ctx *CmdCtx) AccessControlTestRunE(cmd *cobra.Command, args []string) (err error) {
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	suite, err := loadAccessControlTestSuite(args[0])
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizerWithClock(ctx.config, provider)
+
+	return runAccessControlTest(cmd.OutOrStdout(), authorizer, suite)
+}
+
+func loadAccessControlTestSuite(path string) (suite *accessControlTestSuite, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("error occurred reading the test file: %w", err)
+	}
+
+	suite = &accessControlTestSuite{}
+
+	if err = yaml.Unmarshal(data, suite); err != nil {
+		return nil, fmt.Errorf("error occurred parsing the test file: %w", err)
+	}
+
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("error occurred parsing the test file: the file does not contain any tests")
+	}
+
+	for i, test := range suite.Tests {
+		if test.URL == "" {
+			return nil, fmt.Errorf("error occurred parsing the test file: test #%d: option 'url' is required", i+1)
+		}
+
+		if !utils.IsStringInSlice(test.Policy, validAccessControlTestPolicies) {
+			return nil, fmt.Errorf("error occurred parsing the test file: test #%d: option 'policy' must be one of %s but it's configured as '%s'", i+1, utils.StringJoinOr(validAccessControlTestPolicies), test.Policy)
+		}
+	}
+
+	return suite, nil
+}
+
+func runAccessControlTest(w io.Writer, authorizer *authorization.Authorizer, suite *accessControlTestSuite) (err error) {
+	var failures []accessControlTestFailure
+
+	for i, test := range suite.Tests {
+		var (
+			parsedURL *url.URL
+			subject   authorization.Subject
+			object    authorization.Object
+		)
+
+		if parsedURL, err = url.ParseRequestURI(test.URL); err != nil {
+			return fmt.Errorf("error occurred running test #%d: %w", i+1, err)
+		}
+
+		if subject, object, err = newSubjectAndObject(parsedURL, test.GetMethod(), test.Headers, test.Username, test.Groups, test.IP); err != nil {
+			return fmt.Errorf("error occurred running test #%d: %w", i+1, err)
+		}
+
+		// The decision is made the same way as the authorization endpoints, which means rules with subjects match
+		// anonymous subjects as they would be required to authenticate.
+		matched, level := authorizer.GetRequiredRule(subject, object)
+
+		policy, rule := level.String(), "default"
+
+		if matched != nil {
+			rule = fmt.Sprintf("#%d", matched.Position)
+		}
+
+		if policy != test.Policy {
+			failures = append(failures, accessControlTestFailure{position: i + 1, test: test, object: object, policy: policy, rule: rule})
+		}
+	}
+
+	if len(failures) == 0 {
+		_, _ = fmt.Fprintf(w, "All %d access control tests passed.\n\n", len(suite.Tests))
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "%d of %d access control tests failed:\n\n", len(failures), len(suite.Tests))
+
+	tw := tabwriter.NewWriter(w, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "#\tName\tURL\tMethod\tUsername\tGroups\tIP\tExpected\tActual\tRule")
+
+	for _, failure := range failures {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", failure.position, failure.test.Name, failure.object.String(), failure.object.Method, failure.test.Username, strings.Join(failure.test.Groups, ","), failure.test.IP, failure.test.Policy, failure.policy, failure.rule)
+	}
+
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(w, "\n")
+
+	return fmt.Errorf("%d of %d access control tests failed", len(failures), len(suite.Tests))
+}
+
 // AccessControlCheckRunE is the RunE for the authelia access-control check-policy command.
 func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
 	verbose, err := cmd.Flags().GetBool("verbose")
@@ -70,7 +330,12 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return errors.New("failed to execute command due to errors in the configuration")
 	}
 
-	authorizer := authorization.NewAuthorizer(ctx.config)
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizerWithClock(ctx.config, provider)
 
 	subject, object, err := getSubjectAndObjectFromFlags(cmd)
 	if err != nil {
@@ -99,7 +364,7 @@ func runAccessControlCheck(w io.Writer, object authorization.Object, subject aut
 func accessControlCheckWriteOutput(w io.Writer, object authorization.Object, subject authorization.Subject, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
 	accessControlCheckWriteObjectSubject(w, object, subject)
 
-	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tQuery\tMethod\tNetwork\tSubject")
+	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tQuery\tHeaders\tMethod\tNetwork\tSchedule\tSubject\tExpression")
 
 	var (
 		appliedPos int
@@ -117,15 +382,15 @@ func accessControlCheckWriteOutput(w io.Writer, object authorization.Object, sub
 		switch {
 		case result.IsMatch() && !result.Skipped:
 			appliedPos, applied = i+1, result
-			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact))
 		case result.IsPotentialMatch() && !result.Skipped:
 			if potentialPos == 0 {
 				potentialPos, potential = i+1, result
 			}
 
-			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact))
 		default:
-			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact))
 		}
 	}
 
@@ -200,6 +465,11 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
+	headers, err := cmd.Flags().GetStringArray("header")
+	if err != nil {
+		return subject, object, err
+	}
+
 	username, err := cmd.Flags().GetString("username")
 	if err != nil {
 		return subject, object, err
@@ -215,15 +485,92 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
-	parsedIP := net.ParseIP(remoteIP)
+	return newSubjectAndObject(parsedURL, method, headers, username, groups, remoteIP)
+}
 
+func newSubjectAndObject(parsedURL *url.URL, method string, headers []string, username string, groups []string, remoteIP string) (subject authorization.Subject, object authorization.Object, err error) {
 	subject = authorization.Subject{
 		Username: username,
 		Groups:   groups,
-		IP:       parsedIP,
+		IP:       net.ParseIP(remoteIP),
 	}
 
 	object = authorization.NewObject(parsedURL, method)
 
+	if object.Header, err = getHeadersFromFlag(headers); err != nil {
+		return subject, object, err
+	}
+
 	return subject, object, nil
 }
+
+func getClockFromFlags(cmd *cobra.Command) (provider clock.Provider, err error) {
+	value, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return clock.New(), nil
+	}
+
+	var t time.Time
+
+	if t, err = utils.ParseTimeString(value); err != nil {
+		return nil, fmt.Errorf("failed to parse the time flag value '%s': %w", value, err)
+	}
+
+	return clock.NewFixed(t), nil
+}
+
+func getHeadersFromFlag(values []string) (headers http.Header, err error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	headers = http.Header{}
+
+	for _, value := range values {
+		name, content, found := strings.Cut(value, ":")
+
+		if name = strings.TrimSpace(name); !found || name == "" {
+			return nil, fmt.Errorf("header '%s' is not in the format 'Name: value'", value)
+		}
+
+		headers.Add(name, strings.TrimSpace(content))
+	}
+
+	return headers, nil
+}
+
+type accessControlTestSuite struct {
+	Tests []accessControlTestCase `yaml:"tests"`
+}
+
+type accessControlTestCase struct {
+	Name     string   `yaml:"name"`
+	URL      string   `yaml:"url"`
+	Method   string   `yaml:"method"`
+	Headers  []string `yaml:"headers"`
+	Username string   `yaml:"username"`
+	Groups   []string `yaml:"groups"`
+	IP       string   `yaml:"ip"`
+	Policy   string   `yaml:"policy"`
+}
+
+// GetMethod returns the method of the test, defaulting to GET.
+func (t accessControlTestCase) GetMethod() (method string) {
+	if t.Method == "" {
+		return fasthttp.MethodGet
+	}
+
+	return t.Method
+}
+
+type accessControlTestFailure struct {
+	position int
+	test     accessControlTestCase
+	object   authorization.Object
+	policy   string
+	rule     string
+}
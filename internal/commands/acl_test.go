@@ -3,8 +3,12 @@ package commands
 import (
 	"bytes"
 	"net"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +16,7 @@ import (
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
@@ -23,6 +28,12 @@ func TestNewACLCommand(t *testing.T) {
 
 	cmd = newAccessControlCheckCommand(&CmdCtx{})
 	assert.NotNil(t, cmd)
+
+	cmd = newAccessControlLintCommand(&CmdCtx{})
+	assert.NotNil(t, cmd)
+
+	cmd = newAccessControlTestCommand(&CmdCtx{})
+	assert.NotNil(t, cmd)
 }
 
 func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
@@ -31,6 +42,7 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 		url      bool
 		urlValue string
 		method   bool
+		header   bool
 		username bool
 		groups   bool
 		ip       bool
@@ -41,6 +53,7 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 		{
 			name:     "ShouldErrorOnMissingURLFlag",
 			method:   true,
+			header:   true,
 			username: true,
 			groups:   true,
 			ip:       true,
@@ -49,6 +62,7 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 		{
 			name:     "ShouldErrorOnInvalidURLFlag",
 			method:   true,
+			header:   true,
 			url:      true,
 			urlValue: "http://%@#(*$@()#*&$invalid",
 			username: true,
@@ -64,10 +78,20 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 			ip:       true,
 			err:      "flag accessed but not defined: method",
 		},
+		{
+			name:     "ShouldErrorOnMissingHeaderFlag",
+			url:      true,
+			method:   true,
+			username: true,
+			groups:   true,
+			ip:       true,
+			err:      "flag accessed but not defined: header",
+		},
 		{
 			name:   "ShouldErrorOnMissingUsernameFlag",
 			url:    true,
 			method: true,
+			header: true,
 			groups: true,
 			ip:     true,
 			err:    "flag accessed but not defined: username",
@@ -76,6 +100,7 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 			name:     "ShouldErrorOnMissingGroupsFlag",
 			url:      true,
 			method:   true,
+			header:   true,
 			username: true,
 			ip:       true,
 			err:      "flag accessed but not defined: groups",
@@ -84,6 +109,7 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 			name:     "ShouldErrorOnMissingIPFlag",
 			url:      true,
 			method:   true,
+			header:   true,
 			username: true,
 			groups:   true,
 			err:      "flag accessed but not defined: ip",
@@ -92,11 +118,12 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 			name:     "ShouldNotErrorWithAllFlagsSet",
 			url:      true,
 			method:   true,
+			header:   true,
 			username: true,
 			groups:   true,
 			ip:       true,
 			subject:  authorization.Subject{Username: "john", Groups: []string{"example"}, IP: net.ParseIP("127.0.0.1")},
-			object:   authorization.Object{URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/"}, Domain: "example.com", Method: fasthttp.MethodGet, Path: "/"},
+			object:   authorization.Object{URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/"}, Domain: "example.com", Method: fasthttp.MethodGet, Path: "/", Header: http.Header{"X-Api-Version": []string{"2"}}},
 			err:      "",
 		},
 	}
@@ -125,6 +152,12 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 				require.NoError(t, flags.Set("method", fasthttp.MethodGet))
 			}
 
+			if tc.header {
+				flags.StringArray("header", nil, "")
+
+				require.NoError(t, flags.Set("header", "X-Api-Version: 2"))
+			}
+
 			if tc.username {
 				flags.String("username", "", "")
 
@@ -157,6 +190,76 @@ func TestGetSubjectAndObjectFromFlagErrors(t *testing.T) {
 	}
 }
 
+func TestGetClockFromFlags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flag     bool
+		value    string
+		expected time.Time
+		err      string
+	}{
+		{"ShouldErrorOnMissingTimeFlag", false, "", time.Time{}, "flag accessed but not defined: time"},
+		{"ShouldReturnRealClockWhenEmpty", true, "", time.Time{}, ""},
+		{"ShouldReturnFixedClock", true, "2024-01-06T10:00:00+01:00", time.Date(2024, time.January, 6, 10, 0, 0, 0, time.FixedZone("", 3600)), ""},
+		{"ShouldErrorOnInvalidTime", true, "not a time", time.Time{}, "failed to parse the time flag value 'not a time': failed to find a suitable time layout for time 'not a time'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+
+			if tc.flag {
+				cmd.Flags().String("time", "", "")
+
+				require.NoError(t, cmd.Flags().Set("time", tc.value))
+			}
+
+			provider, err := getClockFromFlags(cmd)
+
+			switch {
+			case tc.err != "":
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, provider)
+			case tc.expected.IsZero():
+				assert.NoError(t, err)
+				assert.IsType(t, &clock.Real{}, provider)
+			default:
+				assert.NoError(t, err)
+				require.NotNil(t, provider)
+				assert.True(t, tc.expected.Equal(provider.Now()))
+			}
+		})
+	}
+}
+
+func TestGetHeadersFromFlag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []string
+		expected http.Header
+		err      string
+	}{
+		{"ShouldReturnNilWhenEmpty", nil, nil, ""},
+		{"ShouldParseHeaders", []string{"X-Api-Version: 2", "user-agent:curl/8.0.1", "X-Empty:"}, http.Header{"X-Api-Version": []string{"2"}, "User-Agent": []string{"curl/8.0.1"}, "X-Empty": []string{""}}, ""},
+		{"ShouldErrorWithoutSeparator", []string{"X-Api-Version"}, nil, "header 'X-Api-Version' is not in the format 'Name: value'"},
+		{"ShouldErrorWithoutName", []string{": 2"}, nil, "header ': 2' is not in the format 'Name: value'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers, err := getHeadersFromFlag(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, headers)
+		})
+	}
+}
+
 func TestHitMissMay(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -307,9 +410,11 @@ func TestAccessControlCheckRunE(t *testing.T) {
 
 			cmd.Flags().String("url", "", "")
 			cmd.Flags().String("method", fasthttp.MethodGet, "")
+			cmd.Flags().StringArray("header", nil, "")
 			cmd.Flags().String("username", "", "")
 			cmd.Flags().StringSlice("groups", nil, "")
 			cmd.Flags().String("ip", "", "")
+			cmd.Flags().String("time", "", "")
 			cmd.Flags().Bool("verbose", false, "")
 
 			for k, v := range tc.flags {
@@ -362,15 +467,19 @@ func TestAccessControlCheckWriteOutput(t *testing.T) {
 			name: "ShouldApplyPolicyWhenMatchedRule",
 			results: []authorization.RuleMatchResult{
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.Bypass},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: true,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.Bypass},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   true,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 			},
 			defaultPolicy: "default",
@@ -383,26 +492,34 @@ func TestAccessControlCheckWriteOutput(t *testing.T) {
 			name: "ShouldPreferPotentialWhenBeforeApplied",
 			results: []authorization.RuleMatchResult{
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.Bypass},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: false,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.Bypass},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   false,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.OneFactor},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: true,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.OneFactor},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   true,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 			},
 			defaultPolicy: "default",
@@ -419,26 +536,34 @@ func TestAccessControlCheckWriteOutput(t *testing.T) {
 			name: "ShouldBreakOnSkippedWhenNotVerbose",
 			results: []authorization.RuleMatchResult{
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.OneFactor},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: true,
-					Skipped:            true,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.OneFactor},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   true,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              true,
 				},
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.Bypass},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: true,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.Bypass},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   true,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 			},
 			defaultPolicy: "default",
@@ -451,37 +576,49 @@ func TestAccessControlCheckWriteOutput(t *testing.T) {
 			name: "ShouldHandleMaybeMatch",
 			results: []authorization.RuleMatchResult{
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.OneFactor},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      false,
-					MatchSubjectsExact: false,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.OneFactor},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        false,
+					MatchSubjectsExact:   false,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.OneFactor},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: false,
-					Skipped:            true,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.OneFactor},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   false,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              true,
 				},
 				{
-					Rule:               &authorization.AccessControlRule{Policy: authorization.Bypass},
-					MatchDomain:        true,
-					MatchResources:     true,
-					MatchQuery:         true,
-					MatchMethods:       true,
-					MatchNetworks:      true,
-					MatchSubjects:      true,
-					MatchSubjectsExact: false,
-					Skipped:            false,
+					Rule:                 &authorization.AccessControlRule{Policy: authorization.Bypass},
+					MatchDomain:          true,
+					MatchResources:       true,
+					MatchQuery:           true,
+					MatchHeaders:         true,
+					MatchMethods:         true,
+					MatchNetworks:        true,
+					MatchSchedule:        true,
+					MatchSubjects:        true,
+					MatchSubjectsExact:   false,
+					MatchExpression:      true,
+					MatchExpressionExact: true,
+					Skipped:              false,
 				},
 			},
 			defaultPolicy: "default",
@@ -507,3 +644,271 @@ func TestAccessControlCheckWriteOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestRunAccessControlLint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issues   []authorization.LintIssue
+		expected string
+		err      string
+	}{
+		{
+			"ShouldSucceedWithoutIssues",
+			nil,
+			"Access control configuration linted successfully without issues.\n\n",
+			"",
+		},
+		{
+			"ShouldFailWithIssues",
+			[]authorization.LintIssue{
+				{Position: 2, Message: "rule is shadowed by rule #1 and will never match"},
+				{Message: "network 'vpn' is not used by any rule"},
+			},
+			"Access control configuration linted with issues:\n\n\t - rule #2: rule is shadowed by rule #1 and will never match\n\t - network 'vpn' is not used by any rule\n\n",
+			"access control lint found 2 issues",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			err := runAccessControlLint(buf, tc.issues)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestLoadAccessControlTestSuite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected *accessControlTestSuite
+		err      string
+	}{
+		{
+			"ShouldLoadTests",
+			"tests:\n  - name: 'Admin'\n    url: 'https://example.com/admin'\n    username: 'john'\n    groups: ['admins']\n    policy: 'two_factor'\n",
+			&accessControlTestSuite{Tests: []accessControlTestCase{{Name: "Admin", URL: "https://example.com/admin", Username: "john", Groups: []string{"admins"}, Policy: "two_factor"}}},
+			"",
+		},
+		{
+			"ShouldErrNoTests",
+			"tests: []\n",
+			nil,
+			"error occurred parsing the test file: the file does not contain any tests",
+		},
+		{
+			"ShouldErrMissingURL",
+			"tests:\n  - policy: 'deny'\n",
+			nil,
+			"error occurred parsing the test file: test #1: option 'url' is required",
+		},
+		{
+			"ShouldErrInvalidPolicy",
+			"tests:\n  - url: 'https://example.com'\n    policy: 'allow'\n",
+			nil,
+			"error occurred parsing the test file: test #1: option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'allow'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tests.yml")
+
+			require.NoError(t, os.WriteFile(path, []byte(tc.have), 0600))
+
+			suite, err := loadAccessControlTestSuite(path)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, suite)
+		})
+	}
+}
+
+func TestRunAccessControlTest(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:  schema.AccessControlRuleDomains{"example.com"},
+					Subjects: schema.AccessControlRuleSubjects{{"group:admins"}},
+					Policy:   "two_factor",
+				},
+				{
+					Domains: schema.AccessControlRuleDomains{"public.example.com"},
+					Policy:  "bypass",
+				},
+			},
+		},
+	}
+
+	authorizer := authorization.NewAuthorizer(config)
+
+	testCases := []struct {
+		name     string
+		tests    []accessControlTestCase
+		expected []string
+		err      string
+	}{
+		{
+			"ShouldPass",
+			[]accessControlTestCase{
+				{URL: "https://example.com/", Username: "john", Groups: []string{"admins"}, Policy: "two_factor"},
+				{URL: "https://public.example.com/", Policy: "bypass"},
+				{URL: "https://other.example.com/", Policy: "deny"},
+			},
+			[]string{"All 3 access control tests passed."},
+			"",
+		},
+		{
+			"ShouldFail",
+			[]accessControlTestCase{
+				{Name: "Admins", URL: "https://example.com/", Username: "john", Groups: []string{"admins"}, Policy: "two_factor"},
+				{Name: "Users", URL: "https://example.com/", Method: fasthttp.MethodPost, Username: "harry", Groups: []string{"users"}, Policy: "one_factor"},
+				{Name: "Public", URL: "https://public.example.com/", Policy: "one_factor"},
+			},
+			[]string{"2 of 3 access control tests failed:", "Users", "POST", "harry", "one_factor", "deny", "default", "Public", "bypass", "#2"},
+			"2 of 3 access control tests failed",
+		},
+		{
+			"ShouldPassAnonymous",
+			[]accessControlTestCase{
+				{URL: "https://example.com/", Policy: "two_factor"},
+			},
+			[]string{"All 1 access control tests passed."},
+			"",
+		},
+		{
+			"ShouldFailAnonymous",
+			[]accessControlTestCase{
+				{Name: "Anonymous", URL: "https://example.com/", Policy: "deny"},
+			},
+			[]string{"1 of 1 access control tests failed:", "Anonymous", "deny", "two_factor", "#1"},
+			"1 of 1 access control tests failed",
+		},
+		{
+			"ShouldErrInvalidURL",
+			[]accessControlTestCase{
+				{URL: "example.com", Policy: "deny"},
+			},
+			nil,
+			"error occurred running test #1: parse \"example.com\": invalid URI for request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			err := runAccessControlTest(buf, authorizer, &accessControlTestSuite{Tests: tc.tests})
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			for _, s := range tc.expected {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestAccessControlTestShouldValidateRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rules    []schema.AccessControlRule
+		tests    []accessControlTestCase
+		expected string
+		err      string
+	}{
+		{
+			"ShouldMatchHeaderPattern",
+			[]schema.AccessControlRule{
+				{
+					Domains: schema.AccessControlRuleDomains{"example.com"},
+					Headers: [][]schema.AccessControlRuleHeader{{{Operator: "pattern", Key: "X-Client", Value: "^internal-[a-z]+$"}}},
+					Policy:  "one_factor",
+				},
+			},
+			[]accessControlTestCase{
+				{URL: "https://example.com/", Headers: []string{"X-Client: internal-app"}, Policy: "one_factor"},
+				{URL: "https://example.com/", Headers: []string{"X-Client: external-app"}, Policy: "deny"},
+				{URL: "https://example.com/", Policy: "deny"},
+			},
+			"All 3 access control tests passed.",
+			"",
+		},
+		{
+			"ShouldErrInvalidExpression",
+			[]schema.AccessControlRule{
+				{
+					Domains:    schema.AccessControlRuleDomains{"example.com"},
+					Expression: "request.method ==",
+					Policy:     "one_factor",
+				},
+			},
+			nil,
+			"",
+			"failed to execute command due to errors in the configuration: access_control: rule #1 (domain 'example.com'): option 'expression' is invalid: failed to parse expression 'request.method ==': ",
+		},
+		{
+			"ShouldErrInvalidSchedule",
+			[]schema.AccessControlRule{
+				{
+					Domains:  schema.AccessControlRuleDomains{"example.com"},
+					Schedule: &schema.AccessControlRuleSchedule{},
+					Policy:   "one_factor",
+				},
+			},
+			nil,
+			"",
+			"failed to execute command due to errors in the configuration: access_control: rule #1 (domain 'example.com'): schedule: option 'windows' must have at least one window but it's absent",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewCmdCtx()
+
+			ctx.cconfig = NewCmdCtxConfig()
+			ctx.config = &schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules:         tc.rules,
+				},
+			}
+
+			err := ctx.ConfigValidateAccessControlRunE(nil, nil)
+
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+
+			require.NoError(t, runAccessControlTest(buf, authorization.NewAuthorizer(ctx.config), &accessControlTestSuite{Tests: tc.tests}))
+
+			assert.Contains(t, buf.String(), tc.expected)
+		})
+	}
+}
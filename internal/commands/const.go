@@ -88,7 +88,170 @@ Notes:
 authelia access-control check-policy --config config.yml --url https://example.com --username john
 authelia access-control check-policy --config config.yml --url https://example.com --groups admin,public
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
-authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
+authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose
+authelia access-control check-policy --config config.yml --url https://example.com --header 'X-Api-Version: 2'
+authelia access-control check-policy --config config.yml --url https://example.com --username john --time 2024-01-06T10:00:00Z`
+
+	cmdAutheliaAccessControlLintShort = "Checks the access control configuration for rules and networks which are never used"
+
+	cmdAutheliaAccessControlLintLong = `
+Checks the access control configuration for rules and networks which are never used.
+
+Issues:
+
+	- Rules which can never match because an earlier rule matches every request they would match.
+	- Domain regex patterns which are also used by, or match a domain of, another rule.
+	- Named networks from the access_control.networks section which are not used by any rule.
+	- Subjects which reference a group that is not assigned to any user, only checked when the groups flag is used.
+
+Notes:
+
+	The groups check retrieves every group from the authentication backend. The LDAP backend returns the groups which
+	match the groups filter when the placeholders which refer to a user are replaced with wildcards. The command exits
+	with an error when any issue is found.
+`
+	cmdAutheliaAccessControlLintExample = `authelia access-control lint --config config.yml
+authelia access-control lint --config config.yml --groups`
+
+	cmdAutheliaAccessControlTestShort = "Checks a file of requests against the access control rules to ensure the expected policy is applied"
+
+	cmdAutheliaAccessControlTestLong = `
+Checks a file of requests against the access control rules to ensure the expected policy is applied.
+
+Each test in the file describes a request and the policy expected to be applied to it. The applied policy is the
+policy of the first rule which matches the request in the same way as the authorization endpoints, or the default
+policy if no rule matches. Rules with subjects match tests without a username or groups as the user would be required
+to sign in. Tests which do not match the expected policy are reported in a table of failures which includes the applied
+policy and the rule it was applied from. The command exits with an error when the access control configuration is
+invalid or when any test fails.
+
+File Format:
+
+	tests:
+	  - name: 'Admins require two factor'
+	    url: 'https://app.example.com/admin'
+	    method: 'GET'
+	    username: 'john'
+	    groups: ['admins']
+	    ip: '192.168.1.10'
+	    headers: ['X-Api-Version: 2']
+	    policy: 'two_factor'
+
+Notes:
+
+	The url and policy options are required for each test, and the method defaults to GET. The policy must be one of
+	bypass, one_factor, two_factor, or deny.
+`
+	cmdAutheliaAccessControlTestExample = `authelia access-control test --config config.yml acl-tests.yml
+authelia access-control test --config config.yml acl-tests.yml --time 2024-01-06T10:00:00Z`
+
+	cmdAutheliaUsersShort = "Manage the users in the file authentication backend"
+
+	cmdAutheliaUsersLong = `Manage the users in the file authentication backend.
+
+This subcommand has several methods to interact with the users database of the file authentication backend. The
+database is read from and written to the path configured in the authentication_backend.file.path option.
+`
+
+	cmdAutheliaUsersExample = `authelia users --help`
+
+	cmdAutheliaUsersListShort = "List the users in the database"
+
+	cmdAutheliaUsersListLong = `List the users in the database.
+
+This subcommand allows listing all users in the file authentication backend database.`
+
+	cmdAutheliaUsersListExample = `authelia users list
+authelia users list --config config.yml`
+
+	cmdAutheliaUsersAddShort = "Add a user to the database"
+
+	cmdAutheliaUsersAddLong = `Add a user to the database.
+
+This subcommand allows adding a new user to the file authentication backend database. The password is hashed using
+the algorithm configured in the authentication_backend.file.password section.`
+
+	cmdAutheliaUsersAddExample = `authelia users add john --display-name "John Doe" --email john.doe@example.com
+authelia users add john --display-name "John Doe" --email john.doe@example.com --groups admins,dev
+authelia users add john --display-name "John Doe" --email john.doe@example.com --password 'apple123' --config config.yml`
+
+	cmdAutheliaUsersDeleteShort = "Delete a user from the database"
+
+	cmdAutheliaUsersDeleteLong = `Delete a user from the database.
+
+This subcommand allows deleting a user from the file authentication backend database.`
+
+	cmdAutheliaUsersDeleteExample = `authelia users delete john
+authelia users delete john --config config.yml`
+
+	cmdAutheliaUsersDisableShort = "Disable a user in the database"
+
+	cmdAutheliaUsersDisableLong = `Disable a user in the database.
+
+This subcommand allows disabling a user in the file authentication backend database, or enabling a previously disabled
+user when used with the --enable flag.`
+
+	cmdAutheliaUsersDisableExample = `authelia users disable john
+authelia users disable john --enable
+authelia users disable john --config config.yml`
+
+	cmdAutheliaUsersSetGroupsShort = "Set the groups of a user in the database"
+
+	cmdAutheliaUsersSetGroupsLong = `Set the groups of a user in the database.
+
+This subcommand allows replacing the groups of a user in the file authentication backend database. Omitting all of
+the groups removes the user from every group.`
+
+	cmdAutheliaUsersSetGroupsExample = `authelia users set-groups john admins dev
+authelia users set-groups john
+authelia users set-groups john admins --config config.yml`
+
+	cmdAutheliaUsersSetAttributeShort = "Set an extra attribute of a user in the database"
+
+	cmdAutheliaUsersSetAttributeLong = `Set an extra attribute of a user in the database.
+
+This subcommand allows setting the value of an extra attribute of a user in the file authentication backend database.
+The attribute must be configured in the authentication_backend.file.extra_attributes section and the values must
+match the configured value_type. Multiple values may only be provided for multi-valued attributes, and omitting all of
+the values removes the attribute from the user.`
+
+	cmdAutheliaUsersSetAttributeExample = `authelia users set-attribute john department Engineering
+authelia users set-attribute john employee_id 1024
+authelia users set-attribute john projects apollo gemini
+authelia users set-attribute john department`
+
+	cmdAutheliaSessionsShort = "Manage the sessions of users"
+
+	cmdAutheliaSessionsLong = `Manage the sessions of users.
+
+This subcommand has several methods to interact with the sessions of users stored in the configured session provider.
+The session provider must be shared with the running Authelia instances, which means the memory session provider is
+not supported as it's only accessible to the process which is running it.
+`
+
+	cmdAutheliaSessionsExample = `authelia sessions --help`
+
+	cmdAutheliaSessionsListShort = "List the sessions of a user"
+
+	cmdAutheliaSessionsListLong = `List the sessions of a user.
+
+This subcommand allows listing the active sessions of a user ordered by the most recent activity. The ID shown for each
+session is the same ID shown to the user in the settings and can be used with the revoke subcommand.`
+
+	cmdAutheliaSessionsListExample = `authelia sessions list --user john
+authelia sessions list --user john --config config.yml`
+
+	cmdAutheliaSessionsRevokeShort = "Revoke the sessions of a user"
+
+	cmdAutheliaSessionsRevokeLong = `Revoke the sessions of a user.
+
+This subcommand allows revoking every session of a user, or an individual session when used with the --session flag.
+When revoking every session of a user and OpenID Connect 1.0 is configured, the access tokens and refresh tokens issued
+to the user are also revoked.`
+
+	cmdAutheliaSessionsRevokeExample = `authelia sessions revoke --user john
+authelia sessions revoke --user john --session 0b3a2f7c9d4e8a1b6c5d2e9f7a3b1c4d
+authelia sessions revoke --user john --config config.yml`
 
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
@@ -844,6 +1007,13 @@ const (
 	cmdFlagNamePath        = "path"
 	cmdFlagNameTarget      = "target"
 	cmdFlagNameDestroyData = "destroy-data"
+	cmdFlagNameDisplayName = "display-name"
+	cmdFlagNameEmail       = "email"
+	cmdFlagNameGroups      = "groups"
+	cmdFlagNameDisabled    = "disabled"
+	cmdFlagNameEnable      = "enable"
+	cmdFlagNameUser        = "user"
+	cmdFlagNameSession     = "session"
 
 	cmdFlagNameEncryptionKey      = "encryption-key"
 	cmdFlagNameSQLite3Path        = "sqlite.path"
@@ -903,7 +1073,8 @@ const (
 )
 
 var (
-	validIdentifierServices = []string{identifierServiceOpenIDConnect}
+	validIdentifierServices        = []string{identifierServiceOpenIDConnect}
+	validAccessControlTestPolicies = []string{"bypass", "one_factor", "two_factor", "deny"}
 )
 
 const (
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -48,6 +49,7 @@ type CmdCtx struct {
 	trusted   *x509.CertPool
 
 	cconfig *CmdCtxConfig
+	paths   []string
 
 	factoryX509SystemCertPool utils.X509SystemCertPoolFactory
 }
@@ -98,6 +100,11 @@ func (ctx *CmdCtx) GetConfiguration() *schema.Configuration {
 	return ctx.config
 }
 
+// GetConfigurationPaths returns the configuration file paths satisfying part of the ServiceCtx.
+func (ctx *CmdCtx) GetConfigurationPaths() (paths []string) {
+	return ctx.paths
+}
+
 // CheckSchemaVersion returns an error if the storage schema is not at the latest version.
 func (ctx *CmdCtx) CheckSchemaVersion() (err error) {
 	if ctx.providers.StorageProvider == nil {
@@ -379,6 +386,85 @@ func (ctx *CmdCtx) ConfigValidateSectionPasswordRunE(_ *cobra.Command, _ []strin
 	return fmt.Errorf("errors occurred validating the password configuration: %w", err)
 }
 
+// NewAccessControlConfigurationLoader returns an authorization.ConfigurationLoader which loads the configuration from
+// the same files and filters as the current configuration and validates only the access control section. The result is
+// a copy of the current configuration with only the access control section and the user attribute definitions the rule
+// expressions may refer to replaced. The audit log is opened at startup so changes to it are ignored.
+func (ctx *CmdCtx) NewAccessControlConfigurationLoader() authorization.ConfigurationLoader {
+	current, files, names, defaults, log := ctx.config, ctx.cconfig.files, ctx.cconfig.filters, ctx.cconfig.defaults, ctx.log
+
+	return func() (config *schema.Configuration, err error) {
+		var (
+			filters     []configuration.BytesFilter
+			definitions *schema.Definitions
+		)
+
+		if filters, err = configuration.NewFileFilters(names); err != nil {
+			return nil, err
+		}
+
+		val := schema.NewStructValidator()
+
+		sources := configuration.NewDefaultSourcesWithDefaults(files, filters, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter, defaults)
+
+		if definitions, err = configuration.LoadDefinitions(val, sources...); err != nil {
+			return nil, err
+		}
+
+		loaded := &schema.Configuration{}
+
+		if _, err = configuration.LoadAdvanced(val, "", loaded, definitions, sources...); err != nil {
+			return nil, err
+		}
+
+		next := *current
+
+		next.AccessControl = loaded.AccessControl
+		next.Definitions.UserAttributes = loaded.Definitions.UserAttributes
+
+		validator.ValidateDefinitions(&next, val)
+		validator.ValidateAccessControl(&next, val)
+		validator.ValidateRules(&next, val)
+
+		if next.AccessControl.AuditLog != current.AccessControl.AuditLog {
+			log.Warn("Configuration: access_control: audit_log: changes to this option require a restart and have been ignored")
+
+			next.AccessControl.AuditLog = current.AccessControl.AuditLog
+		}
+
+		errs := val.Errors()
+
+		if len(errs) == 0 {
+			return &next, nil
+		}
+
+		for i, e := range errs {
+			if i == 0 {
+				err = e
+				continue
+			}
+
+			err = fmt.Errorf("%v, %w", err, e)
+		}
+
+		return nil, fmt.Errorf("errors occurred validating the access control configuration: %w", err)
+	}
+}
+
+// ConfigValidateSectionAuthenticationBackendFileRunE validates the configuration (structure, file authentication
+// backend section).
+func (ctx *CmdCtx) ConfigValidateSectionAuthenticationBackendFileRunE(cmd *cobra.Command, args []string) (err error) {
+	if ctx.config.AuthenticationBackend.File == nil {
+		return fmt.Errorf("the file authentication backend is not configured")
+	}
+
+	if ctx.config.AuthenticationBackend.File.Path == "" {
+		return fmt.Errorf("the file authentication backend path is not configured")
+	}
+
+	return ctx.ConfigValidateSectionPasswordRunE(cmd, args)
+}
+
 // ConfigEnsureExistsRunE logs the warnings and errors detected during the validations that have ran.
 func (ctx *CmdCtx) ConfigEnsureExistsRunE(cmd *cobra.Command, _ []string) (err error) {
 	var (
@@ -430,6 +516,8 @@ func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err err
 		return err
 	}
 
+	ctx.paths = ctx.cconfig.files
+
 	ctx.cconfig.filters = make([]string, len(filters))
 
 	for i, filter := range filters {
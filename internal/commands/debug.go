@@ -23,6 +23,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -138,12 +139,17 @@ func (ctx *CmdCtx) DebugOIDCClaimsRunE(cmd *cobra.Command, args []string) (err e
 
 //nolint:gocyclo
 func runDebugOIDCClaims(ctx context.Context, w io.Writer, flags *pflag.FlagSet, config *schema.Configuration, caCertPool *x509.CertPool, username string) (err error) {
-	provider := middlewares.NewAuthenticationProvider(config, caCertPool)
+	var (
+		provider authentication.UserProvider
+		closer   func()
+	)
 
-	if provider == nil {
-		return fmt.Errorf("error occurred initializing user authentication provider: a provider is not configured")
+	if provider, closer, err = newDebugAuthenticationProvider(config, caCertPool); err != nil {
+		return err
 	}
 
+	defer closer()
+
 	if err = provider.StartupCheck(); err != nil {
 		return fmt.Errorf("error occurred initializing user authentication provider: %w", err)
 	}
@@ -245,12 +251,17 @@ func (ctx *CmdCtx) DebugExpressionRunE(cmd *cobra.Command, args []string) (err e
 }
 
 func runDebugExpression(w io.Writer, config *schema.Configuration, caCertPool *x509.CertPool, username, exp string) (err error) {
-	provider := middlewares.NewAuthenticationProvider(config, caCertPool)
+	var (
+		provider authentication.UserProvider
+		closer   func()
+	)
 
-	if provider == nil {
-		return fmt.Errorf("error occurred initializing user authentication provider: a provider is not configured")
+	if provider, closer, err = newDebugAuthenticationProvider(config, caCertPool); err != nil {
+		return err
 	}
 
+	defer closer()
+
 	if err = provider.StartupCheck(); err != nil {
 		return fmt.Errorf("error occurred initializing user authentication provider: %w", err)
 	}
@@ -287,6 +298,34 @@ func runDebugExpression(w io.Writer, config *schema.Configuration, caCertPool *x
 	return nil
 }
 
+func newDebugAuthenticationProvider(config *schema.Configuration, caCertPool *x509.CertPool) (provider authentication.UserProvider, closer func(), err error) {
+	var store storage.Provider
+
+	closer = func() {}
+
+	if config.AuthenticationBackend.SQL != nil {
+		if store, err = storage.NewProvider(config, caCertPool); err != nil {
+			return nil, nil, fmt.Errorf("error occurred initializing storage provider: %w", err)
+		}
+
+		if store == nil {
+			return nil, nil, fmt.Errorf("error occurred initializing storage provider: a provider is not configured")
+		}
+
+		closer = func() {
+			_ = store.Close()
+		}
+	}
+
+	if provider = middlewares.NewAuthenticationProvider(config, caCertPool, store); provider == nil {
+		closer()
+
+		return nil, nil, fmt.Errorf("error occurred initializing user authentication provider: a provider is not configured")
+	}
+
+	return provider, closer, nil
+}
+
 // DebugTLSRunE is the RunE for the authelia debug tls command.
 func (ctx *CmdCtx) DebugTLSRunE(cmd *cobra.Command, args []string) (err error) {
 	return runDebugTLS(cmd.OutOrStdout(), cmd.Flags(), ctx.trusted, args[0])
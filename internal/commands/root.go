@@ -47,6 +47,8 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newBuildInfoCmd(ctx),
 		newCryptoCmd(ctx),
 		newStorageCmd(ctx),
+		newSessionsCmd(ctx),
+		newUsersCmd(ctx),
 		newConfigCmd(ctx),
 		newConfigValidateLegacyCmd(ctx),
 		newDebugCmd(ctx),
@@ -97,6 +99,10 @@ func (ctx *CmdCtx) RootRunE(_ *cobra.Command, _ []string) (err error) {
 		}
 	}
 
+	if ctx.config.AccessControl.Watch {
+		ctx.providers.Authorizer.SetConfigurationLoader(ctx.NewAccessControlConfigurationLoader())
+	}
+
 	ctx.cconfig = nil
 
 	ctx.log.Trace("Starting Services")
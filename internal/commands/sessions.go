@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func newSessionsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "sessions",
+		Short:   cmdAutheliaSessionsShort,
+		Long:    cmdAutheliaSessionsLong,
+		Example: cmdAutheliaSessionsExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.LoadTrustedCertificatesRunE,
+			ctx.ConfigValidateSessionsRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.PersistentFlags().String(cmdFlagNameUser, "", "the username of the user")
+
+	cmd.AddCommand(
+		newSessionsListCmd(ctx),
+		newSessionsRevokeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newSessionsListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaSessionsListShort,
+		Long:    cmdAutheliaSessionsListLong,
+		Example: cmdAutheliaSessionsListExample,
+		Args:    cobra.NoArgs,
+		RunE:    ctx.SessionsListRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newSessionsRevokeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "revoke",
+		Short:   cmdAutheliaSessionsRevokeShort,
+		Long:    cmdAutheliaSessionsRevokeLong,
+		Example: cmdAutheliaSessionsRevokeExample,
+		Args:    cobra.NoArgs,
+		RunE:    ctx.SessionsRevokeRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameSession, "", "the id of an individual session to revoke instead of every session")
+
+	return cmd
+}
+
+// ConfigValidateSessionsRunE validates the session config, and the storage config if OpenID Connect 1.0 or the storage
+// session provider is configured, before running commands using them.
+func (ctx *CmdCtx) ConfigValidateSessionsRunE(_ *cobra.Command, _ []string) (err error) {
+	validator.ValidateSession(ctx.config, ctx.cconfig.validator)
+
+	if ctx.config.IdentityProviders.OIDC != nil || ctx.config.Session.Storage != nil {
+		validator.ValidateStorage(ctx.config.Storage, ctx.cconfig.validator)
+	}
+
+	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
+		var (
+			i int
+			e error
+		)
+
+		for i, e = range errs {
+			if i == 0 {
+				err = e
+				continue
+			}
+
+			err = fmt.Errorf("%w, %v", err, e)
+		}
+
+		return err
+	}
+
+	if ctx.config.Session.Redis == nil && ctx.config.Session.Storage == nil {
+		return errors.New("the sessions command requires the redis or storage session provider as the sessions of the memory session provider are only accessible to the running process")
+	}
+
+	return nil
+}
+
+// SessionsListRunE is the RunE for the authelia sessions list command.
+func (ctx *CmdCtx) SessionsListRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		username string
+		index    *session.UserSessionIndex
+		provider storage.Provider
+	)
+
+	if username, err = sessionsGetUsername(cmd); err != nil {
+		return err
+	}
+
+	if ctx.config.Session.Storage != nil {
+		if provider, err = getStorageProvider(ctx); err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := provider.Close(); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	if index, err = ctx.sessionsGetUserSessionIndex(provider); err != nil {
+		return err
+	}
+
+	return runSessionsList(cmd.OutOrStdout(), index, username)
+}
+
+// SessionsRevokeRunE is the RunE for the authelia sessions revoke command.
+func (ctx *CmdCtx) SessionsRevokeRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		username, id     string
+		index            *session.UserSessionIndex
+		provider, oauth2 storage.Provider
+	)
+
+	if username, err = sessionsGetUsername(cmd); err != nil {
+		return err
+	}
+
+	if id, err = cmd.Flags().GetString(cmdFlagNameSession); err != nil {
+		return err
+	}
+
+	if ctx.config.Session.Storage != nil || ctx.config.IdentityProviders.OIDC != nil {
+		if provider, err = getStorageProvider(ctx); err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := provider.Close(); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	if index, err = ctx.sessionsGetUserSessionIndex(provider); err != nil {
+		return err
+	}
+
+	if id == "" && ctx.config.IdentityProviders.OIDC != nil {
+		oauth2 = provider
+	}
+
+	return runSessionsRevoke(ctx, cmd.OutOrStdout(), index, oauth2, username, id)
+}
+
+func (ctx *CmdCtx) sessionsGetUserSessionIndex(store storage.Provider) (index *session.UserSessionIndex, err error) {
+	provider := session.NewProvider(ctx.config.Session, ctx.trusted, store)
+
+	if err = provider.StartupCheck(); err != nil {
+		return nil, err
+	}
+
+	return provider.UserSessionIndex(), nil
+}
+
+func sessionsGetUsername(cmd *cobra.Command) (username string, err error) {
+	if username, err = cmd.Flags().GetString(cmdFlagNameUser); err != nil {
+		return "", err
+	}
+
+	if username == "" {
+		return "", fmt.Errorf("the --%s flag is required", cmdFlagNameUser)
+	}
+
+	return username, nil
+}
+
+func runSessionsList(w io.Writer, index *session.UserSessionIndex, username string) (err error) {
+	var records []session.UserSessionRecord
+
+	if records, err = index.List(username); err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		_, _ = fmt.Fprintf(w, "No results.\n")
+
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 1, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "ID\tCookie Domain\tRemote IP\tCreated\tLast Activity\tMethods\tUser Agent")
+
+	for _, record := range records {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", record.PublicID(), record.CookieDomain, record.RemoteIP,
+			record.Created.Format(time.RFC3339), record.LastActivity.Format(time.RFC3339), strings.Join(record.AMR, ", "), record.UserAgent)
+	}
+
+	return tw.Flush()
+}
+
+func runSessionsRevoke(ctx context.Context, w io.Writer, index *session.UserSessionIndex, provider storage.Provider, username, id string) (err error) {
+	var revoked []session.UserSessionRecord
+
+	if revoked, err = index.Revoke(username, func(record session.UserSessionRecord) bool {
+		return id == "" || record.PublicID() == id
+	}); err != nil {
+		return err
+	}
+
+	switch {
+	case id == "":
+		_, _ = fmt.Fprintf(w, "Successfully revoked %d sessions of user '%s'.\n", len(revoked), username)
+	case len(revoked) == 0:
+		return fmt.Errorf("error revoking session '%s' of user '%s': the session does not exist", id, username)
+	default:
+		_, _ = fmt.Fprintf(w, "Successfully revoked session '%s' of user '%s'.\n", id, username)
+	}
+
+	if provider == nil {
+		return nil
+	}
+
+	var n int
+
+	if n, err = oidc.RevokeUserOAuth2Sessions(ctx, provider, username); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Successfully revoked %d OpenID Connect 1.0 access tokens and refresh tokens of user '%s'.\n", n, username)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/session/memory"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func newTestUserSessionIndex(t *testing.T) (index *session.UserSessionIndex) {
+	t.Helper()
+
+	backend, err := memory.New(memory.Config{})
+	require.NoError(t, err)
+
+	index = session.NewUserSessionIndex(backend, nil, time.Hour)
+
+	for _, id := range []string{"one", "two"} {
+		require.NoError(t, backend.Save([]byte(id), []byte("data"), time.Hour))
+		require.NoError(t, index.Touch("john", session.UserSessionRecord{ID: id, CookieDomain: "example.com", RemoteIP: "192.168.0.1", UserAgent: "Test/1.0", AMR: []string{"pwd", "otp", "mfa"}}))
+	}
+
+	return index
+}
+
+func TestNewSessionsCmd(t *testing.T) {
+	cmd := newSessionsCmd(&CmdCtx{})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "sessions", cmd.Use)
+	assert.Len(t, cmd.Commands(), 2)
+}
+
+func TestRunSessionsList(t *testing.T) {
+	index := newTestUserSessionIndex(t)
+
+	buf := new(bytes.Buffer)
+
+	require.NoError(t, runSessionsList(buf, index, "john"))
+
+	output := buf.String()
+
+	assert.Contains(t, output, "ID                               Cookie Domain Remote IP   Created")
+	assert.Contains(t, output, session.NewUserSessionRecordPublicID("one"))
+	assert.Contains(t, output, session.NewUserSessionRecordPublicID("two"))
+	assert.Contains(t, output, "pwd, otp, mfa Test/1.0")
+
+	buf.Reset()
+
+	require.NoError(t, runSessionsList(buf, index, "harry"))
+
+	assert.Equal(t, "No results.\n", buf.String())
+}
+
+func TestRunSessionsRevoke(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		setup     func(mock *mocks.MockStorage)
+		expected  string
+		err       string
+		remaining int
+	}{
+		{
+			"ShouldRevokeAllSessions",
+			"",
+			nil,
+			"Successfully revoked 2 sessions of user 'john'.\n",
+			"",
+			0,
+		},
+		{
+			"ShouldRevokeAllSessionsAndOpenIDConnectSessions",
+			"",
+			func(mock *mocks.MockStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "john").Return([]string{"r1"}, nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "r1").Return(nil),
+					mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "john").Return(nil, nil),
+				)
+			},
+			"Successfully revoked 2 sessions of user 'john'.\nSuccessfully revoked 1 OpenID Connect 1.0 access tokens and refresh tokens of user 'john'.\n",
+			"",
+			0,
+		},
+		{
+			"ShouldErrRevokeOpenIDConnectSessions",
+			"",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "john").Return(nil, fmt.Errorf("bad conn"))
+			},
+			"Successfully revoked 2 sessions of user 'john'.\n",
+			"bad conn",
+			0,
+		},
+		{
+			"ShouldRevokeIndividualSession",
+			session.NewUserSessionRecordPublicID("one"),
+			nil,
+			fmt.Sprintf("Successfully revoked session '%s' of user 'john'.\n", session.NewUserSessionRecordPublicID("one")),
+			"",
+			1,
+		},
+		{
+			"ShouldErrRevokeUnknownSession",
+			"abc",
+			nil,
+			"",
+			"error revoking session 'abc' of user 'john': the session does not exist",
+			2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			index := newTestUserSessionIndex(t)
+
+			var provider storage.Provider
+
+			if tc.setup != nil {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mock := mocks.NewMockStorage(ctrl)
+
+				tc.setup(mock)
+
+				provider = mock
+			}
+
+			buf := new(bytes.Buffer)
+
+			err := runSessionsRevoke(context.Background(), buf, index, provider, "john", tc.id)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, buf.String())
+
+			records, err := index.List("john")
+			require.NoError(t, err)
+			assert.Len(t, records, tc.remaining)
+		})
+	}
+}
@@ -0,0 +1,530 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/expression"
+)
+
+func newUsersCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "users",
+		Short:   cmdAutheliaUsersShort,
+		Long:    cmdAutheliaUsersLong,
+		Example: cmdAutheliaUsersExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.ConfigValidateSectionAuthenticationBackendFileRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newUsersListCmd(ctx),
+		newUsersAddCmd(ctx),
+		newUsersDeleteCmd(ctx),
+		newUsersDisableCmd(ctx),
+		newUsersSetGroupsCmd(ctx),
+		newUsersSetAttributeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newUsersListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaUsersListShort,
+		Long:    cmdAutheliaUsersListLong,
+		Example: cmdAutheliaUsersListExample,
+		Args:    cobra.NoArgs,
+		RunE:    ctx.UsersListRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaUsersAddShort,
+		Long:    cmdAutheliaUsersAddLong,
+		Example: cmdAutheliaUsersAddExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    ctx.UsersAddRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the display name of the user, defaults to the username")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the email of the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the groups of the user")
+	cmd.Flags().Bool(cmdFlagNameDisabled, false, "adds the user in the disabled state")
+
+	cmdFlagPassword(cmd, true)
+
+	return cmd
+}
+
+func newUsersDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaUsersDeleteShort,
+		Long:    cmdAutheliaUsersDeleteLong,
+		Example: cmdAutheliaUsersDeleteExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    ctx.UsersDeleteRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersDisableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "disable <username>",
+		Short:   cmdAutheliaUsersDisableShort,
+		Long:    cmdAutheliaUsersDisableLong,
+		Example: cmdAutheliaUsersDisableExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    ctx.UsersDisableRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameEnable, false, "enables the user instead of disabling them")
+
+	return cmd
+}
+
+func newUsersSetGroupsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "set-groups <username> [group...]",
+		Short:   cmdAutheliaUsersSetGroupsShort,
+		Long:    cmdAutheliaUsersSetGroupsLong,
+		Example: cmdAutheliaUsersSetGroupsExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    ctx.UsersSetGroupsRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersSetAttributeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "set-attribute <username> <attribute> [value...]",
+		Short:   cmdAutheliaUsersSetAttributeShort,
+		Long:    cmdAutheliaUsersSetAttributeLong,
+		Example: cmdAutheliaUsersSetAttributeExample,
+		Args:    cobra.MinimumNArgs(2),
+		RunE:    ctx.UsersSetAttributeRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+// UsersListRunE is the RunE for the authelia users list command.
+func (ctx *CmdCtx) UsersListRunE(cmd *cobra.Command, _ []string) (err error) {
+	return runUsersList(cmd.OutOrStdout(), ctx.config.AuthenticationBackend.File)
+}
+
+// UsersAddRunE is the RunE for the authelia users add command.
+func (ctx *CmdCtx) UsersAddRunE(cmd *cobra.Command, args []string) (err error) {
+	return runUsersAdd(cmd.OutOrStdout(), cmd.Flags(), args[0], ctx.config.AuthenticationBackend.File)
+}
+
+// UsersDeleteRunE is the RunE for the authelia users delete command.
+func (ctx *CmdCtx) UsersDeleteRunE(cmd *cobra.Command, args []string) (err error) {
+	return runUsersDelete(cmd.OutOrStdout(), args[0], ctx.config.AuthenticationBackend.File)
+}
+
+// UsersDisableRunE is the RunE for the authelia users disable command.
+func (ctx *CmdCtx) UsersDisableRunE(cmd *cobra.Command, args []string) (err error) {
+	var enable bool
+
+	if enable, err = cmd.Flags().GetBool(cmdFlagNameEnable); err != nil {
+		return err
+	}
+
+	return runUsersDisable(cmd.OutOrStdout(), args[0], !enable, ctx.config.AuthenticationBackend.File)
+}
+
+// UsersSetGroupsRunE is the RunE for the authelia users set-groups command.
+func (ctx *CmdCtx) UsersSetGroupsRunE(cmd *cobra.Command, args []string) (err error) {
+	return runUsersSetGroups(cmd.OutOrStdout(), args[0], args[1:], ctx.config.AuthenticationBackend.File)
+}
+
+// UsersSetAttributeRunE is the RunE for the authelia users set-attribute command.
+func (ctx *CmdCtx) UsersSetAttributeRunE(cmd *cobra.Command, args []string) (err error) {
+	return runUsersSetAttribute(cmd.OutOrStdout(), args[0], args[1], args[2:], ctx.config.AuthenticationBackend.File)
+}
+
+func runUsersList(w io.Writer, config *schema.AuthenticationBackendFile) (err error) {
+	var database *authentication.FileUserDatabase
+
+	if database, err = usersLoadDatabase(config, true); err != nil {
+		return err
+	}
+
+	if len(database.Users) == 0 {
+		_, _ = fmt.Fprintf(w, "No results.\n")
+
+		return nil
+	}
+
+	usernames := make([]string, 0, len(database.Users))
+
+	for username := range database.Users {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+
+	tw := tabwriter.NewWriter(w, 1, 1, 1, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "Username\tDisplay Name\tEmail\tGroups\tDisabled")
+
+	for _, username := range usernames {
+		details := database.Users[username]
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", username, details.DisplayName, details.Email, strings.Join(details.Groups, ", "), details.Disabled)
+	}
+
+	return tw.Flush()
+}
+
+func runUsersAdd(w io.Writer, flags *pflag.FlagSet, username string, config *schema.AuthenticationBackendFile) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  *authentication.FileUserDatabaseUserDetails
+		password string
+		hash     algorithm.Hash
+		digest   algorithm.Digest
+	)
+
+	if config.Search.CaseInsensitive {
+		username = strings.ToLower(username)
+	}
+
+	if database, err = usersLoadDatabase(config, true); err != nil {
+		return err
+	}
+
+	if _, err = database.GetUserDetails(username); err == nil {
+		return fmt.Errorf("error adding user '%s': the user already exists", username)
+	}
+
+	if details, err = usersDetailsFromFlags(flags, username); err != nil {
+		return err
+	}
+
+	if hash, err = authentication.NewFileCryptoHashFromConfig(config.Password); err != nil {
+		return err
+	}
+
+	if password, _, err = cmdFlagsCryptoHashGetPassword(w, flags, "add", nil, false, false); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(password) == "" {
+		return fmt.Errorf("error adding user '%s': the password must not be empty", username)
+	}
+
+	if digest, err = hash.Hash(password); err != nil {
+		return fmt.Errorf("error adding user '%s': error hashing the password: %w", username, err)
+	}
+
+	details.Password = schema.NewPasswordDigest(digest)
+
+	database.SetUserDetails(username, details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Successfully added user '%s'.\n", username)
+
+	return nil
+}
+
+func usersDetailsFromFlags(flags *pflag.FlagSet, username string) (details *authentication.FileUserDatabaseUserDetails, err error) {
+	details = &authentication.FileUserDatabaseUserDetails{
+		Username: username,
+	}
+
+	if details.DisplayName, err = flags.GetString(cmdFlagNameDisplayName); err != nil {
+		return nil, err
+	}
+
+	if details.DisplayName == "" {
+		details.DisplayName = username
+	}
+
+	if details.Email, err = flags.GetString(cmdFlagNameEmail); err != nil {
+		return nil, err
+	}
+
+	if details.Groups, err = flags.GetStringSlice(cmdFlagNameGroups); err != nil {
+		return nil, err
+	}
+
+	if details.Disabled, err = flags.GetBool(cmdFlagNameDisabled); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+func runUsersDelete(w io.Writer, username string, config *schema.AuthenticationBackendFile) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	if database, err = usersLoadDatabase(config, false); err != nil {
+		return err
+	}
+
+	if details, err = usersGetUserDetails(database, username); err != nil {
+		return err
+	}
+
+	if err = database.DeleteUserDetails(details.Username); err != nil {
+		return fmt.Errorf("error deleting user '%s': %w", details.Username, err)
+	}
+
+	if err = usersSaveDatabase(database); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Successfully deleted user '%s'.\n", details.Username)
+
+	return nil
+}
+
+func runUsersDisable(w io.Writer, username string, disabled bool, config *schema.AuthenticationBackendFile) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	if database, err = usersLoadDatabase(config, false); err != nil {
+		return err
+	}
+
+	if details, err = usersGetUserDetails(database, username); err != nil {
+		return err
+	}
+
+	details.Disabled = disabled
+
+	database.SetUserDetails(details.Username, &details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return err
+	}
+
+	if disabled {
+		_, _ = fmt.Fprintf(w, "Successfully disabled user '%s'.\n", details.Username)
+	} else {
+		_, _ = fmt.Fprintf(w, "Successfully enabled user '%s'.\n", details.Username)
+	}
+
+	return nil
+}
+
+func runUsersSetGroups(w io.Writer, username string, groups []string, config *schema.AuthenticationBackendFile) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	if database, err = usersLoadDatabase(config, false); err != nil {
+		return err
+	}
+
+	if details, err = usersGetUserDetails(database, username); err != nil {
+		return err
+	}
+
+	details.Groups = groups
+
+	database.SetUserDetails(details.Username, &details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return err
+	}
+
+	if len(groups) == 0 {
+		_, _ = fmt.Fprintf(w, "Successfully removed all groups from user '%s'.\n", details.Username)
+	} else {
+		_, _ = fmt.Fprintf(w, "Successfully set the groups of user '%s' to '%s'.\n", details.Username, strings.Join(groups, "', '"))
+	}
+
+	return nil
+}
+
+func runUsersSetAttribute(w io.Writer, username, name string, values []string, config *schema.AuthenticationBackendFile) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	attribute, ok := config.ExtraAttributes[name]
+	if !ok {
+		return fmt.Errorf("error setting attribute '%s' for user '%s': the attribute is not configured in the 'authentication_backend.file.extra_attributes' section", name, username)
+	}
+
+	if database, err = usersLoadDatabase(config, false); err != nil {
+		return err
+	}
+
+	if details, err = usersGetUserDetails(database, username); err != nil {
+		return err
+	}
+
+	extra := make(map[string]any, len(details.Extra)+1)
+
+	for k, v := range details.Extra {
+		extra[k] = v
+	}
+
+	if len(values) == 0 {
+		delete(extra, name)
+	} else {
+		var value any
+
+		if value, err = usersParseAttributeValues(attribute, values); err != nil {
+			return fmt.Errorf("error setting attribute '%s' for user '%s': %w", name, details.Username, err)
+		}
+
+		extra[name] = value
+	}
+
+	details.Extra = extra
+
+	if err = details.ToUserDetailsModel().ValidateExtra(details.Username, database.Extra); err != nil {
+		return err
+	}
+
+	database.SetUserDetails(details.Username, &details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		_, _ = fmt.Fprintf(w, "Successfully removed attribute '%s' from user '%s'.\n", name, details.Username)
+	} else {
+		_, _ = fmt.Fprintf(w, "Successfully set attribute '%s' for user '%s'.\n", name, details.Username)
+	}
+
+	return nil
+}
+
+func usersParseAttributeValues(attribute schema.AuthenticationBackendExtraAttribute, values []string) (value any, err error) {
+	if !attribute.IsMultiValued() {
+		if len(values) != 1 {
+			return nil, fmt.Errorf("the attribute is not multi-valued but %d values were provided", len(values))
+		}
+
+		return usersParseAttributeValue(attribute.GetValueType(), values[0])
+	}
+
+	items := make([]any, len(values))
+
+	for i, v := range values {
+		if items[i], err = usersParseAttributeValue(attribute.GetValueType(), v); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func usersParseAttributeValue(vtype, raw string) (value any, err error) {
+	switch vtype {
+	case authentication.ValueTypeInteger:
+		if value, err = strconv.Atoi(raw); err != nil {
+			return nil, fmt.Errorf("the value '%s' is not a valid %s", raw, vtype)
+		}
+	case authentication.ValueTypeBoolean:
+		if value, err = strconv.ParseBool(raw); err != nil {
+			return nil, fmt.Errorf("the value '%s' is not a valid %s", raw, vtype)
+		}
+	default:
+		value = raw
+	}
+
+	return value, nil
+}
+
+func usersGetExtra(config *schema.AuthenticationBackendFile) (extra map[string]expression.ExtraAttribute) {
+	extra = make(map[string]expression.ExtraAttribute, len(config.ExtraAttributes))
+
+	for name, attribute := range config.ExtraAttributes {
+		extra[name] = attribute
+	}
+
+	return extra
+}
+
+func usersLoadDatabase(config *schema.AuthenticationBackendFile, missing bool) (database *authentication.FileUserDatabase, err error) {
+	database = authentication.NewFileUserDatabase(config.Path, config.Search.Email, config.Search.CaseInsensitive, usersGetExtra(config))
+
+	if err = database.Load(); err != nil {
+		if missing && (errors.Is(err, fs.ErrNotExist) || errors.Is(err, authentication.ErrWatcherNoContent)) {
+			return database, nil
+		}
+
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func usersGetUserDetails(database *authentication.FileUserDatabase, username string) (details authentication.FileUserDatabaseUserDetails, err error) {
+	if details, err = database.GetUserDetails(username); err != nil {
+		if errors.Is(err, authentication.ErrUserNotFound) {
+			return details, fmt.Errorf("error looking up user '%s': the user does not exist", username)
+		}
+
+		return details, err
+	}
+
+	return details, nil
+}
+
+func usersSaveDatabase(database *authentication.FileUserDatabase) (err error) {
+	if err = database.LoadAliases(); err != nil {
+		return err
+	}
+
+	if err = database.Save(); err != nil {
+		return fmt.Errorf("error saving the authentication database: %w", err)
+	}
+
+	return nil
+}
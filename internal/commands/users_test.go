@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+const (
+	testUsersDatabase = `users:
+  john:
+    displayname: John Doe
+    password: $plaintext$password
+    email: john.doe@example.com
+    groups:
+      - admins
+  harry:
+    displayname: Harry Potter
+    password: $plaintext$password
+    email: harry.potter@example.com
+    disabled: true
+`
+)
+
+func newTestUsersConfig(t *testing.T, content string) (config *schema.AuthenticationBackendFile) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "users.yml")
+
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	return &schema.AuthenticationBackendFile{
+		Path:     path,
+		Password: schema.DefaultCIPasswordConfig,
+		ExtraAttributes: map[string]schema.AuthenticationBackendExtraAttribute{
+			"department":  {ValueType: authentication.ValueTypeString},
+			"employee_id": {ValueType: authentication.ValueTypeInteger},
+			"projects":    {ValueType: authentication.ValueTypeString, MultiValued: true},
+		},
+	}
+}
+
+func loadTestUsersDatabase(t *testing.T, config *schema.AuthenticationBackendFile) (database *authentication.FileUserDatabase) {
+	t.Helper()
+
+	database, err := usersLoadDatabase(config, false)
+	require.NoError(t, err)
+
+	return database
+}
+
+func TestNewUsersCmd(t *testing.T) {
+	cmd := newUsersCmd(&CmdCtx{})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "users", cmd.Use)
+	assert.Len(t, cmd.Commands(), 6)
+}
+
+func TestRunUsersList(t *testing.T) {
+	config := newTestUsersConfig(t, testUsersDatabase)
+
+	buf := new(bytes.Buffer)
+
+	require.NoError(t, runUsersList(buf, config))
+
+	assert.Equal(t, "Username Display Name Email                    Groups Disabled\nharry    Harry Potter harry.potter@example.com        true\njohn     John Doe     john.doe@example.com     admins false\n", buf.String())
+
+	buf.Reset()
+
+	require.NoError(t, runUsersList(buf, newTestUsersConfig(t, "")))
+
+	assert.Equal(t, "No results.\n", buf.String())
+}
+
+func TestRunUsersAdd(t *testing.T) {
+	testCases := []struct {
+		name            string
+		content         string
+		args            []string
+		username        string
+		caseInsensitive bool
+		expected        string
+		err             string
+	}{
+		{
+			"ShouldAddUser",
+			testUsersDatabase,
+			[]string{"--password", "example", "--display-name", "Fred Weasley", "--email", "fred@example.com", "--groups", "dev,ops"},
+			"fred",
+			false,
+			"Successfully added user 'fred'.\n",
+			"",
+		},
+		{
+			"ShouldAddUserToNewDatabase",
+			"",
+			[]string{"--password", "example"},
+			"fred",
+			false,
+			"Successfully added user 'fred'.\n",
+			"",
+		},
+		{
+			"ShouldErrUserExists",
+			testUsersDatabase,
+			[]string{"--password", "example"},
+			"john",
+			false,
+			"",
+			"error adding user 'john': the user already exists",
+		},
+		{
+			"ShouldErrEmptyPassword",
+			testUsersDatabase,
+			[]string{"--password", " "},
+			"fred",
+			false,
+			"",
+			"error adding user 'fred': the password must not be empty",
+		},
+		{
+			"ShouldAddUserLowercaseWhenCaseInsensitive",
+			testUsersDatabase,
+			[]string{"--password", "example"},
+			"Fred",
+			true,
+			"Successfully added user 'fred'.\n",
+			"",
+		},
+		{
+			"ShouldErrUserExistsDifferentCaseWhenCaseInsensitive",
+			testUsersDatabase,
+			[]string{"--password", "example"},
+			"JOHN",
+			true,
+			"",
+			"error adding user 'john': the user already exists",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestUsersConfig(t, tc.content)
+			config.Search.CaseInsensitive = tc.caseInsensitive
+
+			cmd := newUsersAddCmd(&CmdCtx{})
+
+			require.NoError(t, cmd.ParseFlags(tc.args))
+
+			buf := new(bytes.Buffer)
+
+			err := runUsersAdd(buf, cmd.Flags(), tc.username, config)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buf.String())
+
+			database := loadTestUsersDatabase(t, config)
+
+			details, err := database.GetUserDetails(tc.username)
+			require.NoError(t, err)
+
+			assert.Contains(t, database.Users, strings.ToLower(tc.username))
+
+			assert.True(t, details.Password.Match("example"))
+			assert.NotEmpty(t, details.DisplayName)
+		})
+	}
+}
+
+func TestRunUsersDelete(t *testing.T) {
+	config := newTestUsersConfig(t, testUsersDatabase)
+
+	buf := new(bytes.Buffer)
+
+	require.NoError(t, runUsersDelete(buf, "john", config))
+	assert.Equal(t, "Successfully deleted user 'john'.\n", buf.String())
+
+	_, err := loadTestUsersDatabase(t, config).GetUserDetails("john")
+	assert.ErrorIs(t, err, authentication.ErrUserNotFound)
+
+	assert.EqualError(t, runUsersDelete(buf, "john", config), "error looking up user 'john': the user does not exist")
+}
+
+func TestRunUsersDisable(t *testing.T) {
+	config := newTestUsersConfig(t, testUsersDatabase)
+
+	buf := new(bytes.Buffer)
+
+	require.NoError(t, runUsersDisable(buf, "john", true, config))
+	require.NoError(t, runUsersDisable(buf, "harry", false, config))
+
+	assert.Equal(t, "Successfully disabled user 'john'.\nSuccessfully enabled user 'harry'.\n", buf.String())
+
+	database := loadTestUsersDatabase(t, config)
+
+	details, err := database.GetUserDetails("john")
+	require.NoError(t, err)
+	assert.True(t, details.Disabled)
+
+	details, err = database.GetUserDetails("harry")
+	require.NoError(t, err)
+	assert.False(t, details.Disabled)
+}
+
+func TestRunUsersSetGroups(t *testing.T) {
+	config := newTestUsersConfig(t, testUsersDatabase)
+
+	buf := new(bytes.Buffer)
+
+	require.NoError(t, runUsersSetGroups(buf, "john", []string{"dev", "ops"}, config))
+
+	details, err := loadTestUsersDatabase(t, config).GetUserDetails("john")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "ops"}, details.Groups)
+
+	require.NoError(t, runUsersSetGroups(buf, "john", nil, config))
+
+	details, err = loadTestUsersDatabase(t, config).GetUserDetails("john")
+	require.NoError(t, err)
+	assert.Empty(t, details.Groups)
+
+	assert.Equal(t, "Successfully set the groups of user 'john' to 'dev', 'ops'.\nSuccessfully removed all groups from user 'john'.\n", buf.String())
+}
+
+func TestRunUsersSetAttribute(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attribute string
+		values    []string
+		expected  any
+		err       string
+	}{
+		{
+			"ShouldSetString",
+			"department",
+			[]string{"Engineering"},
+			"Engineering",
+			"",
+		},
+		{
+			"ShouldSetInteger",
+			"employee_id",
+			[]string{"1024"},
+			1024,
+			"",
+		},
+		{
+			"ShouldSetMultiValued",
+			"projects",
+			[]string{"apollo", "gemini"},
+			[]any{"apollo", "gemini"},
+			"",
+		},
+		{
+			"ShouldRemove",
+			"department",
+			nil,
+			nil,
+			"",
+		},
+		{
+			"ShouldErrUnknownAttribute",
+			"unknown",
+			[]string{"value"},
+			nil,
+			"error setting attribute 'unknown' for user 'john': the attribute is not configured in the 'authentication_backend.file.extra_attributes' section",
+		},
+		{
+			"ShouldErrInvalidInteger",
+			"employee_id",
+			[]string{"abc"},
+			nil,
+			"error setting attribute 'employee_id' for user 'john': the value 'abc' is not a valid integer",
+		},
+		{
+			"ShouldErrMultipleValues",
+			"department",
+			[]string{"Engineering", "Sales"},
+			nil,
+			"error setting attribute 'department' for user 'john': the attribute is not multi-valued but 2 values were provided",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestUsersConfig(t, testUsersDatabase)
+
+			buf := new(bytes.Buffer)
+
+			err := runUsersSetAttribute(buf, "john", tc.attribute, tc.values, config)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			details, err := loadTestUsersDatabase(t, config).GetUserDetails("john")
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				assert.NotContains(t, details.Extra, tc.attribute)
+			} else {
+				assert.Equal(t, tc.expected, details.Extra[tc.attribute])
+			}
+		})
+	}
+}
+
+func TestUsersLoadDatabase(t *testing.T) {
+	config := newTestUsersConfig(t, "")
+
+	_, err := usersLoadDatabase(config, false)
+	assert.ErrorContains(t, err, "error reading the authentication database: failed to read the")
+
+	database, err := usersLoadDatabase(config, true)
+	require.NoError(t, err)
+	assert.Empty(t, database.Users)
+}
@@ -84,6 +84,7 @@ var (
 
 var (
 	mapDefaults = map[string]any{
+		"access_control.audit_log.sample_rate":                1.0,
 		"webauthn.metadata.validate_trust_anchor":             true,
 		"webauthn.metadata.validate_entry":                    true,
 		"webauthn.metadata.validate_entry_permit_zero_aaguid": false,
@@ -42,6 +42,7 @@ func DecodeHooksComposeAll(definitions *schema.Definitions) mapstructure.DecodeH
 		StringToPasswordDigestHookFunc(),
 		StringToLanguageTagHookFunc(),
 		StringToIPNetworksHookFunc(definitions.Network),
+		StringToSubjectsHookFunc(definitions.Subjects),
 		StringToUUIDHookFunc(),
 		ToTimeDurationHookFunc(),
 		ToRefreshIntervalDurationHookFunc(),
@@ -860,6 +861,88 @@ func StringToIPNetworksHookFunc(definitions map[string][]*net.IPNet) mapstructur
 	}
 }
 
+// StringToSubjectsHookFunc decodes a string or list into the subjects criteria, resolving any named subject
+// definitions.
+func StringToSubjectsHookFunc(definitions map[string]schema.AccessControlRuleSubjects) mapstructure.DecodeHookFuncType {
+	expectedType := reflect.TypeOf(schema.AccessControlRuleSubjects{})
+
+	return func(f reflect.Type, t reflect.Type, data any) (value any, err error) {
+		if t != expectedType {
+			return data, nil
+		}
+
+		var subjects schema.AccessControlRuleSubjects
+
+		switch d := data.(type) {
+		case string:
+			subjects = schema.AccessControlRuleSubjects{{d}}
+		case []string:
+			for _, subject := range d {
+				subjects = append(subjects, []string{subject})
+			}
+		case []any:
+			for _, v := range d {
+				switch subject := v.(type) {
+				case []any:
+					values := make([]string, len(subject))
+
+					for i := range subject {
+						values[i] = fmt.Sprint(subject[i])
+					}
+
+					subjects = append(subjects, values)
+				case []string:
+					subjects = append(subjects, subject)
+				default:
+					subjects = append(subjects, []string{fmt.Sprint(subject)})
+				}
+			}
+		default:
+			return data, nil
+		}
+
+		if len(definitions) == 0 {
+			return subjects, nil
+		}
+
+		return expandSubjectDefinitions(subjects, definitions), nil
+	}
+}
+
+// expandSubjectDefinitions replaces every reference to a subject definition with the subjects of the definition. Each
+// rule within the subjects must match in full, so a reference alongside other subjects in a single rule is expanded into
+// one rule for each of the rules of the definition.
+func expandSubjectDefinitions(subjects schema.AccessControlRuleSubjects, definitions map[string]schema.AccessControlRuleSubjects) (expanded schema.AccessControlRuleSubjects) {
+	for _, rule := range subjects {
+		rules := [][]string{{}}
+
+		for _, subject := range rule {
+			definition, ok := definitions[subject]
+			if !ok {
+				for i := range rules {
+					rules[i] = append(rules[i], subject)
+				}
+
+				continue
+			}
+
+			next := make([][]string, 0, len(rules)*len(definition))
+
+			for _, r := range rules {
+				for _, d := range definition {
+					next = append(next, append(append(make([]string, 0, len(r)+len(d)), r...), d...))
+				}
+			}
+
+			rules = next
+		}
+
+		expanded = append(expanded, rules...)
+	}
+
+	return expanded
+}
+
 // StringToUUIDHookFunc decodes a string into a uuid.UUID.
 func StringToUUIDHookFunc() mapstructure.DecodeHookFuncType {
 	expectedType := reflect.TypeOf(uuid.UUID{})
@@ -2168,6 +2168,72 @@ func TestStringToIPNetworksHookFunc(t *testing.T) {
 	}
 }
 
+func TestStringToSubjectsHookFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		have     *schema.Definitions
+		expected TestConfigSubjects
+	}{
+		{
+			"ShouldDecode",
+			"decode_subjects.yml",
+			&schema.Definitions{},
+			TestConfigSubjects{
+				Definitions: schema.Definitions{
+					Subjects: map[string]schema.AccessControlRuleSubjects{
+						"admins": {{"group:ops", "group:sre"}, {"user:alice"}},
+						"single": {{"user:bob"}},
+					},
+				},
+			},
+		},
+		{
+			"ShouldDecodeDefinitions",
+			"decode_subjects_abc.yml",
+			&schema.Definitions{
+				Subjects: map[string]schema.AccessControlRuleSubjects{
+					"admins": {{"group:ops", "group:sre"}, {"user:alice"}},
+				},
+			},
+			TestConfigSubjects{
+				AccessControl: schema.AccessControl{
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:  schema.AccessControlRuleDomains{"example.com"},
+							Policy:   "two_factor",
+							Subjects: schema.AccessControlRuleSubjects{{"group:ops", "group:sre"}, {"user:alice"}},
+						},
+						{
+							Domains:  schema.AccessControlRuleDomains{"example.com"},
+							Policy:   "one_factor",
+							Subjects: schema.AccessControlRuleSubjects{{"group:ops", "group:sre", "group:dev"}, {"user:alice", "group:dev"}, {"user:john"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := TestConfigSubjects{}
+
+			val := schema.NewStructValidator()
+			_, err := configuration.LoadAdvanced(val, "", &result, tc.have, configuration.NewDefaultSourcesFiltered([]string{path.Join("./test_resources", tc.path)}, nil, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)...)
+
+			assert.NoError(t, err)
+			assert.Len(t, val.Errors(), 0)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+type TestConfigSubjects struct {
+	Definitions   schema.Definitions   `koanf:"definitions"`
+	AccessControl schema.AccessControl `koanf:"access_control"`
+}
+
 type TestConfigDefinitions struct {
 	Definitions schema.Definitions `koanf:"definitions"`
 }
@@ -1,6 +1,9 @@
 package schema
 
-import "net"
+import (
+	"net"
+	"time"
+)
 
 // AccessControl represents the configuration related to ACLs.
 type AccessControl struct {
@@ -12,6 +15,18 @@ type AccessControl struct {
 
 	// The ACL rules list.
 	Rules []AccessControlRule `koanf:"rules" yaml:"rules,omitempty" toml:"rules,omitempty" json:"rules,omitempty" jsonschema:"title=Rules List" jsonschema_description:"The list of ACL rules to enumerate for requests."`
+
+	// The ACL decision audit log configuration.
+	AuditLog AccessControlAuditLog `koanf:"audit_log" yaml:"audit_log,omitempty" toml:"audit_log,omitempty" json:"audit_log,omitempty" jsonschema:"title=Audit Log" jsonschema_description:"The access control decision audit log configuration."`
+
+	// Enables reloading the ACL rules when the configuration files change.
+	Watch bool `koanf:"watch" yaml:"watch" toml:"watch" json:"watch" jsonschema:"default=false,title=Watch" jsonschema_description:"Enables watching the configuration files for external changes and dynamically reloading the access control rules."`
+}
+
+// AccessControlAuditLog represents the ACL decision audit log configuration.
+type AccessControlAuditLog struct {
+	FilePath   string  `koanf:"file_path" yaml:"file_path,omitempty" toml:"file_path,omitempty" json:"file_path,omitempty" jsonschema:"title=File Path" jsonschema_description:"The File Path to write the decision audit log to, the audit log is disabled unless this is configured."`
+	SampleRate float64 `koanf:"sample_rate" yaml:"sample_rate" toml:"sample_rate" json:"sample_rate" jsonschema:"default=1,minimum=0,maximum=1,title=Sample Rate" jsonschema_description:"The proportion of decisions between 0 and 1 which are written to the decision audit log."`
 }
 
 // AccessControlNetwork represents one ACL network group entry.
@@ -22,14 +37,20 @@ type AccessControlNetwork struct {
 
 // AccessControlRule represents one ACL rule entry.
 type AccessControlRule struct {
-	Domains      AccessControlRuleDomains   `koanf:"domain" yaml:"domain,omitempty" toml:"domain,omitempty" json:"domain,omitempty" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
-	DomainsRegex AccessControlRuleRegex     `koanf:"domain_regex" yaml:"domain_regex,omitempty" toml:"domain_regex,omitempty" json:"domain_regex,omitempty" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
-	Policy       string                     `koanf:"policy" yaml:"policy,omitempty" toml:"policy,omitempty" json:"policy,omitempty" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
-	Subjects     AccessControlRuleSubjects  `koanf:"subject" yaml:"subject,omitempty" toml:"subject,omitempty" json:"subject,omitempty" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
-	Networks     []*net.IPNet               `koanf:"networks" yaml:"networks,omitempty" toml:"networks,omitempty" json:"networks,omitempty" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network definition names that this rule applies to."`
-	Resources    AccessControlRuleRegex     `koanf:"resources" yaml:"resources,omitempty" toml:"resources,omitempty" json:"resources,omitempty" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
-	Methods      AccessControlRuleMethods   `koanf:"methods" yaml:"methods,omitempty" toml:"methods,omitempty" json:"methods,omitempty" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK,title=Methods" jsonschema_description:"The list of request methods this rule applies to."`
-	Query        [][]AccessControlRuleQuery `koanf:"query" yaml:"query,omitempty" toml:"query,omitempty" json:"query,omitempty" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Domains               AccessControlRuleDomains    `koanf:"domain" yaml:"domain,omitempty" toml:"domain,omitempty" json:"domain,omitempty" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
+	DomainsRegex          AccessControlRuleRegex      `koanf:"domain_regex" yaml:"domain_regex,omitempty" toml:"domain_regex,omitempty" json:"domain_regex,omitempty" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
+	Policy                string                      `koanf:"policy" yaml:"policy,omitempty" toml:"policy,omitempty" json:"policy,omitempty" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
+	Subjects              AccessControlRuleSubjects   `koanf:"subject" yaml:"subject,omitempty" toml:"subject,omitempty" json:"subject,omitempty" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
+	Networks              []*net.IPNet                `koanf:"networks" yaml:"networks,omitempty" toml:"networks,omitempty" json:"networks,omitempty" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network definition names that this rule applies to."`
+	Resources             AccessControlRuleRegex      `koanf:"resources" yaml:"resources,omitempty" toml:"resources,omitempty" json:"resources,omitempty" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
+	Methods               AccessControlRuleMethods    `koanf:"methods" yaml:"methods,omitempty" toml:"methods,omitempty" json:"methods,omitempty" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK,title=Methods" jsonschema_description:"The list of request methods this rule applies to."`
+	Query                 [][]AccessControlRuleQuery  `koanf:"query" yaml:"query,omitempty" toml:"query,omitempty" json:"query,omitempty" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Headers               [][]AccessControlRuleHeader `koanf:"headers" yaml:"headers,omitempty" toml:"headers,omitempty" json:"headers,omitempty" jsonschema:"title=Header Rules" jsonschema_description:"The list of request header rules this rule applies to."`
+	Schedule              *AccessControlRuleSchedule  `koanf:"schedule" yaml:"schedule,omitempty" toml:"schedule,omitempty" json:"schedule,omitempty" jsonschema:"title=Schedule" jsonschema_description:"The time windows during which this rule applies."`
+	MaxAge                time.Duration               `koanf:"max_age" yaml:"max_age,omitempty" toml:"max_age,omitempty" json:"max_age,omitempty" jsonschema:"title=Maximum Authentication Age" jsonschema_description:"The maximum amount of time since the user last authenticated for this rule to authorize the request."`
+	RequiredAMR           []string                    `koanf:"required_amr" yaml:"required_amr,omitempty" toml:"required_amr,omitempty" json:"required_amr,omitempty" jsonschema:"enum=pwd,enum=kba,enum=otp,enum=sms,enum=pop,enum=hwk,enum=swk,enum=user,enum=pin,enum=mfa,enum=mca,title=Required AMR" jsonschema_description:"The list of Authentication Method Reference values of which at least one must have been performed by the user for this rule to authorize the request."`
+	DisableTrustedDevices bool                        `koanf:"disable_trusted_devices" yaml:"disable_trusted_devices" toml:"disable_trusted_devices" json:"disable_trusted_devices" jsonschema:"default=false,title=Disable Trusted Devices" jsonschema_description:"Requires the second factor for this rule even if the user has authenticated from a trusted device."`
+	Expression            string                      `koanf:"expression" yaml:"expression,omitempty" toml:"expression,omitempty" json:"expression,omitempty" jsonschema:"title=Expression" jsonschema_description:"The common expression language expression which must evaluate to true for this rule to apply."`
 }
 
 // AccessControlRuleQuery represents the ACL query criteria.
@@ -39,6 +60,26 @@ type AccessControlRuleQuery struct {
 	Value    any    `koanf:"value" yaml:"value,omitempty" toml:"value,omitempty" json:"value,omitempty" jsonschema:"title=Value" jsonschema_description:"The Query Parameter value for this rule."`
 }
 
+// AccessControlRuleHeader represents the ACL header criteria.
+type AccessControlRuleHeader struct {
+	Operator string `koanf:"operator" yaml:"operator,omitempty" toml:"operator,omitempty" json:"operator,omitempty" jsonschema:"enum=equal,enum=not equal,enum=present,enum=absent,enum=pattern,enum=not pattern,title=Operator" jsonschema_description:"The operator this header rule uses to match the header value."`
+	Key      string `koanf:"key" yaml:"key,omitempty" toml:"key,omitempty" json:"key,omitempty" jsonschema:"required,title=Key" jsonschema_description:"The Header name this rule applies to."`
+	Value    any    `koanf:"value" yaml:"value,omitempty" toml:"value,omitempty" json:"value,omitempty" jsonschema:"title=Value" jsonschema_description:"The Header value for this rule."`
+}
+
+// AccessControlRuleSchedule represents the ACL schedule criteria.
+type AccessControlRuleSchedule struct {
+	TimeZone string                            `koanf:"timezone" yaml:"timezone,omitempty" toml:"timezone,omitempty" json:"timezone,omitempty" jsonschema:"title=Time Zone" jsonschema_description:"The IANA time zone name the windows are evaluated in, defaults to the local time zone."`
+	Windows  []AccessControlRuleScheduleWindow `koanf:"windows" yaml:"windows,omitempty" toml:"windows,omitempty" json:"windows,omitempty" jsonschema:"required,title=Windows" jsonschema_description:"The list of time windows during which this rule applies."`
+}
+
+// AccessControlRuleScheduleWindow represents a single time window of the ACL schedule criteria.
+type AccessControlRuleScheduleWindow struct {
+	Days  []string `koanf:"days" yaml:"days,omitempty" toml:"days,omitempty" json:"days,omitempty" jsonschema:"enum=monday,enum=tuesday,enum=wednesday,enum=thursday,enum=friday,enum=saturday,enum=sunday,title=Days" jsonschema_description:"The days of the week this window applies to, defaults to every day."`
+	Start string   `koanf:"start" yaml:"start,omitempty" toml:"start,omitempty" json:"start,omitempty" jsonschema:"default=00:00,title=Start" jsonschema_description:"The time of day in the HH:MM format this window starts at."`
+	End   string   `koanf:"end" yaml:"end,omitempty" toml:"end,omitempty" json:"end,omitempty" jsonschema:"default=24:00,title=End" jsonschema_description:"The time of day in the HH:MM format this window ends at."`
+}
+
 // DefaultACLRule represents the default configuration related to access control rule configuration.
 var DefaultACLRule = []AccessControlRule{
 	{
@@ -3,6 +3,7 @@ package schema
 import (
 	"crypto/tls"
 	"net/url"
+	"regexp"
 	"time"
 )
 
@@ -16,11 +17,22 @@ type AuthenticationBackend struct {
 	// The file authentication backend configuration.
 	File *AuthenticationBackendFile `koanf:"file" yaml:"file,omitempty" toml:"file,omitempty" json:"file,omitempty" jsonschema:"title=File Backend" jsonschema_description:"The file authentication backend configuration."`
 	LDAP *AuthenticationBackendLDAP `koanf:"ldap" yaml:"ldap,omitempty" toml:"ldap,omitempty" json:"ldap,omitempty" jsonschema:"title=LDAP Backend" jsonschema_description:"The LDAP authentication backend configuration."`
+	SQL  *AuthenticationBackendSQL  `koanf:"sql" yaml:"sql,omitempty" toml:"sql,omitempty" json:"sql,omitempty" jsonschema:"title=SQL Backend" jsonschema_description:"The SQL authentication backend configuration which uses the storage database."`
+
+	Chain []AuthenticationBackendChain `koanf:"chain" yaml:"chain,omitempty" toml:"chain,omitempty" json:"chain,omitempty" jsonschema:"title=Chain" jsonschema_description:"The ordered list of configured authentication backends and the users they own."`
+}
+
+// AuthenticationBackendChain represents the configuration related to a single link of the authentication backend chain.
+type AuthenticationBackendChain struct {
+	Backend   string          `koanf:"backend" yaml:"backend" toml:"backend" json:"backend" jsonschema:"enum=file,enum=ldap,enum=sql,title=Backend" jsonschema_description:"The name of the configured authentication backend."`
+	Usernames []regexp.Regexp `koanf:"usernames" yaml:"usernames,omitempty" toml:"usernames,omitempty" json:"usernames,omitempty" jsonschema:"title=Usernames" jsonschema_description:"The regex patterns which match the usernames owned by the backend."`
+	Domains   []string        `koanf:"domains" yaml:"domains,omitempty" toml:"domains,omitempty" json:"domains,omitempty" jsonschema:"title=Domains" jsonschema_description:"The domain suffixes which match the usernames owned by the backend."`
 }
 
 // AuthenticationBackendPasswordChange represents the configuration related to password reset functionality.
 type AuthenticationBackendPasswordChange struct {
-	Disable bool `koanf:"disable" yaml:"disable" toml:"disable" json:"disable" jsonschema:"default=false,title=Disable" jsonschema_description:"Disables the Password Change option."`
+	Disable bool          `koanf:"disable" yaml:"disable" toml:"disable" json:"disable" jsonschema:"default=false,title=Disable" jsonschema_description:"Disables the Password Change option."`
+	MaxAge  time.Duration `koanf:"max_age" yaml:"max_age,omitempty" toml:"max_age,omitempty" json:"max_age,omitempty" jsonschema:"default=0 seconds,title=Maximum Age" jsonschema_description:"The maximum age of a password before the user is required to change it after the first factor. A value of 0 disables password expiry."`
 }
 
 // AuthenticationBackendPasswordReset represents the configuration related to password reset functionality.
@@ -41,6 +53,19 @@ type AuthenticationBackendFile struct {
 	ExtraAttributes map[string]AuthenticationBackendExtraAttribute `koanf:"extra_attributes" yaml:"extra_attributes,omitempty" toml:"extra_attributes,omitempty" json:"extra_attributes,omitempty" jsonschema:"title=Extra Attributes" jsonschema_description:"Configures the extra attributes available in expressions and other areas of Authelia."`
 }
 
+// AuthenticationBackendSQL represents the configuration related to the SQL backend which stores users in the storage
+// provider database.
+type AuthenticationBackendSQL struct {
+	Password AuthenticationBackendFilePassword `koanf:"password" yaml:"password,omitempty" toml:"password,omitempty" json:"password,omitempty" jsonschema:"title=Password Options" jsonschema_description:"Allows configuration of the password hashing options when the user passwords are changed directly by Authelia."`
+
+	Search AuthenticationBackendSQLSearch `koanf:"search" yaml:"search,omitempty" toml:"search,omitempty" json:"search,omitempty" jsonschema:"title=Search" jsonschema_description:"Configures the user searching behavior."`
+}
+
+// AuthenticationBackendSQLSearch represents the configuration related to SQL backend searching.
+type AuthenticationBackendSQLSearch struct {
+	Email bool `koanf:"email" yaml:"email" toml:"email" json:"email" jsonschema:"default=false,title=Email Searching" jsonschema_description:"Allows users to either use their username or their configured email as a username."`
+}
+
 // AuthenticationBackendExtraAttribute represents the configuration of an extra user attribute.
 type AuthenticationBackendExtraAttribute struct {
 	MultiValued bool   `koanf:"multi_valued" yaml:"multi_valued" toml:"multi_valued" json:"multi_valued" jsonschema:"title=Multi-Valued" jsonschema_description:"Defines the attribute as multi-valued."`
@@ -144,9 +169,10 @@ type AuthenticationBackendLDAP struct {
 	AdditionalUsersDN string `koanf:"additional_users_dn" yaml:"additional_users_dn,omitempty" toml:"additional_users_dn,omitempty" json:"additional_users_dn,omitempty" jsonschema:"title=Additional User Base" jsonschema_description:"The base in addition to the Base DN for all directory server operations for users."`
 	UsersFilter       string `koanf:"users_filter" yaml:"users_filter,omitempty" toml:"users_filter,omitempty" json:"users_filter,omitempty" jsonschema:"title=Users Filter" jsonschema_description:"The LDAP filter used to search for user objects."`
 
-	AdditionalGroupsDN string `koanf:"additional_groups_dn" yaml:"additional_groups_dn,omitempty" toml:"additional_groups_dn,omitempty" json:"additional_groups_dn,omitempty" jsonschema:"title=Additional Group Base" jsonschema_description:"The base in addition to the Base DN for all directory server operations for groups."`
-	GroupsFilter       string `koanf:"groups_filter" yaml:"groups_filter,omitempty" toml:"groups_filter,omitempty" json:"groups_filter,omitempty" jsonschema:"title=Groups Filter" jsonschema_description:"The LDAP filter used to search for group objects."`
-	GroupSearchMode    string `koanf:"group_search_mode" yaml:"group_search_mode,omitempty" toml:"group_search_mode,omitempty" json:"group_search_mode,omitempty" jsonschema:"default=filter,enum=filter,enum=memberof,title=Groups Search Modes" jsonschema_description:"The LDAP group search mode used to search for group objects."`
+	AdditionalGroupsDN  string `koanf:"additional_groups_dn" yaml:"additional_groups_dn,omitempty" toml:"additional_groups_dn,omitempty" json:"additional_groups_dn,omitempty" jsonschema:"title=Additional Group Base" jsonschema_description:"The base in addition to the Base DN for all directory server operations for groups."`
+	GroupsFilter        string `koanf:"groups_filter" yaml:"groups_filter,omitempty" toml:"groups_filter,omitempty" json:"groups_filter,omitempty" jsonschema:"title=Groups Filter" jsonschema_description:"The LDAP filter used to search for group objects."`
+	GroupSearchMode     string `koanf:"group_search_mode" yaml:"group_search_mode,omitempty" toml:"group_search_mode,omitempty" json:"group_search_mode,omitempty" jsonschema:"default=filter,enum=filter,enum=memberof,enum=recursive,title=Groups Search Modes" jsonschema_description:"The LDAP group search mode used to search for group objects."`
+	GroupSearchMaxDepth int    `koanf:"group_search_max_depth" yaml:"group_search_max_depth,omitempty" toml:"group_search_max_depth,omitempty" json:"group_search_max_depth,omitempty" jsonschema:"default=5,minimum=1,title=Groups Search Maximum Depth" jsonschema_description:"The maximum number of parent group levels resolved when using the recursive group search mode."`
 
 	Attributes AuthenticationBackendLDAPAttributes `koanf:"attributes" yaml:"attributes,omitempty" toml:"attributes,omitempty" json:"attributes,omitempty" jsonschema:"title=Attributes" jsonschema_description:"The LDAP directory server attributes."`
 
@@ -167,30 +193,33 @@ type AuthenticationBackendLDAPPooling struct {
 
 // AuthenticationBackendLDAPAttributes represents the configuration related to LDAP server attributes.
 type AuthenticationBackendLDAPAttributes struct {
-	DistinguishedName string `koanf:"distinguished_name" yaml:"distinguished_name,omitempty" toml:"distinguished_name,omitempty" json:"distinguished_name,omitempty" jsonschema:"title=Attribute: Distinguished Name" jsonschema_description:"The directory server attribute which contains the distinguished name for all objects."`
-	Username          string `koanf:"username" yaml:"username,omitempty" toml:"username,omitempty" json:"username,omitempty" jsonschema:"title=Attribute: User Username" jsonschema_description:"The directory server attribute which contains the username for all users."`
-	DisplayName       string `koanf:"display_name" yaml:"display_name,omitempty" toml:"display_name,omitempty" json:"display_name,omitempty" jsonschema:"title=Attribute: User Display Name" jsonschema_description:"The directory server attribute which contains the display name for all users."`
-	FamilyName        string `koanf:"family_name" yaml:"family_name,omitempty" toml:"family_name,omitempty" json:"family_name,omitempty" jsonschema:"title=Attribute: Family Name" jsonschema_description:"The directory server attribute which contains the family name for all users."`
-	GivenName         string `koanf:"given_name" yaml:"given_name,omitempty" toml:"given_name,omitempty" json:"given_name,omitempty" jsonschema:"title=Attribute: Given Name" jsonschema_description:"The directory server attribute which contains the given name for all users."`
-	MiddleName        string `koanf:"middle_name" yaml:"middle_name,omitempty" toml:"middle_name,omitempty" json:"middle_name,omitempty" jsonschema:"title=Attribute: Middle Name" jsonschema_description:"The directory server attribute which contains the middle name for all users."`
-	Nickname          string `koanf:"nickname" yaml:"nickname,omitempty" toml:"nickname,omitempty" json:"nickname,omitempty" jsonschema:"title=Attribute: Nickname" jsonschema_description:"The directory server attribute which contains the nickname for all users."`
-	Gender            string `koanf:"gender" yaml:"gender,omitempty" toml:"gender,omitempty" json:"gender,omitempty" jsonschema:"title=Attribute: Gender" jsonschema_description:"The directory server attribute which contains the gender for all users."`
-	Birthdate         string `koanf:"birthdate" yaml:"birthdate,omitempty" toml:"birthdate,omitempty" json:"birthdate,omitempty" jsonschema:"title=Attribute: Birthdate" jsonschema_description:"The directory server attribute which contains the birthdate for all users."`
-	Website           string `koanf:"website" yaml:"website,omitempty" toml:"website,omitempty" json:"website,omitempty" jsonschema:"title=Attribute: Website" jsonschema_description:"The directory server attribute which contains the website URL for all users."`
-	Profile           string `koanf:"profile" yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty" jsonschema:"title=Attribute: Profile" jsonschema_description:"The directory server attribute which contains the profile URL for all users."`
-	Picture           string `koanf:"picture" yaml:"picture,omitempty" toml:"picture,omitempty" json:"picture,omitempty" jsonschema:"title=Attribute: Picture" jsonschema_description:"The directory server attribute which contains the picture URL for all users."`
-	ZoneInfo          string `koanf:"zoneinfo" yaml:"zoneinfo,omitempty" toml:"zoneinfo,omitempty" json:"zoneinfo,omitempty" jsonschema:"title=Attribute: Zone Information" jsonschema_description:"The directory server attribute which contains the time zone information for all users."`
-	Locale            string `koanf:"locale" yaml:"locale,omitempty" toml:"locale,omitempty" json:"locale,omitempty" jsonschema:"title=Attribute: Locale" jsonschema_description:"The directory server attribute which contains the locale information for all users."`
-	PhoneNumber       string `koanf:"phone_number" yaml:"phone_number,omitempty" toml:"phone_number,omitempty" json:"phone_number,omitempty" jsonschema:"title=Attribute: Phone Number" jsonschema_description:"The directory server attribute which contains the phone number for all users."`
-	PhoneExtension    string `koanf:"phone_extension" yaml:"phone_extension,omitempty" toml:"phone_extension,omitempty" json:"phone_extension,omitempty" jsonschema:"title=Attribute: Phone Extension" jsonschema_description:"The directory server attribute which contains the phone extension for all users."`
-	StreetAddress     string `koanf:"street_address" yaml:"street_address,omitempty" toml:"street_address,omitempty" json:"street_address,omitempty" jsonschema:"title=Attribute: Street Address" jsonschema_description:"The directory server attribute which contains the street address for all users."`
-	Locality          string `koanf:"locality" yaml:"locality,omitempty" toml:"locality,omitempty" json:"locality,omitempty" jsonschema:"title=Attribute: Locality" jsonschema_description:"The directory server attribute which contains the locality for all users."`
-	Region            string `koanf:"region" yaml:"region,omitempty" toml:"region,omitempty" json:"region,omitempty" jsonschema:"title=Attribute: Region" jsonschema_description:"The directory server attribute which contains the region for all users."`
-	PostalCode        string `koanf:"postal_code" yaml:"postal_code,omitempty" toml:"postal_code,omitempty" json:"postal_code,omitempty" jsonschema:"title=Attribute: Postal Code" jsonschema_description:"The directory server attribute which contains the postal code for all users."`
-	Country           string `koanf:"country" yaml:"country,omitempty" toml:"country,omitempty" json:"country,omitempty" jsonschema:"title=Attribute: Country" jsonschema_description:"The directory server attribute which contains the country for all users."`
-	Mail              string `koanf:"mail" yaml:"mail,omitempty" toml:"mail,omitempty" json:"mail,omitempty" jsonschema:"title=Attribute: User Mail" jsonschema_description:"The directory server attribute which contains the mail address for all users and groups."`
-	MemberOf          string `koanf:"member_of" yaml:"member_of,omitempty" toml:"member_of,omitempty" json:"member_of,omitempty" jsonschema:"title=Attribute: Member Of" jsonschema_description:"The directory server attribute which contains the objects that an object is a member of."`
-	GroupName         string `koanf:"group_name" yaml:"group_name,omitempty" toml:"group_name,omitempty" json:"group_name,omitempty" jsonschema:"title=Attribute: Group Name" jsonschema_description:"The directory server attribute which contains the group name for all groups."`
+	DistinguishedName      string `koanf:"distinguished_name" yaml:"distinguished_name,omitempty" toml:"distinguished_name,omitempty" json:"distinguished_name,omitempty" jsonschema:"title=Attribute: Distinguished Name" jsonschema_description:"The directory server attribute which contains the distinguished name for all objects."`
+	Username               string `koanf:"username" yaml:"username,omitempty" toml:"username,omitempty" json:"username,omitempty" jsonschema:"title=Attribute: User Username" jsonschema_description:"The directory server attribute which contains the username for all users."`
+	DisplayName            string `koanf:"display_name" yaml:"display_name,omitempty" toml:"display_name,omitempty" json:"display_name,omitempty" jsonschema:"title=Attribute: User Display Name" jsonschema_description:"The directory server attribute which contains the display name for all users."`
+	FamilyName             string `koanf:"family_name" yaml:"family_name,omitempty" toml:"family_name,omitempty" json:"family_name,omitempty" jsonschema:"title=Attribute: Family Name" jsonschema_description:"The directory server attribute which contains the family name for all users."`
+	GivenName              string `koanf:"given_name" yaml:"given_name,omitempty" toml:"given_name,omitempty" json:"given_name,omitempty" jsonschema:"title=Attribute: Given Name" jsonschema_description:"The directory server attribute which contains the given name for all users."`
+	MiddleName             string `koanf:"middle_name" yaml:"middle_name,omitempty" toml:"middle_name,omitempty" json:"middle_name,omitempty" jsonschema:"title=Attribute: Middle Name" jsonschema_description:"The directory server attribute which contains the middle name for all users."`
+	Nickname               string `koanf:"nickname" yaml:"nickname,omitempty" toml:"nickname,omitempty" json:"nickname,omitempty" jsonschema:"title=Attribute: Nickname" jsonschema_description:"The directory server attribute which contains the nickname for all users."`
+	Gender                 string `koanf:"gender" yaml:"gender,omitempty" toml:"gender,omitempty" json:"gender,omitempty" jsonschema:"title=Attribute: Gender" jsonschema_description:"The directory server attribute which contains the gender for all users."`
+	Birthdate              string `koanf:"birthdate" yaml:"birthdate,omitempty" toml:"birthdate,omitempty" json:"birthdate,omitempty" jsonschema:"title=Attribute: Birthdate" jsonschema_description:"The directory server attribute which contains the birthdate for all users."`
+	Website                string `koanf:"website" yaml:"website,omitempty" toml:"website,omitempty" json:"website,omitempty" jsonschema:"title=Attribute: Website" jsonschema_description:"The directory server attribute which contains the website URL for all users."`
+	Profile                string `koanf:"profile" yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty" jsonschema:"title=Attribute: Profile" jsonschema_description:"The directory server attribute which contains the profile URL for all users."`
+	Picture                string `koanf:"picture" yaml:"picture,omitempty" toml:"picture,omitempty" json:"picture,omitempty" jsonschema:"title=Attribute: Picture" jsonschema_description:"The directory server attribute which contains the picture URL for all users."`
+	ZoneInfo               string `koanf:"zoneinfo" yaml:"zoneinfo,omitempty" toml:"zoneinfo,omitempty" json:"zoneinfo,omitempty" jsonschema:"title=Attribute: Zone Information" jsonschema_description:"The directory server attribute which contains the time zone information for all users."`
+	Locale                 string `koanf:"locale" yaml:"locale,omitempty" toml:"locale,omitempty" json:"locale,omitempty" jsonschema:"title=Attribute: Locale" jsonschema_description:"The directory server attribute which contains the locale information for all users."`
+	PhoneNumber            string `koanf:"phone_number" yaml:"phone_number,omitempty" toml:"phone_number,omitempty" json:"phone_number,omitempty" jsonschema:"title=Attribute: Phone Number" jsonschema_description:"The directory server attribute which contains the phone number for all users."`
+	PhoneExtension         string `koanf:"phone_extension" yaml:"phone_extension,omitempty" toml:"phone_extension,omitempty" json:"phone_extension,omitempty" jsonschema:"title=Attribute: Phone Extension" jsonschema_description:"The directory server attribute which contains the phone extension for all users."`
+	StreetAddress          string `koanf:"street_address" yaml:"street_address,omitempty" toml:"street_address,omitempty" json:"street_address,omitempty" jsonschema:"title=Attribute: Street Address" jsonschema_description:"The directory server attribute which contains the street address for all users."`
+	Locality               string `koanf:"locality" yaml:"locality,omitempty" toml:"locality,omitempty" json:"locality,omitempty" jsonschema:"title=Attribute: Locality" jsonschema_description:"The directory server attribute which contains the locality for all users."`
+	Region                 string `koanf:"region" yaml:"region,omitempty" toml:"region,omitempty" json:"region,omitempty" jsonschema:"title=Attribute: Region" jsonschema_description:"The directory server attribute which contains the region for all users."`
+	PostalCode             string `koanf:"postal_code" yaml:"postal_code,omitempty" toml:"postal_code,omitempty" json:"postal_code,omitempty" jsonschema:"title=Attribute: Postal Code" jsonschema_description:"The directory server attribute which contains the postal code for all users."`
+	Country                string `koanf:"country" yaml:"country,omitempty" toml:"country,omitempty" json:"country,omitempty" jsonschema:"title=Attribute: Country" jsonschema_description:"The directory server attribute which contains the country for all users."`
+	Mail                   string `koanf:"mail" yaml:"mail,omitempty" toml:"mail,omitempty" json:"mail,omitempty" jsonschema:"title=Attribute: User Mail" jsonschema_description:"The directory server attribute which contains the mail address for all users and groups."`
+	MemberOf               string `koanf:"member_of" yaml:"member_of,omitempty" toml:"member_of,omitempty" json:"member_of,omitempty" jsonschema:"title=Attribute: Member Of" jsonschema_description:"The directory server attribute which contains the objects that an object is a member of."`
+	Disabled               string `koanf:"disabled" yaml:"disabled,omitempty" toml:"disabled,omitempty" json:"disabled,omitempty" jsonschema:"title=Attribute: Disabled" jsonschema_description:"The directory server attribute which indicates if a user account is disabled."`
+	PasswordLastChanged    string `koanf:"password_last_changed" yaml:"password_last_changed,omitempty" toml:"password_last_changed,omitempty" json:"password_last_changed,omitempty" jsonschema:"title=Attribute: Password Last Changed" jsonschema_description:"The directory server attribute which contains the time a user last changed their password."`
+	PasswordChangeRequired string `koanf:"password_change_required" yaml:"password_change_required,omitempty" toml:"password_change_required,omitempty" json:"password_change_required,omitempty" jsonschema:"title=Attribute: Password Change Required" jsonschema_description:"The directory server attribute which indicates if a user is required to change their password at next login."`
+	GroupName              string `koanf:"group_name" yaml:"group_name,omitempty" toml:"group_name,omitempty" json:"group_name,omitempty" jsonschema:"title=Attribute: Group Name" jsonschema_description:"The directory server attribute which contains the group name for all groups."`
 
 	Extra map[string]AuthenticationBackendLDAPAttributesAttribute `koanf:"extra" yaml:"extra,omitempty" toml:"extra,omitempty" json:"extra,omitempty" jsonschema:"title=Extra Attributes" jsonschema_description:"Configures the extra attributes available in expressions and other areas of Authelia."`
 }
@@ -264,7 +293,8 @@ var DefaultCIPasswordConfig = AuthenticationBackendFilePassword{
 
 // DefaultLDAPAuthenticationBackendConfigurationImplementationCustom represents the default LDAP config.
 var DefaultLDAPAuthenticationBackendConfigurationImplementationCustom = AuthenticationBackendLDAP{
-	GroupSearchMode: ldapGroupSearchModeFilter,
+	GroupSearchMode:     ldapGroupSearchModeFilter,
+	GroupSearchMaxDepth: 5,
 	Attributes: AuthenticationBackendLDAPAttributes{
 		Username:    ldapAttrUserID,
 		DisplayName: ldapAttrDisplayName,
@@ -284,26 +314,28 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationCustom = Authenti
 
 // DefaultLDAPAuthenticationBackendConfigurationImplementationActiveDirectory represents the default LDAP config for the LDAPImplementationActiveDirectory Implementation.
 var DefaultLDAPAuthenticationBackendConfigurationImplementationActiveDirectory = AuthenticationBackendLDAP{
-	UsersFilter:     "(&(|({username_attribute}={input})({mail_attribute}={input}))(sAMAccountType=805306368)(!(userAccountControl:1.2.840.113556.1.4.803:=2))(!(pwdLastSet=0))(|(!(accountExpires=*))(accountExpires=0)(accountExpires>={date-time:microsoft-nt})))",
+	UsersFilter:     "(&(|({username_attribute}={input})({mail_attribute}={input}))(sAMAccountType=805306368)(|(!(accountExpires=*))(accountExpires=0)(accountExpires>={date-time:microsoft-nt})))",
 	GroupsFilter:    "(&(member={dn})(|(sAMAccountType=268435456)(sAMAccountType=536870912)))",
 	GroupSearchMode: ldapGroupSearchModeFilter,
 	Attributes: AuthenticationBackendLDAPAttributes{
-		DistinguishedName: ldapAttrDistinguishedName,
-		Username:          ldapAttrSAMAccountName,
-		DisplayName:       ldapAttrDisplayName,
-		FamilyName:        ldapAttrSurname,
-		GivenName:         ldapAttrGivenName,
-		MiddleName:        ldapAttrMiddleName,
-		Website:           "wWWHomePage",
-		Mail:              ldapAttrMail,
-		PhoneNumber:       "telephoneNumber",
-		StreetAddress:     "streetAddress",
-		Locality:          "l",
-		Region:            "st",
-		PostalCode:        "postalCode",
-		Country:           "c",
-		MemberOf:          ldapAttrMemberOf,
-		GroupName:         ldapAttrCommonName,
+		DistinguishedName:   ldapAttrDistinguishedName,
+		Username:            ldapAttrSAMAccountName,
+		DisplayName:         ldapAttrDisplayName,
+		FamilyName:          ldapAttrSurname,
+		GivenName:           ldapAttrGivenName,
+		MiddleName:          ldapAttrMiddleName,
+		Website:             "wWWHomePage",
+		Mail:                ldapAttrMail,
+		PhoneNumber:         "telephoneNumber",
+		StreetAddress:       "streetAddress",
+		Locality:            "l",
+		Region:              "st",
+		PostalCode:          "postalCode",
+		Country:             "c",
+		MemberOf:            ldapAttrMemberOf,
+		Disabled:            ldapAttrUserAccountControl,
+		PasswordLastChanged: ldapAttrPwdLastSet,
+		GroupName:           ldapAttrCommonName,
 	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
@@ -317,11 +349,13 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationRFC2307bis = Auth
 	GroupsFilter:    "(&(|(member={dn})(uniqueMember={dn}))(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=groupOfMembers))(!(pwdReset=TRUE)))",
 	GroupSearchMode: ldapGroupSearchModeFilter,
 	Attributes: AuthenticationBackendLDAPAttributes{
-		Username:    ldapAttrUserID,
-		DisplayName: ldapAttrDisplayName,
-		Mail:        ldapAttrMail,
-		MemberOf:    ldapAttrMemberOf,
-		GroupName:   ldapAttrCommonName,
+		Username:               ldapAttrUserID,
+		DisplayName:            ldapAttrDisplayName,
+		Mail:                   ldapAttrMail,
+		MemberOf:               ldapAttrMemberOf,
+		PasswordLastChanged:    ldapAttrPwdChangedTime,
+		PasswordChangeRequired: ldapAttrPwdReset,
+		GroupName:              ldapAttrCommonName,
 	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
@@ -331,17 +365,19 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationRFC2307bis = Auth
 
 // DefaultLDAPAuthenticationBackendConfigurationImplementationFreeIPA represents the default LDAP config for the LDAPImplementationFreeIPA Implementation.
 var DefaultLDAPAuthenticationBackendConfigurationImplementationFreeIPA = AuthenticationBackendLDAP{
-	UsersFilter:     "(&(|({username_attribute}={input})({mail_attribute}={input}))(objectClass=person)(!(nsAccountLock=TRUE))(krbPasswordExpiration>={date-time:generalized})(|(!(krbPrincipalExpiration=*))(krbPrincipalExpiration>={date-time:generalized})))",
+	UsersFilter:     "(&(|({username_attribute}={input})({mail_attribute}={input}))(objectClass=person)(krbPasswordExpiration>={date-time:generalized})(|(!(krbPrincipalExpiration=*))(krbPrincipalExpiration>={date-time:generalized})))",
 	GroupsFilter:    "(&(member={dn})(objectClass=groupOfNames))",
 	GroupSearchMode: ldapGroupSearchModeFilter,
 	Attributes: AuthenticationBackendLDAPAttributes{
-		Username:    ldapAttrUserID,
-		DisplayName: ldapAttrDisplayName,
-		FamilyName:  ldapAttrSurname,
-		GivenName:   ldapAttrGivenName,
-		Mail:        ldapAttrMail,
-		MemberOf:    ldapAttrMemberOf,
-		GroupName:   ldapAttrCommonName,
+		Username:            ldapAttrUserID,
+		DisplayName:         ldapAttrDisplayName,
+		FamilyName:          ldapAttrSurname,
+		GivenName:           ldapAttrGivenName,
+		Mail:                ldapAttrMail,
+		MemberOf:            ldapAttrMemberOf,
+		Disabled:            ldapAttrNSAccountLock,
+		PasswordLastChanged: ldapAttrKrbLastPwdChange,
+		GroupName:           ldapAttrCommonName,
 	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
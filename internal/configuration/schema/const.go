@@ -63,6 +63,14 @@ const (
 	RefreshIntervalDefault = time.Minute * 5
 )
 
+const (
+	// SessionBindingActionDestroy is the session binding action which destroys the session.
+	SessionBindingActionDestroy = "destroy"
+
+	// SessionBindingActionReauthenticate is the session binding action which requires the user to authenticate again.
+	SessionBindingActionReauthenticate = "reauthenticate"
+)
+
 const (
 	// LDAPImplementationCustom is the string for the custom LDAP implementation.
 	LDAPImplementationCustom = "custom"
@@ -89,6 +97,9 @@ const (
 
 	// LDAPGroupSearchModeMemberOf is the string for the memberOf group search mode.
 	LDAPGroupSearchModeMemberOf = "memberof"
+
+	// LDAPGroupSearchModeRecursive is the string for the recursive group search mode.
+	LDAPGroupSearchModeRecursive = "recursive"
 )
 
 // TOTP Algorithm.
@@ -167,17 +178,23 @@ const (
 )
 
 const (
-	ldapAttrDistinguishedName = "distinguishedName"
-	ldapAttrMail              = "mail"
-	ldapAttrUserID            = "uid"
-	ldapAttrSAMAccountName    = "sAMAccountName"
-	ldapAttrDisplayName       = "displayName"
-	ldapAttrSurname           = "sn"
-	ldapAttrGivenName         = "givenName"
-	ldapAttrMiddleName        = "middleName"
-	ldapAttrDescription       = "description"
-	ldapAttrCommonName        = "cn"
-	ldapAttrMemberOf          = "memberOf"
+	ldapAttrDistinguishedName  = "distinguishedName"
+	ldapAttrMail               = "mail"
+	ldapAttrUserID             = "uid"
+	ldapAttrSAMAccountName     = "sAMAccountName"
+	ldapAttrDisplayName        = "displayName"
+	ldapAttrSurname            = "sn"
+	ldapAttrGivenName          = "givenName"
+	ldapAttrMiddleName         = "middleName"
+	ldapAttrDescription        = "description"
+	ldapAttrCommonName         = "cn"
+	ldapAttrMemberOf           = "memberOf"
+	ldapAttrUserAccountControl = "userAccountControl"
+	ldapAttrNSAccountLock      = "nsAccountLock"
+	ldapAttrPwdLastSet         = "pwdLastSet"
+	ldapAttrPwdChangedTime     = "pwdChangedTime"
+	ldapAttrPwdReset           = "pwdReset"
+	ldapAttrKrbLastPwdChange   = "krbLastPwdChange"
 )
 
 // Address Schemes.
@@ -4,8 +4,9 @@ import "net"
 
 // Definitions represents the definitions which can be referenced elsewhere in the configuration.
 type Definitions struct {
-	Network        map[string][]*net.IPNet  `koanf:"network" yaml:"network,omitempty" toml:"network,omitempty" json:"network,omitempty" jsonschema:"title=Network Definitions" jsonschema_description:"Networks CIDR ranges that can be utilized elsewhere in the configuration."`
-	UserAttributes map[string]UserAttribute `koanf:"user_attributes" yaml:"user_attributes,omitempty" toml:"user_attributes,omitempty" json:"user_attributes,omitempty" jsonschema:"title=User Attributes" jsonschema_description:"User attributes derived from other attributes."`
+	Network        map[string][]*net.IPNet              `koanf:"network" yaml:"network,omitempty" toml:"network,omitempty" json:"network,omitempty" jsonschema:"title=Network Definitions" jsonschema_description:"Networks CIDR ranges that can be utilized elsewhere in the configuration."`
+	UserAttributes map[string]UserAttribute             `koanf:"user_attributes" yaml:"user_attributes,omitempty" toml:"user_attributes,omitempty" json:"user_attributes,omitempty" jsonschema:"title=User Attributes" jsonschema_description:"User attributes derived from other attributes."`
+	Subjects       map[string]AccessControlRuleSubjects `koanf:"subjects" yaml:"subjects,omitempty" toml:"subjects,omitempty" json:"subjects,omitempty" jsonschema:"title=Subject Definitions" jsonschema_description:"Subjects that can be utilized elsewhere in the configuration."`
 }
 
 // UserAttribute represents a user attribute definition.
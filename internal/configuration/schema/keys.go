@@ -8,13 +8,22 @@ package schema
 
 // Keys is a list of valid schema keys detected by reflecting over a schema.Configuration struct.
 var Keys = []string{
+	"access_control.audit_log.file_path",
+	"access_control.audit_log.sample_rate",
 	"access_control.default_policy",
 	"access_control.networks",
 	"access_control.networks[].name",
 	"access_control.networks[].networks",
 	"access_control.rules",
+	"access_control.rules[].disable_trusted_devices",
 	"access_control.rules[].domain",
 	"access_control.rules[].domain_regex",
+	"access_control.rules[].expression",
+	"access_control.rules[].headers",
+	"access_control.rules[].headers[][].key",
+	"access_control.rules[].headers[][].operator",
+	"access_control.rules[].headers[][].value",
+	"access_control.rules[].max_age",
 	"access_control.rules[].methods",
 	"access_control.rules[].networks",
 	"access_control.rules[].policy",
@@ -22,8 +31,20 @@ var Keys = []string{
 	"access_control.rules[].query[][].key",
 	"access_control.rules[].query[][].operator",
 	"access_control.rules[].query[][].value",
+	"access_control.rules[].required_amr",
 	"access_control.rules[].resources",
+	"access_control.rules[].schedule",
+	"access_control.rules[].schedule.timezone",
+	"access_control.rules[].schedule.windows",
+	"access_control.rules[].schedule.windows[].days",
+	"access_control.rules[].schedule.windows[].end",
+	"access_control.rules[].schedule.windows[].start",
 	"access_control.rules[].subject",
+	"access_control.watch",
+	"authentication_backend.chain",
+	"authentication_backend.chain[].backend",
+	"authentication_backend.chain[].domains",
+	"authentication_backend.chain[].usernames",
 	"authentication_backend.file.extra_attributes",
 	"authentication_backend.file.extra_attributes.*",
 	"authentication_backend.file.extra_attributes.*.multi_valued",
@@ -63,6 +84,7 @@ var Keys = []string{
 	"authentication_backend.ldap.address",
 	"authentication_backend.ldap.attributes.birthdate",
 	"authentication_backend.ldap.attributes.country",
+	"authentication_backend.ldap.attributes.disabled",
 	"authentication_backend.ldap.attributes.display_name",
 	"authentication_backend.ldap.attributes.distinguished_name",
 	"authentication_backend.ldap.attributes.extra",
@@ -80,6 +102,8 @@ var Keys = []string{
 	"authentication_backend.ldap.attributes.member_of",
 	"authentication_backend.ldap.attributes.middle_name",
 	"authentication_backend.ldap.attributes.nickname",
+	"authentication_backend.ldap.attributes.password_change_required",
+	"authentication_backend.ldap.attributes.password_last_changed",
 	"authentication_backend.ldap.attributes.phone_extension",
 	"authentication_backend.ldap.attributes.phone_number",
 	"authentication_backend.ldap.attributes.picture",
@@ -91,6 +115,7 @@ var Keys = []string{
 	"authentication_backend.ldap.attributes.website",
 	"authentication_backend.ldap.attributes.zoneinfo",
 	"authentication_backend.ldap.base_dn",
+	"authentication_backend.ldap.group_search_max_depth",
 	"authentication_backend.ldap.group_search_mode",
 	"authentication_backend.ldap.groups_filter",
 	"authentication_backend.ldap.implementation",
@@ -112,14 +137,44 @@ var Keys = []string{
 	"authentication_backend.ldap.user",
 	"authentication_backend.ldap.users_filter",
 	"authentication_backend.password_change.disable",
+	"authentication_backend.password_change.max_age",
 	"authentication_backend.password_reset.custom_url",
 	"authentication_backend.password_reset.disable",
 	"authentication_backend.refresh_interval",
+	"authentication_backend.sql.password.algorithm",
+	"authentication_backend.sql.password.argon2.iterations",
+	"authentication_backend.sql.password.argon2.key_length",
+	"authentication_backend.sql.password.argon2.memory",
+	"authentication_backend.sql.password.argon2.parallelism",
+	"authentication_backend.sql.password.argon2.salt_length",
+	"authentication_backend.sql.password.argon2.variant",
+	"authentication_backend.sql.password.bcrypt.cost",
+	"authentication_backend.sql.password.bcrypt.variant",
+	"authentication_backend.sql.password.iterations",
+	"authentication_backend.sql.password.key_length",
+	"authentication_backend.sql.password.memory",
+	"authentication_backend.sql.password.parallelism",
+	"authentication_backend.sql.password.pbkdf2.iterations",
+	"authentication_backend.sql.password.pbkdf2.salt_length",
+	"authentication_backend.sql.password.pbkdf2.variant",
+	"authentication_backend.sql.password.salt_length",
+	"authentication_backend.sql.password.scrypt.block_size",
+	"authentication_backend.sql.password.scrypt.iterations",
+	"authentication_backend.sql.password.scrypt.key_length",
+	"authentication_backend.sql.password.scrypt.parallelism",
+	"authentication_backend.sql.password.scrypt.salt_length",
+	"authentication_backend.sql.password.scrypt.variant",
+	"authentication_backend.sql.password.sha2crypt.iterations",
+	"authentication_backend.sql.password.sha2crypt.salt_length",
+	"authentication_backend.sql.password.sha2crypt.variant",
+	"authentication_backend.sql.search.email",
 	"certificates_directory",
 	"default_2fa_method",
 	"default_redirection_url",
 	"definitions.network",
 	"definitions.network.*",
+	"definitions.subjects",
+	"definitions.subjects.*",
 	"definitions.user_attributes",
 	"definitions.user_attributes.*",
 	"definitions.user_attributes.*.expression",
@@ -311,6 +366,12 @@ var Keys = []string{
 	"ntp.disable_startup_check",
 	"ntp.max_desync",
 	"ntp.version",
+	"password_policy.breached.enabled",
+	"password_policy.breached.path",
+	"password_policy.breached.threshold",
+	"password_policy.breached.timeout",
+	"password_policy.breached.url",
+	"password_policy.history",
 	"password_policy.standard.enabled",
 	"password_policy.standard.max_length",
 	"password_policy.standard.min_length",
@@ -393,6 +454,12 @@ var Keys = []string{
 	"server.tls.client_certificates",
 	"server.tls.key",
 	"session",
+	"session.administrators",
+	"session.binding.action",
+	"session.binding.disable_user_agent",
+	"session.binding.ipv4_prefix",
+	"session.binding.ipv6_prefix",
+	"session.binding.notify",
 	"session.cookies",
 	"session.cookies[]",
 	"session.cookies[].authelia_url",
@@ -433,6 +500,9 @@ var Keys = []string{
 	"session.remember_me",
 	"session.same_site",
 	"session.secret",
+	"session.storage.garbage_collection_interval",
+	"session.trusted_devices.lifespan",
+	"session.trusted_devices.name",
 	"storage.encryption_key",
 	"storage.local.path",
 	"storage.mysql.address",
@@ -1,9 +1,17 @@
 package schema
 
+import (
+	"net/url"
+	"time"
+)
+
 // PasswordPolicy represents the configuration related to password policy.
 type PasswordPolicy struct {
 	Standard PasswordPolicyStandard `koanf:"standard" yaml:"standard,omitempty" toml:"standard,omitempty" json:"standard,omitempty" jsonschema:"title=Standard" jsonschema_description:"The standard password policy engine."`
 	ZXCVBN   PasswordPolicyZXCVBN   `koanf:"zxcvbn" yaml:"zxcvbn,omitempty" toml:"zxcvbn,omitempty" json:"zxcvbn,omitempty" jsonschema:"title=ZXCVBN" jsonschema_description:"The ZXCVBN password policy engine."`
+	Breached PasswordPolicyBreached `koanf:"breached" yaml:"breached,omitempty" toml:"breached,omitempty" json:"breached,omitempty" jsonschema:"title=Breached" jsonschema_description:"The breached password policy which rejects passwords found in a breached password corpus."`
+
+	History int `koanf:"history" yaml:"history" toml:"history" json:"history" jsonschema:"default=0,title=History" jsonschema_description:"The number of previous passwords remembered for each user which they may not reuse. A value of 0 disables the password history."`
 }
 
 // PasswordPolicyStandard represents the configuration related to standard parameters of password policy.
@@ -23,6 +31,16 @@ type PasswordPolicyZXCVBN struct {
 	MinScore int  `koanf:"min_score" yaml:"min_score" toml:"min_score" json:"min_score" jsonschema:"default=3,title=Minimum Score" jsonschema_description:"The minimum ZXCVBN score allowed."`
 }
 
+// PasswordPolicyBreached represents the configuration related to the breached password policy which uses SHA-1
+// k-anonymity range lists in the Have I Been Pwned format.
+type PasswordPolicyBreached struct {
+	Enabled   bool          `koanf:"enabled" yaml:"enabled" toml:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables the breached password policy."`
+	Path      string        `koanf:"path" yaml:"path,omitempty" toml:"path,omitempty" json:"path,omitempty" jsonschema:"title=Path" jsonschema_description:"The file ordered by hash or the directory containing the range files named after their SHA-1 prefix."`
+	URL       *url.URL      `koanf:"url" yaml:"url,omitempty" toml:"url,omitempty" json:"url,omitempty" jsonschema:"format=uri,title=URL" jsonschema_description:"The base URL of a range API which the SHA-1 prefix is appended to."`
+	Timeout   time.Duration `koanf:"timeout" yaml:"timeout,omitempty" toml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout for requests to the range API."`
+	Threshold int           `koanf:"threshold" yaml:"threshold" toml:"threshold" json:"threshold" jsonschema:"default=1,title=Threshold" jsonschema_description:"The minimum number of times a password must appear in the corpus to be rejected."`
+}
+
 // DefaultPasswordPolicyConfiguration is the default password policy configuration.
 var DefaultPasswordPolicyConfiguration = PasswordPolicy{
 	Standard: PasswordPolicyStandard{
@@ -32,4 +50,8 @@ var DefaultPasswordPolicyConfiguration = PasswordPolicy{
 	ZXCVBN: PasswordPolicyZXCVBN{
 		MinScore: 3,
 	},
+	Breached: PasswordPolicyBreached{
+		Timeout:   time.Second * 5,
+		Threshold: 1,
+	},
 }
@@ -16,6 +16,14 @@ type Session struct {
 
 	Redis *SessionRedis `koanf:"redis" yaml:"redis,omitempty" toml:"redis,omitempty" json:"redis,omitempty" jsonschema:"title=Redis" jsonschema_description:"Redis Session Provider configuration."`
 
+	Storage *SessionStorage `koanf:"storage" yaml:"storage,omitempty" toml:"storage,omitempty" json:"storage,omitempty" jsonschema:"title=Storage" jsonschema_description:"Storage Session Provider configuration."`
+
+	Binding *SessionBinding `koanf:"binding" yaml:"binding,omitempty" toml:"binding,omitempty" json:"binding,omitempty" jsonschema:"title=Binding" jsonschema_description:"Binds the authenticated sessions to the client which established them."`
+
+	TrustedDevices *SessionTrustedDevices `koanf:"trusted_devices" yaml:"trusted_devices,omitempty" toml:"trusted_devices,omitempty" json:"trusted_devices,omitempty" jsonschema:"title=Trusted Devices" jsonschema_description:"Allows users to skip the second factor on devices they've chosen to trust."`
+
+	Administrators []string `koanf:"administrators" yaml:"administrators,omitempty" toml:"administrators,omitempty" json:"administrators,omitempty" jsonschema:"title=Administrators" jsonschema_description:"List of subjects which are permitted to manage the sessions of other users."`
+
 	// Deprecated: Use the session cookies option with the same name instead.
 	Domain string `koanf:"domain" yaml:"domain,omitempty" toml:"domain,omitempty" json:"domain,omitempty" jsonschema:"deprecated,title=Domain"`
 }
@@ -42,6 +50,26 @@ type SessionCookie struct {
 	Legacy bool `yaml:"-" toml:"-" json:"-"`
 }
 
+// SessionBinding represents the configuration related to binding sessions to the client which established them.
+type SessionBinding struct {
+	Action           string `koanf:"action" yaml:"action,omitempty" toml:"action,omitempty" json:"action,omitempty" jsonschema:"default=destroy,enum=destroy,enum=reauthenticate,title=Action" jsonschema_description:"The action taken when a session is used by a client which doesn't match the binding."`
+	IPv4Prefix       int    `koanf:"ipv4_prefix" yaml:"ipv4_prefix,omitempty" toml:"ipv4_prefix,omitempty" json:"ipv4_prefix,omitempty" jsonschema:"default=24,minimum=1,maximum=32,title=IPv4 Prefix" jsonschema_description:"The prefix length of the IPv4 network the client must remain within."`
+	IPv6Prefix       int    `koanf:"ipv6_prefix" yaml:"ipv6_prefix,omitempty" toml:"ipv6_prefix,omitempty" json:"ipv6_prefix,omitempty" jsonschema:"default=64,minimum=1,maximum=128,title=IPv6 Prefix" jsonschema_description:"The prefix length of the IPv6 network the client must remain within."`
+	DisableUserAgent bool   `koanf:"disable_user_agent" yaml:"disable_user_agent" toml:"disable_user_agent" json:"disable_user_agent" jsonschema:"default=false,title=Disable User Agent" jsonschema_description:"Disables binding the session to the user agent of the client."`
+	Notify           bool   `koanf:"notify" yaml:"notify" toml:"notify" json:"notify" jsonschema:"default=false,title=Notify" jsonschema_description:"Notifies the user when a session is used by a client which doesn't match the binding."`
+}
+
+// SessionTrustedDevices represents the configuration related to devices users have chosen to trust.
+type SessionTrustedDevices struct {
+	Name     string        `koanf:"name" yaml:"name,omitempty" toml:"name,omitempty" json:"name,omitempty" jsonschema:"default=authelia_trusted_device,title=Name" jsonschema_description:"The name of the cookie which identifies a trusted device."`
+	Lifespan time.Duration `koanf:"lifespan" yaml:"lifespan,omitempty" toml:"lifespan,omitempty" json:"lifespan,omitempty" jsonschema:"default=30 days,title=Lifespan" jsonschema_description:"The length of time a device remains trusted after the user completes the second factor on it."`
+}
+
+// SessionStorage represents the configuration related to the storage session store.
+type SessionStorage struct {
+	GarbageCollectionInterval time.Duration `koanf:"garbage_collection_interval" yaml:"garbage_collection_interval,omitempty" toml:"garbage_collection_interval,omitempty" json:"garbage_collection_interval,omitempty" jsonschema:"default=5 minutes,title=Garbage Collection Interval" jsonschema_description:"How frequently the expired sessions are removed from the storage backend."`
+}
+
 // SessionRedis represents the configuration related to redis session store.
 type SessionRedis struct {
 	Host                     string        `koanf:"host" yaml:"host,omitempty" toml:"host,omitempty" json:"host,omitempty" jsonschema:"title=Host" jsonschema_description:"The redis server host."`
@@ -97,6 +125,24 @@ var DefaultRedisConfiguration = SessionRedis{
 	},
 }
 
+// DefaultSessionBindingConfiguration is the default session binding configuration.
+var DefaultSessionBindingConfiguration = SessionBinding{
+	Action:     SessionBindingActionDestroy,
+	IPv4Prefix: 24,
+	IPv6Prefix: 64,
+}
+
+// DefaultSessionTrustedDevicesConfiguration is the default trusted devices configuration.
+var DefaultSessionTrustedDevicesConfiguration = SessionTrustedDevices{
+	Name:     "authelia_trusted_device",
+	Lifespan: time.Hour * 24 * 30,
+}
+
+// DefaultSessionStorageConfiguration is the default storage session configuration.
+var DefaultSessionStorageConfiguration = SessionStorage{
+	GarbageCollectionInterval: time.Minute * 5,
+}
+
 // DefaultRedisHighAvailabilityConfiguration is the default redis configuration.
 var DefaultRedisHighAvailabilityConfiguration = SessionRedis{
 	Port:                     26379,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -19,6 +20,10 @@ func ValidateAccessControl(config *schema.Configuration, validator *schema.Struc
 	if !IsPolicyValid(config.AccessControl.DefaultPolicy) {
 		validator.Push(fmt.Errorf(errFmtAccessControlDefaultPolicyValue, utils.StringJoinOr(validACLRulePolicies), config.AccessControl.DefaultPolicy))
 	}
+
+	if rate := config.AccessControl.AuditLog.SampleRate; rate < 0 || rate > 1 {
+		validator.Push(fmt.Errorf(errFmtAccessControlAuditLogSampleRate, rate))
+	}
 }
 
 // ValidateRules validates an ACL Rule configuration.
@@ -35,6 +40,8 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 		return
 	}
 
+	subjects := validateExpressions(config, validator)
+
 	for i, rule := range config.AccessControl.Rules {
 		rulePosition := i + 1
 
@@ -55,17 +62,69 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 
 		validateQuery(i, rule, config, validator)
 
+		validateHeaders(i, rule, config, validator)
+
+		validateSchedule(rulePosition, rule, validator)
+
+		validateRequiredAMR(rulePosition, rule, validator)
+
+		validateMaxAge(rulePosition, rule, validator)
+
+		if rule.DisableTrustedDevices && rule.Policy != policyTwoFactor {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleTwoFactorPolicy, ruleDescriptor(rulePosition, rule), "disable_trusted_devices", rule.Policy))
+		}
+
 		if rule.Policy == policyBypass {
-			validateBypass(rulePosition, rule, validator)
+			validateBypass(rulePosition, rule, subjects[i], validator)
+		}
+	}
+}
+
+// validateExpressions compiles the expression of each rule and returns a slice indicating which rules have an
+// expression that refers to the user.
+func validateExpressions(config *schema.Configuration, validator *schema.StructValidator) (subjects []bool) {
+	subjects = make([]bool, len(config.AccessControl.Rules))
+
+	var (
+		env     *expression.AccessControlEnv
+		program *expression.AccessControlExpression
+		err     error
+	)
+
+	for i, rule := range config.AccessControl.Rules {
+		if rule.Expression == "" {
+			continue
+		}
+
+		if env == nil {
+			if env, err = expression.NewAccessControlEnv(config); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleExpressionEnvironment, err))
+
+				return subjects
+			}
+		}
+
+		if program, err = env.Compile(rule.Expression); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleExpressionInvalid, ruleDescriptor(i+1, rule), err))
+
+			continue
 		}
+
+		subjects[i] = program.HasSubject()
 	}
+
+	return subjects
 }
 
-func validateBypass(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+func validateBypass(rulePosition int, rule schema.AccessControlRule, subject bool, validator *schema.StructValidator) {
 	if len(rule.Subjects) != 0 {
 		validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjects, ruleDescriptor(rulePosition, rule)))
 	}
 
+	if subject {
+		validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjectExpression, ruleDescriptor(rulePosition, rule)))
+	}
+
 	for _, pattern := range rule.DomainsRegex {
 		if utils.IsStringSliceContainsAny(authorization.IdentitySubexpNames, pattern.SubexpNames()) {
 			validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupDomainRegex, ruleDescriptor(rulePosition, rule)))
@@ -119,59 +178,129 @@ func validateMethods(rulePosition int, rule schema.AccessControlRule, validator
 	}
 }
 
-//nolint:gocyclo
 func validateQuery(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
 	for j := 0; j < len(config.AccessControl.Rules[i].Query); j++ {
 		for k := 0; k < len(config.AccessControl.Rules[i].Query[j]); k++ {
-			if config.AccessControl.Rules[i].Query[j][k].Operator == "" {
-				if config.AccessControl.Rules[i].Query[j][k].Key != "" {
-					switch config.AccessControl.Rules[i].Query[j][k].Value {
-					case "", nil:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorPresent
-					default:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorEqual
-					}
-				}
-			} else if !utils.IsStringInSliceFold(config.AccessControl.Rules[i].Query[j][k].Operator, validACLRuleOperators) {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalid, ruleDescriptor(i+1, rule), utils.StringJoinOr(validACLRuleOperators), config.AccessControl.Rules[i].Query[j][k].Operator))
-			}
+			query := &config.AccessControl.Rules[i].Query[j][k]
 
-			if config.AccessControl.Rules[i].Query[j][k].Key == "" {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValue, ruleDescriptor(i+1, rule), "key"))
-			}
+			validateRuleMatcher(ruleDescriptor(i+1, rule), "query", &query.Operator, query.Key, &query.Value, validator)
+		}
+	}
+}
 
-			op := config.AccessControl.Rules[i].Query[j][k].Operator
+func validateHeaders(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	for j := 0; j < len(config.AccessControl.Rules[i].Headers); j++ {
+		for k := 0; k < len(config.AccessControl.Rules[i].Headers[j]); k++ {
+			header := &config.AccessControl.Rules[i].Headers[j][k]
 
-			if op == "" {
-				continue
-			}
+			validateRuleMatcher(ruleDescriptor(i+1, rule), "headers", &header.Operator, header.Key, &header.Value, validator)
+		}
+	}
+}
+
+func validateSchedule(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if rule.Schedule == nil {
+		return
+	}
+
+	if len(rule.Schedule.Windows) == 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleNoWindows, ruleDescriptor(rulePosition, rule)))
+
+		return
+	}
+
+	if _, err := authorization.NewAccessControlSchedule(rule.Schedule); err != nil {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleInvalid, ruleDescriptor(rulePosition, rule), err))
+	}
+}
+
+func validateRequiredAMR(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if len(rule.RequiredAMR) == 0 {
+		return
+	}
+
+	switch rule.Policy {
+	case policyBypass, policyDeny:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleAuthenticatedPolicy, ruleDescriptor(rulePosition, rule), "required_amr", rule.Policy))
+	}
+
+	invalid, duplicates := validateList(rule.RequiredAMR, validACLRuleAMR, true)
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidEntries, ruleDescriptor(rulePosition, rule), "required_amr", utils.StringJoinOr(validACLRuleAMR), utils.StringJoinAnd(invalid)))
+	}
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidDuplicates, ruleDescriptor(rulePosition, rule), "required_amr", utils.StringJoinAnd(duplicates)))
+	}
+}
 
-			switch v := config.AccessControl.Rules[i].Query[j][k].Value.(type) {
-			case nil:
-				if op != operatorAbsent && op != operatorPresent {
-					validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValueOperator, ruleDescriptor(i+1, rule), "value", op))
-				}
-			case string:
-				switch op {
-				case operatorPresent, operatorAbsent:
-					if v != "" {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValue, ruleDescriptor(i+1, rule), "value", op))
-					}
-				case operatorPattern, operatorNotPattern:
-					var (
-						pattern *regexp.Regexp
-						err     error
-					)
-					if pattern, err = regexp.Compile(v); err != nil {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueParse, ruleDescriptor(i+1, rule), "value", err))
-					} else {
-						config.AccessControl.Rules[i].Query[j][k].Value = pattern
-					}
-				}
+func validateMaxAge(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	switch {
+	case rule.MaxAge == 0:
+		return
+	case rule.MaxAge < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeNegative, ruleDescriptor(rulePosition, rule), rule.MaxAge))
+	}
+
+	switch rule.Policy {
+	case policyBypass, policyDeny:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleAuthenticatedPolicy, ruleDescriptor(rulePosition, rule), "max_age", rule.Policy))
+	}
+}
+
+// validateRuleMatcher validates a single key, value, and operator matcher such as those used by the query and headers
+// criteria. The operator is defaulted and pattern values are compiled in place.
+//
+//nolint:gocyclo
+func validateRuleMatcher(descriptor, name string, operator *string, key string, value *any, validator *schema.StructValidator) {
+	if *operator == "" {
+		if key != "" {
+			switch *value {
+			case "", nil:
+				*operator = operatorPresent
 			default:
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueType, ruleDescriptor(i+1, rule), v))
+				*operator = operatorEqual
 			}
 		}
+	} else if !utils.IsStringInSliceFold(*operator, validACLRuleOperators) {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalid, descriptor, name, utils.StringJoinOr(validACLRuleOperators), *operator))
+	}
+
+	if key == "" {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidNoValue, descriptor, name, "key"))
+	}
+
+	op := *operator
+
+	if op == "" {
+		return
+	}
+
+	switch v := (*value).(type) {
+	case nil:
+		if op != operatorAbsent && op != operatorPresent {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidNoValueOperator, descriptor, name, "value", op))
+		}
+	case string:
+		switch op {
+		case operatorPresent, operatorAbsent:
+			if v != "" {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValue, descriptor, name, "value", op))
+			}
+		case operatorPattern, operatorNotPattern:
+			var (
+				pattern *regexp.Regexp
+				err     error
+			)
+			if pattern, err = regexp.Compile(v); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValueParse, descriptor, name, "value", err))
+			} else {
+				*value = pattern
+			}
+		}
+	default:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValueType, descriptor, name, v))
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -71,6 +72,26 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidDefaultPolicy() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: option 'default_policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'invalid'")
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidAuditLogSampleRate() {
+	suite.config.AccessControl.AuditLog = schema.AccessControlAuditLog{FilePath: "/var/log/authelia/audit.log", SampleRate: 1.5}
+
+	ValidateAccessControl(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: audit_log: option 'sample_rate' must be between 0 and 1 but it's configured as '1.5'")
+
+	suite.validator.Clear()
+
+	suite.config.AccessControl.AuditLog.SampleRate = 0.25
+
+	ValidateAccessControl(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
 func (suite *AccessControl) TestShouldRaiseWarningOnBadDomain() {
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
 		{
@@ -311,6 +332,55 @@ func (suite *AccessControl) TestShouldRaiseErrorBypassWithSubjectDomainRegexGrou
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1: 'policy' option 'bypass' is not supported when 'domain_regex' option contains the user or group named matches. For more information see: https://www.authelia.com/c/acl-match-concept-2")
 }
 
+func (suite *AccessControl) TestShouldValidateExpression() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:    []string{"public.example.com"},
+			Policy:     "bypass",
+			Expression: "request.method == 'GET' && remote_ip.startsWith('10.')",
+		},
+		{
+			Domains:    []string{"admin.example.com"},
+			Policy:     "two_factor",
+			Expression: "'admins' in groups && now.getDayOfWeek() in [1, 2, 3, 4, 5]",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidExpression() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:    []string{"public.example.com"},
+			Policy:     "bypass",
+			Expression: "'admins' in groups",
+		},
+		{
+			Domains:    []string{"admin.example.com"},
+			Policy:     "two_factor",
+			Expression: "department == 'ops'",
+		},
+		{
+			Domains:    []string{"app.example.com"},
+			Policy:     "one_factor",
+			Expression: "remote_ip",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().ErrorContains(suite.validator.Errors()[0], "access_control: rule #2 (domain 'admin.example.com'): option 'expression' is invalid: failed to parse expression 'department == 'ops'': ERROR: <input>:1:1: undeclared reference to 'department'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #3 (domain 'app.example.com'): option 'expression' is invalid: expression 'remote_ip' must evaluate to a bool but it evaluates to a string")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): 'policy' option 'bypass' is not supported when the 'expression' option refers to attributes of the user")
+}
+
 func (suite *AccessControl) TestShouldSetQueryDefaults() {
 	domains := []string{"public.example.com"}
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
@@ -454,6 +524,234 @@ func (suite *AccessControl) TestShouldErrorOnInvalidRulesQuery() {
 	suite.Assert().EqualError(suite.validator.Errors()[6], "access_control: rule #9 (domain 'public.example.com'): query: option 'value' is invalid: expected type was string but got int")
 }
 
+func (suite *AccessControl) TestShouldSetHeadersDefaults() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Key: "X-Api-Version"},
+					{Key: "X-Client", Value: "app"},
+					{Operator: "not pattern", Key: "User-Agent", Value: "(?i)curl"},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Require().Len(suite.config.AccessControl.Rules[0].Headers, 1)
+	suite.Require().Len(suite.config.AccessControl.Rules[0].Headers[0], 3)
+
+	suite.Assert().Equal("present", suite.config.AccessControl.Rules[0].Headers[0][0].Operator)
+	suite.Assert().Equal("equal", suite.config.AccessControl.Rules[0].Headers[0][1].Operator)
+	suite.Assert().IsType(&regexp.Regexp{}, suite.config.AccessControl.Rules[0].Headers[0][2].Value)
+}
+
+func (suite *AccessControl) TestShouldErrorOnInvalidRulesHeaders() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "equal", Key: "X-Api-Version"},
+					{Operator: "present"},
+				},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "like", Key: "User-Agent", Value: "curl"},
+					{Operator: "pattern", Key: "User-Agent", Value: "(bad pattern"},
+					{Operator: "absent", Key: "X-Api-Version", Value: 2},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 5)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): headers: option 'value' must be present when the option 'operator' is 'equal' but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): headers: option 'key' is required but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'public.example.com'): headers: option 'operator' must be one of 'present', 'absent', 'equal', 'not equal', 'pattern', or 'not pattern' but it's configured as 'like'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2 (domain 'public.example.com'): headers: option 'value' is invalid: error parsing regexp: missing closing ): `(bad pattern`")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #2 (domain 'public.example.com'): headers: option 'value' is invalid: expected type was string but got int")
+}
+
+func (suite *AccessControl) TestShouldValidateSchedule() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "two_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				TimeZone: "Europe/Berlin",
+				Windows: []schema.AccessControlRuleScheduleWindow{
+					{Days: []string{"monday", "friday"}, Start: "08:00", End: "18:00"},
+					{Days: []string{"saturday"}, Start: "22:00", End: "02:00"},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSchedule() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:  []string{"one.example.com"},
+			Policy:   "two_factor",
+			Schedule: &schema.AccessControlRuleSchedule{},
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "two_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows:  []schema.AccessControlRuleScheduleWindow{{}},
+			},
+		},
+		{
+			Domains: []string{"three.example.com"},
+			Policy:  "two_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"funday"}}},
+			},
+		},
+		{
+			Domains: []string{"four.example.com"},
+			Policy:  "two_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				Windows: []schema.AccessControlRuleScheduleWindow{{Start: "08:00", End: "8pm"}},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'one.example.com'): schedule: option 'windows' must have at least one window but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'two.example.com'): schedule: option 'timezone' is invalid: unknown time zone Mars/Olympus_Mons")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #3 (domain 'three.example.com'): schedule: window #1: option 'days' has an invalid value 'funday'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #4 (domain 'four.example.com'): schedule: window #1: option 'end' is invalid: time of day '8pm' must be in the 'HH:MM' format")
+}
+
+func (suite *AccessControl) TestShouldValidateRequiredAMR() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:     []string{"app.example.com"},
+			Policy:      "two_factor",
+			RequiredAMR: []string{"hwk", "otp"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidRequiredAMR() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:     []string{"one.example.com"},
+			Policy:      "bypass",
+			RequiredAMR: []string{"hwk"},
+		},
+		{
+			Domains:     []string{"two.example.com"},
+			Policy:      "two_factor",
+			RequiredAMR: []string{"hwk", "face", "hwk"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'one.example.com'): option 'required_amr' must only be configured with the 'one_factor' or 'two_factor' policies but the policy is 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'two.example.com'): option 'required_amr' must only have the values 'pwd', 'kba', 'otp', 'sms', 'pop', 'hwk', 'swk', 'user', 'pin', 'mfa', or 'mca' but the values 'face' are present")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'two.example.com'): option 'required_amr' must have unique values but the values 'hwk' are duplicated")
+}
+
+func (suite *AccessControl) TestShouldValidateMaxAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "two_factor",
+			MaxAge:  time.Minute * 15,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"one.example.com"},
+			Policy:  "deny",
+			MaxAge:  time.Minute * 15,
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "one_factor",
+			MaxAge:  -time.Minute,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'one.example.com'): option 'max_age' must only be configured with the 'one_factor' or 'two_factor' policies but the policy is 'deny'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'two.example.com'): option 'max_age' must be a positive duration but it's configured as '-1m0s'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorDisableTrustedDevicesWithoutTwoFactorPolicy() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:               []string{"one.example.com"},
+			Policy:                "one_factor",
+			DisableTrustedDevices: true,
+		},
+		{
+			Domains:               []string{"two.example.com"},
+			Policy:                "two_factor",
+			DisableTrustedDevices: true,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'one.example.com'): option 'disable_trusted_devices' must only be configured with the 'two_factor' policy but the policy is 'one_factor'")
+}
+
 func TestAccessControl(t *testing.T) {
 	suite.Run(t, new(AccessControl))
 }
@@ -20,7 +20,15 @@ import (
 
 // ValidateAuthenticationBackend validates and updates the authentication backend configuration.
 func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
-	if config.LDAP == nil && config.File == nil {
+	configured := 0
+
+	for _, enabled := range []bool{config.File != nil, config.LDAP != nil, config.SQL != nil} {
+		if enabled {
+			configured++
+		}
+	}
+
+	if configured == 0 {
 		validator.Push(errors.New(errFmtAuthBackendNotConfigured))
 	}
 
@@ -41,7 +49,17 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 		}
 	}
 
-	if config.LDAP != nil && config.File != nil {
+	switch {
+	case config.PasswordChange.MaxAge < 0:
+		validator.Push(fmt.Errorf(errFmtAuthBackendPasswordChangeMaxAgeNegative, config.PasswordChange.MaxAge))
+	case config.PasswordChange.MaxAge > 0 && config.PasswordChange.Disable:
+		validator.Push(fmt.Errorf(errFmtAuthBackendPasswordChangeMaxAgeDisabled, config.PasswordChange.MaxAge))
+	}
+
+	switch {
+	case len(config.Chain) != 0:
+		validateAuthenticationBackendChain(config, validator)
+	case configured > 1:
 		validator.Push(errors.New(errFmtAuthBackendMultipleConfigured))
 	}
 
@@ -52,6 +70,59 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 	if config.LDAP != nil {
 		validateLDAPAuthenticationBackend(config, validator)
 	}
+
+	if config.SQL != nil {
+		validateSQLAuthenticationBackend(config.SQL, validator)
+	}
+}
+
+func validateAuthenticationBackendChain(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	configured := map[string]bool{
+		authentication.BackendFile: config.File != nil,
+		authentication.BackendLDAP: config.LDAP != nil,
+		authentication.BackendSQL:  config.SQL != nil,
+	}
+
+	referenced := map[string]int{}
+
+	for i, link := range config.Chain {
+		n := i + 1
+
+		enabled, known := configured[link.Backend]
+
+		switch {
+		case !known:
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainBackendInvalid, n, link.Backend))
+		case !enabled:
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainBackendNotConfigured, n, link.Backend, link.Backend))
+		}
+
+		if known {
+			if previous, ok := referenced[link.Backend]; ok {
+				validator.Push(fmt.Errorf(errFmtAuthBackendChainBackendDuplicate, n, link.Backend, previous))
+			} else {
+				referenced[link.Backend] = n
+			}
+		}
+
+		for _, domain := range link.Domains {
+			if strings.TrimPrefix(domain, "@") == "" {
+				validator.Push(fmt.Errorf(errFmtAuthBackendChainDomainEmpty, n))
+
+				break
+			}
+		}
+
+		if n != len(config.Chain) && len(link.Usernames) == 0 && len(link.Domains) == 0 {
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainCatchAllNotLast, n))
+		}
+	}
+
+	for _, name := range []string{authentication.BackendFile, authentication.BackendLDAP, authentication.BackendSQL} {
+		if _, ok := referenced[name]; configured[name] && !ok {
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainBackendNotReferenced, name))
+		}
+	}
 }
 
 func validateFileAuthenticationBackend(config *schema.AuthenticationBackendFile, validator *schema.StructValidator) {
@@ -77,6 +148,10 @@ func validateFileAuthenticationBackend(config *schema.AuthenticationBackendFile,
 	ValidatePasswordConfiguration(&config.Password, validator)
 }
 
+func validateSQLAuthenticationBackend(config *schema.AuthenticationBackendSQL, validator *schema.StructValidator) {
+	ValidatePasswordConfiguration(&config.Password, validator)
+}
+
 // ValidatePasswordConfiguration validates the file auth backend password configuration.
 func ValidatePasswordConfiguration(config *schema.AuthenticationBackendFilePassword, validator *schema.StructValidator) {
 	validateFileAuthenticationBackendPasswordConfigLegacy(config)
@@ -573,6 +648,10 @@ func validateLDAPGroupFilter(config *schema.AuthenticationBackend, validator *sc
 		}
 	}
 
+	if config.LDAP.GroupSearchMode == schema.LDAPGroupSearchModeRecursive {
+		validateLDAPGroupSearchRecursive(config, pMemberOfDN || pMemberOfRDN, validator)
+	}
+
 	if pMemberOfDN && config.LDAP.Attributes.DistinguishedName == "" {
 		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterMissingAttribute, "distinguished_name", utils.StringJoinOr([]string{"{memberof:dn}"})))
 	}
@@ -581,3 +660,20 @@ func validateLDAPGroupFilter(config *schema.AuthenticationBackend, validator *sc
 		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterMissingAttribute, "member_of", utils.StringJoinOr([]string{"{memberof:rdn}", "{memberof:dn}"})))
 	}
 }
+
+func validateLDAPGroupSearchRecursive(config *schema.AuthenticationBackend, memberof bool, validator *schema.StructValidator) {
+	if !strings.Contains(config.LDAP.GroupsFilter, "{dn}") {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterMissingPlaceholderGroupSearchMode, "groups_filter", utils.StringJoinOr([]string{"{dn}"}), config.LDAP.GroupSearchMode))
+	}
+
+	if memberof {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterInvalidPlaceholderGroupSearchMode, "groups_filter", utils.StringJoinOr([]string{"{memberof:rdn}", "{memberof:dn}"}), config.LDAP.GroupSearchMode))
+	}
+
+	switch {
+	case config.LDAP.GroupSearchMaxDepth == 0:
+		config.LDAP.GroupSearchMaxDepth = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationCustom.GroupSearchMaxDepth
+	case config.LDAP.GroupSearchMaxDepth < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendGroupSearchMaxDepth, "group_search_max_depth", config.LDAP.GroupSearchMaxDepth))
+	}
+}
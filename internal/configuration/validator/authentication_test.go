@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -28,7 +29,7 @@ func TestShouldRaiseErrorWhenBothBackendsProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 6)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file' or 'ldap' backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
 	assert.EqualError(t, validator.Errors()[1], "authentication_backend: ldap: option 'address' is required")
 	assert.EqualError(t, validator.Errors()[2], "authentication_backend: ldap: option 'user' is required")
 	assert.EqualError(t, validator.Errors()[3], "authentication_backend: ldap: option 'password' is required")
@@ -43,7 +44,165 @@ func TestShouldRaiseErrorWhenNoBackendProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file' or 'ldap' authentication backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure one of the 'file', 'ldap', or 'sql' authentication backend is configured")
+}
+
+func TestShouldRaiseErrorWhenFileAndSQLBackendsProvided(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackend{
+		File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+		SQL:  &schema.AuthenticationBackendSQL{},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
+}
+
+func TestShouldValidateSQLBackend(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackend{
+		SQL: &schema.AuthenticationBackendSQL{},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.DefaultPasswordConfig.Algorithm, backendConfig.SQL.Password.Algorithm)
+	assert.Equal(t, schema.DefaultPasswordConfig.Argon2.Variant, backendConfig.SQL.Password.Argon2.Variant)
+	assert.Equal(t, schema.DefaultPasswordConfig.Argon2.Iterations, backendConfig.SQL.Password.Argon2.Iterations)
+	assert.Equal(t, schema.NewRefreshIntervalDuration(schema.RefreshIntervalDefault), backendConfig.RefreshInterval)
+}
+
+func TestValidateAuthenticationBackendChain(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.AuthenticationBackend
+		expected []string
+	}{
+		{
+			"ShouldValidateFileAndSQLChain",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				SQL:  &schema.AuthenticationBackendSQL{},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Usernames: []regexp.Regexp{*regexp.MustCompile(`^svc-`)}},
+					{Backend: "sql"},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldValidateDomainOnlyChain",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				SQL:  &schema.AuthenticationBackendSQL{},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Domains: []string{"example.com"}},
+					{Backend: "sql", Domains: []string{"@example.org"}},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorUnknownBackend",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Domains: []string{"example.com"}},
+					{Backend: "radius"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: backend #2: option 'backend' must be one of 'file', 'ldap', or 'sql' but it's configured as 'radius'",
+			},
+		},
+		{
+			"ShouldRaiseErrorBackendNotConfigured",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Domains: []string{"example.com"}},
+					{Backend: "sql"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: backend #2: option 'backend' is configured as 'sql' but the 'sql' authentication backend is not configured",
+			},
+		},
+		{
+			"ShouldRaiseErrorDuplicateBackend",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Domains: []string{"example.com"}},
+					{Backend: "file"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: backend #2: option 'backend' is configured as 'file' but it's already used by backend #1",
+			},
+		},
+		{
+			"ShouldRaiseErrorEmptyDomain",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				SQL:  &schema.AuthenticationBackendSQL{},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file", Domains: []string{"example.com", "@"}},
+					{Backend: "sql"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: backend #1: option 'domains' must not contain empty values",
+			},
+		},
+		{
+			"ShouldRaiseErrorCatchAllNotLast",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				SQL:  &schema.AuthenticationBackendSQL{},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "file"},
+					{Backend: "sql"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: backend #1: options 'usernames' and 'domains' must be configured as only the last backend may match all users",
+			},
+		},
+		{
+			"ShouldRaiseErrorBackendNotReferenced",
+			schema.AuthenticationBackend{
+				File: &schema.AuthenticationBackendFile{Path: "/tmp"},
+				SQL:  &schema.AuthenticationBackendSQL{},
+				Chain: []schema.AuthenticationBackendChain{
+					{Backend: "sql"},
+				},
+			},
+			[]string{
+				"authentication_backend: chain: the 'file' authentication backend is configured but is not referenced by any backend in the chain",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			ValidateAuthenticationBackend(&tc.have, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
 }
 
 type FileBasedAuthenticationBackend struct {
@@ -573,6 +732,38 @@ func (suite *FileBasedAuthenticationBackend) TestShouldConfigureDisableResetPass
 	suite.False(suite.config.PasswordReset.Disable)
 }
 
+func (suite *FileBasedAuthenticationBackend) TestShouldRaiseErrorWhenPasswordChangeMaxAgeNegative() {
+	suite.config.PasswordChange.MaxAge = -time.Hour
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: password_change: option 'max_age' is configured to '-1h0m0s' but it must be greater than or equal to 0")
+}
+
+func (suite *FileBasedAuthenticationBackend) TestShouldRaiseErrorWhenPasswordChangeMaxAgeAndDisabled() {
+	suite.config.PasswordChange.MaxAge = time.Hour * 24 * 90
+	suite.config.PasswordChange.Disable = true
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: password_change: option 'max_age' is configured to '2160h0m0s' but the password change option is disabled which prevents users with expired passwords from logging in")
+}
+
+func (suite *FileBasedAuthenticationBackend) TestShouldNotRaiseErrorWhenPasswordChangeMaxAgeValid() {
+	suite.config.PasswordChange.MaxAge = time.Hour * 24 * 90
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+}
+
 func (suite *FileBasedAuthenticationBackend) TestShouldValidateExtraAttributeString() {
 	suite.config.File.ExtraAttributes = map[string]schema.AuthenticationBackendExtraAttribute{
 		"custom_attr": {ValueType: "string"},
@@ -1042,7 +1233,7 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnBadSearchMode() {
 	suite.Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
 
-	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'group_search_mode' must be one of 'filter' or 'memberof' but it's configured as 'memberOF'")
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'group_search_mode' must be one of 'filter', 'memberof', or 'recursive' but it's configured as 'memberOF'")
 }
 
 func (suite *LDAPAuthenticationBackendSuite) TestShouldNoErrorOnPlaceholderSearchMode() {
@@ -1067,6 +1258,43 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnMissingPlaceholder
 	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'groups_filter' must contain one of the '{memberof:rdn}' or '{memberof:dn}' placeholders when using a group_search_mode of 'memberof' but they're absent")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetDefaultMaxDepthRecursiveSearchMode() {
+	suite.config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeRecursive
+	suite.config.LDAP.GroupsFilter = "(&(member={dn})(objectClass=groupOfNames))"
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(5, suite.config.LDAP.GroupSearchMaxDepth)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotSetMaxDepthNonRecursiveSearchMode() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(0, suite.config.LDAP.GroupSearchMaxDepth)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnInvalidRecursiveSearchMode() {
+	suite.config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeRecursive
+	suite.config.LDAP.GroupsFilter = filterMemberOfRDN
+	suite.config.LDAP.GroupSearchMaxDepth = -1
+	suite.config.LDAP.Attributes.MemberOf = memberOf
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'groups_filter' must contain one of the '{dn}' placeholders when using a group_search_mode of 'recursive' but they're absent")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: option 'groups_filter' must not contain the '{memberof:rdn}' or '{memberof:dn}' placeholders when using a group_search_mode of 'recursive'")
+	suite.EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: option 'group_search_max_depth' is configured as '-1' but must be greater than or equal to '1'")
+}
+
 func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnMissingDistinguishedNameDN() {
 	suite.config.LDAP.Attributes.DistinguishedName = ""
 	suite.config.LDAP.GroupsFilter = "(|({memberof:dn}))"
@@ -7,6 +7,7 @@ import (
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -32,6 +33,12 @@ const (
 	durationZero = time.Duration(0)
 )
 
+const (
+	// passwordPolicyHistoryMax is the maximum number of remembered passwords, as every one of them is verified when a
+	// password is changed or reset.
+	passwordPolicyHistoryMax = 24
+)
+
 // Hashing constants.
 const (
 	hashLegacyArgon2id = "argon2id"
@@ -83,18 +90,29 @@ const (
 
 const (
 	errFmtDefinitionsUserAttributesReservedOrDefined = "definitions: user_attributes: %s: attribute name '%s' is either reserved or already defined in the authentication backend"
+	errFmtDefinitionsSubjectsNameInvalid             = "definitions: subjects: %s: name '%s' is invalid: must not contain the ':' character"
+	errFmtDefinitionsSubjectsEmpty                   = "definitions: subjects: %s: must have at least one subject"
+	errFmtDefinitionsSubjectsSubjectInvalid          = "definitions: subjects: %s: subject '%s' is invalid: must start with 'user:', 'group:', or 'oauth2:client:'"
 )
 
 // Authentication Backend Error constants.
 const (
-	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file' or 'ldap' " +
+	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure one of the 'file', 'ldap', or 'sql' " +
 		"authentication backend is configured"
-	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file' or 'ldap' " +
-		"backend is configured"
+	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file', 'ldap', or " +
+		"'sql' backend is configured"
 	errFmtAuthBackendRefreshInterval = "authentication_backend: option 'refresh_interval' is configured to '%s' but " +
 		"it must be either in duration common syntax or one of 'disable', or 'always': %w"
 	errFmtAuthBackendPasswordResetCustomURLScheme = "authentication_backend: password_reset: option 'custom_url' is" +
 		" configured to '%s' which has the scheme '%s' but the scheme must be either 'http' or 'https'"
+	errFmtAuthBackendPasswordChangeMaxAgeNegative = "authentication_backend: password_change: option 'max_age' is configured to '%s' but it must be greater than or equal to 0"
+	errFmtAuthBackendPasswordChangeMaxAgeDisabled = "authentication_backend: password_change: option 'max_age' is configured to '%s' but the password change option is disabled which prevents users with expired passwords from logging in"
+	errFmtAuthBackendChainBackendInvalid          = "authentication_backend: chain: backend #%d: option 'backend' must be one of 'file', 'ldap', or 'sql' but it's configured as '%s'"
+	errFmtAuthBackendChainBackendNotConfigured    = "authentication_backend: chain: backend #%d: option 'backend' is configured as '%s' but the '%s' authentication backend is not configured"
+	errFmtAuthBackendChainBackendDuplicate        = "authentication_backend: chain: backend #%d: option 'backend' is configured as '%s' but it's already used by backend #%d"
+	errFmtAuthBackendChainDomainEmpty             = "authentication_backend: chain: backend #%d: option 'domains' must not contain empty values"
+	errFmtAuthBackendChainCatchAllNotLast         = "authentication_backend: chain: backend #%d: options 'usernames' and 'domains' must be configured as only the last backend may match all users"
+	errFmtAuthBackendChainBackendNotReferenced    = "authentication_backend: chain: the '%s' authentication backend is configured but is not referenced by any backend in the chain"
 
 	errFmtFileAuthBackendPathNotConfigured              = "authentication_backend: file: option 'path' is required"
 	errFmtFileAuthBackendExtraAttributeValueTypeMissing = "authentication_backend: file: extra_attributes: %s: option 'value_type' is required"
@@ -134,6 +152,10 @@ const (
 		"must contain the placeholder '{%s}' but it's absent"
 	errFmtLDAPAuthBackendFilterMissingPlaceholderGroupSearchMode = errFmtLDAPAuthBackendOption +
 		"must contain one of the %s placeholders when using a group_search_mode of '%s' but they're absent"
+	errFmtLDAPAuthBackendFilterInvalidPlaceholderGroupSearchMode = errFmtLDAPAuthBackendOption +
+		"must not contain the %s placeholders when using a group_search_mode of '%s'"
+	errFmtLDAPAuthBackendGroupSearchMaxDepth = errFmtLDAPAuthBackendOption +
+		"is configured as '%d' but must be greater than or equal to '1'"
 	errFmtLDAPAuthBackendFilterMissingAttribute = "authentication_backend: ldap: attributes: option '%s' " +
 		"must be provided when using the %s placeholder but it's absent"
 )
@@ -345,22 +367,32 @@ const (
 	errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupDomainRegex = errAccessControlRuleBypassPolicyOptionBypassIs +
 		"not supported when 'domain_regex' option contains the user or group named matches. For more information see: " +
 		"https://www.authelia.com/c/acl-match-concept-2"
+	errAccessControlRuleBypassPolicyInvalidWithSubjectExpression = errAccessControlRuleBypassPolicyOptionBypassIs +
+		"not supported when the 'expression' option refers to attributes of the user"
 	errFmtAccessControlRuleNetworksInvalid = "access_control: rule %s: the network '%s' is not a " +
 		"valid Group Name, IP, or CIDR notation"
 	errFmtAccessControlRuleSubjectInvalid = "access_control: rule %s: 'subject' option '%s' is " +
 		"invalid: must start with 'user:', 'group:', or 'oauth2:client:'"
 	errFmtAccessControlRuleOAuth2ClientSubjectInvalid = "access_control: rule %s: option 'subject' with value '%s' is " +
 		"invalid: the client id '%s' does not belong to a registered client"
-	errFmtAccessControlRuleInvalidEntries              = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
-	errFmtAccessControlRuleInvalidDuplicates           = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
-	errFmtAccessControlRuleQueryInvalid                = "access_control: rule %s: query: option 'operator' must be one of %s but it's configured as '%s'"
-	errFmtAccessControlRuleQueryInvalidNoValue         = "access_control: rule %s: query: option '%s' is required but it's absent"
-	errFmtAccessControlRuleQueryInvalidNoValueOperator = "access_control: rule %s: query: option '%s' must be present when the option 'operator' is '%s' but it's absent"
-	errFmtAccessControlRuleQueryInvalidValue           = "access_control: rule %s: query: option '%s' must not be present when the option 'operator' is '%s' but it's present"
-	errFmtAccessControlRuleQueryInvalidValueParse      = "access_control: rule %s: query: option '%s' is " +
+	errFmtAccessControlRuleInvalidEntries                = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
+	errFmtAccessControlRuleInvalidDuplicates             = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
+	errFmtAccessControlRuleMatcherInvalid                = "access_control: rule %s: %s: option 'operator' must be one of %s but it's configured as '%s'"
+	errFmtAccessControlRuleMatcherInvalidNoValue         = "access_control: rule %s: %s: option '%s' is required but it's absent"
+	errFmtAccessControlRuleMatcherInvalidNoValueOperator = "access_control: rule %s: %s: option '%s' must be present when the option 'operator' is '%s' but it's absent"
+	errFmtAccessControlRuleMatcherInvalidValue           = "access_control: rule %s: %s: option '%s' must not be present when the option 'operator' is '%s' but it's present"
+	errFmtAccessControlRuleMatcherInvalidValueParse      = "access_control: rule %s: %s: option '%s' is " +
 		"invalid: %w"
-	errFmtAccessControlRuleQueryInvalidValueType = "access_control: rule %s: query: option 'value' is " +
+	errFmtAccessControlRuleMatcherInvalidValueType = "access_control: rule %s: %s: option 'value' is " +
 		"invalid: expected type was string but got %T"
+	errFmtAccessControlRuleScheduleNoWindows     = "access_control: rule %s: schedule: option 'windows' must have at least one window but it's absent"
+	errFmtAccessControlRuleScheduleInvalid       = "access_control: rule %s: schedule: %w"
+	errFmtAccessControlRuleExpressionInvalid     = "access_control: rule %s: option 'expression' is invalid: %w"
+	errFmtAccessControlRuleExpressionEnvironment = "access_control: option 'expression' could not be validated: %w"
+	errFmtAccessControlRuleAuthenticatedPolicy   = "access_control: rule %s: option '%s' must only be configured with the 'one_factor' or 'two_factor' policies but the policy is '%s'"
+	errFmtAccessControlRuleTwoFactorPolicy       = "access_control: rule %s: option '%s' must only be configured with the 'two_factor' policy but the policy is '%s'"
+	errFmtAccessControlRuleMaxAgeNegative        = "access_control: rule %s: option 'max_age' must be a positive duration but it's configured as '%s'"
+	errFmtAccessControlAuditLogSampleRate        = "access_control: audit_log: option 'sample_rate' must be between 0 and 1 but it's configured as '%v'"
 )
 
 // Theme Error constants.
@@ -382,6 +414,12 @@ const (
 	errFmtSessionLegacyAndWarning         = "session: option 'domain' and option 'cookies' can't be specified at the same time"
 	errFmtSessionSameSite                 = "session: option 'same_site' must be one of %s but it's configured as '%s'"
 	errFmtSessionSecretRequired           = "session: option 'secret' is required when using the '%s' provider"
+	errFmtSessionRedisAndStorage          = "session: option 'redis' and option 'storage' can't be specified at the same time"
+	errFmtSessionAdministratorInvalid     = "session: option 'administrators' with value '%s' is invalid: must start with 'user:' or 'group:'"
+	errFmtSessionBindingAction            = "session: binding: option 'action' must be one of %s but it's configured as '%s'"
+	errFmtSessionBindingPrefixRange       = "session: binding: option '%s' must be between 1 and %d but it's configured as '%d'"
+	errFmtSessionTrustedDevicesSecret     = "session: option 'secret' is required when the 'trusted_devices' option is configured"
+	errFmtSessionTrustedDevicesName       = "session: trusted_devices: option 'name' must not be the same as the name of a session cookie but it's configured as '%s'"
 	errFmtSessionRedisPortRange           = "session: redis: option 'port' must be between 1 and 65535 but it's configured as '%d'"
 	errFmtSessionRedisHostRequired        = "session: redis: option 'host' is required"
 	errFmtSessionRedisHostOrNodesRequired = "session: redis: option 'host' or the 'high_availability' option 'nodes' is required"
@@ -443,6 +481,15 @@ const (
 	errPasswordPolicyMultipleDefined                        = "password_policy: only a single password policy mechanism can be specified"
 	errFmtPasswordPolicyStandardMinLengthNotGreaterThanZero = "password_policy: standard: option 'min_length' must be greater than 0 but it's configured as %d"
 	errFmtPasswordPolicyZXCVBNMinScoreInvalid               = "password_policy: zxcvbn: option 'min_score' is invalid: must be between 1 and 4 but it's configured as %d"
+	errFmtPasswordPolicyHistoryNegative                     = "password_policy: option 'history' must be 0 or greater but it's configured as %d"
+	errFmtPasswordPolicyHistoryTooLarge                     = "password_policy: option 'history' must be %d or less but it's configured as %d"
+
+	errPasswordPolicyBreachedNoSource             = "password_policy: breached: either option 'path' or 'url' must be configured"
+	errPasswordPolicyBreachedMultipleSources      = "password_policy: breached: option 'path' and 'url' must not both be configured"
+	errFmtPasswordPolicyBreachedPathNotExist      = "password_policy: breached: option 'path' with value '%s' refers to a file or directory that doesn't exist"
+	errFmtPasswordPolicyBreachedPathUnknownError  = "password_policy: breached: option 'path' with value '%s' could not be verified due to a file system error: %w"
+	errFmtPasswordPolicyBreachedURLScheme         = "password_policy: breached: option 'url' must have the 'http' or 'https' scheme but it's configured with the '%s' scheme"
+	errFmtPasswordPolicyBreachedThresholdNegative = "password_policy: breached: option 'threshold' must be 1 or greater but it's configured as %d"
 )
 
 const (
@@ -513,6 +560,7 @@ var (
 	validLDAPGroupSearchModes = []string{
 		schema.LDAPGroupSearchModeFilter,
 		schema.LDAPGroupSearchModeMemberOf,
+		schema.LDAPGroupSearchModeRecursive,
 	}
 )
 
@@ -529,6 +577,7 @@ var (
 	validStoragePostgreSQLSSLModes           = []string{"disable", "require", "verify-ca", "verify-full"}
 	validThemeNames                          = []string{"light", "dark", "grey", "oled", auto}
 	validSessionSameSiteValues               = []string{"none", "lax", "strict"}
+	validSessionBindingActions               = []string{schema.SessionBindingActionDestroy, schema.SessionBindingActionReauthenticate}
 	validLogLevels                           = []string{logging.LevelTrace, logging.LevelDebug, logging.LevelInfo, logging.LevelWarn, logging.LevelError}
 	validLogFormats                          = []string{logging.FormatText, logging.FormatJSON}
 	validWebAuthnConveyancePreferences       = []string{string(protocol.PreferNoAttestation), string(protocol.PreferIndirectAttestation), string(protocol.PreferDirectAttestation)}
@@ -543,6 +592,12 @@ var (
 	validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
 	validACLRulePolicies    = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
 	validACLRuleOperators   = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
+	validACLRuleAMR         = []string{
+		authorization.AMRPasswordBasedAuthentication, authorization.AMRKnowledgeBasedAuthentication, authorization.AMROneTimePassword,
+		authorization.AMRShortMessageService, authorization.AMRProofOfPossession, authorization.AMRHardwareSecuredKey,
+		authorization.AMRSoftwareSecuredKey, authorization.AMRUserPresence, authorization.AMRPersonalIdentificationNumber,
+		authorization.AMRMultiFactorAuthentication, authorization.AMRMultiChannelAuthentication,
+	}
 )
 
 var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -13,4 +14,28 @@ func ValidateDefinitions(config *schema.Configuration, validator *schema.StructV
 			validator.Push(fmt.Errorf(errFmtDefinitionsUserAttributesReservedOrDefined, name, name))
 		}
 	}
+
+	for name, subjects := range config.Definitions.Subjects {
+		validateDefinitionsSubjects(name, subjects, validator)
+	}
+}
+
+func validateDefinitionsSubjects(name string, subjects schema.AccessControlRuleSubjects, validator *schema.StructValidator) {
+	if strings.Contains(name, ":") {
+		validator.Push(fmt.Errorf(errFmtDefinitionsSubjectsNameInvalid, name, name))
+	}
+
+	if len(subjects) == 0 {
+		validator.Push(fmt.Errorf(errFmtDefinitionsSubjectsEmpty, name))
+
+		return
+	}
+
+	for _, rule := range subjects {
+		for _, subject := range rule {
+			if _, isValid := IsSubjectValid(subject); !isValid {
+				validator.Push(fmt.Errorf(errFmtDefinitionsSubjectsSubjectInvalid, name, subject))
+			}
+		}
+	}
 }
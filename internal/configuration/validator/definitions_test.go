@@ -177,4 +177,71 @@ func TestValidateDefinitions(t *testing.T) {
 		assert.Len(t, validator.Warnings(), 0)
 		assert.Len(t, validator.Errors(), 0)
 	})
+
+	t.Run("ShouldSucceedWithValidSubjects", func(t *testing.T) {
+		config := &schema.Configuration{
+			Definitions: schema.Definitions{
+				Subjects: map[string]schema.AccessControlRuleSubjects{
+					"admins": {{"group:ops", "group:sre"}, {"user:alice"}},
+				},
+			},
+		}
+		validator := schema.NewStructValidator()
+
+		ValidateDefinitions(config, validator)
+
+		assert.Len(t, validator.Warnings(), 0)
+		assert.Len(t, validator.Errors(), 0)
+	})
+
+	t.Run("ShouldErrInvalidSubjectsName", func(t *testing.T) {
+		config := &schema.Configuration{
+			Definitions: schema.Definitions{
+				Subjects: map[string]schema.AccessControlRuleSubjects{
+					"group:admins": {{"user:alice"}},
+				},
+			},
+		}
+		validator := schema.NewStructValidator()
+
+		ValidateDefinitions(config, validator)
+
+		assert.Len(t, validator.Warnings(), 0)
+		require.Len(t, validator.Errors(), 1)
+		assert.EqualError(t, validator.Errors()[0], "definitions: subjects: group:admins: name 'group:admins' is invalid: must not contain the ':' character")
+	})
+
+	t.Run("ShouldErrEmptySubjects", func(t *testing.T) {
+		config := &schema.Configuration{
+			Definitions: schema.Definitions{
+				Subjects: map[string]schema.AccessControlRuleSubjects{
+					"admins": {},
+				},
+			},
+		}
+		validator := schema.NewStructValidator()
+
+		ValidateDefinitions(config, validator)
+
+		assert.Len(t, validator.Warnings(), 0)
+		require.Len(t, validator.Errors(), 1)
+		assert.EqualError(t, validator.Errors()[0], "definitions: subjects: admins: must have at least one subject")
+	})
+
+	t.Run("ShouldErrInvalidSubject", func(t *testing.T) {
+		config := &schema.Configuration{
+			Definitions: schema.Definitions{
+				Subjects: map[string]schema.AccessControlRuleSubjects{
+					"admins": {{"group:ops", "ops"}},
+				},
+			},
+		}
+		validator := schema.NewStructValidator()
+
+		ValidateDefinitions(config, validator)
+
+		assert.Len(t, validator.Warnings(), 0)
+		require.Len(t, validator.Errors(), 1)
+		assert.EqualError(t, validator.Errors()[0], "definitions: subjects: admins: subject 'ops' is invalid: must start with 'user:', 'group:', or 'oauth2:client:'")
+	})
 }
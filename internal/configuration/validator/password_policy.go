@@ -3,6 +3,7 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -34,4 +35,46 @@ func ValidatePasswordPolicy(config *schema.PasswordPolicy, validator *schema.Str
 			validator.Push(fmt.Errorf(errFmtPasswordPolicyZXCVBNMinScoreInvalid, config.ZXCVBN.MinScore))
 		}
 	}
+
+	if config.Breached.Enabled {
+		validatePasswordPolicyBreached(&config.Breached, validator)
+	}
+
+	switch {
+	case config.History < 0:
+		validator.Push(fmt.Errorf(errFmtPasswordPolicyHistoryNegative, config.History))
+	case config.History > passwordPolicyHistoryMax:
+		validator.Push(fmt.Errorf(errFmtPasswordPolicyHistoryTooLarge, passwordPolicyHistoryMax, config.History))
+	}
+}
+
+func validatePasswordPolicyBreached(config *schema.PasswordPolicyBreached, validator *schema.StructValidator) {
+	switch {
+	case config.Path == "" && config.URL == nil:
+		validator.Push(errors.New(errPasswordPolicyBreachedNoSource))
+	case config.Path != "" && config.URL != nil:
+		validator.Push(errors.New(errPasswordPolicyBreachedMultipleSources))
+	case config.Path != "":
+		switch _, err := os.Stat(config.Path); {
+		case os.IsNotExist(err):
+			validator.Push(fmt.Errorf(errFmtPasswordPolicyBreachedPathNotExist, config.Path))
+		case err != nil:
+			validator.Push(fmt.Errorf(errFmtPasswordPolicyBreachedPathUnknownError, config.Path, err))
+		}
+	default:
+		if config.URL.Scheme != schemeHTTPS && config.URL.Scheme != schemeHTTP {
+			validator.Push(fmt.Errorf(errFmtPasswordPolicyBreachedURLScheme, config.URL.Scheme))
+		}
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = schema.DefaultPasswordPolicyConfiguration.Breached.Timeout
+	}
+
+	switch {
+	case config.Threshold == 0:
+		config.Threshold = schema.DefaultPasswordPolicyConfiguration.Breached.Threshold
+	case config.Threshold < 0:
+		validator.Push(fmt.Errorf(errFmtPasswordPolicyBreachedThresholdNegative, config.Threshold))
+	}
 }
@@ -2,7 +2,11 @@ package validator
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +15,11 @@ import (
 )
 
 func TestValidatePasswordPolicy(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "00000.txt")
+
+	require.NoError(t, os.WriteFile(file, []byte("0005AD76BD555C1D6D771DE417A4B87E4B4:10\n"), 0600))
+
 	testCases := []struct {
 		desc           string
 		have, expected *schema.PasswordPolicy
@@ -122,6 +131,174 @@ func TestValidatePasswordPolicy(t *testing.T) {
 				"password_policy: zxcvbn: option 'min_score' is invalid: must be between 1 and 4 but it's configured as 5",
 			},
 		},
+		{
+			desc: "ShouldNotRaiseErrorsHistory",
+			have: &schema.PasswordPolicy{
+				History: 5,
+			},
+			expected: &schema.PasswordPolicy{
+				History: 5,
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsHistoryNegative",
+			have: &schema.PasswordPolicy{
+				History: -1,
+			},
+			expected: &schema.PasswordPolicy{
+				History: -1,
+			},
+			expectedErrs: []string{
+				"password_policy: option 'history' must be 0 or greater but it's configured as -1",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsHistoryTooLarge",
+			have: &schema.PasswordPolicy{
+				History: 25,
+			},
+			expected: &schema.PasswordPolicy{
+				History: 25,
+			},
+			expectedErrs: []string{
+				"password_policy: option 'history' must be 24 or less but it's configured as 25",
+			},
+		},
+		{
+			desc: "ShouldSetDefaultsBreachedPath",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled: true,
+					Path:    dir,
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Path:      dir,
+					Timeout:   time.Second * 5,
+					Threshold: 1,
+				},
+			},
+		},
+		{
+			desc: "ShouldNotRaiseErrorsBreachedURL",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					URL:       &url.URL{Scheme: "https", Host: "api.pwnedpasswords.com", Path: "/range/"},
+					Timeout:   time.Second,
+					Threshold: 10,
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					URL:       &url.URL{Scheme: "https", Host: "api.pwnedpasswords.com", Path: "/range/"},
+					Timeout:   time.Second,
+					Threshold: 10,
+				},
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsBreachedNoSource",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Threshold: -1,
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Timeout:   time.Second * 5,
+					Threshold: -1,
+				},
+			},
+			expectedErrs: []string{
+				"password_policy: breached: either option 'path' or 'url' must be configured",
+				"password_policy: breached: option 'threshold' must be 1 or greater but it's configured as -1",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsBreachedMultipleSources",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled: true,
+					Path:    dir,
+					URL:     &url.URL{Scheme: "https", Host: "api.pwnedpasswords.com", Path: "/range/"},
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Path:      dir,
+					URL:       &url.URL{Scheme: "https", Host: "api.pwnedpasswords.com", Path: "/range/"},
+					Timeout:   time.Second * 5,
+					Threshold: 1,
+				},
+			},
+			expectedErrs: []string{
+				"password_policy: breached: option 'path' and 'url' must not both be configured",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsBreachedPathNotExist",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled: true,
+					Path:    filepath.Join(dir, "missing"),
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Path:      filepath.Join(dir, "missing"),
+					Timeout:   time.Second * 5,
+					Threshold: 1,
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf("password_policy: breached: option 'path' with value '%s' refers to a file or directory that doesn't exist", filepath.Join(dir, "missing")),
+			},
+		},
+		{
+			desc: "ShouldNotRaiseErrorsBreachedPathFile",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled: true,
+					Path:    file,
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					Path:      file,
+					Timeout:   time.Second * 5,
+					Threshold: 1,
+				},
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsBreachedURLScheme",
+			have: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled: true,
+					URL:     &url.URL{Scheme: "ftp", Host: "example.com"},
+				},
+			},
+			expected: &schema.PasswordPolicy{
+				Breached: schema.PasswordPolicyBreached{
+					Enabled:   true,
+					URL:       &url.URL{Scheme: "ftp", Host: "example.com"},
+					Timeout:   time.Second * 5,
+					Threshold: 1,
+				},
+			},
+			expectedErrs: []string{
+				"password_policy: breached: option 'url' must have the 'http' or 'https' scheme but it's configured with the 'ftp' scheme",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -138,6 +315,8 @@ func TestValidatePasswordPolicy(t *testing.T) {
 			assert.Equal(t, tc.expected.Standard.RequireUppercase, tc.have.Standard.RequireUppercase)
 			assert.Equal(t, tc.expected.Standard.RequireLowercase, tc.have.Standard.RequireLowercase)
 			assert.Equal(t, tc.expected.ZXCVBN.MinScore, tc.have.ZXCVBN.MinScore)
+			assert.Equal(t, tc.expected.Breached, tc.have.Breached)
+			assert.Equal(t, tc.expected.History, tc.have.History)
 
 			errs := validator.Errors()
 			require.Len(t, errs, len(tc.expectedErrs))
@@ -17,12 +17,17 @@ func ValidateSession(config *schema.Configuration, validator *schema.StructValid
 		config.Session.Name = schema.DefaultSessionConfiguration.Name
 	}
 
-	if config.Session.Redis != nil {
+	switch {
+	case config.Session.Redis != nil && config.Session.Storage != nil:
+		validator.Push(errors.New(errFmtSessionRedisAndStorage))
+	case config.Session.Redis != nil:
 		if config.Session.Redis.HighAvailability != nil {
 			validateRedisSentinel(&config.Session, validator)
 		} else {
 			validateRedis(&config.Session, validator)
 		}
+	case config.Session.Storage != nil:
+		validateSessionStorage(&config.Session, validator)
 	}
 
 	validateSession(config, validator)
@@ -79,6 +84,76 @@ func validateSession(config *schema.Configuration, validator *schema.StructValid
 	}
 
 	validateSessionCookieDomains(&config.Session, validator)
+	validateSessionAdministrators(&config.Session, validator)
+	validateSessionBinding(&config.Session, validator)
+	validateSessionTrustedDevices(&config.Session, validator)
+}
+
+func validateSessionAdministrators(config *schema.Session, validator *schema.StructValidator) {
+	for _, subject := range config.Administrators {
+		if !IsSubjectValidBasic(subject) {
+			validator.Push(fmt.Errorf(errFmtSessionAdministratorInvalid, subject))
+		}
+	}
+}
+
+func validateSessionBinding(config *schema.Session, validator *schema.StructValidator) {
+	if config.Binding == nil {
+		return
+	}
+
+	if config.Binding.Action == "" {
+		config.Binding.Action = schema.DefaultSessionBindingConfiguration.Action
+	} else if !utils.IsStringInSlice(config.Binding.Action, validSessionBindingActions) {
+		validator.Push(fmt.Errorf(errFmtSessionBindingAction, utils.StringJoinOr(validSessionBindingActions), config.Binding.Action))
+	}
+
+	switch {
+	case config.Binding.IPv4Prefix == 0:
+		config.Binding.IPv4Prefix = schema.DefaultSessionBindingConfiguration.IPv4Prefix
+	case config.Binding.IPv4Prefix < 0 || config.Binding.IPv4Prefix > 32:
+		validator.Push(fmt.Errorf(errFmtSessionBindingPrefixRange, "ipv4_prefix", 32, config.Binding.IPv4Prefix))
+	}
+
+	switch {
+	case config.Binding.IPv6Prefix == 0:
+		config.Binding.IPv6Prefix = schema.DefaultSessionBindingConfiguration.IPv6Prefix
+	case config.Binding.IPv6Prefix < 0 || config.Binding.IPv6Prefix > 128:
+		validator.Push(fmt.Errorf(errFmtSessionBindingPrefixRange, "ipv6_prefix", 128, config.Binding.IPv6Prefix))
+	}
+}
+
+func validateSessionTrustedDevices(config *schema.Session, validator *schema.StructValidator) {
+	if config.TrustedDevices == nil {
+		return
+	}
+
+	if config.TrustedDevices.Name == "" {
+		config.TrustedDevices.Name = schema.DefaultSessionTrustedDevicesConfiguration.Name
+	}
+
+	if config.TrustedDevices.Lifespan <= 0 {
+		config.TrustedDevices.Lifespan = schema.DefaultSessionTrustedDevicesConfiguration.Lifespan
+	}
+
+	// The secret is used to sign the trusted device cookies. The redis and storage providers already require it.
+	if config.Secret == "" && config.Redis == nil && config.Storage == nil {
+		validator.Push(errors.New(errFmtSessionTrustedDevicesSecret))
+	}
+
+	if config.TrustedDevices.Name == config.Name {
+		validator.Push(fmt.Errorf(errFmtSessionTrustedDevicesName, config.TrustedDevices.Name))
+
+		return
+	}
+
+	for _, cookie := range config.Cookies {
+		if config.TrustedDevices.Name == cookie.Name {
+			validator.Push(fmt.Errorf(errFmtSessionTrustedDevicesName, config.TrustedDevices.Name))
+
+			return
+		}
+	}
 }
 
 func validateSessionCookieDomains(config *schema.Session, validator *schema.StructValidator) {
@@ -250,6 +325,16 @@ func sessionDomainDescriptor(position int, domain schema.SessionCookie) string {
 	return fmt.Sprintf("#%d (domain '%s')", position+1, domain.Domain)
 }
 
+func validateSessionStorage(config *schema.Session, validator *schema.StructValidator) {
+	if config.Secret == "" {
+		validator.Push(fmt.Errorf(errFmtSessionSecretRequired, "storage"))
+	}
+
+	if config.Storage.GarbageCollectionInterval <= 0 {
+		config.Storage.GarbageCollectionInterval = schema.DefaultSessionStorageConfiguration.GarbageCollectionInterval
+	}
+}
+
 func validateRedisCommon(config *schema.Session, validator *schema.StructValidator) {
 	if config.Secret == "" {
 		validator.Push(fmt.Errorf(errFmtSessionSecretRequired, "redis"))
@@ -960,6 +960,215 @@ func TestShouldRaiseErrorWhenSameSiteSetIncorrectlyGlobal(t *testing.T) {
 	assert.EqualError(t, validator.Errors()[0], "session: option 'same_site' must be one of 'none', 'lax', or 'strict' but it's configured as 'NOne'")
 }
 
+func TestShouldValidateSessionStorage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     func(config *schema.Configuration)
+		expected time.Duration
+		errs     []string
+	}{
+		{
+			"ShouldSetDefaults",
+			func(config *schema.Configuration) {
+				config.Session.Storage = &schema.SessionStorage{}
+			},
+			time.Minute * 5,
+			nil,
+		},
+		{
+			"ShouldNotOverrideConfiguredValues",
+			func(config *schema.Configuration) {
+				config.Session.Storage = &schema.SessionStorage{GarbageCollectionInterval: time.Minute}
+			},
+			time.Minute,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorWithoutSecret",
+			func(config *schema.Configuration) {
+				config.Session.Secret = ""
+				config.Session.Storage = &schema.SessionStorage{}
+			},
+			time.Minute * 5,
+			[]string{"session: option 'secret' is required when using the 'storage' provider"},
+		},
+		{
+			"ShouldRaiseErrorWithRedis",
+			func(config *schema.Configuration) {
+				config.Session.Storage = &schema.SessionStorage{GarbageCollectionInterval: time.Minute}
+				config.Session.Redis = &schema.SessionRedis{Host: "redis.local"}
+			},
+			time.Minute,
+			[]string{"session: option 'redis' and option 'storage' can't be specified at the same time"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+
+			tc.have(&config)
+
+			ValidateSession(&config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+
+			errs := validator.Errors()
+			require.Len(t, errs, len(tc.errs))
+
+			for i, err := range errs {
+				assert.EqualError(t, err, tc.errs[i])
+			}
+
+			assert.Equal(t, tc.expected, config.Session.Storage.GarbageCollectionInterval)
+		})
+	}
+}
+
+func TestShouldRaiseErrorWhenAdministratorsInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Session.Administrators = []string{"user:john", "group:admins", "john", "oauth2:client:abc"}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	require.Len(t, validator.Errors(), 2)
+
+	assert.EqualError(t, validator.Errors()[0], "session: option 'administrators' with value 'john' is invalid: must start with 'user:' or 'group:'")
+	assert.EqualError(t, validator.Errors()[1], "session: option 'administrators' with value 'oauth2:client:abc' is invalid: must start with 'user:' or 'group:'")
+}
+
+func TestShouldValidateSessionBinding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.SessionBinding
+		expected *schema.SessionBinding
+		errs     []string
+	}{
+		{
+			"ShouldNotSetDefaultsWhenNotConfigured",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			&schema.SessionBinding{},
+			&schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64},
+			nil,
+		},
+		{
+			"ShouldNotOverrideConfiguredValues",
+			&schema.SessionBinding{Action: schema.SessionBindingActionReauthenticate, IPv4Prefix: 32, IPv6Prefix: 48, DisableUserAgent: true, Notify: true},
+			&schema.SessionBinding{Action: schema.SessionBindingActionReauthenticate, IPv4Prefix: 32, IPv6Prefix: 48, DisableUserAgent: true, Notify: true},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorsOnInvalidValues",
+			&schema.SessionBinding{Action: "logout", IPv4Prefix: 33, IPv6Prefix: -1},
+			&schema.SessionBinding{Action: "logout", IPv4Prefix: 33, IPv6Prefix: -1},
+			[]string{
+				"session: binding: option 'action' must be one of 'destroy' or 'reauthenticate' but it's configured as 'logout'",
+				"session: binding: option 'ipv4_prefix' must be between 1 and 32 but it's configured as '33'",
+				"session: binding: option 'ipv6_prefix' must be between 1 and 128 but it's configured as '-1'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+			config.Session.Binding = tc.have
+
+			ValidateSession(&config, validator)
+
+			assert.False(t, validator.HasWarnings())
+			assert.Equal(t, tc.expected, config.Session.Binding)
+
+			errs := validator.Errors()
+			require.Len(t, errs, len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, errs[i], err)
+			}
+		})
+	}
+}
+
+func TestShouldValidateSessionTrustedDevices(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.SessionTrustedDevices
+		secret   string
+		expected *schema.SessionTrustedDevices
+		errs     []string
+	}{
+		{
+			"ShouldNotSetDefaultsWhenNotConfigured",
+			nil,
+			testJWTSecret,
+			nil,
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			&schema.SessionTrustedDevices{},
+			testJWTSecret,
+			&schema.SessionTrustedDevices{Name: "authelia_trusted_device", Lifespan: time.Hour * 24 * 30},
+			nil,
+		},
+		{
+			"ShouldNotOverrideConfiguredValues",
+			&schema.SessionTrustedDevices{Name: "trusted", Lifespan: time.Hour},
+			testJWTSecret,
+			&schema.SessionTrustedDevices{Name: "trusted", Lifespan: time.Hour},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnSessionCookieName",
+			&schema.SessionTrustedDevices{Name: "authelia_session"},
+			testJWTSecret,
+			&schema.SessionTrustedDevices{Name: "authelia_session", Lifespan: time.Hour * 24 * 30},
+			[]string{
+				"session: trusted_devices: option 'name' must not be the same as the name of a session cookie but it's configured as 'authelia_session'",
+			},
+		},
+		{
+			"ShouldRaiseErrorOnMissingSecret",
+			&schema.SessionTrustedDevices{},
+			"",
+			&schema.SessionTrustedDevices{Name: "authelia_trusted_device", Lifespan: time.Hour * 24 * 30},
+			[]string{
+				"session: option 'secret' is required when the 'trusted_devices' option is configured",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+			config.Session.Secret = tc.secret
+			config.Session.TrustedDevices = tc.have
+
+			ValidateSession(&config, validator)
+
+			assert.False(t, validator.HasWarnings())
+			assert.Equal(t, tc.expected, config.Session.TrustedDevices)
+
+			errs := validator.Errors()
+			require.Len(t, errs, len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, errs[i], err)
+			}
+		})
+	}
+}
+
 func TestShouldRaiseErrorWhenSameSiteSetIncorrectly(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := newDefaultSessionConfig()
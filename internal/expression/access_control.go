@@ -0,0 +1,147 @@
+package expression
+
+import (
+	"fmt"
+	"time"
+
+	"cel.dev/cel-go/cel"
+	"cel.dev/cel-go/common/types/ref"
+	"cel.dev/cel-go/interpreter"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlEnv returns an *AccessControlEnv which compiles access control rule expressions. The user attributes
+// available to the expressions are determined by the configured authentication backend and user attribute definitions.
+func NewAccessControlEnv(config *schema.Configuration) (env *AccessControlEnv, err error) {
+	env = &AccessControlEnv{
+		resolver: &UserAttributes{},
+	}
+
+	opts := getUserAttributesCELEnvOpts(&config.AuthenticationBackend)
+
+	opts = append(opts,
+		cel.Variable(AttributeAccessControlRequest, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(AttributeAccessControlRemoteIP, cel.StringType),
+		cel.Variable(AttributeAccessControlClientID, cel.StringType),
+		cel.Variable(AttributeAccessControlNow, cel.TimestampType),
+	)
+
+	if len(config.Definitions.UserAttributes) != 0 {
+		for name := range config.Definitions.UserAttributes {
+			opts = append(opts, cel.Variable(name, cel.DynType))
+		}
+
+		env.resolver = NewUserAttributes(config)
+
+		if err = env.resolver.StartupCheck(); err != nil {
+			return nil, err
+		}
+	}
+
+	if env.env, err = cel.NewEnv(opts...); err != nil {
+		return nil, fmt.Errorf("failed to create common expression language environment: %w", err)
+	}
+
+	return env, nil
+}
+
+// AccessControlEnv is the common expression language environment used to compile access control rule expressions.
+type AccessControlEnv struct {
+	env      *cel.Env
+	resolver UserAttributeResolver
+}
+
+// Compile parses and checks the given expression and returns the resulting *AccessControlExpression.
+func (e *AccessControlEnv) Compile(expression string) (program *AccessControlExpression, err error) {
+	ast, issues := e.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to parse expression '%s': %w", expression, issues.Err())
+	}
+
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression '%s' must evaluate to a bool but it evaluates to a %s", expression, t)
+	}
+
+	program = &AccessControlExpression{
+		resolver: e.resolver,
+	}
+
+	if program.program, err = e.env.Program(ast); err != nil {
+		return nil, fmt.Errorf("failed to create expression program for '%s': %w", expression, err)
+	}
+
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if reference.Name != "" && !isAccessControlAttribute(reference.Name) {
+			program.subject = true
+
+			break
+		}
+	}
+
+	return program, nil
+}
+
+// AccessControlExpression is a compiled access control rule expression.
+type AccessControlExpression struct {
+	program  cel.Program
+	resolver UserAttributeResolver
+	subject  bool
+}
+
+// HasSubject returns true if the expression refers to any attribute of the user, meaning it can't be evaluated for
+// anonymous users.
+func (e *AccessControlExpression) HasSubject() bool {
+	return e.subject
+}
+
+// Evaluate returns the result of the expression given the details of the user and the values which describe the
+// request. The detailer may be nil if the user is anonymous.
+func (e *AccessControlExpression) Evaluate(detailer UserDetailer, values map[string]any) (match bool, err error) {
+	var (
+		activation interpreter.Activation
+		val        ref.Val
+		ok         bool
+	)
+
+	if detailer == nil {
+		activation = NewMapActivation(nil, values)
+	} else {
+		activation = &accessControlActivation{resolver: e.resolver, detailer: detailer, values: values}
+	}
+
+	if val, _, err = e.program.Eval(activation); err != nil {
+		return false, err
+	}
+
+	if match, ok = val.Value().(bool); !ok {
+		return false, fmt.Errorf("expression evaluated to a %s rather than a bool", val.Type().TypeName())
+	}
+
+	return match, nil
+}
+
+type accessControlActivation struct {
+	resolver UserAttributeResolver
+	detailer UserDetailer
+	values   map[string]any
+}
+
+// ResolveName returns the value of the named attribute.
+func (a *accessControlActivation) ResolveName(name string) (object any, found bool) {
+	return a.resolver.ResolveWithExtra(name, a.detailer, time.Time{}, a.values)
+}
+
+// Parent returns the parent activation.
+func (a *accessControlActivation) Parent() interpreter.Activation {
+	return nil
+}
+
+func isAccessControlAttribute(name string) bool {
+	switch name {
+	case AttributeAccessControlRequest, AttributeAccessControlRemoteIP, AttributeAccessControlClientID, AttributeAccessControlNow:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,171 @@
+package expression_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	. "github.com/authelia/authelia/v4/internal/expression"
+)
+
+func TestAccessControlExpression(t *testing.T) {
+	config := &schema.Configuration{
+		AuthenticationBackend: schema.AuthenticationBackend{
+			File: &schema.AuthenticationBackendFile{
+				ExtraAttributes: map[string]schema.AuthenticationBackendExtraAttribute{
+					"department": {
+						ValueType: "string",
+					},
+				},
+			},
+		},
+		Definitions: schema.Definitions{
+			UserAttributes: map[string]schema.UserAttribute{
+				"is_ops": {
+					Expression: "department == 'ops'",
+				},
+			},
+		},
+	}
+
+	env, err := NewAccessControlEnv(config)
+	require.NoError(t, err)
+
+	john := &authentication.UserDetailsExtended{
+		UserDetails: &authentication.UserDetails{Username: "john", Groups: []string{"admin", "dev"}},
+		Extra:       map[string]any{"department": "ops"},
+	}
+
+	harry := &authentication.UserDetailsExtended{
+		UserDetails: &authentication.UserDetails{Username: "harry", Groups: []string{"admin"}},
+		Extra:       map[string]any{"department": "sales"},
+	}
+
+	monday := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+	newValues := func(now time.Time, path string) map[string]any {
+		return map[string]any{
+			AttributeAccessControlRequest: map[string]any{
+				AttributeAccessControlRequestDomain:  "app.example.com",
+				AttributeAccessControlRequestPath:    path,
+				AttributeAccessControlRequestMethod:  "GET",
+				AttributeAccessControlRequestQuery:   map[string]string{"debug": "1"},
+				AttributeAccessControlRequestHeaders: map[string]string{"x-api-version": "2"},
+			},
+			AttributeAccessControlRemoteIP: "192.168.1.10",
+			AttributeAccessControlClientID: "",
+			AttributeAccessControlNow:      now,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		expression string
+		detailer   UserDetailer
+		values     map[string]any
+		subject    bool
+		expected   bool
+		err        string
+	}{
+		{
+			"ShouldMatchGroupAttributeAndWeekday",
+			"'admin' in groups && department == 'ops' && now.getDayOfWeek() in [1, 2, 3, 4, 5]",
+			john,
+			newValues(monday, "/"),
+			true,
+			true,
+			"",
+		},
+		{
+			"ShouldNotMatchWeekend",
+			"'admin' in groups && department == 'ops' && now.getDayOfWeek() in [1, 2, 3, 4, 5]",
+			john,
+			newValues(saturday, "/"),
+			true,
+			false,
+			"",
+		},
+		{
+			"ShouldNotMatchOtherDepartment",
+			"'admin' in groups && department == 'ops'",
+			harry,
+			newValues(monday, "/"),
+			true,
+			false,
+			"",
+		},
+		{
+			"ShouldMatchUserAttributeDefinition",
+			"is_ops && request.path.startsWith('/admin')",
+			john,
+			newValues(monday, "/admin/users"),
+			true,
+			true,
+			"",
+		},
+		{
+			"ShouldMatchRequestOnlyWithoutUser",
+			"request.method == 'GET' && request.query['debug'] == '1' && request.headers['x-api-version'] == '2' && remote_ip.startsWith('192.168.')",
+			nil,
+			newValues(monday, "/"),
+			false,
+			true,
+			"",
+		},
+		{
+			"ShouldErrorEvaluatingUserAttributeWithoutUser",
+			"username == 'john'",
+			nil,
+			newValues(monday, "/"),
+			true,
+			false,
+			"no such attribute(s): username",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := env.Compile(tc.expression)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.subject, program.HasSubject())
+
+			match, err := program.Evaluate(tc.detailer, tc.values)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, match)
+		})
+	}
+}
+
+func TestAccessControlExpressionCompileErrors(t *testing.T) {
+	env, err := NewAccessControlEnv(&schema.Configuration{})
+	require.NoError(t, err)
+
+	_, err = env.Compile("department == 'ops'")
+	assert.ErrorContains(t, err, "failed to parse expression 'department == 'ops'': ERROR: <input>:1:1: undeclared reference to 'department'")
+
+	_, err = env.Compile("remote_ip + '/'")
+	assert.EqualError(t, err, "expression 'remote_ip + '/'' must evaluate to a bool but it evaluates to a string")
+
+	_, err = NewAccessControlEnv(&schema.Configuration{
+		Definitions: schema.Definitions{
+			UserAttributes: map[string]schema.UserAttribute{
+				"is_ops": {
+					Expression: "department == 'ops'",
+				},
+			},
+		},
+	})
+	assert.EqualError(t, err, "error reading config: no authentication backend configured")
+}
@@ -34,3 +34,20 @@ const (
 	AttributeOpenIDAuthorizationRequestClaimValue  = "openid_authreq_claim_value"
 	AttributeOpenIDAuthorizationRequestClaimValues = "openid_authreq_claim_values"
 )
+
+// Access control expression variable name strings.
+const (
+	AttributeAccessControlRequest  = "request"
+	AttributeAccessControlRemoteIP = "remote_ip"
+	AttributeAccessControlClientID = "client_id"
+	AttributeAccessControlNow      = "now"
+)
+
+// Access control expression request field name strings.
+const (
+	AttributeAccessControlRequestDomain  = "domain"
+	AttributeAccessControlRequestPath    = "path"
+	AttributeAccessControlRequestMethod  = "method"
+	AttributeAccessControlRequestQuery   = "query"
+	AttributeAccessControlRequestHeaders = "headers"
+)
@@ -42,10 +42,8 @@ func (e *UserAttributesExpressions) StartupCheck() (err error) {
 	switch {
 	case e.config == nil:
 		err = fmt.Errorf("error reading config: no authentication backend configured")
-	case e.config.AuthenticationBackend.LDAP != nil:
-		err = e.ldapStartupCheck()
-	case e.config.AuthenticationBackend.File != nil:
-		err = e.fileStartupCheck()
+	case e.config.AuthenticationBackend.LDAP != nil, e.config.AuthenticationBackend.File != nil, e.config.AuthenticationBackend.SQL != nil:
+		err = e.setup(getUserAttributesCELEnvOpts(&e.config.AuthenticationBackend)...)
 	default:
 		err = fmt.Errorf("error reading config: no authentication backend configured")
 	}
@@ -59,122 +57,6 @@ func (e *UserAttributesExpressions) StartupCheck() (err error) {
 	return nil
 }
 
-//nolint:gocyclo
-func (e *UserAttributesExpressions) ldapStartupCheck() (err error) {
-	opts := withBaseCELEnvOpts(
-		newAttributeUserUsername(),
-		newAttributeUserGroups(),
-		newAttributeUserDisplayName(),
-		newAttributeUserEmail(),
-		newAttributeUserEmailVerified(),
-		newAttributeUserEmails(),
-		newAttributeUserEmailsExtra(),
-		newAttributeUpdatedAt(),
-	)
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.GivenName != "" {
-		opts = append(opts, newAttributeUserGivenName())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.MiddleName != "" {
-		opts = append(opts, newAttributeUserMiddleName())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.FamilyName != "" {
-		opts = append(opts, newAttributeUserFamilyName())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Nickname != "" {
-		opts = append(opts, newAttributeUserNickname())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Profile != "" {
-		opts = append(opts, newAttributeUserProfile())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Picture != "" {
-		opts = append(opts, newAttributeUserPicture())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Website != "" {
-		opts = append(opts, newAttributeUserWebsite())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Gender != "" {
-		opts = append(opts, newAttributeUserGender())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Birthdate != "" {
-		opts = append(opts, newAttributeUserBirthdate())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.ZoneInfo != "" {
-		opts = append(opts, newAttributeUserZoneInfo())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Locale != "" {
-		opts = append(opts, newAttributeUserLocale())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.PhoneNumber != "" {
-		opts = append(opts, newAttributeUserPhoneNumber(), newAttributeUserPhoneNumberVerified())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.PhoneExtension != "" {
-		opts = append(opts, newAttributeUserPhoneExtension())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.PhoneNumber != "" {
-		opts = append(opts, newAttributeUserPhoneNumberRFC3966())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.StreetAddress != "" ||
-		e.config.AuthenticationBackend.LDAP.Attributes.Locality != "" ||
-		e.config.AuthenticationBackend.LDAP.Attributes.Region != "" ||
-		e.config.AuthenticationBackend.LDAP.Attributes.PostalCode != "" ||
-		e.config.AuthenticationBackend.LDAP.Attributes.Country != "" {
-		opts = append(opts, newAttributeUserAddress())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.StreetAddress != "" {
-		opts = append(opts, newAttributeUserStreetAddress())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Locality != "" {
-		opts = append(opts, newAttributeUserLocality())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Region != "" {
-		opts = append(opts, newAttributeUserRegion())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.PostalCode != "" {
-		opts = append(opts, newAttributeUserPostalCode())
-	}
-
-	if e.config.AuthenticationBackend.LDAP.Attributes.Country != "" {
-		opts = append(opts, newAttributeUserCountry())
-	}
-
-	for attribute, properties := range e.config.AuthenticationBackend.LDAP.Attributes.Extra {
-		opts = append(opts, optExtra(attribute, properties))
-	}
-
-	opts = append(opts, newAttributeOAuth2AuthorizationRequestClaimValue(), newAttributeOAuth2AuthorizationRequestClaimValues())
-
-	return e.setup(opts...)
-}
-
-func (e *UserAttributesExpressions) fileStartupCheck() (err error) {
-	opts := getStandardCELEnvOpts()
-
-	for attribute, properties := range e.config.AuthenticationBackend.File.ExtraAttributes {
-		opts = append(opts, optExtra(attribute, properties))
-	}
-
-	return e.setup(opts...)
-}
-
 func (e *UserAttributesExpressions) setup(opts ...cel.EnvOption) (err error) {
 	if e.env, err = cel.NewEnv(opts...); err != nil {
 		return fmt.Errorf("failed to create common expression language environment: %w", err)
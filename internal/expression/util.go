@@ -5,6 +5,8 @@ import (
 	"cel.dev/cel-go/common/types"
 	"cel.dev/cel-go/common/types/ref"
 	"cel.dev/cel-go/ext"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
 func optExtra(name string, attribute ExtraAttribute) (opt cel.EnvOption) {
@@ -162,7 +164,9 @@ func IsReservedAttribute(key string) bool {
 		AttributeUserPhoneNumber, AttributeUserPhoneNumberRFC3966, AttributeUserPhoneExtension,
 		AttributeUserPhoneNumberVerified, AttributeUserAddress, AttributeUserStreetAddress, AttributeUserLocality,
 		AttributeUserRegion, AttributeUserPostalCode, AttributeUserCountry, AttributeUserUpdatedAt,
-		AttributeOpenIDAuthorizationRequestClaimValue, AttributeOpenIDAuthorizationRequestClaimValues:
+		AttributeOpenIDAuthorizationRequestClaimValue, AttributeOpenIDAuthorizationRequestClaimValues,
+		AttributeAccessControlRequest, AttributeAccessControlRemoteIP, AttributeAccessControlClientID,
+		AttributeAccessControlNow:
 		return true
 	default:
 		return false
@@ -264,3 +268,126 @@ func getStandardCELEnvOpts() []cel.EnvOption {
 		newAttributeOAuth2AuthorizationRequestClaimValues(),
 	)
 }
+
+func getUserAttributesCELEnvOpts(config *schema.AuthenticationBackend) (opts []cel.EnvOption) {
+	switch {
+	case config.LDAP != nil:
+		return getLDAPCELEnvOpts(config.LDAP)
+	case config.File != nil:
+		opts = getStandardCELEnvOpts()
+
+		for attribute, properties := range config.File.ExtraAttributes {
+			opts = append(opts, optExtra(attribute, properties))
+		}
+
+		return opts
+	default:
+		return getStandardCELEnvOpts()
+	}
+}
+
+//nolint:gocyclo
+func getLDAPCELEnvOpts(config *schema.AuthenticationBackendLDAP) (opts []cel.EnvOption) {
+	opts = withBaseCELEnvOpts(
+		newAttributeUserUsername(),
+		newAttributeUserGroups(),
+		newAttributeUserDisplayName(),
+		newAttributeUserEmail(),
+		newAttributeUserEmailVerified(),
+		newAttributeUserEmails(),
+		newAttributeUserEmailsExtra(),
+		newAttributeUpdatedAt(),
+	)
+
+	if config.Attributes.GivenName != "" {
+		opts = append(opts, newAttributeUserGivenName())
+	}
+
+	if config.Attributes.MiddleName != "" {
+		opts = append(opts, newAttributeUserMiddleName())
+	}
+
+	if config.Attributes.FamilyName != "" {
+		opts = append(opts, newAttributeUserFamilyName())
+	}
+
+	if config.Attributes.Nickname != "" {
+		opts = append(opts, newAttributeUserNickname())
+	}
+
+	if config.Attributes.Profile != "" {
+		opts = append(opts, newAttributeUserProfile())
+	}
+
+	if config.Attributes.Picture != "" {
+		opts = append(opts, newAttributeUserPicture())
+	}
+
+	if config.Attributes.Website != "" {
+		opts = append(opts, newAttributeUserWebsite())
+	}
+
+	if config.Attributes.Gender != "" {
+		opts = append(opts, newAttributeUserGender())
+	}
+
+	if config.Attributes.Birthdate != "" {
+		opts = append(opts, newAttributeUserBirthdate())
+	}
+
+	if config.Attributes.ZoneInfo != "" {
+		opts = append(opts, newAttributeUserZoneInfo())
+	}
+
+	if config.Attributes.Locale != "" {
+		opts = append(opts, newAttributeUserLocale())
+	}
+
+	if config.Attributes.PhoneNumber != "" {
+		opts = append(opts, newAttributeUserPhoneNumber(), newAttributeUserPhoneNumberVerified())
+	}
+
+	if config.Attributes.PhoneExtension != "" {
+		opts = append(opts, newAttributeUserPhoneExtension())
+	}
+
+	if config.Attributes.PhoneNumber != "" {
+		opts = append(opts, newAttributeUserPhoneNumberRFC3966())
+	}
+
+	if config.Attributes.StreetAddress != "" ||
+		config.Attributes.Locality != "" ||
+		config.Attributes.Region != "" ||
+		config.Attributes.PostalCode != "" ||
+		config.Attributes.Country != "" {
+		opts = append(opts, newAttributeUserAddress())
+	}
+
+	if config.Attributes.StreetAddress != "" {
+		opts = append(opts, newAttributeUserStreetAddress())
+	}
+
+	if config.Attributes.Locality != "" {
+		opts = append(opts, newAttributeUserLocality())
+	}
+
+	if config.Attributes.Region != "" {
+		opts = append(opts, newAttributeUserRegion())
+	}
+
+	if config.Attributes.PostalCode != "" {
+		opts = append(opts, newAttributeUserPostalCode())
+	}
+
+	if config.Attributes.Country != "" {
+		opts = append(opts, newAttributeUserCountry())
+	}
+
+	for attribute, properties := range config.Attributes.Extra {
+		opts = append(opts, optExtra(attribute, properties))
+	}
+
+	opts = append(opts, newAttributeOAuth2AuthorizationRequestClaimValue(), newAttributeOAuth2AuthorizationRequestClaimValues())
+
+	return opts
+}
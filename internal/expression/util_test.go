@@ -94,6 +94,10 @@ func TestIsReservedAttribute(t *testing.T) {
 		{"ShouldReturnTrueForUpdatedAt", AttributeUserUpdatedAt, true},
 		{"ShouldReturnTrueForClaimValue", AttributeOpenIDAuthorizationRequestClaimValue, true},
 		{"ShouldReturnTrueForClaimValues", AttributeOpenIDAuthorizationRequestClaimValues, true},
+		{"ShouldReturnTrueForAccessControlRequest", AttributeAccessControlRequest, true},
+		{"ShouldReturnTrueForAccessControlRemoteIP", AttributeAccessControlRemoteIP, true},
+		{"ShouldReturnTrueForAccessControlClientID", AttributeAccessControlClientID, true},
+		{"ShouldReturnTrueForAccessControlNow", AttributeAccessControlNow, true},
 		{"ShouldReturnFalseForCustomAttribute", "custom_attr", false},
 		{"ShouldReturnFalseForEmptyString", "", false},
 		{"ShouldReturnFalseForUnknownAttribute", "unknown", false},
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
@@ -17,6 +18,16 @@ const (
 	anonymous = "<anonymous>"
 )
 
+const (
+	// trustedDeviceSigningKeyInfo is the HKDF info used to derive the trusted device cookie signing key from the
+	// session secret.
+	trustedDeviceSigningKeyInfo = "trusted-device"
+
+	// trustedDeviceRefreshInterval is the interval the authorization endpoints check a trusted device is still trusted
+	// when the authentication backend refresh interval is disabled.
+	trustedDeviceRefreshInterval = time.Minute * 5
+)
+
 var (
 	headerAuthorization   = []byte(fasthttp.HeaderAuthorization)
 	headerWWWAuthenticate = []byte(fasthttp.HeaderWWWAuthenticate)
@@ -42,6 +53,8 @@ var (
 const (
 	queryArgRD        = "rd"
 	queryArgRM        = "rm"
+	queryArgAMR       = "amr"
+	queryArgMaxAge    = "max_age"
 	queryArgAuth      = "auth"
 	queryArgConsentID = "consent_id"
 	queryArgFlow      = "flow"
@@ -76,6 +89,11 @@ const (
 	messageIncorrectPassword                     = "Incorrect Password"
 	messageMFAValidationFailed                   = "Authentication failed, please retry later."
 	messagePasswordWeak                          = "Your supplied password does not meet the password policy requirements."
+	messagePasswordReused                        = "Your supplied password has been used recently."
+	messagePasswordBreached                      = "Your supplied password has appeared in a data breach."
+	messagePasswordTooShort                      = "Your supplied password is too short."
+	messagePasswordTooYoung                      = "Your password was changed too recently to be changed again."
+	messagePasswordExpired                       = "Your password has expired."
 )
 
 const (
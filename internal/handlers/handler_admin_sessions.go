@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// AdminSessionsGET lists the active sessions of the user provided in the path on behalf of an administrator.
+func AdminSessionsGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		provider *session.Session
+		username string
+		records  []session.UserSessionRecord
+		err      error
+	)
+
+	if provider, username, err = getAdminSessionsProvider(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred listing sessions on behalf of an administrator")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	if records, err = provider.UserSessionIndex().List(username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing sessions for user '%s' on behalf of an administrator", username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	current := provider.GetSessionID(ctx.RequestCtx)
+
+	response := make([]bodyGETUserSession, len(records))
+
+	for i, record := range records {
+		response[i] = bodyGETUserSession{
+			ID:           record.PublicID(),
+			CookieDomain: record.CookieDomain,
+			RemoteIP:     record.RemoteIP,
+			UserAgent:    record.UserAgent,
+			Created:      record.Created,
+			LastActivity: record.LastActivity,
+			AMR:          record.AMR,
+			Current:      record.ID == current,
+		}
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing sessions for user '%s' on behalf of an administrator: %s", username, errStrRespBody)
+	}
+}
+
+// AdminSessionsDELETE revokes every session of the user provided in the path on behalf of an administrator, including
+// the OpenID Connect 1.0 access and refresh tokens issued to the user when OpenID Connect 1.0 is configured. The current
+// session of the administrator is never revoked.
+func AdminSessionsDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		provider *session.Session
+		username string
+		revoked  []session.UserSessionRecord
+		err      error
+	)
+
+	if provider, username, err = getAdminSessionsProvider(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred revoking sessions on behalf of an administrator")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	current := provider.GetSessionID(ctx.RequestCtx)
+
+	revoked, err = provider.UserSessionIndex().Revoke(username, func(record session.UserSessionRecord) bool {
+		return record.ID != current
+	})
+
+	for _, record := range revoked {
+		ctx.Logger.WithFields(map[string]any{"username": username, "session": record.PublicID(), "remote_ip": record.RemoteIP}).
+			Info("User session was revoked by an administrator")
+	}
+
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking sessions for user '%s' on behalf of an administrator", username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	response := bodyDELETEAdminSessions{Revoked: len(revoked)}
+
+	if ctx.Configuration.IdentityProviders.OIDC != nil {
+		if response.RevokedOAuth2, err = oidc.RevokeUserOAuth2Sessions(ctx, ctx.Providers.StorageProvider, username); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking OpenID Connect 1.0 sessions for user '%s' on behalf of an administrator", username)
+
+			ctx.SetJSONError(messageOperationFailed)
+
+			return
+		}
+
+		if response.RevokedOAuth2 != 0 {
+			ctx.Logger.WithFields(map[string]any{"username": username, "revoked": response.RevokedOAuth2}).
+				Info("OpenID Connect 1.0 sessions of the user were revoked by an administrator")
+		}
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking sessions for user '%s' on behalf of an administrator: %s", username, errStrRespBody)
+	}
+}
+
+func getAdminSessionsProvider(ctx *middlewares.AutheliaCtx) (provider *session.Session, username string, err error) {
+	var userSession session.UserSession
+
+	if provider, err = ctx.GetSessionProvider(); err != nil {
+		return nil, "", err
+	}
+
+	if userSession, err = provider.GetSession(ctx.RequestCtx); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", errStrUserSessionData, err)
+	}
+
+	if userSession.IsAnonymous() {
+		return nil, "", errUserAnonymous
+	}
+
+	if userSession.AuthenticationLevel(ctx.Configuration.WebAuthn.EnablePasskey2FA) < authentication.TwoFactor {
+		return nil, "", fmt.Errorf("user '%s' has not performed two-factor authentication", userSession.Username)
+	}
+
+	if !isSessionAdministrator(ctx, userSession) {
+		return nil, "", fmt.Errorf("user '%s' is not a session administrator", userSession.Username)
+	}
+
+	if username, _ = ctx.UserValue("username").(string); username == "" {
+		return nil, "", fmt.Errorf("the username was not provided")
+	}
+
+	if provider.UserSessionIndex() == nil {
+		return nil, "", fmt.Errorf("the session provider does not have a user session index")
+	}
+
+	return provider, username, nil
+}
+
+func isSessionAdministrator(ctx *middlewares.AutheliaCtx, userSession session.UserSession) bool {
+	rules := make([][]string, len(ctx.Configuration.Session.Administrators))
+
+	for i, administrator := range ctx.Configuration.Session.Administrators {
+		rules[i] = []string{administrator}
+	}
+
+	subject := authorization.Subject{Username: userSession.Username, Groups: userSession.Groups}
+
+	for _, subjects := range authorization.NewSubjects(rules) {
+		if subjects.IsMatch(subject) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func setupAdminSessionsTest(t *testing.T, mock *mocks.MockAutheliaCtx, secondFactor bool) (provider *session.Session) {
+	var err error
+
+	provider, err = mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	mock.Ctx.Configuration.Session.Administrators = []string{"group:admins"}
+
+	userSession := provider.NewDefaultUserSession()
+	userSession.Username = "harry"
+	userSession.Groups = []string{"admins"}
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = secondFactor
+
+	require.NoError(t, provider.SaveSession(mock.Ctx.RequestCtx, userSession))
+
+	for _, agent := range []string{"One/1.0", "Two/1.0"} {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetUserAgent(agent)
+
+		userSession = provider.NewDefaultUserSession()
+		userSession.Username = testUsername
+		userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+
+		require.NoError(t, provider.SaveSession(ctx, userSession))
+	}
+
+	mock.Ctx.SetUserValue("username", testUsername)
+
+	return provider
+}
+
+func TestAdminSessionsGET(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	setupAdminSessionsTest(t, mock, true)
+
+	AdminSessionsGET(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	var response struct {
+		Status string               `json:"status"`
+		Data   []bodyGETUserSession `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), &response))
+	require.Len(t, response.Data, 2)
+
+	for _, s := range response.Data {
+		assert.False(t, s.Current)
+		assert.Equal(t, []string{"pwd"}, s.AMR)
+	}
+}
+
+func TestAdminSessionsDELETE(t *testing.T) {
+	testCases := []struct {
+		name     string
+		oidc     bool
+		setup    func(mock *mocks.MockAutheliaCtx)
+		expected bodyDELETEAdminSessions
+	}{
+		{
+			"ShouldRevokeSessions",
+			false,
+			nil,
+			bodyDELETEAdminSessions{Revoked: 2},
+		},
+		{
+			"ShouldRevokeSessionsAndOpenIDConnectSessions",
+			true,
+			func(mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadOAuth2SessionSignaturesByUsername(mock.Ctx, storage.OAuth2SessionTypeRefreshToken, testUsername).Return([]string{"r1"}, nil),
+					mock.StorageMock.EXPECT().RevokeOAuth2Session(mock.Ctx, storage.OAuth2SessionTypeRefreshToken, "r1").Return(nil),
+					mock.StorageMock.EXPECT().LoadOAuth2SessionSignaturesByUsername(mock.Ctx, storage.OAuth2SessionTypeAccessToken, testUsername).Return([]string{"a1", "a2"}, nil),
+					mock.StorageMock.EXPECT().RevokeOAuth2Session(mock.Ctx, storage.OAuth2SessionTypeAccessToken, "a1").Return(nil),
+					mock.StorageMock.EXPECT().RevokeOAuth2Session(mock.Ctx, storage.OAuth2SessionTypeAccessToken, "a2").Return(nil),
+				)
+			},
+			bodyDELETEAdminSessions{Revoked: 2, RevokedOAuth2: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			provider := setupAdminSessionsTest(t, mock, true)
+
+			if tc.oidc {
+				mock.Ctx.Configuration.IdentityProviders.OIDC = &schema.IdentityProvidersOpenIDConnect{}
+			}
+
+			if tc.setup != nil {
+				tc.setup(mock)
+			}
+
+			AdminSessionsDELETE(mock.Ctx)
+
+			mock.Assert200OK(t, tc.expected)
+
+			records, err := provider.UserSessionIndex().List(testUsername)
+			require.NoError(t, err)
+			assert.Len(t, records, 0)
+
+			records, err = provider.UserSessionIndex().List("harry")
+			require.NoError(t, err)
+			assert.Len(t, records, 1)
+		})
+	}
+}
+
+func TestAdminSessionsShouldFailUnauthorized(t *testing.T) {
+	testCases := []struct {
+		name           string
+		secondFactor   bool
+		administrators []string
+		expected       string
+	}{
+		{
+			"ShouldFailOneFactor",
+			false,
+			[]string{"group:admins"},
+			"user 'harry' has not performed two-factor authentication",
+		},
+		{
+			"ShouldFailNotAdministrator",
+			true,
+			[]string{"user:jane", "group:dev"},
+			"user 'harry' is not a session administrator",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			provider := setupAdminSessionsTest(t, mock, tc.secondFactor)
+
+			mock.Ctx.Configuration.Session.Administrators = tc.administrators
+
+			AdminSessionsDELETE(mock.Ctx)
+
+			mock.Assert403KO(t, messageOperationFailed)
+			mock.AssertLastLogMessage(t, "Error occurred revoking sessions on behalf of an administrator", tc.expected)
+
+			records, err := provider.UserSessionIndex().List(testUsername)
+			require.NoError(t, err)
+			assert.Len(t, records, 2)
+		})
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -98,6 +102,12 @@ type AuthzContext interface {
 	// GetRequestHeaderValue returns the value of the header with the given key.
 	GetRequestHeaderValue(key []byte) (value []byte)
 
+	// GetRequestHeaders should return a copy of the request headers.
+	GetRequestHeaders() (headers http.Header)
+
+	// GetResponseStatusCode should return the status code of the response.
+	GetResponseStatusCode() (statusCode int)
+
 	// SetResponseHeaderValue should set the value of the header with the given key.
 	SetResponseHeaderValue(key []byte, value string)
 
@@ -138,6 +148,9 @@ type AuthzContext interface {
 	// RemoteIP Should return the remote IP of the request.
 	RemoteIP() net.IP
 
+	// UserAgent should return the User-Agent header of the request.
+	UserAgent() (userAgent []byte)
+
 	// GetSessionProviderByTargetURI should return the session provider for the target URL.
 	GetSessionProviderByTargetURI(targetURL *url.URL) (provider *session.Session, err error)
 }
@@ -193,15 +206,22 @@ func (authz *Authz) Handler(ctx AuthzContext) {
 	authn.Object = object
 	authn.Method = friendlyMethod(authn.Object.Method)
 
-	ruleHasSubject, required := ctx.GetProviders().Authorizer.GetRequiredLevel(
-		authorization.Subject{
-			Username: authn.Details.Username,
-			Groups:   authn.Details.Groups,
-			ClientID: authn.ClientID,
-			IP:       ctx.RemoteIP(),
-		},
-		object,
-	)
+	subject := authorization.Subject{
+		Username: authn.Details.Username,
+		Groups:   authn.Details.Groups,
+		ClientID: authn.ClientID,
+		IP:       ctx.RemoteIP(),
+	}
+
+	authz.handleGetSubjectDetails(ctx, authn, &subject, &object)
+
+	rule, required := ctx.GetProviders().Authorizer.GetRequiredRule(subject, object)
+
+	ruleHasSubject := rule != nil && rule.HasSubjects
+
+	defer authz.handleAuditLog(ctx, subject, object, rule, required)
+
+	stepUp := authz.getStepUp(ctx, authn, rule)
 
 	if err != nil {
 		authn.Object = object
@@ -213,7 +233,7 @@ func (authz *Authz) Handler(ctx AuthzContext) {
 			case strategy.HeaderStrategy():
 				ctx.GetLogger().WithError(err).Error("Error occurred while attempting to authenticate a request")
 
-				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
+				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL, stepUp))
 
 				return
 			}
@@ -222,7 +242,20 @@ func (authz *Authz) Handler(ctx AuthzContext) {
 		ctx.GetLogger().WithError(err).Debug("Error occurred while attempting to authenticate a request but the matched rule was a bypass rule")
 	}
 
-	switch isAuthzResult(authn.Level, required, ruleHasSubject) {
+	result := isAuthzResult(authz.getLevel(authn, rule, required), required, ruleHasSubject)
+
+	if result == AuthzResultAuthorized && stepUp.IsRequired() {
+		switch {
+		case len(stepUp.RequiredAMR) != 0:
+			ctx.GetLogger().Infof("Access to '%s' by user '%s' requires one of the authentication methods %s which have not been performed", object.URL.String(), authn.Username, utils.StringJoinOr(stepUp.RequiredAMR))
+		default:
+			ctx.GetLogger().Infof("Access to '%s' by user '%s' requires authentication within the last %s but they last authenticated at %s", object.URL.String(), authn.Username, stepUp.MaxAge, authn.AuthenticatedAt)
+		}
+
+		result = AuthzResultUnauthorized
+	}
+
+	switch result {
 	case AuthzResultForbidden:
 		ctx.GetLogger().Infof("Access to '%s' is forbidden to user '%s'", object.URL.String(), authn.Username)
 		ctx.ReplyForbidden()
@@ -235,7 +268,7 @@ func (authz *Authz) Handler(ctx AuthzContext) {
 			handler = authz.handleUnauthorized
 		}
 
-		handler(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
+		handler(ctx, authn, authz.getRedirectionURL(&object, autheliaURL, stepUp))
 	case AuthzResultAuthorized:
 		authz.handleAuthorized(ctx, authn)
 	}
@@ -262,7 +295,7 @@ func (authz *Authz) getAutheliaURL(ctx AuthzContext, manager session.Manager) (a
 	return nil, fmt.Errorf("authelia url lookup failed")
 }
 
-func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL *url.URL) (redirectionURL *url.URL) {
+func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL *url.URL, stepUp AuthzStepUp) (redirectionURL *url.URL) {
 	if autheliaURL == nil {
 		return nil
 	}
@@ -281,11 +314,99 @@ func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL
 		qry.Set(queryArgRM, object.Method)
 	}
 
+	if len(stepUp.RequiredAMR) != 0 {
+		qry.Set(queryArgAMR, strings.Join(stepUp.RequiredAMR, ","))
+	}
+
+	if stepUp.MaxAge != 0 {
+		qry.Set(queryArgMaxAge, strconv.Itoa(int(stepUp.MaxAge.Seconds())))
+	}
+
 	redirectionURL.RawQuery = qry.Encode()
 
 	return redirectionURL
 }
 
+// getLevel returns the authentication level of the user for the purpose of the authorization result. Completing the
+// first factor from a trusted device satisfies the two_factor policy unless the matched rule disables trusted devices.
+func (authz *Authz) getLevel(authn *Authn, rule *authorization.AccessControlRule, required authorization.Level) (level authentication.Level) {
+	if authn.TrustedDevice && authn.Level == authentication.OneFactor && required == authorization.TwoFactor && rule.AllowsTrustedDevices() {
+		return authentication.TwoFactor
+	}
+
+	return authn.Level
+}
+
+// getStepUp returns the requirements of the matched rule which the user has not met. The maximum authentication age is
+// only applicable to the cookie session strategy as the header strategies authenticate every request.
+func (authz *Authz) getStepUp(ctx AuthzContext, authn *Authn, rule *authorization.AccessControlRule) (stepUp AuthzStepUp) {
+	if rule == nil {
+		return stepUp
+	}
+
+	if !rule.RequiredAMR.IsMet(authn.AMR) {
+		stepUp.RequiredAMR = rule.RequiredAMR
+	}
+
+	if rule.MaxAge != 0 && authn.Type == AuthnTypeCookie && authn.Level != authentication.NotAuthenticated &&
+		ctx.GetClock().Now().Sub(authn.AuthenticatedAt) > rule.MaxAge {
+		stepUp.MaxAge = rule.MaxAge
+	}
+
+	return stepUp
+}
+
+// handleGetSubjectDetails populates the request headers and user details required to evaluate access control rules
+// which have an expression. Failure to retrieve the user details results in them being omitted which means any
+// expression which depends on them can't match.
+func (authz *Authz) handleGetSubjectDetails(ctx AuthzContext, authn *Authn, subject *authorization.Subject, object *authorization.Object) {
+	authorizer := ctx.GetProviders().Authorizer
+
+	if authorizer.IsRequestHeadersRequired() {
+		object.Header = ctx.GetRequestHeaders()
+	}
+
+	if !authorizer.IsUserDetailsRequired() || authn.Details.Username == "" {
+		return
+	}
+
+	username := authn.Details.Username
+
+	// The details are retrieved at most once per request and only when a rule which refers to them is evaluated.
+	subject.DetailsFunc = sync.OnceValue(func() expression.UserDetailer {
+		details, err := ctx.GetUserProvider().GetDetailsExtended(username)
+		if err != nil {
+			ctx.GetLogger().WithError(err).WithField("username", username).Error("Error occurred retrieving user details for access control rule expressions")
+
+			return nil
+		}
+
+		return details
+	})
+}
+
+// handleAuditLog writes the decision to the access control decision audit log if it's enabled. It must only be called
+// once the response status code has been set.
+func (authz *Authz) handleAuditLog(ctx AuthzContext, subject authorization.Subject, object authorization.Object, rule *authorization.AccessControlRule, level authorization.Level) {
+	auditor := ctx.GetProviders().AuditLogger
+
+	if auditor == nil {
+		return
+	}
+
+	var position int
+
+	if rule != nil {
+		position = rule.Position
+	}
+
+	decision := authorization.NewAuditDecision(ctx.GetClock().Now(), subject, object, position, level, ctx.GetResponseStatusCode())
+
+	if err := auditor.Log(decision); err != nil {
+		ctx.GetLogger().WithError(err).Error("Error occurred writing to the access control decision audit log")
+	}
+}
+
 func (authz *Authz) authn(ctx AuthzContext, manager session.Manager, object *authorization.Object) (authn *Authn, strategy AuthnStrategy, err error) {
 	for _, strategy = range authz.strategies {
 		if authn, err = strategy.Get(ctx, manager, object); err != nil {
@@ -294,6 +415,7 @@ func (authz *Authz) authn(ctx AuthzContext, manager session.Manager, object *aut
 			authn.Username = anonymous
 			authn.ClientID = ""
 			authn.Details = authentication.UserDetails{}
+			authn.AMR = authorization.AuthenticationMethodsReferences{}
 
 			if strategy.CanHandleUnauthorized() {
 				return authn, strategy, err
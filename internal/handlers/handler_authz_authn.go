@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/url"
@@ -147,7 +148,11 @@ func (s *CookieSessionAuthnStrategy) Get(ctx AuthzContext, manager session.Manag
 			Groups:      userSession.Groups,
 		},
 		Level: userSession.AuthenticationLevel(ctx.GetConfiguration().WebAuthn.EnablePasskey2FA),
+		AMR:   userSession.AuthenticationMethodRefs,
 		Type:  AuthnTypeCookie,
+
+		AuthenticatedAt: userSession.LastAuthenticatedTime(),
+		TrustedDevice:   userSession.IsTrustedDevice(),
 	}, nil
 }
 
@@ -418,7 +423,10 @@ func handleGetBasic(ctx AuthzContext, delayer middlewares.Delayer, authn *Authn,
 	}
 
 	if details, err = ctx.GetUserProvider().GetDetails(username); err != nil {
-		if errors.Is(err, authentication.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, authentication.ErrUserDisabled):
+			doMarkAuthenticationAttemptWithRequest(ctx, false, regulation.NewBan(regulation.BanTypeNone, username, nil), regulation.AuthType1FA, object.String(), object.Method, err)
+		case errors.Is(err, authentication.ErrUserNotFound):
 			doMarkAuthenticationAttemptWithRequest(ctx, false, regulation.NewBan(regulation.BanTypeUnknown, "", nil), regulation.AuthType1FA, object.String(), object.Method, err)
 
 			ctx.GetLogger().WithField("username", username).Error("Error occurred while attempting to get user details for user: the user was not found indicating they were deleted, disabled, or otherwise no longer authorized to login")
@@ -465,6 +473,12 @@ func handleGetBasic(ctx AuthzContext, delayer middlewares.Delayer, authn *Authn,
 		doMarkAuthenticationAttemptWithRequest(ctx, true, regulation.NewBan(regulation.BanTypeNone, details.Username, nil), regulation.AuthType1FA, object.String(), object.Method, nil)
 	}
 
+	if details.IsPasswordChangeRequired(ctx.GetClock().Now(), ctx.GetConfiguration().AuthenticationBackend.PasswordChange.MaxAge) {
+		return nil, authentication.NotAuthenticated, fmt.Errorf("failed to validate parsed credentials of %s header valid for user '%s': the user is required to change their password", header, details.Username)
+	}
+
+	authn.AMR = authorization.AuthenticationMethodsReferences{KnowledgeBasedAuthentication: true, UsernameAndPassword: true}
+
 	return details, authentication.OneFactor, nil
 }
 
@@ -478,6 +492,12 @@ func handleAuthnCookieValidate(ctx AuthzContext, manager session.Manager, userSe
 		return modified, true
 	}
 
+	// A session which doesn't match the client it's bound to has already been destroyed or reset by the time the
+	// binding is validated so the reset session is used for the remainder of the request.
+	if handleAuthnCookieValidateBinding(ctx, manager, userSession) {
+		return false, false
+	}
+
 	if invalid = handleAuthnCookieValidateInactivity(ctx, manager, userSession, isAnonymous); invalid {
 		ctx.GetLogger().WithField("username", userSession.Username).Info("Session for user not marked as remembered has exceeded configured session inactivity")
 
@@ -488,6 +508,10 @@ func handleAuthnCookieValidate(ctx AuthzContext, manager session.Manager, userSe
 		return modified, true
 	}
 
+	if handleAuthnCookieValidateTrustedDevice(ctx, userSession, refresh) {
+		modified = true
+	}
+
 	if username := ctx.GetRequestHeaderValue(headerSessionUsername); username != nil && !strings.EqualFold(string(username), userSession.Username) {
 		ctx.GetLogger().WithField("username", userSession.Username).Warnf("Session for user does not match the Session-Username header with value '%s' which could be a sign of a cookie hijack", username)
 
@@ -503,6 +527,71 @@ func handleAuthnCookieValidate(ctx AuthzContext, manager session.Manager, userSe
 	return modified, false
 }
 
+func handleAuthnCookieValidateBinding(ctx AuthzContext, manager session.Manager, userSession *session.UserSession) (mismatch bool) {
+	config := ctx.GetConfiguration().Session.Binding
+
+	if config == nil {
+		return false
+	}
+
+	var (
+		binding  = session.NewBinding(config, ctx.RemoteIP(), ctx.UserAgent())
+		original = *userSession
+		err      error
+	)
+
+	if *userSession, mismatch, err = session.ValidateBinding(manager, config, original, binding); err != nil {
+		ctx.GetLogger().WithError(err).Error("Error occurred trying to validate the session binding")
+	}
+
+	if mismatch {
+		middlewares.HandleSessionBindingMismatch(ctx, original, binding)
+	}
+
+	return mismatch
+}
+
+// handleAuthnCookieValidateTrustedDevice checks the device the session was established from is still trusted at the
+// refresh interval, or every request if the refresh interval is always, so revoking a device stops sessions which were
+// established from it from satisfying the two factor policy.
+func handleAuthnCookieValidateTrustedDevice(ctx AuthzContext, userSession *session.UserSession, refresh schema.RefreshIntervalDuration) (modified bool) {
+	if !userSession.IsTrustedDevice() {
+		return false
+	}
+
+	now := ctx.GetClock().Now()
+
+	if !refresh.Always() && userSession.TrustedDeviceRefreshTTL.After(now) {
+		return false
+	}
+
+	id := *userSession.TrustedDeviceID
+
+	device, err := ctx.GetProviderStorage().LoadTrustedDevice(ctx, id)
+
+	switch {
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		ctx.GetLogger().WithError(err).WithField("username", userSession.Username).Error("Error occurred while attempting to check the trusted device for user")
+
+		return false
+	case err != nil, device.Username != userSession.Username, !device.IsTrusted(now):
+		ctx.GetLogger().WithFields(map[string]any{"username": userSession.Username, "device": id.String()}).
+			Info("Session for user was established from a device which is no longer trusted")
+
+		userSession.TrustedDeviceID, userSession.TrustedDeviceRefreshTTL = nil, time.Time{}
+
+		return true
+	case refresh.Always():
+		return false
+	case refresh.Never():
+		userSession.TrustedDeviceRefreshTTL = now.Add(trustedDeviceRefreshInterval)
+	default:
+		userSession.TrustedDeviceRefreshTTL = now.Add(refresh.Value())
+	}
+
+	return true
+}
+
 func handleAuthnCookieValidateInactivity(ctx AuthzContext, manager session.Manager, userSession *session.UserSession, isAnonymous bool) (invalid bool) {
 	config := manager.GetSessionConfig()
 
@@ -533,7 +622,12 @@ func handleSessionValidateRefresh(ctx AuthzContext, userSession *session.UserSes
 		err     error
 	)
 	if details, err = ctx.GetUserProvider().GetDetails(userSession.Username); err != nil {
-		if errors.Is(err, authentication.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, authentication.ErrUserDisabled):
+			ctx.GetLogger().WithField("username", userSession.Username).Error("Error occurred while attempting to update user details for user: the user is disabled and is no longer authorized to login")
+
+			return false, true
+		case errors.Is(err, authentication.ErrUserNotFound):
 			ctx.GetLogger().WithField("username", userSession.Username).Error("Error occurred while attempting to update user details for user: the user was not found indicating they were deleted, disabled, or otherwise no longer authorized to login")
 
 			return false, true
@@ -602,7 +696,10 @@ func handleVerifyGETAuthorizationBearerResolveUser(ctx AuthzContext, username, c
 	}
 
 	if details, err = ctx.GetUserProvider().GetDetails(username); err != nil {
-		if errors.Is(err, authentication.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, authentication.ErrUserDisabled):
+			ctx.GetLogger().WithField("username", username).Error("Error occurred while attempting to get user details for user: the user is disabled and is no longer authorized to login")
+		case errors.Is(err, authentication.ErrUserNotFound):
 			ctx.GetLogger().WithField("username", username).Error("Error occurred while attempting to get user details for user: the user was not found indicating they were deleted, disabled, or otherwise no longer authorized to login")
 		}
 
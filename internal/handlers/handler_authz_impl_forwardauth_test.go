@@ -5,12 +5,15 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -309,6 +312,148 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsWithMethodsACL() {
 	}
 }
 
+func (s *ForwardAuthAuthzSuite) TestShouldHandleRequiredAMR() {
+	testCases := []struct {
+		name     string
+		amr      authorization.AuthenticationMethodsReferences
+		expected int
+	}{
+		{"ShouldRedirectWhenNotMet", authorization.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}, fasthttp.StatusFound},
+		{"ShouldAllowWhenMet", authorization.AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnHardware: true}, fasthttp.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz := s.Builder().WithStrategies(NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity))).Build()
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setUpMockClock(mock)
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:     []string{"two-factor.example.com"},
+							Policy:      "two_factor",
+							RequiredAMR: []string{"hwk", "swk"},
+						},
+					},
+				},
+			})
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationMethodRefs = tc.amr
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+
+			if tc.expected == fasthttp.StatusOK {
+				assert.Equal(t, []byte(nil), mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation))
+
+				return
+			}
+
+			expected := s.RequireParseRequestURI("https://login.example.com:8080/")
+
+			query := expected.Query()
+			query.Set(queryArgRD, targetURI.String())
+			query.Set(queryArgRM, fasthttp.MethodGet)
+			query.Set(queryArgAMR, "hwk,swk")
+			expected.RawQuery = query.Encode()
+
+			assert.Equal(t, expected.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+		})
+	}
+}
+
+func (s *ForwardAuthAuthzSuite) TestShouldHandleMaxAge() {
+	testCases := []struct {
+		name     string
+		age      time.Duration
+		expected int
+	}{
+		{"ShouldRedirectWhenStale", time.Minute * 20, fasthttp.StatusFound},
+		{"ShouldAllowWhenFresh", time.Minute * 5, fasthttp.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz := s.Builder().WithStrategies(NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity))).Build()
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setUpMockClock(mock)
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains: []string{"two-factor.example.com"},
+							Policy:  "two_factor",
+							MaxAge:  time.Minute * 15,
+						},
+					},
+				},
+			})
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationMethodRefs = authorization.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-tc.age - time.Minute).Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-tc.age).Unix()
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+
+			if tc.expected == fasthttp.StatusOK {
+				assert.Equal(t, []byte(nil), mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation))
+
+				return
+			}
+
+			expected := s.RequireParseRequestURI("https://login.example.com:8080/")
+
+			query := expected.Query()
+			query.Set(queryArgRD, targetURI.String())
+			query.Set(queryArgRM, fasthttp.MethodGet)
+			query.Set(queryArgMaxAge, "900")
+			expected.RawQuery = query.Encode()
+
+			assert.Equal(t, expected.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+		})
+	}
+}
+
 func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsAllowXHR() {
 	for _, method := range testRequestMethods {
 		s.T().Run(fmt.Sprintf("Method%s", method), func(t *testing.T) {
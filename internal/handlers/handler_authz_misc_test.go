@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"database/sql"
 	"fmt"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
@@ -45,6 +47,67 @@ func TestCookieSessionAuthnStrategyFlags(t *testing.T) {
 	assert.Equal(t, []byte(nil), mock.Ctx.Response.Header.Peek(fasthttp.HeaderWWWAuthenticate))
 }
 
+func TestAuthzGetLevel(t *testing.T) {
+	authz := &Authz{}
+
+	testCases := []struct {
+		name     string
+		authn    *Authn
+		rule     *authorization.AccessControlRule
+		required authorization.Level
+		expected authentication.Level
+	}{
+		{
+			"ShouldRaiseTrustedDeviceToTwoFactor",
+			&Authn{Level: authentication.OneFactor, TrustedDevice: true},
+			&authorization.AccessControlRule{Policy: authorization.TwoFactor},
+			authorization.TwoFactor,
+			authentication.TwoFactor,
+		},
+		{
+			"ShouldRaiseTrustedDeviceToTwoFactorForDefaultPolicy",
+			&Authn{Level: authentication.OneFactor, TrustedDevice: true},
+			nil,
+			authorization.TwoFactor,
+			authentication.TwoFactor,
+		},
+		{
+			"ShouldNotRaiseWhenRuleDisablesTrustedDevices",
+			&Authn{Level: authentication.OneFactor, TrustedDevice: true},
+			&authorization.AccessControlRule{Policy: authorization.TwoFactor, DisableTrustedDevices: true},
+			authorization.TwoFactor,
+			authentication.OneFactor,
+		},
+		{
+			"ShouldNotRaiseUntrustedDevice",
+			&Authn{Level: authentication.OneFactor},
+			&authorization.AccessControlRule{Policy: authorization.TwoFactor},
+			authorization.TwoFactor,
+			authentication.OneFactor,
+		},
+		{
+			"ShouldNotRaiseOneFactorPolicy",
+			&Authn{Level: authentication.OneFactor, TrustedDevice: true},
+			&authorization.AccessControlRule{Policy: authorization.OneFactor},
+			authorization.OneFactor,
+			authentication.OneFactor,
+		},
+		{
+			"ShouldNotRaiseAnonymous",
+			&Authn{Level: authentication.NotAuthenticated, TrustedDevice: true},
+			nil,
+			authorization.TwoFactor,
+			authentication.NotAuthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, authz.getLevel(tc.authn, tc.rule, tc.required))
+		})
+	}
+}
+
 func TestHandleGetBasicShouldRejectEmptyCredentialsWithDelay(t *testing.T) {
 	testCases := []struct {
 		Name        string
@@ -170,6 +233,19 @@ func TestHandleVerifyGETAuthorizationBearerResolveUser(t *testing.T) {
 			},
 			ExpectError: "failed to retrieve user details for user missing: user not found",
 		},
+		{
+			Name:     "ShouldReturnErrorWhenUserDisabled",
+			Username: "disabled",
+			ClientID: "",
+			CCS:      false,
+			Level:    authentication.OneFactor,
+			Setup: func(mock *mocks.MockAutheliaCtx) {
+				mock.UserProviderMock.EXPECT().
+					GetDetails(gomock.Eq("disabled")).
+					Return(nil, authentication.ErrUserDisabled)
+			},
+			ExpectError: "failed to retrieve user details for user disabled: user is disabled",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -210,3 +286,151 @@ func TestGenerateVerifySessionHasUpToDateProfileTraceLogs(t *testing.T) {
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}}, &authentication.UserDetails{Username: "john", DisplayName: "example"})
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example"}, &authentication.UserDetails{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}})
 }
+
+func TestHandleAuthnCookieValidateTrustedDevice(t *testing.T) {
+	id := uuid.MustParse("8d8f4f3e-8c9a-4b4e-9f0a-5d1f0b0c3a21")
+
+	testCases := []struct {
+		name        string
+		refresh     schema.RefreshIntervalDuration
+		ttl         time.Duration
+		setup       func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		modified    bool
+		trusted     bool
+		expectedTTL time.Duration
+	}{
+		{
+			"ShouldRefreshTrusted",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: testUsername, ExpiresAt: mock.Clock.Now().Add(time.Hour)}, nil)
+			},
+			true,
+			true,
+			time.Minute,
+		},
+		{
+			"ShouldRefreshTrustedNever",
+			schema.NewRefreshIntervalDurationNever(),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: testUsername, ExpiresAt: mock.Clock.Now().Add(time.Hour)}, nil)
+			},
+			true,
+			true,
+			trustedDeviceRefreshInterval,
+		},
+		{
+			"ShouldCheckTrustedAlways",
+			schema.NewRefreshIntervalDurationAlways(),
+			time.Minute,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: testUsername, ExpiresAt: mock.Clock.Now().Add(time.Hour)}, nil)
+			},
+			false,
+			true,
+			time.Minute,
+		},
+		{
+			"ShouldNotCheckBeforeRefresh",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			time.Second * 30,
+			nil,
+			false,
+			true,
+			time.Second * 30,
+		},
+		{
+			"ShouldUntrustRevoked",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: testUsername, ExpiresAt: mock.Clock.Now().Add(time.Hour), RevokedAt: sql.NullTime{Time: mock.Clock.Now(), Valid: true}}, nil)
+			},
+			true,
+			false,
+			0,
+		},
+		{
+			"ShouldUntrustExpired",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: testUsername, ExpiresAt: mock.Clock.Now()}, nil)
+			},
+			true,
+			false,
+			0,
+		},
+		{
+			"ShouldUntrustOtherUser",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(&model.TrustedDevice{PublicID: id, Username: "harry", ExpiresAt: mock.Clock.Now().Add(time.Hour)}, nil)
+			},
+			true,
+			false,
+			0,
+		},
+		{
+			"ShouldUntrustNotFound",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(nil, fmt.Errorf("error loading trusted device: %w", sql.ErrNoRows))
+			},
+			true,
+			false,
+			0,
+		},
+		{
+			"ShouldNotModifyOnError",
+			schema.NewRefreshIntervalDuration(time.Minute),
+			0,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, id).Return(nil, fmt.Errorf("bad conn"))
+			},
+			false,
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Providers.Clock = &mock.Clock
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			now := mock.Clock.Now()
+
+			userSession := &session.UserSession{Username: testUsername, TrustedDeviceID: &id, TrustedDeviceRefreshTTL: now.Add(tc.ttl)}
+
+			assert.Equal(t, tc.modified, handleAuthnCookieValidateTrustedDevice(mock.Ctx, userSession, tc.refresh))
+
+			if tc.trusted {
+				assert.Equal(t, &id, userSession.TrustedDeviceID)
+				assert.Equal(t, now.Add(tc.expectedTTL), userSession.TrustedDeviceRefreshTTL)
+			} else {
+				assert.Nil(t, userSession.TrustedDeviceID)
+				assert.True(t, userSession.TrustedDeviceRefreshTTL.IsZero())
+			}
+		})
+	}
+}
+
+func TestHandleAuthnCookieValidateTrustedDeviceShouldSkipUntrusted(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	assert.False(t, handleAuthnCookieValidateTrustedDevice(mock.Ctx, &session.UserSession{Username: testUsername}, schema.NewRefreshIntervalDurationAlways()))
+}
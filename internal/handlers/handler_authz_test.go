@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	fjwt "authelia.com/provider/oauth2/token/jwt"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
@@ -77,7 +82,7 @@ func (s *AuthzSuite) ConfigureMockSessionProviderWithoutAutheliaURLs(mock *mocks
 		mock.Ctx.Configuration.Session.Cookies[i].AutheliaURL = nil
 	}
 
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 }
 
 func (s *AuthzSuite) Builder() (builder *AuthzBuilder) {
@@ -368,6 +373,52 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfBypassDomain() {
 	s.Equal([]byte(nil), mock.Ctx.Response.Header.Peek(fasthttp.HeaderProxyAuthenticate))
 }
 
+func (s *AuthzSuite) TestShouldWriteDecisionToAuditLog() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	authz := s.BuildWithDelayer()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	setUpMockClock(mock)
+
+	path := filepath.Join(s.T().TempDir(), "audit.log")
+
+	mock.Ctx.Providers.AuditLogger = authorization.NewAuditLogger(schema.AccessControlAuditLog{FilePath: path, SampleRate: 1}, mock.Ctx.Providers.Random)
+
+	s.Require().NoError(mock.Ctx.Providers.AuditLogger.Open())
+
+	targetURI := s.RequireParseRequestURI("https://bypass.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	authz.Handler(mock.Ctx)
+
+	s.Require().NoError(mock.Ctx.Providers.AuditLogger.Close())
+
+	s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	var decision authorization.AuditDecision
+
+	s.Require().NoError(json.Unmarshal(data, &decision))
+
+	s.Require().NotNil(decision.RulePosition)
+	s.Equal(1, *decision.RulePosition)
+	s.Equal("bypass", decision.Policy)
+	s.Equal(fasthttp.StatusOK, decision.Status)
+	s.Equal("https://bypass.example.com", decision.Object.URL)
+	s.Equal(fasthttp.MethodGet, decision.Object.Method)
+	s.Equal(mock.Ctx.RemoteIP().String(), decision.Subject.IP)
+	s.Equal(mock.Ctx.Providers.Clock.Now().Unix(), decision.Time.Unix())
+}
+
 func (s *AuthzSuite) TestShouldVerifyFailureToGetDetailsUsingBasicScheme() {
 	if s.setRequest == nil {
 		s.T().Skip()
@@ -2092,7 +2143,7 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenInactiveForTooLong() {
 	past := mock.Clock.Now().Add(-1 * time.Hour)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
 
@@ -2138,7 +2189,7 @@ func (s *AuthzSuite) TestShouldNotDestroySessionWhenInactiveForTooLongRememberMe
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
 
@@ -2186,7 +2237,7 @@ func (s *AuthzSuite) TestShouldNotDestroySessionWhenNotInactiveForTooLong() {
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
 
@@ -2235,7 +2286,7 @@ func (s *AuthzSuite) TestShouldUpdateInactivityTimestampEvenWhenHittingForbidden
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://deny.example.com")
 
@@ -2297,7 +2348,7 @@ func (s *AuthzSuite) TestShouldNotRefreshUserDetailsFromBackendWhenRefreshDisabl
 	mock.Ctx.Providers.Clock = &mock.Clock
 	mock.Ctx.Configuration.AuthenticationBackend.RefreshInterval = schema.NewRefreshIntervalDurationNever()
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
 
@@ -2377,7 +2428,7 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenUserDoesNotExist() {
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
 
@@ -2463,7 +2514,7 @@ func (s *AuthzSuite) TestShouldUpdateRemovedUserGroupsFromBackendAndDeny() {
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://admin.example.com")
 
@@ -2547,7 +2598,7 @@ func (s *AuthzSuite) TestShouldUpdateAddedUserGroupsFromBackendAndDeny() {
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://admin.example.com")
 
@@ -2630,7 +2681,7 @@ func (s *AuthzSuite) TestShouldCheckValidSessionUsernameHeaderAndReturn200() {
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
 
@@ -2680,7 +2731,7 @@ func (s *AuthzSuite) TestShouldCheckInvalidSessionUsernameHeaderAndReturn401AndD
 	setUpMockClock(mock)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
 
@@ -2728,6 +2779,123 @@ func (s *AuthzSuite) TestShouldCheckInvalidSessionUsernameHeaderAndReturn401AndD
 	s.Equal(mock.Clock.Now().Unix(), userSession.LastActivity)
 }
 
+func (s *AuthzSuite) TestShouldDestroySessionWhenSessionBindingMismatched() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name      string
+		ip, agent string
+	}{
+		{"ShouldHandleDifferentNetwork", "10.0.0.1", "Test/1.0"},
+		{"ShouldHandleDifferentUserAgent", "192.168.0.1", "Other/1.0"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			builder := s.Builder()
+
+			builder = builder.WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+			)
+
+			authz := builder.Build()
+
+			mock := mocks.NewMockAutheliaCtx(s.T())
+
+			defer mock.Close()
+
+			setUpMockClock(mock)
+
+			mock.Ctx.Configuration.Session.Binding = &schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64}
+			mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
+
+			targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, tc.ip)
+			mock.Ctx.Request.Header.SetUserAgent(tc.agent)
+
+			userSession, err := mock.Ctx.GetSession()
+			s.Require().NoError(err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+			userSession.Binding = session.NewBinding(mock.Ctx.Configuration.Session.Binding, net.ParseIP("192.168.0.1"), []byte("Test/1.0"))
+
+			s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			switch s.implementation {
+			case AuthzImplAuthRequest, AuthzImplLegacy:
+				s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+			default:
+				s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+			}
+
+			userSession, err = mock.Ctx.GetSession()
+			s.Require().NoError(err)
+
+			s.Equal("", userSession.Username)
+			s.Equal(authentication.NotAuthenticated, userSession.AuthenticationLevel(false))
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldBindUnboundSession() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	setUpMockClock(mock)
+
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
+
+	targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	mock.Ctx.Configuration.Session.Binding = &schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64}
+
+	authz.Handler(mock.Ctx)
+
+	s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	userSession, err = mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Equal(testUsername, userSession.Username)
+	s.Require().NotNil(userSession.Binding)
+	s.Equal(mock.Ctx.NewSessionBinding(), userSession.Binding)
+}
+
 func (s *AuthzSuite) TestShouldNotRedirectRequestsForBypassACLWhenInactiveForTooLong() {
 	if s.setRequest == nil {
 		s.T().Skip()
@@ -2750,7 +2918,7 @@ func (s *AuthzSuite) TestShouldNotRedirectRequestsForBypassACLWhenInactiveForToo
 	past := mock.Clock.Now().Add(-24 * time.Hour)
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://bypass.example.com")
 
@@ -2822,7 +2990,7 @@ func (s *AuthzSuite) TestShouldFailToParsePortalURL() {
 	defer mock.Close()
 
 	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	targetURI := s.RequireParseRequestURI("https://bypass.example.com")
 
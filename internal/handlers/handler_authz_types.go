@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
@@ -75,12 +76,33 @@ type Authn struct {
 
 	Details authentication.UserDetails
 	Level   authentication.Level
+	AMR     authorization.AuthenticationMethodsReferences
 	Object  authorization.Object
 	Type    AuthnType
 
+	// AuthenticatedAt is the most recent time the user authenticated with either factor, it's only set for the cookie
+	// session strategy.
+	AuthenticatedAt time.Time
+
+	// TrustedDevice is true when the user completed the first factor from a trusted device, it's only set for the cookie
+	// session strategy.
+	TrustedDevice bool
+
 	Header HeaderAuthorization
 }
 
+// AuthzStepUp represents the requirements of a matched rule which the user has not met at their current authentication
+// level and must satisfy by authenticating again. These are communicated to the portal when redirecting the user.
+type AuthzStepUp struct {
+	RequiredAMR authorization.AccessControlRequiredAMR
+	MaxAge      time.Duration
+}
+
+// IsRequired returns true if the user must authenticate again.
+func (s AuthzStepUp) IsRequired() (required bool) {
+	return len(s.RequiredAMR) != 0 || s.MaxAge != 0
+}
+
 // HeaderAuthorization represents the parsed Authorization header of an authorization request.
 type HeaderAuthorization struct {
 	Authorization *model.Authorization
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
@@ -49,44 +50,72 @@ func ChangePasswordPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if err = ctx.Providers.PasswordPolicy.Check(requestBody.NewPassword); err != nil {
-		ctx.GetLogger().WithError(err).
-			WithFields(map[string]any{"username": username}).
-			Debug("Unable to change password for user as their new password was weak or empty")
-		ctx.SetJSONError(messagePasswordWeak)
-		ctx.SetStatusCode(http.StatusBadRequest)
-
-		return
-	}
-
-	if err = ctx.Providers.UserProvider.ChangePassword(username, requestBody.OldPassword, requestBody.NewPassword); err != nil {
+	if err = ctx.Providers.PasswordPolicy.Check(ctx, requestBody.NewPassword); err != nil {
 		switch {
-		case errors.Is(err, authentication.ErrIncorrectPassword):
-			ctx.GetLogger().WithError(err).
-				WithFields(map[string]any{"username": username}).
-				Debug("Unable to change password for user as their old password was incorrect")
-			ctx.SetJSONError(messageIncorrectPassword)
-			ctx.SetStatusCode(http.StatusUnauthorized)
-		case errors.Is(err, authentication.ErrPasswordWeak):
+		case errors.Is(err, middlewares.ErrPasswordPolicyUnavailable):
 			ctx.GetLogger().WithError(err).
 				WithFields(map[string]any{"username": username}).
-				Debug("Unable to change password for user as their new password was weak or empty")
-			ctx.SetJSONError(messagePasswordWeak)
-			ctx.SetStatusCode(http.StatusBadRequest)
-		case errors.Is(err, authentication.ErrAuthenticationFailed):
+				Error("Unable to change password for user as an error occurred checking their new password against the password policy")
+			ctx.SetJSONError(messageOperationFailed)
+			ctx.SetStatusCode(http.StatusInternalServerError)
+
+			return
+		case errors.Is(err, middlewares.ErrPasswordPolicyBreached):
 			ctx.GetLogger().WithError(err).
 				WithFields(map[string]any{"username": username}).
-				Error("Unable to change password for user as authentication failed for the user")
-			ctx.SetJSONError(messageOperationFailed)
-			ctx.SetStatusCode(http.StatusUnauthorized)
+				Debug("Unable to change password for user as their new password has appeared in a data breach")
+			ctx.SetJSONError(messagePasswordBreached)
 		default:
 			ctx.GetLogger().WithError(err).
 				WithFields(map[string]any{"username": username}).
-				Error("Unable to change password for user for an unknown reason")
-			ctx.SetJSONError(messageOperationFailed)
-			ctx.SetStatusCode(http.StatusInternalServerError)
+				Debug("Unable to change password for user as their new password was weak or empty")
+			ctx.SetJSONError(messagePasswordWeak)
 		}
 
+		ctx.SetStatusCode(http.StatusBadRequest)
+
+		return
+	}
+
+	// The old password must be verified before the history is checked otherwise the response could be used to test
+	// candidate passwords against the history without knowing the current password.
+	if err = verifyChangePasswordOldPassword(ctx, username, requestBody.OldPassword); err != nil {
+		handleChangePasswordError(ctx, username, err)
+
+		return
+	}
+
+	// The current password isn't necessarily in the history, for example when it was set before the history was enabled
+	// or outside of Authelia, so it's explicitly rejected.
+	reused := ctx.Configuration.PasswordPolicy.History > 0 && requestBody.NewPassword == requestBody.OldPassword
+
+	if !reused {
+		reused, err = isPasswordInHistory(ctx, username, requestBody.NewPassword)
+	}
+
+	if err != nil {
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Error("Unable to change password for user as an error occurred checking their password history")
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(http.StatusInternalServerError)
+
+		return
+	}
+
+	if reused {
+		ctx.GetLogger().
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their new password was used recently")
+		ctx.SetJSONError(messagePasswordReused)
+		ctx.SetStatusCode(http.StatusBadRequest)
+
+		return
+	}
+
+	if err = ctx.Providers.UserProvider.ChangePassword(username, requestBody.OldPassword, requestBody.NewPassword); err != nil {
+		handleChangePasswordError(ctx, username, err)
+
 		return
 	}
 
@@ -94,6 +123,21 @@ func ChangePasswordPOST(ctx *middlewares.AutheliaCtx) {
 		WithFields(map[string]any{"username": username}).
 		Debug("User has changed their password")
 
+	if err = savePasswordHistoryCurrent(ctx, username, requestBody.OldPassword); err != nil {
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Error("Unable to save the previous password to the password history for user")
+	}
+
+	if err = savePasswordHistory(ctx, username, requestBody.NewPassword); err != nil {
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Error("Unable to save the password history for user")
+	}
+
+	userSession.PasswordChangeRequired = false
+	userSession.PasswordGraceLoginsRemaining = nil
+
 	if err = provider.SaveSession(ctx.RequestCtx, userSession); err != nil {
 		ctx.GetLogger().WithError(err).
 			WithFields(map[string]any{"username": username}).
@@ -151,3 +195,78 @@ func ChangePasswordPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 }
+
+// handleChangePasswordError sets the response for an error which occurred while changing the password of a user.
+func handleChangePasswordError(ctx *middlewares.AutheliaCtx, username string, err error) {
+	switch {
+	case errors.Is(err, authentication.ErrIncorrectPassword):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their old password was incorrect")
+		ctx.SetJSONError(messageIncorrectPassword)
+		ctx.SetStatusCode(http.StatusUnauthorized)
+	case errors.Is(err, authentication.ErrPasswordExpired):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their old password has expired")
+		ctx.SetJSONError(messagePasswordExpired)
+		ctx.SetStatusCode(http.StatusUnauthorized)
+	case errors.Is(err, authentication.ErrPasswordTooShort):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their new password was too short for the backend password policy")
+		ctx.SetJSONError(messagePasswordTooShort)
+		ctx.SetStatusCode(http.StatusBadRequest)
+	case errors.Is(err, authentication.ErrPasswordInHistory):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their new password is in the backend password history")
+		ctx.SetJSONError(messagePasswordReused)
+		ctx.SetStatusCode(http.StatusBadRequest)
+	case errors.Is(err, authentication.ErrPasswordTooYoung):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their password was changed too recently for the backend password policy")
+		ctx.SetJSONError(messagePasswordTooYoung)
+		ctx.SetStatusCode(http.StatusBadRequest)
+	case errors.Is(err, authentication.ErrPasswordWeak):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Debug("Unable to change password for user as their new password was weak or empty")
+		ctx.SetJSONError(messagePasswordWeak)
+		ctx.SetStatusCode(http.StatusBadRequest)
+	case errors.Is(err, authentication.ErrAuthenticationFailed):
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Error("Unable to change password for user as authentication failed for the user")
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(http.StatusUnauthorized)
+	default:
+		ctx.GetLogger().WithError(err).
+			WithFields(map[string]any{"username": username}).
+			Error("Unable to change password for user for an unknown reason")
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(http.StatusInternalServerError)
+	}
+}
+
+// verifyChangePasswordOldPassword verifies the old password of a user when the password history is enforced. Errors
+// other than an expired password are wrapped so they're reported as an authentication failure.
+func verifyChangePasswordOldPassword(ctx *middlewares.AutheliaCtx, username, password string) (err error) {
+	if ctx.Configuration.PasswordPolicy.History <= 0 {
+		return nil
+	}
+
+	var valid bool
+
+	switch valid, err = ctx.Providers.UserProvider.CheckUserPassword(username, password); {
+	case err == nil && valid:
+		return nil
+	case err == nil:
+		return authentication.ErrIncorrectPassword
+	case errors.Is(err, authentication.ErrPasswordExpired):
+		return err
+	default:
+		return fmt.Errorf("%w: %w", authentication.ErrAuthenticationFailed, err)
+	}
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 	"go.uber.org/mock/gomock"
 
@@ -15,6 +19,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
 )
 
 const (
@@ -71,6 +76,48 @@ func TestChangePasswordPOST_ShouldSucceedWithValidCredentials(t *testing.T) {
 	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
 }
 
+func TestChangePasswordPOST_ShouldClearPasswordChangeRequired(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.PasswordChangeRequired = true
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	requestBody := changePasswordRequestBody{
+		OldPassword: testPasswordOld,
+		NewPassword: testPasswordNew,
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+	mock.UserProviderMock.EXPECT().
+		ChangePassword(testUsername, testPasswordOld, testPasswordNew).
+		Return(nil)
+
+	mock.UserProviderMock.EXPECT().
+		GetDetails(testUsername).
+		Return(&authentication.UserDetails{}, nil)
+
+	ChangePasswordPOST(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	userSession, err = mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	assert.False(t, userSession.PasswordChangeRequired)
+}
+
 func TestChangePasswordPOST_ShouldFailWhenPasswordPolicyNotMet(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 
@@ -121,6 +168,92 @@ func TestChangePasswordPOST_ShouldFailWhenPasswordPolicyNotMet(t *testing.T) {
 	assert.Equal(t, "Your supplied password does not meet the password policy requirements.", errResponse.Message)
 }
 
+func TestChangePasswordPOST_ShouldFailWhenPasswordBreached(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Logger.Logger.SetLevel(logrus.DebugLevel)
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	requestBody := changePasswordRequestBody{
+		OldPassword: testPasswordOld,
+		NewPassword: "password",
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:10\r\n"), 0600))
+
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{
+		Breached: schema.PasswordPolicyBreached{
+			Enabled:   true,
+			Path:      dir,
+			Threshold: 1,
+		},
+	})
+
+	ChangePasswordPOST(mock.Ctx)
+
+	mock.AssertLogEntryAdvanced(t, 0, logrus.DebugLevel, "Unable to change password for user as their new password has appeared in a data breach", map[string]any{"username": testUsername, "error": "the supplied password has appeared in a data breach"})
+
+	assert.Equal(t, fasthttp.StatusBadRequest, mock.Ctx.Response.StatusCode())
+
+	errResponse := mock.GetResponseError(t)
+	assert.Equal(t, "KO", errResponse.Status)
+	assert.Equal(t, messagePasswordBreached, errResponse.Message)
+}
+
+func TestChangePasswordPOST_ShouldFailWhenBreachedPasswordRangeUnavailable(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	requestBody := changePasswordRequestBody{
+		OldPassword: testPasswordOld,
+		NewPassword: "password",
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{
+		Breached: schema.PasswordPolicyBreached{
+			Enabled:   true,
+			Path:      t.TempDir(),
+			Threshold: 1,
+		},
+	})
+
+	ChangePasswordPOST(mock.Ctx)
+
+	mock.AssertLogEntryAdvanced(t, 0, logrus.ErrorLevel, "Unable to change password for user as an error occurred checking their new password against the password policy", map[string]any{"username": testUsername})
+
+	assert.Equal(t, fasthttp.StatusInternalServerError, mock.Ctx.Response.StatusCode())
+
+	errResponse := mock.GetResponseError(t)
+	assert.Equal(t, "KO", errResponse.Status)
+	assert.Equal(t, messageOperationFailed, errResponse.Message)
+}
+
 func TestChangePasswordPOST_ShouldFailWhenRequestBodyIsInvalid(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 
@@ -234,6 +367,317 @@ func TestChangePasswordPOST_ShouldFailWhenPasswordReuseIsNotAllowed(t *testing.T
 	assert.Equal(t, messagePasswordWeak, errResponse.Message)
 }
 
+func TestChangePasswordPOST_ShouldMapBackendPasswordPolicyErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		status   int
+		expected string
+	}{
+		{"ShouldHandleTooShort", authentication.ErrPasswordTooShort, fasthttp.StatusBadRequest, messagePasswordTooShort},
+		{"ShouldHandleInHistory", authentication.ErrPasswordInHistory, fasthttp.StatusBadRequest, messagePasswordReused},
+		{"ShouldHandleTooYoung", authentication.ErrPasswordTooYoung, fasthttp.StatusBadRequest, messagePasswordTooYoung},
+		{"ShouldHandleExpired", authentication.ErrPasswordExpired, fasthttp.StatusUnauthorized, messagePasswordExpired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			userSession, err := mock.Ctx.GetSession()
+			assert.NoError(t, err)
+
+			userSession.Username = testUsername
+
+			assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			bodyBytes, err := json.Marshal(changePasswordRequestBody{
+				OldPassword: testPasswordOld,
+				NewPassword: testPasswordNew,
+			})
+			assert.NoError(t, err)
+			mock.Ctx.Request.SetBody(bodyBytes)
+
+			mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+			mock.UserProviderMock.EXPECT().
+				ChangePassword(testUsername, testPasswordOld, testPasswordNew).
+				Return(fmt.Errorf("%w: LDAP Result Code 19 \"Constraint Violation\"", tc.err))
+
+			ChangePasswordPOST(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			errResponse := mock.GetResponseError(t)
+			assert.Equal(t, "KO", errResponse.Status)
+			assert.Equal(t, tc.expected, errResponse.Message)
+		})
+	}
+}
+
+func TestChangePasswordPOST_ShouldFailWhenPasswordInHistory(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Logger.Logger.SetLevel(logrus.DebugLevel)
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	requestBody := changePasswordRequestBody{
+		OldPassword: testPasswordOld,
+		NewPassword: testPasswordNew,
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Configuration.PasswordPolicy.History = 3
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().
+			CheckUserPassword(testUsername, testPasswordOld).
+			Return(true, nil),
+		mock.StorageMock.EXPECT().
+			LoadPasswordHistory(mock.Ctx, testUsername, 3).
+			Return([]model.PasswordHistory{
+				{Username: testUsername, Password: "$plaintext$another_password789"},
+				{Username: testUsername, Password: "$plaintext$" + testPasswordNew},
+			}, nil),
+	)
+
+	ChangePasswordPOST(mock.Ctx)
+
+	mock.AssertLogEntryAdvanced(t, 0, logrus.DebugLevel, "Unable to change password for user as their new password was used recently", map[string]any{"username": testUsername})
+
+	assert.Equal(t, fasthttp.StatusBadRequest, mock.Ctx.Response.StatusCode())
+
+	errResponse := mock.GetResponseError(t)
+	assert.Equal(t, "KO", errResponse.Status)
+	assert.Equal(t, messagePasswordReused, errResponse.Message)
+}
+
+func TestChangePasswordPOST_ShouldVerifyOldPasswordBeforePasswordHistory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		valid    bool
+		err      error
+		status   int
+		expected string
+	}{
+		{"ShouldFailIncorrectPassword", false, nil, fasthttp.StatusUnauthorized, messageIncorrectPassword},
+		{"ShouldFailExpiredPassword", false, authentication.ErrPasswordExpired, fasthttp.StatusUnauthorized, messagePasswordExpired},
+		{"ShouldFailBackendError", false, fmt.Errorf("failed to connect"), fasthttp.StatusUnauthorized, messageOperationFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			userSession, err := mock.Ctx.GetSession()
+			assert.NoError(t, err)
+
+			userSession.Username = testUsername
+
+			assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			bodyBytes, err := json.Marshal(changePasswordRequestBody{OldPassword: testPasswordOld, NewPassword: testPasswordNew})
+			assert.NoError(t, err)
+			mock.Ctx.Request.SetBody(bodyBytes)
+
+			mock.Ctx.Configuration.PasswordPolicy.History = 3
+			mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+			mock.UserProviderMock.EXPECT().
+				CheckUserPassword(testUsername, testPasswordOld).
+				Return(tc.valid, tc.err)
+
+			mock.StorageMock.EXPECT().LoadPasswordHistory(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			mock.UserProviderMock.EXPECT().ChangePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			ChangePasswordPOST(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			errResponse := mock.GetResponseError(t)
+			assert.Equal(t, "KO", errResponse.Status)
+			assert.Equal(t, tc.expected, errResponse.Message)
+		})
+	}
+}
+
+func TestChangePasswordPOST_ShouldSavePasswordHistory(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	requestBody := changePasswordRequestBody{
+		OldPassword: testPasswordOld,
+		NewPassword: testPasswordNew,
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Configuration.PasswordPolicy.History = 3
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().
+			CheckUserPassword(testUsername, testPasswordOld).
+			Return(true, nil),
+		mock.StorageMock.EXPECT().
+			LoadPasswordHistory(mock.Ctx, testUsername, 3).
+			Return([]model.PasswordHistory{{Username: testUsername, Password: "$plaintext$" + testPasswordOld}}, nil),
+		mock.UserProviderMock.EXPECT().
+			ChangePassword(testUsername, testPasswordOld, testPasswordNew).
+			Return(nil),
+		mock.StorageMock.EXPECT().
+			LoadPasswordHistory(mock.Ctx, testUsername, 1).
+			Return([]model.PasswordHistory{{Username: testUsername, Password: "$plaintext$" + testPasswordOld}}, nil),
+		mock.StorageMock.EXPECT().
+			SavePasswordHistory(mock.Ctx, gomock.Any(), 3).
+			DoAndReturn(func(_ context.Context, history model.PasswordHistory, keep int) error {
+				assert.Equal(t, testUsername, history.Username)
+
+				digest, err := schema.DecodePasswordDigest(history.Password)
+				assert.NoError(t, err)
+				assert.True(t, digest.Match(testPasswordNew))
+
+				return nil
+			}),
+		mock.UserProviderMock.EXPECT().
+			GetDetails(testUsername).
+			Return(&authentication.UserDetails{}, nil),
+	)
+
+	ChangePasswordPOST(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+}
+
+func TestChangePasswordPOST_ShouldSaveOldPasswordToPasswordHistory(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	bodyBytes, err := json.Marshal(changePasswordRequestBody{OldPassword: testPasswordOld, NewPassword: testPasswordNew})
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Configuration.PasswordPolicy.History = 3
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+	var saved []string
+
+	save := func(_ context.Context, history model.PasswordHistory, keep int) error {
+		saved = append(saved, history.Password)
+
+		return nil
+	}
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().
+			CheckUserPassword(testUsername, testPasswordOld).
+			Return(true, nil),
+		mock.StorageMock.EXPECT().
+			LoadPasswordHistory(mock.Ctx, testUsername, 3).
+			Return(nil, nil),
+		mock.UserProviderMock.EXPECT().
+			ChangePassword(testUsername, testPasswordOld, testPasswordNew).
+			Return(nil),
+		mock.StorageMock.EXPECT().
+			LoadPasswordHistory(mock.Ctx, testUsername, 1).
+			Return(nil, nil),
+		mock.StorageMock.EXPECT().
+			SavePasswordHistory(mock.Ctx, gomock.Any(), 3).
+			DoAndReturn(save),
+		mock.StorageMock.EXPECT().
+			SavePasswordHistory(mock.Ctx, gomock.Any(), 3).
+			DoAndReturn(save),
+		mock.UserProviderMock.EXPECT().
+			GetDetails(testUsername).
+			Return(&authentication.UserDetails{}, nil),
+	)
+
+	ChangePasswordPOST(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	require.Len(t, saved, 2)
+
+	for i, password := range []string{testPasswordOld, testPasswordNew} {
+		digest, err := schema.DecodePasswordDigest(saved[i])
+		require.NoError(t, err)
+		assert.True(t, digest.Match(password))
+	}
+}
+
+func TestChangePasswordPOST_ShouldFailWhenNewPasswordIsOldPassword(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Logger.Logger.SetLevel(logrus.DebugLevel)
+
+	userSession, err := mock.Ctx.GetSession()
+	assert.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	assert.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	bodyBytes, err := json.Marshal(changePasswordRequestBody{OldPassword: testPasswordOld, NewPassword: testPasswordOld})
+	assert.NoError(t, err)
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	mock.Ctx.Configuration.PasswordPolicy.History = 3
+	mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicy{})
+
+	mock.UserProviderMock.EXPECT().
+		CheckUserPassword(testUsername, testPasswordOld).
+		Return(true, nil)
+
+	mock.StorageMock.EXPECT().LoadPasswordHistory(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mock.UserProviderMock.EXPECT().ChangePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	ChangePasswordPOST(mock.Ctx)
+
+	mock.AssertLogEntryAdvanced(t, 0, logrus.DebugLevel, "Unable to change password for user as their new password was used recently", map[string]any{"username": testUsername})
+
+	assert.Equal(t, fasthttp.StatusBadRequest, mock.Ctx.Response.StatusCode())
+
+	errResponse := mock.GetResponseError(t)
+	assert.Equal(t, "KO", errResponse.Status)
+	assert.Equal(t, messagePasswordReused, errResponse.Message)
+}
+
 func TestChangePasswordPOST_ShouldSucceedButLogErrorWhenUserHasNoEmail(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 	defer mock.Close()
@@ -14,6 +14,7 @@ func ConfigurationGET(ctx *middlewares.AutheliaCtx) {
 
 	if ctx.Providers.Authorizer.IsSecondFactorEnabled() {
 		body.AvailableMethods = ctx.AvailableSecondFactorMethods()
+		body.TrustedDevicesEnabled = ctx.Configuration.Session.TrustedDevices != nil
 	}
 
 	body.PasswordChangeDisabled = ctx.Configuration.AuthenticationBackend.PasswordChange.Disable
@@ -24,6 +25,7 @@ func ConfigurationGET(ctx *middlewares.AutheliaCtx) {
 			"available_methods":        body.AvailableMethods,
 			"password_change_disabled": body.PasswordChangeDisabled,
 			"password_reset_disabled":  body.PasswordResetDisabled,
+			"trusted_devices_enabled":  body.TrustedDevicesEnabled,
 		}).Trace("Authelia configuration requested")
 
 	if err := ctx.SetJSONBody(body); err != nil {
@@ -7,7 +7,8 @@ import (
 // PasswordPolicyConfigurationGET get the password policy configuration.
 func PasswordPolicyConfigurationGET(ctx *middlewares.AutheliaCtx) {
 	policyResponse := PasswordPolicyBody{
-		Mode: "disabled",
+		Mode:     "disabled",
+		Breached: ctx.Configuration.PasswordPolicy.Breached.Enabled,
 	}
 
 	if ctx.Configuration.PasswordPolicy.Standard.Enabled {
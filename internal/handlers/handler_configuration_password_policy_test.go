@@ -77,6 +77,21 @@ func (s *PasswordPolicySuite) TestShouldBeZXCVBN() {
 	assert.Equal(s.T(), "zxcvbn", response.Data.Mode)
 }
 
+func (s *PasswordPolicySuite) TestShouldReportBreached() {
+	s.mock.Ctx.Configuration.PasswordPolicy.Standard.Enabled = true
+	s.mock.Ctx.Configuration.PasswordPolicy.Breached.Enabled = true
+
+	PasswordPolicyConfigurationGET(s.mock.Ctx)
+
+	response := &passwordPolicyResponseBody{}
+	err := json.Unmarshal(s.mock.Ctx.Response.Body(), response)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	assert.Equal(s.T(), "standard", response.Data.Mode)
+	assert.True(s.T(), response.Data.Breached)
+}
+
 func TestRunPasswordPolicySuite(t *testing.T) {
 	s := new(PasswordPolicySuite)
 	suite.Run(t, s)
@@ -274,6 +274,12 @@ func FirstFactorPasskeyPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.SetStatusCode(fasthttp.StatusForbidden)
 		ctx.SetJSONError(messageMFAValidationFailed)
 
+		if errors.Is(err, authentication.ErrUserDisabled) {
+			doMarkAuthenticationAttempt(ctx, false, regulation.NewBan(regulation.BanTypeNone, user.Username, nil), regulation.AuthTypePasskey, err)
+
+			return
+		}
+
 		ctx.Logger.WithError(err).Errorf(logFmtErrPasskeyAuthenticationChallengeValidateUser, u.WebAuthnName(), "error retrieving user details")
 
 		doMarkAuthenticationAttempt(ctx, false, regulation.NewBan(regulation.BanTypeNone, "", nil), regulation.AuthTypePasskey, nil)
@@ -307,6 +313,17 @@ func FirstFactorPasskeyPOST(ctx *middlewares.AutheliaCtx) {
 
 	doMarkAuthenticationAttempt(ctx, true, regulation.NewBan(regulation.BanTypeNone, details.Username, nil), regulation.AuthTypePasskey, nil)
 
+	passwordChangeRequired := details.IsPasswordChangeRequired(ctx.GetClock().Now(), ctx.Configuration.AuthenticationBackend.PasswordChange.MaxAge)
+
+	if passwordChangeRequired && ctx.Configuration.AuthenticationBackend.PasswordChange.Disable {
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' as they are required to change their password but password change is disabled", regulation.AuthTypePasskey, details.Username)
+
+		return
+	}
+
 	if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 		userSession.RefreshTTL = ctx.GetClock().Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 	}
@@ -338,6 +355,24 @@ func FirstFactorPasskeyPOST(ctx *middlewares.AutheliaCtx) {
 		response.Response.AuthenticatorData.Flags.HasUserVerified(),
 	)
 
+	userSession.Binding = ctx.NewSessionBinding()
+
+	userSession.PasswordChangeRequired = passwordChangeRequired
+
+	if passwordChangeRequired {
+		ctx.Logger.WithFields(map[string]any{"username": details.Username}).Info("User is required to change their password before the authentication can be completed")
+
+		ctx.ReplyOK()
+
+		return
+	}
+
+	isTwoFactor := userSession.AuthenticationLevel(ctx.Configuration.WebAuthn.EnablePasskey2FA) == authentication.TwoFactor
+
+	if !isTwoFactor {
+		userSession.TrustedDeviceID = handleTrustedDevice(ctx, details.Username)
+	}
+
 	if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 		userSession.RefreshTTL = ctx.GetClock().Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 	}
@@ -345,6 +380,6 @@ func FirstFactorPasskeyPOST(ctx *middlewares.AutheliaCtx) {
 	if len(bodyJSON.Flow) > 0 {
 		handleFlowResponse(ctx, &userSession, bodyJSON.FlowID, bodyJSON.Flow, bodyJSON.SubFlow, bodyJSON.UserCode)
 	} else {
-		HandlePasskeyResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, userSession.AuthenticationLevel(ctx.Configuration.WebAuthn.EnablePasskey2FA) == authentication.TwoFactor)
+		HandlePasskeyResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, isTwoFactor, userSession.IsTrustedDevice())
 	}
 }
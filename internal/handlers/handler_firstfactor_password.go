@@ -37,7 +37,16 @@ func FirstFactorPasswordPOST(delayer middlewares.Delayer) middlewares.RequestHan
 			return
 		}
 
-		if details, err = ctx.Providers.UserProvider.GetDetails(bodyJSON.Username); err != nil || details == nil {
+		details, err = ctx.Providers.UserProvider.GetDetails(bodyJSON.Username)
+
+		switch {
+		case errors.Is(err, authentication.ErrUserDisabled):
+			doMarkAuthenticationAttempt(ctx, false, regulation.NewBan(regulation.BanTypeNone, bodyJSON.Username, nil), regulation.AuthType1FA, err)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		case err != nil || details == nil:
 			doMarkAuthenticationAttempt(ctx, false, regulation.NewBan(regulation.BanTypeUnknown, "", nil), regulation.AuthType1FA, err)
 
 			ctx.Logger.WithError(err).Errorf("Error occurred getting details for user with username input '%s' which usually indicates they do not exist", bodyJSON.Username)
@@ -63,7 +72,17 @@ func FirstFactorPasswordPOST(delayer middlewares.Delayer) middlewares.RequestHan
 			return
 		}
 
-		userPasswordOk, err := ctx.Providers.UserProvider.CheckUserPassword(details.Username, bodyJSON.Password)
+		var (
+			userPasswordOk bool
+			status         *authentication.PasswordStatus
+		)
+
+		if provider, ok := ctx.Providers.UserProvider.(authentication.PasswordStatusUserProvider); ok {
+			userPasswordOk, status, err = provider.CheckUserPasswordWithStatus(details.Username, bodyJSON.Password)
+		} else {
+			userPasswordOk, err = ctx.Providers.UserProvider.CheckUserPassword(details.Username, bodyJSON.Password)
+		}
+
 		if err != nil {
 			if isRegulatorSkippedErr(err) {
 				ctx.Logger.WithError(err).Errorf("Unsuccessful %s authentication attempt by user '%s'", regulation.AuthType1FA, details.Username)
@@ -86,6 +105,16 @@ func FirstFactorPasswordPOST(delayer middlewares.Delayer) middlewares.RequestHan
 
 		doMarkAuthenticationAttempt(ctx, true, regulation.NewBan(regulation.BanTypeNone, details.Username, nil), regulation.AuthType1FA, nil)
 
+		passwordChangeRequired := details.IsPasswordChangeRequired(ctx.GetClock().Now(), ctx.Configuration.AuthenticationBackend.PasswordChange.MaxAge)
+
+		if passwordChangeRequired && ctx.Configuration.AuthenticationBackend.PasswordChange.Disable {
+			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' as they are required to change their password but password change is disabled", regulation.AuthType1FA, details.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
 		var provider *session.Session
 
 		if provider, err = ctx.GetSessionProvider(); err != nil {
@@ -137,6 +166,21 @@ func FirstFactorPasswordPOST(delayer middlewares.Delayer) middlewares.RequestHan
 
 		userSession.SetOneFactorPassword(ctx.GetClock().Now(), details, keepMeLoggedIn)
 
+		userSession.Binding = ctx.NewSessionBinding()
+
+		userSession.TrustedDeviceID = handleTrustedDevice(ctx, details.Username)
+
+		userSession.PasswordChangeRequired = passwordChangeRequired
+
+		if status != nil {
+			ctx.Logger.WithFields(map[string]any{"username": details.Username, "grace_logins_remaining": status.GraceLoginsRemaining}).
+				Warn("User has authenticated with an expired password using a grace login")
+
+			userSession.PasswordGraceLoginsRemaining = &status.GraceLoginsRemaining
+		} else {
+			userSession.PasswordGraceLoginsRemaining = nil
+		}
+
 		if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 			userSession.RefreshTTL = ctx.GetClock().Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 		}
@@ -151,10 +195,18 @@ func FirstFactorPasswordPOST(delayer middlewares.Delayer) middlewares.RequestHan
 
 		successful = true
 
+		if passwordChangeRequired {
+			ctx.Logger.WithFields(map[string]any{"username": details.Username}).Info("User is required to change their password before the authentication can be completed")
+
+			ctx.ReplyOK()
+
+			return
+		}
+
 		if len(bodyJSON.Flow) > 0 {
 			handleFlowResponse(ctx, &userSession, bodyJSON.FlowID, bodyJSON.Flow, bodyJSON.SubFlow, bodyJSON.UserCode)
 		} else {
-			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, userSession.IsTrustedDevice())
 		}
 	}
 }
@@ -268,6 +320,10 @@ func FirstFactorReauthenticatePOST(delayer middlewares.Delayer) middlewares.Requ
 
 		userSession.SetOneFactorReauthenticate(ctx.GetClock().Now(), userDetails)
 
+		userSession.Binding = ctx.NewSessionBinding()
+
+		userSession.TrustedDeviceID = handleTrustedDevice(ctx, userSession.Username)
+
 		if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 			userSession.RefreshTTL = ctx.GetClock().Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 		}
@@ -285,7 +341,7 @@ func FirstFactorReauthenticatePOST(delayer middlewares.Delayer) middlewares.Requ
 		if len(bodyJSON.Flow) > 0 {
 			handleFlowResponse(ctx, &userSession, bodyJSON.FlowID, bodyJSON.Flow, bodyJSON.SubFlow, bodyJSON.UserCode)
 		} else {
-			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, userSession.IsTrustedDevice())
 		}
 	}
 }
@@ -210,6 +210,32 @@ func (s *FirstFactorSuite) TestShouldFailIfUserProviderGetDetailsFail() {
 	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
 }
 
+func (s *FirstFactorSuite) TestShouldFailIfUserDisabled() {
+	attempt := model.AuthenticationAttempt{Username: testValue, Time: s.mock.Clock.Now(), Type: regulation.AuthType1FA, RemoteIP: model.NewNullIPFromString("0.0.0.0")}
+
+	gomock.InOrder(
+		s.mock.UserProviderMock.
+			EXPECT().
+			GetDetails(gomock.Eq(testValue)).
+			Return(nil, authentication.ErrUserDisabled),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(attempt)).
+			Return(nil),
+	)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": true
+	}`)
+
+	FirstFactorPasswordPOST(nil)(s.mock.Ctx)
+
+	s.mock.AssertLastLogMessage(s.T(), "Unsuccessful 1FA authentication attempt by user 'test' as the user is disabled", "")
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
 func (s *FirstFactorSuite) TestShouldFailIfUserProviderGetDetailsFailAndGetIPFail() {
 	attempt := model.AuthenticationAttempt{Time: s.mock.Clock.Now(), Type: regulation.AuthType1FA, RemoteIP: model.NewNullIPFromString("0.0.0.0")}
 
@@ -413,6 +439,69 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
 	assert.Equal(s.T(), []string{"dev", "admins"}, userSession.Groups)
 }
 
+type testPasswordStatusUserProvider struct {
+	*mocks.MockUserProvider
+
+	status *authentication.PasswordStatus
+}
+
+func (p *testPasswordStatusUserProvider) CheckUserPasswordWithStatus(username string, password string) (valid bool, status *authentication.PasswordStatus, err error) {
+	valid, err = p.CheckUserPassword(username, password)
+
+	return valid, p.status, err
+}
+
+func (s *FirstFactorSuite) TestShouldSavePasswordGraceLoginsRemainingInSession() {
+	s.mock.Ctx.Providers.UserProvider = &testPasswordStatusUserProvider{
+		MockUserProvider: s.mock.UserProviderMock,
+		status:           &authentication.PasswordStatus{GraceLoginsRemaining: 2},
+	}
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq(testValue), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq(testValue)).
+		Return(&authentication.UserDetails{
+			Username: testValue,
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedIP(gomock.Eq(s.mock.Ctx), gomock.Eq(model.NewIP(s.mock.Ctx.RemoteIP()))).Return(nil, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedUser(gomock.Eq(s.mock.Ctx), gomock.Eq(testValue)).Return(nil, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPasswordPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	assert.Equal(s.T(), authentication.OneFactor, userSession.AuthenticationLevel(s.mock.Ctx.Configuration.WebAuthn.EnablePasskey2FA))
+	s.Require().NotNil(userSession.PasswordGraceLoginsRemaining)
+	assert.Equal(s.T(), 2, *userSession.PasswordGraceLoginsRemaining)
+}
+
 func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSession() {
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -466,6 +555,109 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 	assert.Equal(s.T(), []string{"dev", "admins"}, userSession.Groups)
 }
 
+func (s *FirstFactorSuite) TestShouldRequirePasswordChangeWhenPasswordExpired() {
+	s.mock.Ctx.Configuration.AuthenticationBackend.PasswordChange.MaxAge = time.Hour * 24 * 90
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq(testValue)).
+		Return(&authentication.UserDetails{
+			Username:            testValue,
+			Emails:              []string{"test@example.com"},
+			Groups:              []string{"dev", "admins"},
+			PasswordLastChanged: time.Now().Add(-time.Hour * 24 * 365),
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedIP(gomock.Eq(s.mock.Ctx), gomock.Eq(model.NewIP(s.mock.Ctx.RemoteIP()))).Return(nil, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedUser(gomock.Eq(s.mock.Ctx), gomock.Eq(testValue)).Return(nil, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq(testValue), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET",
+		"targetURL": "https://test.example.com",
+		"keepMeLoggedIn": false
+	}`)
+
+	FirstFactorPasswordPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	assert.Equal(s.T(), []byte("{\"status\":\"OK\"}"), s.mock.Ctx.Response.Body())
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	assert.Equal(s.T(), testValue, userSession.Username)
+	assert.True(s.T(), userSession.PasswordChangeRequired)
+	assert.True(s.T(), userSession.AuthenticationMethodRefs.UsernameAndPassword)
+	assert.Equal(s.T(), authentication.NotAuthenticated, userSession.AuthenticationLevel(s.mock.Ctx.Configuration.WebAuthn.EnablePasskey2FA))
+}
+
+func (s *FirstFactorSuite) TestShouldFailIfPasswordChangeRequiredAndPasswordChangeDisabled() {
+	s.mock.Ctx.Configuration.AuthenticationBackend.PasswordChange.Disable = true
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq(testValue)).
+		Return(&authentication.UserDetails{
+			Username:               testValue,
+			Emails:                 []string{"test@example.com"},
+			Groups:                 []string{"dev", "admins"},
+			PasswordChangeRequired: true,
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedIP(gomock.Eq(s.mock.Ctx), gomock.Eq(model.NewIP(s.mock.Ctx.RemoteIP()))).Return(nil, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadBannedUser(gomock.Eq(s.mock.Ctx), gomock.Eq(testValue)).Return(nil, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq(testValue), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET",
+		"keepMeLoggedIn": false
+	}`)
+
+	FirstFactorPasswordPOST(nil)(s.mock.Ctx)
+
+	s.mock.AssertLastLogMessage(s.T(), "Unsuccessful 1FA authentication attempt by user 'test' as they are required to change their password but password change is disabled", "")
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	assert.Equal(s.T(), "", userSession.Username)
+}
+
 type FirstFactorRedirectionSuite struct {
 	suite.Suite
 
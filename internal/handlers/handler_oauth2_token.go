@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
@@ -80,6 +82,10 @@ func OAuth2TokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *
 
 	ctx.GetLogger().Debugf("Access Request with id '%s' on client with id '%s' is being processed", requester.GetID(), client.GetID())
 
+	if handled := handleOAuth2TokenRefreshUser(ctx, rw, requester, requester.GetSession().(*oidc.Session)); handled {
+		return
+	}
+
 	if handled := handleOAuth2TokenHydration(ctx, rw, requester, client, requester.GetSession().(*oidc.Session)); handled {
 		return
 	}
@@ -101,6 +107,43 @@ func OAuth2TokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *
 	ctx.Providers.OpenIDConnect.WriteAccessResponse(ctx, rw, requester, responder)
 }
 
+// handleOAuth2TokenRefreshUser ensures the user a refresh token was issued to is still permitted to login.
+func handleOAuth2TokenRefreshUser(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, requester oauthelia2.AccessRequester, session *oidc.Session) (handled bool) {
+	if !requester.GetGrantTypes().ExactOne(oidc.GrantTypeRefreshToken) || len(session.Subject) == 0 {
+		return false
+	}
+
+	var err error
+
+	if _, err = oidc.UserDetailerFromSubjectString(ctx, session.Subject); err == nil {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, authentication.ErrUserDisabled):
+		ctx.GetLogger().
+			WithFields(map[string]any{"oauth2_access_request_id": requester.GetID(), "subject": session.Subject, "username": session.Username}).
+			Error("Access Request with the refresh token grant was rejected as the user is disabled")
+
+		err = oauthelia2.ErrInvalidGrant.WithDebug("The user the refresh token was issued to is disabled.")
+	case errors.Is(err, authentication.ErrUserNotFound):
+		ctx.GetLogger().
+			WithFields(map[string]any{"oauth2_access_request_id": requester.GetID(), "subject": session.Subject, "username": session.Username}).
+			Error("Access Request with the refresh token grant was rejected as the user was not found")
+
+		err = oauthelia2.ErrInvalidGrant.WithDebug("The user the refresh token was issued to no longer exists.")
+	default:
+		ctx.GetLogger().
+			WithFields(map[string]any{"oauth2_access_request_id": requester.GetID()}).
+			WithError(oauthelia2.ErrorToDebugRFC6749Error(err)).
+			Error("Access Request with the refresh token grant encountered an error while trying to lookup the user")
+	}
+
+	ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, requester, err)
+
+	return true
+}
+
 func handleOAuth2TokenHydration(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, requester oauthelia2.AccessRequester, client oidc.Client, session *oidc.Session) (handled bool) {
 	var err error
 
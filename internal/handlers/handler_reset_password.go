@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -160,13 +161,39 @@ func ResetPasswordPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if err = ctx.Providers.PasswordPolicy.Check(requestBody.Password); err != nil {
-		ctx.Error(err, messagePasswordWeak)
+	if err = ctx.Providers.PasswordPolicy.Check(ctx, requestBody.Password); err != nil {
+		switch {
+		case errors.Is(err, middlewares.ErrPasswordPolicyUnavailable):
+			ctx.Error(err, messageOperationFailed)
+		case errors.Is(err, middlewares.ErrPasswordPolicyBreached):
+			ctx.Error(err, messagePasswordBreached)
+		default:
+			ctx.Error(err, messagePasswordWeak)
+		}
+
+		return
+	}
+
+	var reused bool
+
+	if reused, err = isPasswordInHistory(ctx, username, requestBody.Password); err != nil {
+		ctx.Error(err, messageUnableToResetPassword)
+		return
+	}
+
+	if reused {
+		ctx.Error(fmt.Errorf("password of user %s was used recently", username), messagePasswordReused)
 		return
 	}
 
 	if err = ctx.Providers.UserProvider.UpdatePassword(username, requestBody.Password); err != nil {
 		switch {
+		case errors.Is(err, authentication.ErrPasswordTooShort):
+			ctx.Error(err, messagePasswordTooShort)
+		case errors.Is(err, authentication.ErrPasswordInHistory):
+			ctx.Error(err, messagePasswordReused)
+		case errors.Is(err, authentication.ErrPasswordTooYoung):
+			ctx.Error(err, messagePasswordTooYoung)
 		case utils.IsStringInSliceContains(err.Error(), ldapPasswordComplexityCodes),
 			utils.IsStringInSliceContains(err.Error(), ldapPasswordComplexityErrors):
 			ctx.Error(err, ldapPasswordComplexityCode)
@@ -179,6 +206,10 @@ func ResetPasswordPOST(ctx *middlewares.AutheliaCtx) {
 
 	ctx.GetLogger().Debugf("Password of user %s has been reset", username)
 
+	if err = savePasswordHistory(ctx, username, requestBody.Password); err != nil {
+		ctx.GetLogger().WithError(err).WithFields(map[string]any{"username": username}).Error("Error occurred saving the password history")
+	}
+
 	userSession.PasswordResetUsername = nil
 
 	if err = ctx.SaveSession(userSession); err != nil {
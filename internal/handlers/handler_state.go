@@ -23,6 +23,9 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 		Username:            userSession.Username,
 		AuthenticationLevel: userSession.AuthenticationLevel(ctx.Configuration.WebAuthn.EnablePasskey2FA),
 		FactorKnowledge:     userSession.AuthenticationMethodRefs.FactorKnowledge(),
+
+		PasswordChangeRequired:       userSession.PasswordChangeRequired,
+		PasswordGraceLoginsRemaining: userSession.PasswordGraceLoginsRemaining,
 	}
 
 	if uri := ctx.GetDefaultRedirectionURL(); uri != nil {
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// UserSessionsGET lists the active sessions of the current user.
+func UserSessionsGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		provider    *session.Session
+		userSession session.UserSession
+		records     []session.UserSessionRecord
+		err         error
+	)
+
+	if provider, userSession, err = getUserSessionsProvider(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred listing user sessions")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	if records, err = provider.UserSessionIndex().List(userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing user sessions for user '%s'", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	current := provider.GetSessionID(ctx.RequestCtx)
+
+	response := make([]bodyGETUserSession, len(records))
+
+	for i, record := range records {
+		response[i] = bodyGETUserSession{
+			ID:           record.PublicID(),
+			CookieDomain: record.CookieDomain,
+			RemoteIP:     record.RemoteIP,
+			UserAgent:    record.UserAgent,
+			Created:      record.Created,
+			LastActivity: record.LastActivity,
+			AMR:          record.AMR,
+			Current:      record.ID == current,
+		}
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing user sessions for user '%s': %s", userSession.Username, errStrRespBody)
+	}
+}
+
+// UserSessionsDELETE revokes all sessions of the current user except the current session.
+func UserSessionsDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		provider    *session.Session
+		userSession session.UserSession
+		err         error
+	)
+
+	if provider, userSession, err = getUserSessionsProvider(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred revoking user sessions")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	current := provider.GetSessionID(ctx.RequestCtx)
+
+	revokeUserSessions(ctx, provider, userSession.Username, func(record session.UserSessionRecord) bool {
+		return record.ID != current
+	})
+}
+
+// UserSessionDELETE revokes an individual session of the current user.
+func UserSessionDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		provider    *session.Session
+		userSession session.UserSession
+		err         error
+	)
+
+	if provider, userSession, err = getUserSessionsProvider(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred revoking user session")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	id, ok := ctx.UserValue("sessionID").(string)
+	if !ok || id == "" {
+		ctx.Logger.Errorf("Error occurred revoking user session for user '%s': the session id was not provided", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	current := provider.GetSessionID(ctx.RequestCtx)
+
+	if session.NewUserSessionRecordPublicID(current) == id {
+		ctx.Logger.Errorf("Error occurred revoking user session for user '%s': the current session can't be revoked and the user should log out instead", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	revokeUserSessions(ctx, provider, userSession.Username, func(record session.UserSessionRecord) bool {
+		return record.PublicID() == id
+	})
+}
+
+func getUserSessionsProvider(ctx *middlewares.AutheliaCtx) (provider *session.Session, userSession session.UserSession, err error) {
+	if provider, err = ctx.GetSessionProvider(); err != nil {
+		return nil, userSession, err
+	}
+
+	if userSession, err = provider.GetSession(ctx.RequestCtx); err != nil {
+		return nil, userSession, fmt.Errorf("%s: %w", errStrUserSessionData, err)
+	}
+
+	if userSession.IsAnonymous() {
+		return nil, userSession, errUserAnonymous
+	}
+
+	if provider.UserSessionIndex() == nil {
+		return nil, userSession, fmt.Errorf("the session provider does not have a user session index")
+	}
+
+	return provider, userSession, nil
+}
+
+func revokeUserSessions(ctx *middlewares.AutheliaCtx, provider *session.Session, username string, filter func(record session.UserSessionRecord) bool) {
+	revoked, err := provider.UserSessionIndex().Revoke(username, filter)
+
+	for _, record := range revoked {
+		ctx.Logger.WithFields(map[string]any{"username": username, "session": record.PublicID(), "remote_ip": record.RemoteIP}).
+			Info("User session was revoked by the user")
+	}
+
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking user sessions for user '%s'", username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(bodyDELETEUserSessions{Revoked: len(revoked)}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking user sessions for user '%s': %s", username, errStrRespBody)
+	}
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+func setupUserSessionsTest(t *testing.T, mock *mocks.MockAutheliaCtx) (provider *session.Session, other string) {
+	var err error
+
+	provider, err = mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	userSession := provider.NewDefaultUserSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+
+	require.NoError(t, provider.SaveSession(mock.Ctx.RequestCtx, userSession))
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetUserAgent("Other/1.0")
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	return provider, provider.GetSessionID(ctx)
+}
+
+func TestUserSessionsGET(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	_, other := setupUserSessionsTest(t, mock)
+
+	UserSessionsGET(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	var response struct {
+		Status string               `json:"status"`
+		Data   []bodyGETUserSession `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), &response))
+	require.Len(t, response.Data, 2)
+
+	var current, others int
+
+	for _, s := range response.Data {
+		assert.Equal(t, "example.com", s.CookieDomain)
+		assert.Equal(t, []string{"pwd"}, s.AMR)
+
+		if s.Current {
+			current++
+
+			continue
+		}
+
+		others++
+
+		assert.Equal(t, session.NewUserSessionRecordPublicID(other), s.ID)
+		assert.Equal(t, "Other/1.0", s.UserAgent)
+	}
+
+	assert.Equal(t, 1, current)
+	assert.Equal(t, 1, others)
+}
+
+func TestUserSessionsGETShouldFailAnonymous(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	UserSessionsGET(mock.Ctx)
+
+	mock.Assert403KO(t, messageOperationFailed)
+	mock.AssertLastLogMessage(t, "Error occurred listing user sessions", "user is anonymous")
+}
+
+func TestUserSessionsDELETE(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	provider, other := setupUserSessionsTest(t, mock)
+
+	UserSessionsDELETE(mock.Ctx)
+
+	mock.Assert200OK(t, bodyDELETEUserSessions{Revoked: 1})
+
+	records, err := provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, provider.GetSessionID(mock.Ctx.RequestCtx), records[0].ID)
+	assert.NotEqual(t, other, records[0].ID)
+}
+
+func TestUserSessionDELETE(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     func(current, other string) any
+		expected int
+		status   int
+	}{
+		{
+			"ShouldRevokeOtherSession",
+			func(current, other string) any { return session.NewUserSessionRecordPublicID(other) },
+			1,
+			fasthttp.StatusOK,
+		},
+		{
+			"ShouldNotRevokeUnknownSession",
+			func(current, other string) any { return "abc" },
+			0,
+			fasthttp.StatusOK,
+		},
+		{
+			"ShouldNotRevokeCurrentSession",
+			func(current, other string) any { return session.NewUserSessionRecordPublicID(current) },
+			0,
+			fasthttp.StatusBadRequest,
+		},
+		{
+			"ShouldNotRevokeMissingSessionID",
+			func(current, other string) any { return nil },
+			0,
+			fasthttp.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			provider, other := setupUserSessionsTest(t, mock)
+
+			if value := tc.have(provider.GetSessionID(mock.Ctx.RequestCtx), other); value != nil {
+				mock.Ctx.SetUserValue("sessionID", value)
+			}
+
+			UserSessionDELETE(mock.Ctx)
+
+			records, err := provider.UserSessionIndex().List(testUsername)
+			require.NoError(t, err)
+
+			assert.Len(t, records, 2-tc.expected)
+
+			if tc.status == fasthttp.StatusOK {
+				mock.Assert200OK(t, bodyDELETEUserSessions{Revoked: tc.expected})
+			} else {
+				mock.AssertKO(t, messageOperationFailed, tc.status)
+			}
+		})
+	}
+}
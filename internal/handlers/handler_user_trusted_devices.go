@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// UserTrustedDevicesGET lists the trusted devices of the current user.
+func UserTrustedDevicesGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		devices     []model.TrustedDevice
+		err         error
+	)
+
+	if userSession, err = getUserTrustedDevicesSession(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred listing trusted devices")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	if devices, err = ctx.Providers.StorageProvider.LoadTrustedDevices(ctx, userSession.Username, ctx.GetClock().Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing trusted devices for user '%s': error occurred loading the devices from the storage backend", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	current, _ := getTrustedDeviceIDFromCookie(ctx, userSession.Username)
+
+	response := make([]bodyGETUserTrustedDevice, len(devices))
+
+	for i, device := range devices {
+		response[i] = bodyGETUserTrustedDevice{
+			ID:        device.PublicID.String(),
+			RemoteIP:  device.IP.String(),
+			UserAgent: device.UserAgent,
+			Created:   device.CreatedAt,
+			Expires:   device.ExpiresAt,
+			Current:   device.PublicID == current,
+		}
+
+		if device.LastUsedAt.Valid {
+			response[i].LastUsed = &device.LastUsedAt.Time
+		}
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing trusted devices for user '%s': %s", userSession.Username, errStrRespBody)
+	}
+}
+
+// UserTrustedDevicesPOST trusts the current device of a user who has completed the second factor and sets the cookie
+// which identifies it.
+func UserTrustedDevicesPOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		device      *model.TrustedDevice
+		issuerURL   *url.URL
+		key         []byte
+		token       string
+		err         error
+	)
+
+	if userSession, err = getUserTrustedDevicesSession(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trusting device")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	if userSession.AuthenticationLevel(ctx.Configuration.WebAuthn.EnablePasskey2FA) < authentication.TwoFactor {
+		ctx.Logger.Errorf("Error occurred trusting device for user '%s': the user has not completed the second factor", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	config := ctx.Configuration.Session.TrustedDevices
+
+	if device, err = model.NewTrustedDevice(ctx, userSession.Username, string(ctx.UserAgent()), config.Lifespan); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred generating the device", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if issuerURL, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred determining the issuer", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if key, err = getTrustedDeviceSigningKey(ctx.Configuration.Session.Secret); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred deriving the signing key", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, device.ToClaims(issuerURL.String())).SignedString(key); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred signing the token", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveTrustedDevice(ctx, *device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred saving the device to the storage backend", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(config.Name)
+	cookie.SetValue(token)
+	cookie.SetPath("/")
+	cookie.SetExpire(device.ExpiresAt)
+	cookie.SetHTTPOnly(true)
+	cookie.SetSecure(true)
+	cookie.SetSameSite(fasthttp.CookieSameSiteStrictMode)
+
+	ctx.Response.Header.SetCookie(cookie)
+
+	ctx.Logger.WithFields(map[string]any{"username": userSession.Username, "device": device.PublicID.String(), "remote_ip": device.IP.String()}).
+		Info("User has trusted a device")
+
+	ctx.ReplyOK()
+}
+
+// UserTrustedDeviceDELETE revokes a trusted device of the current user.
+func UserTrustedDeviceDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		id          uuid.UUID
+		err         error
+	)
+
+	if userSession, err = getUserTrustedDevicesSession(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred revoking trusted device")
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+
+		return
+	}
+
+	value, ok := ctx.UserValue("deviceID").(string)
+	if !ok {
+		ctx.Logger.Errorf("Error occurred revoking trusted device for user '%s': the device id was not provided", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	if id, err = uuid.Parse(value); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': the device id is not valid", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+
+		return
+	}
+
+	switch err = ctx.Providers.StorageProvider.RevokeTrustedDevice(ctx, userSession.Username, id, ctx.GetClock().Now()); {
+	case err == nil:
+		break
+	case errors.Is(err, sql.ErrNoRows):
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': the device does not exist or belongs to another user", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+		ctx.SetStatusCode(fasthttp.StatusNotFound)
+
+		return
+	default:
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': error occurred revoking the device in the storage backend", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if current, _ := getTrustedDeviceIDFromCookie(ctx, userSession.Username); current == id {
+		ctx.Response.Header.DelClientCookie(ctx.Configuration.Session.TrustedDevices.Name)
+	}
+
+	// The current session stops being trusted immediately while any other session which was established from the
+	// revoked device stops being trusted the next time the authorization endpoints check the device.
+	if userSession.TrustedDeviceID != nil && *userSession.TrustedDeviceID == id {
+		userSession.TrustedDeviceID = nil
+
+		if err = ctx.SaveSession(userSession); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': %s", userSession.Username, errStrUserSessionDataSave)
+		}
+	}
+
+	ctx.Logger.WithFields(map[string]any{"username": userSession.Username, "device": id.String()}).
+		Info("User has revoked a trusted device")
+
+	ctx.ReplyOK()
+}
+
+// handleTrustedDevice returns the public ID of the device if the request has the cookie of a device the user has trusted
+// which has neither been revoked nor expired, in which case the time the device was last used is updated. Otherwise it
+// returns nil.
+func handleTrustedDevice(ctx *middlewares.AutheliaCtx, username string) (trusted *uuid.UUID) {
+	if ctx.Configuration.Session.TrustedDevices == nil || !ctx.Providers.Authorizer.IsSecondFactorEnabled() {
+		return nil
+	}
+
+	id, err := getTrustedDeviceIDFromCookie(ctx, username)
+
+	switch {
+	case err != nil:
+		ctx.Logger.WithError(err).Warnf("Error occurred validating the trusted device cookie for user '%s'", username)
+
+		return nil
+	case id == uuid.Nil:
+		return nil
+	}
+
+	var device *model.TrustedDevice
+
+	if device, err = ctx.Providers.StorageProvider.LoadTrustedDevice(ctx, id); err != nil {
+		ctx.Logger.WithError(err).Warnf("Error occurred loading the trusted device for user '%s' from the storage backend", username)
+
+		return nil
+	}
+
+	now := ctx.GetClock().Now()
+
+	if device.Username != username || !device.IsTrusted(now) {
+		ctx.Logger.WithFields(map[string]any{"username": username, "device": id.String()}).
+			Debug("User has authenticated from a device which is no longer trusted")
+
+		return nil
+	}
+
+	if err = ctx.Providers.StorageProvider.UpdateTrustedDeviceLastUsed(ctx, id, now); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred updating the trusted device for user '%s' in the storage backend", username)
+	}
+
+	ctx.Logger.WithFields(map[string]any{"username": username, "device": id.String()}).
+		Debug("User has authenticated from a trusted device")
+
+	return &id
+}
+
+// getTrustedDeviceIDFromCookie returns the id of the trusted device from the signed cookie of the request. It returns
+// uuid.Nil without an error if the request does not have the cookie.
+func getTrustedDeviceIDFromCookie(ctx *middlewares.AutheliaCtx, username string) (id uuid.UUID, err error) {
+	value := ctx.Request.Header.Cookie(ctx.Configuration.Session.TrustedDevices.Name)
+
+	if len(value) == 0 {
+		return uuid.Nil, nil
+	}
+
+	var (
+		issuerURL *url.URL
+		key       []byte
+	)
+
+	if issuerURL, err = ctx.IssuerURL(); err != nil {
+		return uuid.Nil, fmt.Errorf("error occurred determining the issuer: %w", err)
+	}
+
+	if key, err = getTrustedDeviceSigningKey(ctx.Configuration.Session.Secret); err != nil {
+		return uuid.Nil, fmt.Errorf("error occurred deriving the signing key: %w", err)
+	}
+
+	claims := &jwt.RegisteredClaims{}
+
+	if _, err = jwt.ParseWithClaims(string(value), claims,
+		func(token *jwt.Token) (any, error) {
+			return key, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(issuerURL.String()),
+		jwt.WithSubject(username),
+		jwt.WithExpirationRequired(),
+		jwt.WithStrictDecoding(),
+		ctx.GetClock().GetJWTWithTimeFuncOption(),
+	); err != nil {
+		return uuid.Nil, err
+	}
+
+	if id, err = uuid.Parse(claims.ID); err != nil {
+		return uuid.Nil, fmt.Errorf("error occurred parsing the device id: %w", err)
+	}
+
+	return id, nil
+}
+
+// getTrustedDeviceSigningKey derives the key used to sign the trusted device cookie from the session secret so the key
+// is only valid for this purpose.
+func getTrustedDeviceSigningKey(secret string) (key []byte, err error) {
+	return hkdf.Key(sha256.New, []byte(secret), nil, trustedDeviceSigningKeyInfo, sha256.Size)
+}
+
+func getUserTrustedDevicesSession(ctx *middlewares.AutheliaCtx) (userSession session.UserSession, err error) {
+	if userSession, err = ctx.GetSession(); err != nil {
+		return userSession, fmt.Errorf("%s: %w", errStrUserSessionData, err)
+	}
+
+	if userSession.IsAnonymous() {
+		return userSession, errUserAnonymous
+	}
+
+	return userSession, nil
+}
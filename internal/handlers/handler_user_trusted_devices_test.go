@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func setupTrustedDevicesTest(t *testing.T, twoFactor bool) (mock *mocks.MockAutheliaCtx) {
+	mock = mocks.NewMockAutheliaCtx(t)
+
+	mock.Ctx.Providers.Clock = &mock.Clock
+	mock.Ctx.Configuration.Session.Secret = "a_very_important_secret"
+	mock.Ctx.Configuration.Session.TrustedDevices = &schema.SessionTrustedDevices{Name: "authelia_trusted_device", Lifespan: time.Hour}
+
+	userSession, err := mock.Ctx.GetSession()
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = twoFactor
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	return mock
+}
+
+func newTestTrustedDevice(mock *mocks.MockAutheliaCtx, username string) model.TrustedDevice {
+	now := mock.Clock.Now()
+
+	return model.TrustedDevice{
+		ID:        1,
+		PublicID:  uuid.New(),
+		CreatedAt: now.Add(-time.Minute),
+		ExpiresAt: now.Add(time.Hour),
+		Username:  username,
+		IP:        model.NewIP(net.ParseIP("127.0.0.1")),
+		UserAgent: "Test/1.0",
+	}
+}
+
+func setTestTrustedDeviceCookie(t *testing.T, mock *mocks.MockAutheliaCtx, device model.TrustedDevice, secret string) {
+	key, err := getTrustedDeviceSigningKey(secret)
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, device.ToClaims("https://login.example.com:8080")).SignedString(key)
+	require.NoError(t, err)
+
+	mock.Ctx.Request.Header.SetCookie("authelia_trusted_device", token)
+}
+
+func TestUserTrustedDevicesPOST(t *testing.T) {
+	mock := setupTrustedDevicesTest(t, true)
+
+	defer mock.Close()
+
+	mock.Ctx.Request.Header.SetUserAgent("Test/1.0")
+
+	var saved model.TrustedDevice
+
+	mock.StorageMock.EXPECT().
+		SaveTrustedDevice(mock.Ctx, gomock.Any()).
+		DoAndReturn(func(_ any, device model.TrustedDevice) error {
+			saved = device
+
+			return nil
+		})
+
+	UserTrustedDevicesPOST(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+	assert.Equal(t, testUsername, saved.Username)
+	assert.Equal(t, "Test/1.0", saved.UserAgent)
+	assert.Equal(t, mock.Clock.Now().Add(time.Hour), saved.ExpiresAt)
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey("authelia_trusted_device")
+
+	require.True(t, mock.Ctx.Response.Header.Cookie(cookie))
+
+	assert.True(t, cookie.HTTPOnly())
+	assert.True(t, cookie.Secure())
+	assert.Equal(t, fasthttp.CookieSameSiteStrictMode, cookie.SameSite())
+	assert.Equal(t, []byte("/"), cookie.Path())
+	assert.Empty(t, cookie.Domain())
+
+	mock.Ctx.Request.Header.SetCookieBytesKV(cookie.Key(), cookie.Value())
+
+	id, err := getTrustedDeviceIDFromCookie(mock.Ctx, testUsername)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved.PublicID, id)
+}
+
+func TestUserTrustedDevicesPOSTShouldFailOneFactor(t *testing.T) {
+	mock := setupTrustedDevicesTest(t, false)
+
+	defer mock.Close()
+
+	UserTrustedDevicesPOST(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+	assert.Equal(t, "Error occurred trusting device for user 'john': the user has not completed the second factor", mock.Hook.LastEntry().Message)
+}
+
+func TestUserTrustedDevicesGET(t *testing.T) {
+	mock := setupTrustedDevicesTest(t, false)
+
+	defer mock.Close()
+
+	current, other := newTestTrustedDevice(mock, testUsername), newTestTrustedDevice(mock, testUsername)
+	other.LastUsedAt = sql.NullTime{Time: mock.Clock.Now(), Valid: true}
+
+	setTestTrustedDeviceCookie(t, mock, current, "a_very_important_secret")
+
+	mock.StorageMock.EXPECT().
+		LoadTrustedDevices(mock.Ctx, testUsername, mock.Clock.Now()).
+		Return([]model.TrustedDevice{other, current}, nil)
+
+	UserTrustedDevicesGET(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	var response struct {
+		Status string                     `json:"status"`
+		Data   []bodyGETUserTrustedDevice `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), &response))
+	require.Len(t, response.Data, 2)
+
+	assert.Equal(t, other.PublicID.String(), response.Data[0].ID)
+	assert.False(t, response.Data[0].Current)
+	require.NotNil(t, response.Data[0].LastUsed)
+
+	assert.Equal(t, current.PublicID.String(), response.Data[1].ID)
+	assert.True(t, response.Data[1].Current)
+	assert.Nil(t, response.Data[1].LastUsed)
+	assert.Equal(t, "127.0.0.1", response.Data[1].RemoteIP)
+	assert.Equal(t, "Test/1.0", response.Data[1].UserAgent)
+}
+
+func TestUserTrustedDevicesGETShouldFailAnonymous(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Configuration.Session.TrustedDevices = &schema.SessionTrustedDevices{Name: "authelia_trusted_device", Lifespan: time.Hour}
+
+	UserTrustedDevicesGET(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+}
+
+func TestUserTrustedDeviceDELETE(t *testing.T) {
+	id := uuid.MustParse("8d8f4f3e-8c9a-4b4e-9f0a-5d1f0b0c3a21")
+
+	testCases := []struct {
+		name     string
+		value    any
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected int
+	}{
+		{
+			"ShouldRevoke",
+			id.String(),
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().RevokeTrustedDevice(mock.Ctx, testUsername, id, mock.Clock.Now()).Return(nil)
+			},
+			fasthttp.StatusOK,
+		},
+		{
+			"ShouldRevokeCurrent",
+			id.String(),
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+				device.PublicID = id
+
+				setTestTrustedDeviceCookie(t, mock, device, "a_very_important_secret")
+
+				userSession, err := mock.Ctx.GetSession()
+				require.NoError(t, err)
+
+				userSession.TrustedDeviceID = &id
+
+				require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+				mock.StorageMock.EXPECT().RevokeTrustedDevice(mock.Ctx, testUsername, id, mock.Clock.Now()).Return(nil)
+			},
+			fasthttp.StatusOK,
+		},
+		{
+			"ShouldRevokeCurrentWithoutCookie",
+			id.String(),
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				userSession, err := mock.Ctx.GetSession()
+				require.NoError(t, err)
+
+				userSession.TrustedDeviceID = &id
+
+				require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+				mock.StorageMock.EXPECT().RevokeTrustedDevice(mock.Ctx, testUsername, id, mock.Clock.Now()).Return(nil)
+			},
+			fasthttp.StatusOK,
+		},
+		{
+			"ShouldFailNotFound",
+			id.String(),
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().RevokeTrustedDevice(mock.Ctx, testUsername, id, mock.Clock.Now()).Return(fmt.Errorf("error revoking trusted device: %w", sql.ErrNoRows))
+			},
+			fasthttp.StatusNotFound,
+		},
+		{
+			"ShouldFailInvalidID",
+			"abc",
+			nil,
+			fasthttp.StatusBadRequest,
+		},
+		{
+			"ShouldFailMissingID",
+			nil,
+			nil,
+			fasthttp.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := setupTrustedDevicesTest(t, false)
+
+			defer mock.Close()
+
+			if tc.value != nil {
+				mock.Ctx.SetUserValue("deviceID", tc.value)
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			UserTrustedDeviceDELETE(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Nil(t, userSession.TrustedDeviceID)
+		})
+	}
+}
+
+func TestHandleTrustedDevice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected bool
+	}{
+		{
+			"ShouldNotTrustWithoutCookie",
+			nil,
+			false,
+		},
+		{
+			"ShouldTrust",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+
+				setTestTrustedDeviceCookie(t, mock, device, "a_very_important_secret")
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, device.PublicID).Return(&device, nil),
+					mock.StorageMock.EXPECT().UpdateTrustedDeviceLastUsed(mock.Ctx, device.PublicID, mock.Clock.Now()).Return(nil),
+				)
+			},
+			true,
+		},
+		{
+			"ShouldNotTrustRevoked",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+				device.RevokedAt = sql.NullTime{Time: mock.Clock.Now(), Valid: true}
+
+				setTestTrustedDeviceCookie(t, mock, device, "a_very_important_secret")
+
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, device.PublicID).Return(&device, nil)
+			},
+			false,
+		},
+		{
+			"ShouldNotTrustNotFound",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+
+				setTestTrustedDeviceCookie(t, mock, device, "a_very_important_secret")
+
+				mock.StorageMock.EXPECT().LoadTrustedDevice(mock.Ctx, device.PublicID).Return(nil, sql.ErrNoRows)
+			},
+			false,
+		},
+		{
+			"ShouldNotTrustOtherUser",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setTestTrustedDeviceCookie(t, mock, newTestTrustedDevice(mock, "harry"), "a_very_important_secret")
+			},
+			false,
+		},
+		{
+			"ShouldNotTrustBadSignature",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setTestTrustedDeviceCookie(t, mock, newTestTrustedDevice(mock, testUsername), "another_secret")
+			},
+			false,
+		},
+		{
+			"ShouldNotTrustSessionSecretSignature",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, device.ToClaims("https://login.example.com:8080")).SignedString([]byte("a_very_important_secret"))
+				require.NoError(t, err)
+
+				mock.Ctx.Request.Header.SetCookie("authelia_trusted_device", token)
+			},
+			false,
+		},
+		{
+			"ShouldNotTrustExpiredToken",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				device := newTestTrustedDevice(mock, testUsername)
+				device.ExpiresAt = mock.Clock.Now().Add(-time.Second)
+
+				setTestTrustedDeviceCookie(t, mock, device, "a_very_important_secret")
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := setupTrustedDevicesTest(t, false)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			assert.Equal(t, tc.expected, handleTrustedDevice(mock.Ctx, testUsername) != nil)
+		})
+	}
+}
+
+func TestHandleTrustedDeviceShouldNotTrustWithoutConfiguration(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Request.Header.SetCookie("authelia_trusted_device", "abc")
+
+	assert.Nil(t, handleTrustedDevice(mock.Ctx, testUsername))
+}
+
+func TestHandle1FAResponseTrustedDevice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		trusted  bool
+		expected any
+	}{
+		{
+			"ShouldRedirectTrustedDevice",
+			true,
+			&redirectResponse{Redirect: "https://two-factor.example.com"},
+		},
+		{
+			"ShouldNotRedirectUntrustedDevice",
+			false,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			Handle1FAResponse(mock.Ctx, "https://two-factor.example.com", fasthttp.MethodGet, testUsername, nil, tc.trusted)
+
+			mock.Assert200OK(t, tc.expected)
+		})
+	}
+}
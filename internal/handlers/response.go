@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
@@ -22,14 +23,15 @@ import (
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
-// Handle1FAResponse handle the redirection upon 1FA authentication.
-func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, username string, groups []string) {
+// Handle1FAResponse handle the redirection upon 1FA authentication. The trusted parameter indicates the first factor was
+// completed from a trusted device which satisfies the 2FA requirement of rules which allow trusted devices.
+func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, username string, groups []string, trusted bool) {
 	var err error
 
 	if len(targetURI) == 0 {
 		defaultRedirectionURL := ctx.GetDefaultRedirectionURL()
 
-		if !ctx.Providers.Authorizer.IsSecondFactorEnabled() && defaultRedirectionURL != nil {
+		if (!ctx.Providers.Authorizer.IsSecondFactorEnabled() || trusted) && defaultRedirectionURL != nil {
 			if err = ctx.SetJSONBody(redirectResponse{Redirect: defaultRedirectionURL.String()}); err != nil {
 				ctx.Logger.Errorf("Unable to set default redirection URL in body: %s", err)
 			}
@@ -48,7 +50,7 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, u
 		return
 	}
 
-	_, requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
+	rule, requiredLevel := ctx.Providers.Authorizer.GetRequiredRule(
 		authorization.Subject{
 			Username: username,
 			Groups:   groups,
@@ -58,7 +60,7 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, u
 
 	ctx.Logger.Debugf("Required level for the URL %s is %s", targetURI, requiredLevel)
 
-	if requiredLevel == authorization.TwoFactor {
+	if requiredLevel == authorization.TwoFactor && !(trusted && rule.AllowsTrustedDevices()) {
 		ctx.Logger.Warnf("%s requires 2FA, cannot be redirected yet", targetURI)
 		ctx.ReplyOK()
 
@@ -136,13 +138,13 @@ func Handle2FAResponse(ctx *middlewares.AutheliaCtx, targetURI string) {
 }
 
 // HandlePasskeyResponse is a specialized handler for the Passkey login flow which switches adaptively between the 1FA and 2FA response handlers respectively.
-func HandlePasskeyResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, username string, groups []string, isTwoFactor bool) {
+func HandlePasskeyResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod, username string, groups []string, isTwoFactor, trusted bool) {
 	if isTwoFactor {
 		Handle2FAResponse(ctx, targetURI)
 		return
 	}
 
-	Handle1FAResponse(ctx, targetURI, requestMethod, username, groups)
+	Handle1FAResponse(ctx, targetURI, requestMethod, username, groups, trusted)
 }
 
 func handleFlowResponse(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, id, flow, subflow, userCode string) {
@@ -488,6 +490,8 @@ func doMarkAuthenticationAttemptWithRequest(ctx markContext, successful bool, ba
 		ctx.GetLogger().Debugf("Successful %s authentication attempt made by user '%s'", authType, ban.Value())
 	} else {
 		switch {
+		case errors.Is(errAuth, authentication.ErrUserDisabled):
+			ctx.GetLogger().Errorf("Unsuccessful %s authentication attempt by user '%s' as the user is disabled", authType, ban.Value())
 		case errAuth != nil:
 			ctx.GetLogger().WithError(errAuth).Errorf("Unsuccessful %s authentication attempt by user '%s'", authType, ban.Value())
 		case ban.IsBanned():
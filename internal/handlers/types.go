@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -23,6 +24,7 @@ type configurationBody struct {
 	AvailableMethods       MethodList `json:"available_methods"`
 	PasswordChangeDisabled bool       `json:"password_change_disabled"`
 	PasswordResetDisabled  bool       `json:"password_reset_disabled"`
+	TrustedDevicesEnabled  bool       `json:"trusted_devices_enabled"`
 }
 
 type bodySignTOTPRequest struct {
@@ -79,6 +81,36 @@ type bodyPOSTUserSessionElevate struct {
 	DeleteID string `json:"delete_id"`
 }
 
+type bodyGETUserSession struct {
+	ID           string    `json:"id"`
+	CookieDomain string    `json:"cookie_domain"`
+	RemoteIP     string    `json:"remote_ip"`
+	UserAgent    string    `json:"user_agent"`
+	Created      time.Time `json:"created"`
+	LastActivity time.Time `json:"last_activity"`
+	AMR          []string  `json:"amr"`
+	Current      bool      `json:"current"`
+}
+
+type bodyDELETEUserSessions struct {
+	Revoked int `json:"revoked"`
+}
+
+type bodyGETUserTrustedDevice struct {
+	ID        string     `json:"id"`
+	RemoteIP  string     `json:"remote_ip"`
+	UserAgent string     `json:"user_agent"`
+	Created   time.Time  `json:"created"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+	Expires   time.Time  `json:"expires"`
+	Current   bool       `json:"current"`
+}
+
+type bodyDELETEAdminSessions struct {
+	Revoked       int `json:"revoked"`
+	RevokedOAuth2 int `json:"revoked_oauth2"`
+}
+
 type bodyPUTUserSessionElevate struct {
 	OneTimeCode string `json:"otc"`
 }
@@ -185,10 +217,13 @@ type DuoSignResponse struct {
 
 // StateResponse represents the response sent by the state endpoint.
 type StateResponse struct {
-	Username              string               `json:"username"`
-	AuthenticationLevel   authentication.Level `json:"authentication_level"`
-	FactorKnowledge       bool                 `json:"factor_knowledge"`
-	DefaultRedirectionURL string               `json:"default_redirection_url,omitempty"`
+	Username               string               `json:"username"`
+	AuthenticationLevel    authentication.Level `json:"authentication_level"`
+	FactorKnowledge        bool                 `json:"factor_knowledge"`
+	DefaultRedirectionURL  string               `json:"default_redirection_url,omitempty"`
+	PasswordChangeRequired bool                 `json:"password_change_required,omitempty"`
+
+	PasswordGraceLoginsRemaining *int `json:"password_grace_logins_remaining,omitempty"`
 }
 
 type resetPasswordStep1RequestBody struct {
@@ -219,6 +254,7 @@ type PasswordPolicyBody struct {
 	RequireLowercase bool   `json:"require_lowercase"`
 	RequireNumber    bool   `json:"require_number"`
 	RequireSpecial   bool   `json:"require_special"`
+	Breached         bool   `json:"breached"`
 }
 
 type handlerAuthorizationConsent func(
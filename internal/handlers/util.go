@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-crypt/crypt/algorithm"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
-	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/model"
 )
 
 const (
@@ -32,50 +35,10 @@ const (
 	eventLogCategoryWebAuthnCredential = "WebAuthn Credential" //nolint:gosec
 )
 
-type emailEventBody struct {
-	Prefix string
-	Body   string
-	Suffix string
-}
+type emailEventBody = middlewares.EventEmailBody
 
 func ctxLogEvent(ctx *middlewares.AutheliaCtx, username, description string, body emailEventBody, eventDetails map[string]any) {
-	var (
-		details *authentication.UserDetails
-		err     error
-	)
-
-	ctx.Logger.Debugf("Getting user details for notification")
-
-	if details, err = ctx.Providers.UserProvider.GetDetails(username); err != nil {
-		ctx.Logger.WithError(err).Errorf("Error occurred looking up user details for user '%s' while attempting to alert them of an important event", username)
-		return
-	}
-
-	if len(details.Emails) == 0 {
-		ctx.Logger.WithError(fmt.Errorf("no email address was found for user")).Errorf("Error occurred looking up user details for user '%s' while attempting to alert them of an important event", username)
-		return
-	}
-
-	data := templates.EmailEventValues{
-		Title:       description,
-		DisplayName: details.DisplayName,
-		RemoteIP:    ctx.RemoteIP().String(),
-		Details:     eventDetails,
-		BodyPrefix:  body.Prefix,
-		BodyEvent:   body.Body,
-		BodySuffix:  body.Suffix,
-	}
-
-	ctx.Logger.Debugf("Getting user addresses for notification")
-
-	addresses := details.Addresses()
-
-	ctx.Logger.Debugf("Sending an email to user %s (%s) to inform them of an important event.", username, addresses[0].String())
-
-	if err = ctx.Providers.Notifier.Send(ctx, addresses[0], description, ctx.Providers.Templates.GetEventEmailTemplate(), data); err != nil {
-		ctx.Logger.WithError(err).Errorf("Error occurred sending notification to user '%s' while attempting to alert them of an important event", username)
-		return
-	}
+	ctx.LogEvent(username, description, body, eventDetails)
 }
 
 func redactEmail(email string) string {
@@ -107,3 +70,111 @@ func isRegulatorSkippedErr(err error) bool {
 
 	return false
 }
+
+// isPasswordInHistory checks the password against the password history of the user retained by the password policy.
+func isPasswordInHistory(ctx *middlewares.AutheliaCtx, username, password string) (reused bool, err error) {
+	if ctx.Configuration.PasswordPolicy.History <= 0 {
+		return false, nil
+	}
+
+	var history []model.PasswordHistory
+
+	if history, err = ctx.Providers.StorageProvider.LoadPasswordHistory(ctx, username, ctx.Configuration.PasswordPolicy.History); err != nil {
+		return false, fmt.Errorf("error loading password history: %w", err)
+	}
+
+	var digest *schema.PasswordDigest
+
+	for _, entry := range history {
+		if digest, err = schema.DecodePasswordDigest(entry.Password); err != nil {
+			return false, fmt.Errorf("error decoding password history digest: %w", err)
+		}
+
+		if reused, err = digest.MatchAdvanced(password); err != nil {
+			return false, fmt.Errorf("error matching password history digest: %w", err)
+		}
+
+		if reused {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// savePasswordHistoryCurrent saves the verified current password of a user to the password history unless it's
+// already the most recent entry, which is the case when it was last changed by Authelia.
+func savePasswordHistoryCurrent(ctx *middlewares.AutheliaCtx, username, password string) (err error) {
+	if ctx.Configuration.PasswordPolicy.History <= 0 {
+		return nil
+	}
+
+	var history []model.PasswordHistory
+
+	if history, err = ctx.Providers.StorageProvider.LoadPasswordHistory(ctx, username, 1); err != nil {
+		return fmt.Errorf("error loading password history: %w", err)
+	}
+
+	if len(history) != 0 {
+		var (
+			digest *schema.PasswordDigest
+			match  bool
+		)
+
+		if digest, err = schema.DecodePasswordDigest(history[0].Password); err != nil {
+			return fmt.Errorf("error decoding password history digest: %w", err)
+		}
+
+		if match, err = digest.MatchAdvanced(password); err != nil {
+			return fmt.Errorf("error matching password history digest: %w", err)
+		}
+
+		if match {
+			return nil
+		}
+	}
+
+	return savePasswordHistory(ctx, username, password)
+}
+
+// savePasswordHistory hashes the password and saves it to the password history of the user, discarding any entries
+// older than those retained by the password policy.
+func savePasswordHistory(ctx *middlewares.AutheliaCtx, username, password string) (err error) {
+	if ctx.Configuration.PasswordPolicy.History <= 0 {
+		return nil
+	}
+
+	config := schema.DefaultPasswordConfig
+
+	switch {
+	case ctx.Configuration.AuthenticationBackend.File != nil:
+		config = ctx.Configuration.AuthenticationBackend.File.Password
+	case ctx.Configuration.AuthenticationBackend.SQL != nil:
+		config = ctx.Configuration.AuthenticationBackend.SQL.Password
+	}
+
+	var (
+		hash   algorithm.Hash
+		digest algorithm.Digest
+	)
+
+	if hash, err = authentication.NewFileCryptoHashFromConfig(config); err != nil {
+		return fmt.Errorf("error initializing password history hash: %w", err)
+	}
+
+	if digest, err = hash.Hash(password); err != nil {
+		return fmt.Errorf("error hashing password for password history: %w", err)
+	}
+
+	history := model.PasswordHistory{
+		Created:  ctx.GetClock().Now(),
+		Username: username,
+		Password: digest.Encode(),
+	}
+
+	if err = ctx.Providers.StorageProvider.SavePasswordHistory(ctx, history, ctx.Configuration.PasswordPolicy.History); err != nil {
+		return fmt.Errorf("error saving password history: %w", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package middlewares
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -405,9 +408,109 @@ func (ctx *AutheliaCtx) GetSession() (userSession session.UserSession, err error
 		}
 	}
 
+	if ctx.Configuration.Session.Binding != nil {
+		var (
+			binding  = ctx.NewSessionBinding()
+			original = userSession
+			mismatch bool
+		)
+
+		if userSession, mismatch, err = session.ValidateBinding(session.NewEncapsulatedSession(provider, ctx.RequestCtx), ctx.Configuration.Session.Binding, userSession, binding); err != nil {
+			ctx.Logger.WithError(err).Error("Error occurred trying to validate the session binding")
+		}
+
+		if mismatch {
+			HandleSessionBindingMismatch(ctx, original, binding)
+		}
+	}
+
 	return userSession, nil
 }
 
+// NewSessionBinding returns the *session.Binding of the client which made the request, or nil if session binding is
+// not configured.
+func (ctx *AutheliaCtx) NewSessionBinding() *session.Binding {
+	return session.NewBinding(ctx.Configuration.Session.Binding, ctx.RemoteIP(), ctx.UserAgent())
+}
+
+// HandleSessionBindingMismatch logs a session which was used by a client that doesn't match the client the session is
+// bound to, and notifies the user if configured. The notification is sent in the background so it doesn't delay the
+// response to the client.
+func HandleSessionBindingMismatch(ctx SessionBindingContext, userSession session.UserSession, binding *session.Binding) {
+	config := ctx.GetConfiguration().Session.Binding
+
+	ctx.GetLogger().WithFields(map[string]any{
+		"username":         userSession.Username,
+		"action":           config.Action,
+		"network":          userSession.Binding.Network,
+		"network_actual":   binding.Network,
+		"user_agent_match": userSession.Binding.UserAgentHash == binding.UserAgentHash,
+	}).Warn("Session was used by a client which doesn't match the client the session is bound to which may be a sign the session cookie was stolen")
+
+	if !config.Notify {
+		return
+	}
+
+	body := EventEmailBody{
+		Prefix: eventEmailSessionBindingPrefix,
+		Body:   eventEmailSessionBindingBody,
+		Suffix: eventEmailSessionBindingSuffix,
+	}
+
+	details := map[string]any{
+		eventLogKeyAction:    eventLogActionSessionBindingMismatch,
+		eventLogKeyNetwork:   binding.Network,
+		eventLogKeyUserAgent: string(ctx.UserAgent()),
+	}
+
+	go logEvent(context.Background(), ctx.GetLogger(), ctx.GetProviders(), ctx.RemoteIP().String(), userSession.Username, eventLogActionSessionBindingMismatch, body, details)
+}
+
+// LogEvent sends the user a notification of an important event which occurred on their account.
+func (ctx *AutheliaCtx) LogEvent(username, description string, body EventEmailBody, eventDetails map[string]any) {
+	logEvent(ctx, ctx.Logger, ctx.Providers, ctx.RemoteIP().String(), username, description, body, eventDetails)
+}
+
+func logEvent(ctx context.Context, logger *logrus.Entry, providers Providers, remoteIP, username, description string, body EventEmailBody, eventDetails map[string]any) {
+	var (
+		details *authentication.UserDetails
+		err     error
+	)
+
+	logger.Debugf("Getting user details for notification")
+
+	if details, err = providers.UserProvider.GetDetails(username); err != nil {
+		logger.WithError(err).Errorf("Error occurred looking up user details for user '%s' while attempting to alert them of an important event", username)
+		return
+	}
+
+	if len(details.Emails) == 0 {
+		logger.WithError(fmt.Errorf("no email address was found for user")).Errorf("Error occurred looking up user details for user '%s' while attempting to alert them of an important event", username)
+		return
+	}
+
+	data := templates.EmailEventValues{
+		Title:       description,
+		DisplayName: details.DisplayName,
+		RemoteIP:    remoteIP,
+		Details:     eventDetails,
+		BodyPrefix:  body.Prefix,
+		BodyEvent:   body.Body,
+		BodySuffix:  body.Suffix,
+	}
+
+	logger.Debugf("Getting user addresses for notification")
+
+	addresses := details.Addresses()
+
+	logger.Debugf("Sending an email to user %s (%s) to inform them of an important event.", username, addresses[0].String())
+
+	if err = providers.Notifier.Send(ctx, addresses[0], description, providers.Templates.GetEventEmailTemplate(), data); err != nil {
+		logger.WithError(err).Errorf("Error occurred sending notification to user '%s' while attempting to alert them of an important event", username)
+		return
+	}
+}
+
 // SaveSession saves the content of the session.
 func (ctx *AutheliaCtx) SaveSession(userSession session.UserSession) error {
 	provider, err := ctx.GetSessionProvider()
@@ -506,6 +609,22 @@ func (ctx *AutheliaCtx) GetRequestHeaderValue(key []byte) (value []byte) {
 	return ctx.Request.Header.PeekBytes(key)
 }
 
+// GetRequestHeaders returns a copy of the request headers.
+func (ctx *AutheliaCtx) GetRequestHeaders() (headers http.Header) {
+	headers = http.Header{}
+
+	for key, value := range ctx.Request.Header.All() {
+		headers.Add(string(key), string(value))
+	}
+
+	return headers
+}
+
+// GetResponseStatusCode returns the status code of the response.
+func (ctx *AutheliaCtx) GetResponseStatusCode() (statusCode int) {
+	return ctx.Response.StatusCode()
+}
+
 // SetResponseHeaderValue sets a response header with the specified key and value.
 func (ctx *AutheliaCtx) SetResponseHeaderValue(key []byte, value string) {
 	ctx.Response.Header.SetBytesK(key, value)
@@ -1,10 +1,13 @@
 package middlewares_test
 
 import (
+	"context"
 	"math"
 	"net"
+	"net/mail"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +15,7 @@ import (
 	"github.com/valyala/fasthttp"
 	"go.uber.org/mock/gomock"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/expression"
 	"github.com/authelia/authelia/v4/internal/handlers"
@@ -21,6 +25,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 func TestNewRequestLogger(t *testing.T) {
@@ -574,7 +579,7 @@ func TestAutheliaCtx_IssuerURL(t *testing.T) {
 
 			if tc.config != nil {
 				mock.Ctx.Configuration.Session.Cookies = tc.config.Cookies
-				mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+				mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 			}
 
 			for k, v := range tc.headers {
@@ -1009,7 +1014,7 @@ func TestAutheliaCtx_GetCookieDomainSessionProvider(t *testing.T) {
 			providers := middlewares.NewProvidersBasic()
 
 			if tc.config != nil {
-				providers.SessionProvider = session.NewProvider(*tc.config, nil)
+				providers.SessionProvider = session.NewProvider(*tc.config, nil, nil)
 			}
 
 			middleware := middlewares.NewAutheliaCtx(ctx, config, providers)
@@ -1035,7 +1040,7 @@ func TestShouldCallNextWithAutheliaCtx(t *testing.T) {
 	providers := middlewares.NewProvidersBasic()
 
 	providers.UserProvider = mocks.NewMockUserProvider(ctrl)
-	providers.SessionProvider = session.NewProvider(configuration.Session, nil)
+	providers.SessionProvider = session.NewProvider(configuration.Session, nil, nil)
 
 	nextCalled := false
 
@@ -1292,6 +1297,133 @@ func TestAutheliaCtx_Session(t *testing.T) {
 	assert.EqualError(t, err, "unable to retrieve session cookie domain: missing required X-Forwarded-Host header")
 }
 
+func TestAutheliaCtx_GetSessionBinding(t *testing.T) {
+	testCases := []struct {
+		name             string
+		config           *schema.SessionBinding
+		ip, agent        string
+		setup            func(t *testing.T, mock *mocks.MockAutheliaCtx, done chan struct{})
+		expectedUsername string
+		expectedLevel    authentication.Level
+	}{
+		{
+			"ShouldRetainSessionWithinNetwork",
+			&schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64},
+			"192.168.0.200",
+			"Test/1.0",
+			nil,
+			"john",
+			authentication.OneFactor,
+		},
+		{
+			"ShouldRetainSessionWhenBindingNotConfigured",
+			nil,
+			"10.0.0.1",
+			"Other/1.0",
+			nil,
+			"john",
+			authentication.OneFactor,
+		},
+		{
+			"ShouldDestroySessionOutsideNetwork",
+			&schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64},
+			"192.168.1.1",
+			"Test/1.0",
+			nil,
+			"",
+			authentication.NotAuthenticated,
+		},
+		{
+			"ShouldReauthenticateSessionWithDifferentUserAgent",
+			&schema.SessionBinding{Action: schema.SessionBindingActionReauthenticate, IPv4Prefix: 24, IPv6Prefix: 64},
+			"192.168.0.1",
+			"Other/1.0",
+			nil,
+			"john",
+			authentication.NotAuthenticated,
+		},
+		{
+			"ShouldRetainSessionWithDifferentUserAgentWhenDisabled",
+			&schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64, DisableUserAgent: true},
+			"192.168.0.1",
+			"Other/1.0",
+			nil,
+			"john",
+			authentication.OneFactor,
+		},
+		{
+			"ShouldNotifyUser",
+			&schema.SessionBinding{Action: schema.SessionBindingActionDestroy, IPv4Prefix: 24, IPv6Prefix: 64, Notify: true},
+			"10.0.0.1",
+			"Test/1.0",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, done chan struct{}) {
+				gomock.InOrder(
+					mock.UserProviderMock.EXPECT().GetDetails("john").Return(&authentication.UserDetails{Username: "john", DisplayName: "John Smith", Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().Send(gomock.Any(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Session Used By Unrecognized Client", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ mail.Address, _ string, _ *templates.EmailTemplate, _ any) error {
+							close(done)
+
+							return nil
+						}),
+				)
+			},
+			"",
+			authentication.NotAuthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.Session.Binding = &schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64}
+			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.0.1")
+			mock.Ctx.Request.Header.SetUserAgent("Test/1.0")
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = "john"
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+			userSession.Binding = mock.Ctx.NewSessionBinding()
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			mock.Ctx.Configuration.Session.Binding = tc.config
+			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, tc.ip)
+			mock.Ctx.Request.Header.SetUserAgent(tc.agent)
+
+			done := make(chan struct{})
+
+			if tc.setup != nil {
+				tc.setup(t, mock, done)
+			}
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			if tc.setup != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second * 5):
+					t.Fatal("timed out waiting for the notification to be sent")
+				}
+			}
+
+			assert.Equal(t, tc.expectedUsername, userSession.Username)
+			assert.Equal(t, tc.expectedLevel, userSession.AuthenticationLevel(false))
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedUsername, userSession.Username)
+			assert.Equal(t, tc.expectedLevel, userSession.AuthenticationLevel(false))
+		})
+	}
+}
+
 func TestAutheliaCtx_GetClockGetRandomGetUserAttributeResolver(t *testing.T) {
 	ctx := middlewares.NewAutheliaCtx(&fasthttp.RequestCtx{}, schema.Configuration{}, middlewares.Providers{})
 
@@ -171,6 +171,18 @@ const (
 	messageIdentityVerificationTokenSig         = "The identity verification token has an invalid signature"
 )
 
+const (
+	eventLogKeyAction    = "Action"
+	eventLogKeyNetwork   = "Network"
+	eventLogKeyUserAgent = "User Agent"
+
+	eventLogActionSessionBindingMismatch = "Session Used By Unrecognized Client"
+
+	eventEmailSessionBindingPrefix = "your"
+	eventEmailSessionBindingBody   = "session"
+	eventEmailSessionBindingSuffix = "was used by a client which doesn't match the client it was established by and has been ended."
+)
+
 var protoHostSeparator = []byte("://")
 
 var errPasswordPolicyNoMet = errors.New("the supplied password does not met the security policy")
@@ -19,6 +19,14 @@ var (
 
 	// ErrMissingXOriginalURL is returned on methods which require an X-Original-URL header.
 	ErrMissingXOriginalURL = errors.New("missing required X-Original-URL header")
+
+	// ErrPasswordPolicyBreached is returned by password policy providers when the password appears in a breached
+	// password corpus.
+	ErrPasswordPolicyBreached = errors.New("the supplied password has appeared in a data breach")
+
+	// ErrPasswordPolicyUnavailable is returned by password policy providers when the password could not be checked
+	// because a resource the policy relies on is unavailable.
+	ErrPasswordPolicyUnavailable = errors.New("the supplied password could not be checked against the security policy")
 )
 
 // RecoverPanic recovers from panics and logs the error.
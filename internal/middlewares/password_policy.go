@@ -1,20 +1,46 @@
 package middlewares
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // Required by the k-anonymity range format.
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/trustelem/zxcvbn"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // PasswordPolicyProvider represents an implementation of a password policy provider.
 type PasswordPolicyProvider interface {
-	Check(password string) (err error)
+	Check(ctx context.Context, password string) (err error)
 }
 
 // NewPasswordPolicyProvider returns a new password policy provider.
 func NewPasswordPolicyProvider(config schema.PasswordPolicy) (provider PasswordPolicyProvider) {
+	provider = newPasswordPolicyProvider(config)
+
+	if config.Breached.Enabled {
+		return NewBreachedPasswordPolicyProvider(config.Breached, provider)
+	}
+
+	return provider
+}
+
+func newPasswordPolicyProvider(config schema.PasswordPolicy) (provider PasswordPolicyProvider) {
 	if !config.Standard.Enabled && !config.ZXCVBN.Enabled {
 		return &StandardPasswordPolicyProvider{}
 	}
@@ -56,7 +82,7 @@ type ZXCVBNPasswordPolicyProvider struct {
 }
 
 // Check checks the password against the policy.
-func (p ZXCVBNPasswordPolicyProvider) Check(password string) (err error) {
+func (p ZXCVBNPasswordPolicyProvider) Check(_ context.Context, password string) (err error) {
 	result := zxcvbn.PasswordStrength(password, nil)
 
 	if result.Score < p.minScore {
@@ -73,7 +99,7 @@ type StandardPasswordPolicyProvider struct {
 }
 
 // Check checks the password against the policy.
-func (p StandardPasswordPolicyProvider) Check(password string) (err error) {
+func (p StandardPasswordPolicyProvider) Check(_ context.Context, password string) (err error) {
 	patterns := len(p.patterns)
 
 	if (p.min > 0 && len(password) < p.min) || (p.max > 0 && len(password) > p.max) {
@@ -92,3 +118,265 @@ func (p StandardPasswordPolicyProvider) Check(password string) (err error) {
 
 	return nil
 }
+
+// NewBreachedPasswordPolicyProvider returns a new breached password policy provider which checks passwords against
+// the next provider before checking them against the configured range list.
+func NewBreachedPasswordPolicyProvider(config schema.PasswordPolicyBreached, next PasswordPolicyProvider) (provider *BreachedPasswordPolicyProvider) {
+	provider = &BreachedPasswordPolicyProvider{
+		next:      next,
+		threshold: config.Threshold,
+		log:       logging.Logger(),
+	}
+
+	switch {
+	case config.URL != nil:
+		provider.ranges = &HTTPBreachedPasswordRangeProvider{
+			url:    config.URL,
+			client: &http.Client{Timeout: config.Timeout},
+		}
+	default:
+		if info, err := os.Stat(config.Path); err == nil && !info.IsDir() {
+			provider.ranges = &FileBreachedPasswordRangeProvider{path: config.Path}
+		} else {
+			provider.ranges = &DirectoryBreachedPasswordRangeProvider{path: config.Path}
+		}
+	}
+
+	return provider
+}
+
+// BreachedPasswordPolicyProvider handles breached password policy checking using SHA-1 k-anonymity range lists.
+type BreachedPasswordPolicyProvider struct {
+	next      PasswordPolicyProvider
+	ranges    BreachedPasswordRangeProvider
+	threshold int
+
+	log *logrus.Logger
+}
+
+// Check checks the password against the policy.
+func (p *BreachedPasswordPolicyProvider) Check(ctx context.Context, password string) (err error) {
+	if p.next != nil {
+		if err = p.next.Check(ctx, password); err != nil {
+			return err
+		}
+	}
+
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // Required by the k-anonymity range format.
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	var count int
+
+	if count, err = p.count(ctx, digest[:5], digest[5:]); err != nil {
+		p.log.WithError(err).Error("Error occurred checking the password against the breached password range list")
+
+		return fmt.Errorf("%w: %w", ErrPasswordPolicyUnavailable, err)
+	}
+
+	if count >= p.threshold {
+		return ErrPasswordPolicyBreached
+	}
+
+	return nil
+}
+
+func (p *BreachedPasswordPolicyProvider) count(ctx context.Context, prefix, suffix string) (count int, err error) {
+	var rc io.ReadCloser
+
+	if rc, err = p.ranges.Range(ctx, prefix); err != nil {
+		return 0, err
+	}
+
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+
+	for scanner.Scan() {
+		value, occurrences, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+
+		if !found || !strings.EqualFold(value, suffix) {
+			continue
+		}
+
+		if count, err = strconv.Atoi(occurrences); err != nil {
+			return 0, fmt.Errorf("error parsing the count for range '%s': %w", prefix, err)
+		}
+
+		return count, nil
+	}
+
+	if err = scanner.Err(); err != nil {
+		return 0, fmt.Errorf("error reading range '%s': %w", prefix, err)
+	}
+
+	return 0, nil
+}
+
+// BreachedPasswordRangeProvider represents an implementation which provides the suffixes and occurrence counts of a
+// SHA-1 prefix in the Have I Been Pwned range format.
+type BreachedPasswordRangeProvider interface {
+	Range(ctx context.Context, prefix string) (rc io.ReadCloser, err error)
+}
+
+// DirectoryBreachedPasswordRangeProvider provides ranges from a directory of files named after their SHA-1 prefix.
+type DirectoryBreachedPasswordRangeProvider struct {
+	path string
+}
+
+// Range returns the range file for the given prefix.
+func (p *DirectoryBreachedPasswordRangeProvider) Range(_ context.Context, prefix string) (rc io.ReadCloser, err error) {
+	if rc, err = os.Open(filepath.Join(p.path, prefix+".txt")); err != nil {
+		return nil, fmt.Errorf("error opening range '%s': %w", prefix, err)
+	}
+
+	return rc, nil
+}
+
+// FileBreachedPasswordRangeProvider provides ranges from a single file containing the full SHA-1 hash and occurrence
+// count of every password ordered by hash, which is the format of the Have I Been Pwned offline download.
+type FileBreachedPasswordRangeProvider struct {
+	path string
+}
+
+// Range searches the file for the lines starting with the given prefix and returns them in the range format.
+func (p *FileBreachedPasswordRangeProvider) Range(_ context.Context, prefix string) (rc io.ReadCloser, err error) {
+	var (
+		file   *os.File
+		info   os.FileInfo
+		offset int64
+	)
+
+	if file, err = os.Open(p.path); err != nil {
+		return nil, fmt.Errorf("error opening range '%s': %w", prefix, err)
+	}
+
+	defer file.Close()
+
+	if info, err = file.Stat(); err != nil {
+		return nil, fmt.Errorf("error opening range '%s': %w", prefix, err)
+	}
+
+	if offset, err = breachedFileSearch(file, info.Size(), prefix); err != nil {
+		return nil, fmt.Errorf("error searching range '%s': %w", prefix, err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	scanner := bufio.NewScanner(io.NewSectionReader(file, offset, info.Size()-offset))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) < len(prefix) || !strings.EqualFold(line[:len(prefix)], prefix) {
+			break
+		}
+
+		buf.WriteString(line[len(prefix):])
+		buf.WriteByte('\n')
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading range '%s': %w", prefix, err)
+	}
+
+	return io.NopCloser(buf), nil
+}
+
+// breachedFileSearch returns the offset of the first line in the sorted file which is not ordered before the prefix.
+func breachedFileSearch(r io.ReaderAt, size int64, prefix string) (offset int64, err error) {
+	var (
+		lo, hi = int64(0), size
+		start  int64
+		line   string
+	)
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		if start, line, err = breachedFileLineAt(r, size, mid); err != nil {
+			return 0, err
+		}
+
+		if start >= size || strings.ToUpper(breachedFileLinePrefix(line, len(prefix))) >= prefix {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	if start, _, err = breachedFileLineAt(r, size, lo); err != nil {
+		return 0, err
+	}
+
+	return start, nil
+}
+
+// breachedFileLineAt returns the start and content of the first line which starts at or after the offset.
+func breachedFileLineAt(r io.ReaderAt, size, offset int64) (start int64, line string, err error) {
+	if start = offset; offset > 0 {
+		start--
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(r, start, size-start))
+
+	// The line containing the byte before the offset is skipped as the offset may be in the middle of it.
+	if offset > 0 {
+		var skipped string
+
+		if skipped, err = reader.ReadString('\n'); err != nil && !errors.Is(err, io.EOF) {
+			return 0, "", err
+		}
+
+		start += int64(len(skipped))
+	}
+
+	if start >= size {
+		return size, "", nil
+	}
+
+	if line, err = reader.ReadString('\n'); err != nil && !errors.Is(err, io.EOF) {
+		return 0, "", err
+	}
+
+	return start, line, nil
+}
+
+func breachedFileLinePrefix(line string, n int) string {
+	if len(line) < n {
+		return line
+	}
+
+	return line[:n]
+}
+
+// HTTPBreachedPasswordRangeProvider provides ranges from a range API.
+type HTTPBreachedPasswordRangeProvider struct {
+	url    *url.URL
+	client *http.Client
+}
+
+// Range requests the range for the given prefix from the range API.
+func (p *HTTPBreachedPasswordRangeProvider) Range(ctx context.Context, prefix string) (rc io.ReadCloser, err error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+	)
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.url.JoinPath(prefix).String(), nil); err != nil {
+		return nil, fmt.Errorf("error creating request for range '%s': %w", prefix, err)
+	}
+
+	req.Header.Set("Add-Padding", "true")
+
+	if resp, err = p.client.Do(req); err != nil {
+		return nil, fmt.Errorf("error requesting range '%s': %w", prefix, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("error requesting range '%s': unexpected status code: %d", prefix, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
@@ -1,8 +1,16 @@
 package middlewares
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,7 +147,7 @@ func TestPasswordPolicyProvider_Validate(t *testing.T) {
 			for i := 0; i < len(tc.have); i++ {
 				provider := NewPasswordPolicyProvider(tc.config)
 				t.Run(tc.have[i], func(t *testing.T) {
-					assert.Equal(t, tc.expected[i], provider.Check(tc.have[i]))
+					assert.Equal(t, tc.expected[i], provider.Check(context.Background(), tc.have[i]))
 				})
 			}
 		})
@@ -170,7 +178,7 @@ func TestZXCVBNPasswordPolicyProvider_Check(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			p := ZXCVBNPasswordPolicyProvider{minScore: tc.minScore}
 
-			err := p.Check(tc.password)
+			err := p.Check(context.Background(), tc.password)
 
 			if tc.wantErr {
 				require.ErrorIs(t, err, errPasswordPolicyNoMet)
@@ -180,3 +188,183 @@ func TestZXCVBNPasswordPolicyProvider_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestBreachedPasswordPolicyProvider_Check(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte("1D72CD07550416C216D8AD296BF5C0AE8E0:10\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:5\r\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "D4F1F.txt"), []byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n"), 0600))
+
+	file := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+
+	require.NoError(t, os.WriteFile(file, []byte("000000005AD76BD555C1D6D771DE417A4B87E4B4:10\r\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:5\r\n5BAA6FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:2\r\nD4F1F0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+
+		switch r.URL.Path {
+		case "/range/5BAA6":
+			_, _ = rw.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:5\r\n0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n"))
+		case "/range/D4F1F":
+			_, _ = rw.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n"))
+		default:
+			rw.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL + "/range/")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		config   schema.PasswordPolicy
+		password string
+		expected error
+	}{
+		{
+			name:     "ShouldRejectBreachedPasswordFile",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: dir, Threshold: 1}},
+			password: "password",
+			expected: ErrPasswordPolicyBreached,
+		},
+		{
+			name:     "ShouldAllowBreachedPasswordFileBelowThreshold",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: dir, Threshold: 6}},
+			password: "password",
+		},
+		{
+			name:     "ShouldAllowPasswordFileNotInRange",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: dir, Threshold: 1}},
+			password: "qjik2n@jAkjlmn123",
+		},
+		{
+			name:     "ShouldFailPasswordFileMissingRange",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: dir, Threshold: 1}},
+			password: "a",
+			expected: ErrPasswordPolicyUnavailable,
+		},
+		{
+			name:     "ShouldRejectBreachedPasswordSingleFile",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: file, Threshold: 1}},
+			password: "password",
+			expected: ErrPasswordPolicyBreached,
+		},
+		{
+			name:     "ShouldAllowBreachedPasswordSingleFileBelowThreshold",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: file, Threshold: 6}},
+			password: "password",
+		},
+		{
+			name:     "ShouldAllowPasswordSingleFileNotInRange",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, Path: file, Threshold: 1}},
+			password: "a",
+		},
+		{
+			name:     "ShouldRejectPasswordNotMeetingNextPolicy",
+			config:   schema.PasswordPolicy{Standard: schema.PasswordPolicyStandard{Enabled: true, MinLength: 20}, Breached: schema.PasswordPolicyBreached{Enabled: true, Path: dir, Threshold: 1}},
+			password: "qjik2n@jAkjlmn123",
+			expected: errPasswordPolicyNoMet,
+		},
+		{
+			name:     "ShouldRejectBreachedPasswordHTTP",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, URL: serverURL, Timeout: time.Second, Threshold: 1}},
+			password: "password",
+			expected: ErrPasswordPolicyBreached,
+		},
+		{
+			name:     "ShouldAllowPaddedPasswordHTTP",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, URL: serverURL, Timeout: time.Second, Threshold: 1}},
+			password: "qjik2n@jAkjlmn123",
+		},
+		{
+			name:     "ShouldFailPasswordHTTPBadStatus",
+			config:   schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, URL: serverURL, Timeout: time.Second, Threshold: 1}},
+			password: "a",
+			expected: ErrPasswordPolicyUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := NewPasswordPolicyProvider(tc.config)
+
+			err := provider.Check(context.Background(), tc.password)
+
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBreachedPasswordPolicyProvider_CheckShouldUseRequestContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL + "/range/")
+	require.NoError(t, err)
+
+	provider := NewPasswordPolicyProvider(schema.PasswordPolicy{Breached: schema.PasswordPolicyBreached{Enabled: true, URL: serverURL, Timeout: time.Minute, Threshold: 1}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	err = provider.Check(ctx, "password")
+
+	assert.ErrorIs(t, err, ErrPasswordPolicyUnavailable)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFileBreachedPasswordRangeProvider_Range(t *testing.T) {
+	content := "00000AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:1\n" +
+		"5BAA50000000000000000000000000000000000:3\n" +
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:5\n" +
+		"5BAA6FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:2\n" +
+		"FFFFFAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:4"
+
+	file := filepath.Join(t.TempDir(), "pwned-passwords.txt")
+
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+
+	testCases := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{"ShouldReturnFirstLine", "00000", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:1\n"},
+		{"ShouldReturnMultipleLines", "5BAA6", "1E4C9B93F3F0682250B6CF8331B7EE68FD8:5\nFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:2\n"},
+		{"ShouldReturnLastLineWithoutNewline", "FFFFF", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:4\n"},
+		{"ShouldReturnEmptyBetweenLines", "5BAA5F", ""},
+		{"ShouldReturnLongerPrefix", "00000A", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:1\n"},
+		{"ShouldReturnEmptyAfterLastLine", "FFFFFF", ""},
+		{"ShouldReturnEmptyMissing", "12345", ""},
+	}
+
+	provider := &FileBreachedPasswordRangeProvider{path: file}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rc, err := provider.Range(context.Background(), tc.prefix)
+			require.NoError(t, err)
+
+			defer rc.Close()
+
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+
+	_, err := (&FileBreachedPasswordRangeProvider{path: filepath.Join(t.TempDir(), "missing.txt")}).Range(context.Background(), "5BAA6")
+
+	assert.ErrorContains(t, err, "error opening range '5BAA6'")
+}
@@ -55,6 +55,22 @@ func RequireElevated(next RequestHandler) RequestHandler {
 	}
 }
 
+// RequirePasswordChangeOrElevated allows sessions which must change their password after successfully performing
+// username and password first factor authentication, otherwise it requires the various elevation criteria.
+func RequirePasswordChangeOrElevated(next RequestHandler) RequestHandler {
+	elevated := RequireElevated(next)
+
+	return func(ctx *AutheliaCtx) {
+		if userSession, err := ctx.GetSession(); err == nil && userSession.Username != "" && userSession.PasswordChangeRequired && userSession.AuthenticationMethodRefs.UsernameAndPassword {
+			next(ctx)
+
+			return
+		}
+
+		elevated(ctx)
+	}
+}
+
 func handleRequireElevatedShouldDoNext(ctx *AutheliaCtx, userSession *session.UserSession) (doNext bool) {
 	var err error
 
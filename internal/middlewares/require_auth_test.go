@@ -256,6 +256,102 @@ func TestRequireElevated(t *testing.T) {
 	}
 }
 
+func TestRequirePasswordChangeOrElevated(t *testing.T) {
+	type response struct {
+		Status string                                `json:"status"`
+		Data   middlewares.ElevatedForbiddenResponse `json:"data"`
+	}
+
+	testCases := []struct {
+		name              string
+		username          string
+		password          bool
+		required          bool
+		expected          int
+		expected1FA       bool
+		expectedElevation bool
+	}{
+		{
+			"ShouldPassPasswordChangeRequired",
+			john,
+			true,
+			true,
+			fasthttp.StatusOK,
+			false,
+			false,
+		},
+		{
+			"ShouldRequireAuthenticationPasswordChangeRequiredWithoutPassword",
+			john,
+			false,
+			true,
+			fasthttp.StatusForbidden,
+			true,
+			false,
+		},
+		{
+			"ShouldRequireAuthenticationPasswordChangeRequiredAnonymous",
+			"",
+			true,
+			true,
+			fasthttp.StatusForbidden,
+			true,
+			false,
+		},
+		{
+			"ShouldRequireElevation",
+			john,
+			true,
+			false,
+			fasthttp.StatusForbidden,
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.IdentityValidation.ElevatedSession = schema.IdentityValidationElevatedSession{
+				CodeLifespan:      time.Minute,
+				ElevationLifespan: time.Minute,
+				Characters:        8,
+			}
+
+			mock.Ctx.Providers.Clock = &mock.Clock
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = tc.username
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = tc.password
+			userSession.PasswordChangeRequired = tc.required
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			handler := middlewares.RequirePasswordChangeOrElevated(NilHandler)
+
+			handler(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+
+			if tc.expected == fasthttp.StatusOK {
+				assert.Equal(t, "Example Nil", string(mock.Ctx.Response.Body()))
+			} else {
+				data := &response{}
+
+				require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), data))
+
+				assert.Equal(t, tc.expectedElevation, data.Data.Elevation)
+				assert.Equal(t, tc.expected1FA, data.Data.FirstFactor)
+			}
+		})
+	}
+}
+
 func NilHandler(ctx *middlewares.AutheliaCtx) {
 	ctx.SetContentTypeTextPlain()
 	ctx.Response.SetBodyString("Example Nil")
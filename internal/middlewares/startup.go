@@ -34,8 +34,14 @@ func (p *Providers) StartupChecks(ctx ServiceContext, log bool) (err error) {
 	provider, disable = ctx.GetProviders().SessionProvider, false
 	doStartupCheck(ctx, ProviderNameSession, provider, nil, disable, log, e.errors)
 
+	var required []string
+
+	if config.AuthenticationBackend.SQL != nil {
+		required = []string{ProviderNameStorage}
+	}
+
 	provider, disable = ctx.GetProviders().UserProvider, false
-	doStartupCheck(ctx, ProviderNameUser, provider, nil, disable, log, e.errors)
+	doStartupCheck(ctx, ProviderNameUser, provider, required, disable, log, e.errors)
 
 	provider, disable = ctx.GetProviders().Notifier, ctx.GetConfiguration().Notifier.DisableStartupCheck
 	doStartupCheck(ctx, ProviderNameNotification, provider, nil, disable, log, e.errors)
@@ -39,6 +39,7 @@ type AutheliaCtx struct {
 // Providers contain all provider provided to Authelia.
 type Providers struct {
 	Authorizer            *authorization.Authorizer
+	AuditLogger           *authorization.AuditLogger
 	SessionProvider       *session.Provider
 	Regulator             *regulation.Regulator
 	OpenIDConnect         *oidc.OpenIDConnectProvider
@@ -145,6 +146,24 @@ type IdentityVerificationFinishBody struct {
 	Token string `json:"token"`
 }
 
+// SessionBindingContext is the context required to handle a session which was used by a client that doesn't match the
+// client the session is bound to.
+type SessionBindingContext interface {
+	GetLogger() (logger *logrus.Entry)
+	GetConfiguration() (config *schema.Configuration)
+	GetProviders() (providers Providers)
+	RemoteIP() (ip net.IP)
+	UserAgent() (userAgent []byte)
+}
+
+// EventEmailBody is the body of an event notification which is rendered as a sentence consisting of the prefix, the
+// body, and the suffix.
+type EventEmailBody struct {
+	Prefix string
+	Body   string
+	Suffix string
+}
+
 // OKResponse model of a status OK response.
 type OKResponse struct {
 	Status string `json:"status"`
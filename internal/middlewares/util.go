@@ -51,14 +51,23 @@ func NewProviders(config *schema.Configuration, caCertPool *x509.CertPool) (prov
 		errs = append(errs, err)
 	}
 
-	providers.Authorizer = authorization.NewAuthorizer(config)
+	providers.Authorizer = authorization.NewAuthorizerWithClock(config, providers.Clock)
+
+	if config.AccessControl.AuditLog.FilePath != "" {
+		providers.AuditLogger = authorization.NewAuditLogger(config.AccessControl.AuditLog, providers.Random)
+
+		if err = providers.AuditLogger.Open(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	providers.NTP = ntp.NewProvider(&config.NTP)
 	providers.PasswordPolicy = NewPasswordPolicyProvider(config.PasswordPolicy)
 	providers.Regulator = regulation.NewRegulator(config.Regulation, providers.StorageProvider, providers.Clock)
-	providers.SessionProvider = session.NewProvider(config.Session, caCertPool)
+	providers.SessionProvider = session.NewProvider(config.Session, caCertPool, providers.StorageProvider)
 	providers.TOTP = totp.NewTimeBasedProvider(config.TOTP)
 	providers.UserAttributeResolver = expression.NewUserAttributes(config)
-	providers.UserProvider = NewAuthenticationProvider(config, caCertPool)
+	providers.UserProvider = NewAuthenticationProvider(config, caCertPool, providers.StorageProvider)
 
 	switch {
 	case config.Notifier.SMTP != nil:
@@ -69,6 +78,12 @@ func NewProviders(config *schema.Configuration, caCertPool *x509.CertPool) (prov
 
 	providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(config, providers.StorageProvider, providers.Templates)
 
+	providers.GarbageCollector.Register(providers.SessionProvider)
+
+	if config.Session.TrustedDevices != nil && providers.StorageProvider != nil {
+		providers.GarbageCollector.Register(storage.NewTrustedDevicesGarbageCollector(providers.StorageProvider, providers.Clock))
+	}
+
 	if config.Telemetry.Metrics.Enabled {
 		if providers.Metrics, err = metrics.NewPrometheus(); err != nil {
 			errs = append(errs, err)
@@ -87,13 +102,34 @@ func NewProvidersBasic() Providers {
 	}
 }
 
-// NewAuthenticationProvider returns a new authentication.UserProvider.
-func NewAuthenticationProvider(config *schema.Configuration, caCertPool *x509.CertPool) (provider authentication.UserProvider) {
+// NewAuthenticationProvider returns a new authentication.UserProvider. The storage.Provider is only used by the SQL
+// authentication backend.
+func NewAuthenticationProvider(config *schema.Configuration, caCertPool *x509.CertPool, store storage.Provider) (provider authentication.UserProvider) {
+	if len(config.AuthenticationBackend.Chain) != 0 {
+		backends := map[string]authentication.UserProvider{}
+
+		if config.AuthenticationBackend.File != nil {
+			backends[authentication.BackendFile] = authentication.NewFileUserProvider(config.AuthenticationBackend.File)
+		}
+
+		if config.AuthenticationBackend.LDAP != nil {
+			backends[authentication.BackendLDAP] = authentication.NewLDAPUserProvider(config.AuthenticationBackend, caCertPool)
+		}
+
+		if config.AuthenticationBackend.SQL != nil {
+			backends[authentication.BackendSQL] = authentication.NewSQLUserProvider(config.AuthenticationBackend.SQL, store)
+		}
+
+		return authentication.NewChainUserProvider(config.AuthenticationBackend.Chain, backends)
+	}
+
 	switch {
 	case config.AuthenticationBackend.File != nil:
 		return authentication.NewFileUserProvider(config.AuthenticationBackend.File)
 	case config.AuthenticationBackend.LDAP != nil:
 		return authentication.NewLDAPUserProvider(config.AuthenticationBackend, caCertPool)
+	case config.AuthenticationBackend.SQL != nil:
+		return authentication.NewSQLUserProvider(config.AuthenticationBackend.SQL, store)
 	default:
 		return nil
 	}
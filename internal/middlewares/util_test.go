@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
@@ -57,7 +58,7 @@ func TestNewAuthenticationProvider(t *testing.T) {
 	for i := range testCases {
 		t.Run(testCases[i].name, func(t *testing.T) {
 			tc := testCases[i]
-			provider := NewAuthenticationProvider(&tc.config, nil)
+			provider := NewAuthenticationProvider(&tc.config, nil, nil)
 			require.Nil(t, provider)
 		})
 	}
@@ -79,7 +80,7 @@ func TestNewAuthenticationProviderFile(t *testing.T) {
 		},
 	}
 
-	provider := NewAuthenticationProvider(&config, nil)
+	provider := NewAuthenticationProvider(&config, nil, nil)
 
 	assert.NotNil(t, provider)
 }
@@ -93,11 +94,54 @@ func TestNewAuthenticationProviderLDAP(t *testing.T) {
 		},
 	}
 
-	provider := NewAuthenticationProvider(&config, nil)
+	provider := NewAuthenticationProvider(&config, nil, nil)
 
 	assert.NotNil(t, provider)
 }
 
+func TestNewAuthenticationProviderSQL(t *testing.T) {
+	config := schema.Configuration{
+		AuthenticationBackend: schema.AuthenticationBackend{
+			SQL: &schema.AuthenticationBackendSQL{
+				Password: schema.DefaultCIPasswordConfig,
+			},
+		},
+	}
+
+	provider := NewAuthenticationProvider(&config, nil, nil)
+
+	require.NotNil(t, provider)
+	assert.IsType(t, &authentication.SQLUserProvider{}, provider)
+}
+
+func TestNewAuthenticationProviderChain(t *testing.T) {
+	config := schema.Configuration{
+		AuthenticationBackend: schema.AuthenticationBackend{
+			LDAP: &schema.AuthenticationBackendLDAP{
+				Address: &schema.AddressLDAP{},
+			},
+			SQL: &schema.AuthenticationBackendSQL{
+				Password: schema.DefaultCIPasswordConfig,
+			},
+			Chain: []schema.AuthenticationBackendChain{
+				{Backend: authentication.BackendSQL, Domains: []string{"example.com"}},
+				{Backend: authentication.BackendLDAP},
+			},
+		},
+	}
+
+	provider := NewAuthenticationProvider(&config, nil, nil)
+
+	require.NotNil(t, provider)
+	require.IsType(t, &authentication.ChainUserProvider{}, provider)
+
+	chain := provider.(*authentication.ChainUserProvider)
+
+	assert.IsType(t, &authentication.SQLUserProvider{}, chain.Backend(authentication.BackendSQL))
+	assert.IsType(t, &authentication.LDAPUserProvider{}, chain.Backend(authentication.BackendLDAP))
+	assert.Nil(t, chain.Backend(authentication.BackendFile))
+}
+
 func TestNewProvidersBasic(t *testing.T) {
 	providers := NewProvidersBasic()
 
@@ -203,7 +203,7 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 	providers.Authorizer = authorization.NewAuthorizer(
 		&config)
 
-	providers.SessionProvider = session.NewProvider(config.Session, nil)
+	providers.SessionProvider = session.NewProvider(config.Session, nil, nil)
 
 	providers.Regulator = regulation.NewRegulator(config.Regulation, providers.StorageProvider, &mockAuthelia.Clock)
 
@@ -102,6 +102,21 @@ func (mr *MockStorageMockRecorder) Commit(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockStorage)(nil).Commit), ctx)
 }
 
+// CompareAndSwapSessionData mocks base method.
+func (m *MockStorage) CompareAndSwapSessionData(ctx context.Context, id string, old, data []byte, expires sql.NullTime) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSwapSessionData", ctx, id, old, data, expires)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwapSessionData indicates an expected call of CompareAndSwapSessionData.
+func (mr *MockStorageMockRecorder) CompareAndSwapSessionData(ctx, id, old, data, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwapSessionData", reflect.TypeOf((*MockStorage)(nil).CompareAndSwapSessionData), ctx, id, old, data, expires)
+}
+
 // ConsumeIdentityVerification mocks base method.
 func (m *MockStorage) ConsumeIdentityVerification(ctx context.Context, jti string, ip model.NullIP) error {
 	m.ctrl.T.Helper()
@@ -130,6 +145,21 @@ func (mr *MockStorageMockRecorder) ConsumeOneTimeCode(ctx, code any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).ConsumeOneTimeCode), ctx, code)
 }
 
+// CountSessionData mocks base method.
+func (m *MockStorage) CountSessionData(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSessionData", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSessionData indicates an expected call of CountSessionData.
+func (mr *MockStorageMockRecorder) CountSessionData(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSessionData", reflect.TypeOf((*MockStorage)(nil).CountSessionData), ctx)
+}
+
 // DeactivateOAuth2DeviceCodeSession mocks base method.
 func (m *MockStorage) DeactivateOAuth2DeviceCodeSession(ctx context.Context, signature string) error {
 	m.ctrl.T.Helper()
@@ -172,6 +202,20 @@ func (mr *MockStorageMockRecorder) DeactivateOAuth2SessionByRequestID(ctx, sessi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOAuth2SessionByRequestID", reflect.TypeOf((*MockStorage)(nil).DeactivateOAuth2SessionByRequestID), ctx, sessionType, requestID)
 }
 
+// DeleteAuthenticationUser mocks base method.
+func (m *MockStorage) DeleteAuthenticationUser(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthenticationUser", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthenticationUser indicates an expected call of DeleteAuthenticationUser.
+func (mr *MockStorageMockRecorder) DeleteAuthenticationUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthenticationUser", reflect.TypeOf((*MockStorage)(nil).DeleteAuthenticationUser), ctx, username)
+}
+
 // DeleteCachedData mocks base method.
 func (m *MockStorage) DeleteCachedData(ctx context.Context, name string) error {
 	m.ctrl.T.Helper()
@@ -186,6 +230,34 @@ func (mr *MockStorageMockRecorder) DeleteCachedData(ctx, name any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCachedData", reflect.TypeOf((*MockStorage)(nil).DeleteCachedData), ctx, name)
 }
 
+// DeleteExpiredSessionData mocks base method.
+func (m *MockStorage) DeleteExpiredSessionData(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSessionData", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredSessionData indicates an expected call of DeleteExpiredSessionData.
+func (mr *MockStorageMockRecorder) DeleteExpiredSessionData(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessionData", reflect.TypeOf((*MockStorage)(nil).DeleteExpiredSessionData), ctx, now)
+}
+
+// DeleteExpiredTrustedDevices mocks base method.
+func (m *MockStorage) DeleteExpiredTrustedDevices(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTrustedDevices", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredTrustedDevices indicates an expected call of DeleteExpiredTrustedDevices.
+func (mr *MockStorageMockRecorder) DeleteExpiredTrustedDevices(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTrustedDevices", reflect.TypeOf((*MockStorage)(nil).DeleteExpiredTrustedDevices), ctx, before)
+}
+
 // DeletePreferredDuoDevice mocks base method.
 func (m *MockStorage) DeletePreferredDuoDevice(ctx context.Context, username string) error {
 	m.ctrl.T.Helper()
@@ -200,6 +272,20 @@ func (mr *MockStorageMockRecorder) DeletePreferredDuoDevice(ctx, username any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).DeletePreferredDuoDevice), ctx, username)
 }
 
+// DeleteSessionData mocks base method.
+func (m *MockStorage) DeleteSessionData(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionData", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionData indicates an expected call of DeleteSessionData.
+func (mr *MockStorageMockRecorder) DeleteSessionData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionData", reflect.TypeOf((*MockStorage)(nil).DeleteSessionData), ctx, id)
+}
+
 // DeleteTOTPConfiguration mocks base method.
 func (m *MockStorage) DeleteTOTPConfiguration(ctx context.Context, username string) error {
 	m.ctrl.T.Helper()
@@ -272,6 +358,81 @@ func (mr *MockStorageMockRecorder) FindIdentityVerification(ctx, jti any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentityVerification", reflect.TypeOf((*MockStorage)(nil).FindIdentityVerification), ctx, jti)
 }
 
+// LoadAuthenticationGroups mocks base method.
+func (m *MockStorage) LoadAuthenticationGroups(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationGroups", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationGroups indicates an expected call of LoadAuthenticationGroups.
+func (mr *MockStorageMockRecorder) LoadAuthenticationGroups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationGroups", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationGroups), ctx)
+}
+
+// LoadAuthenticationUser mocks base method.
+func (m *MockStorage) LoadAuthenticationUser(ctx context.Context, username string) (*model.AuthenticationUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationUser", ctx, username)
+	ret0, _ := ret[0].(*model.AuthenticationUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationUser indicates an expected call of LoadAuthenticationUser.
+func (mr *MockStorageMockRecorder) LoadAuthenticationUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationUser", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationUser), ctx, username)
+}
+
+// LoadAuthenticationUserByEmail mocks base method.
+func (m *MockStorage) LoadAuthenticationUserByEmail(ctx context.Context, email string) (*model.AuthenticationUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.AuthenticationUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationUserByEmail indicates an expected call of LoadAuthenticationUserByEmail.
+func (mr *MockStorageMockRecorder) LoadAuthenticationUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationUserByEmail", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationUserByEmail), ctx, email)
+}
+
+// LoadAuthenticationUserGroups mocks base method.
+func (m *MockStorage) LoadAuthenticationUserGroups(ctx context.Context, username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationUserGroups", ctx, username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationUserGroups indicates an expected call of LoadAuthenticationUserGroups.
+func (mr *MockStorageMockRecorder) LoadAuthenticationUserGroups(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationUserGroups", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationUserGroups), ctx, username)
+}
+
+// LoadAuthenticationUsers mocks base method.
+func (m *MockStorage) LoadAuthenticationUsers(ctx context.Context, limit int, page int) ([]model.AuthenticationUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationUsers", ctx, limit, page)
+	ret0, _ := ret[0].([]model.AuthenticationUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationUsers indicates an expected call of LoadAuthenticationUsers.
+func (mr *MockStorageMockRecorder) LoadAuthenticationUsers(ctx, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationUsers", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationUsers), ctx, limit, page)
+}
+
 // LoadBannedIP mocks base method.
 func (m *MockStorage) LoadBannedIP(ctx context.Context, remoteIP model.IP) ([]model.BannedIP, error) {
 	m.ctrl.T.Helper()
@@ -497,6 +658,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2Session(ctx, sessionType, signature
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2Session", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2Session), ctx, sessionType, signature)
 }
 
+// LoadOAuth2SessionSignaturesByUsername mocks base method.
+func (m *MockStorage) LoadOAuth2SessionSignaturesByUsername(ctx context.Context, sessionType storage.OAuth2SessionType, username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2SessionSignaturesByUsername", ctx, sessionType, username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2SessionSignaturesByUsername indicates an expected call of LoadOAuth2SessionSignaturesByUsername.
+func (mr *MockStorageMockRecorder) LoadOAuth2SessionSignaturesByUsername(ctx, sessionType, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2SessionSignaturesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2SessionSignaturesByUsername), ctx, sessionType, username)
+}
+
 // LoadOneTimeCode mocks base method.
 func (m *MockStorage) LoadOneTimeCode(ctx context.Context, username string, ip model.IP, intent, raw string) (*model.OneTimeCode, error) {
 	m.ctrl.T.Helper()
@@ -557,6 +733,21 @@ func (mr *MockStorageMockRecorder) LoadOneTimeCodeBySignature(ctx, signature any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOneTimeCodeBySignature", reflect.TypeOf((*MockStorage)(nil).LoadOneTimeCodeBySignature), ctx, signature)
 }
 
+// LoadPasswordHistory mocks base method.
+func (m *MockStorage) LoadPasswordHistory(ctx context.Context, username string, limit int) ([]model.PasswordHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadPasswordHistory", ctx, username, limit)
+	ret0, _ := ret[0].([]model.PasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadPasswordHistory indicates an expected call of LoadPasswordHistory.
+func (mr *MockStorageMockRecorder) LoadPasswordHistory(ctx, username, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPasswordHistory", reflect.TypeOf((*MockStorage)(nil).LoadPasswordHistory), ctx, username, limit)
+}
+
 // LoadPreferred2FAMethod mocks base method.
 func (m *MockStorage) LoadPreferred2FAMethod(ctx context.Context, username string) (string, error) {
 	m.ctrl.T.Helper()
@@ -617,6 +808,21 @@ func (mr *MockStorageMockRecorder) LoadRegulationRecordsByUser(ctx, username, si
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationRecordsByUser", reflect.TypeOf((*MockStorage)(nil).LoadRegulationRecordsByUser), ctx, username, since, limit)
 }
 
+// LoadSessionData mocks base method.
+func (m *MockStorage) LoadSessionData(ctx context.Context, id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSessionData", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSessionData indicates an expected call of LoadSessionData.
+func (mr *MockStorageMockRecorder) LoadSessionData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSessionData", reflect.TypeOf((*MockStorage)(nil).LoadSessionData), ctx, id)
+}
+
 // LoadTOTPConfiguration mocks base method.
 func (m *MockStorage) LoadTOTPConfiguration(ctx context.Context, username string) (*model.TOTPConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -647,6 +853,36 @@ func (mr *MockStorageMockRecorder) LoadTOTPConfigurations(ctx, limit, page any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTOTPConfigurations", reflect.TypeOf((*MockStorage)(nil).LoadTOTPConfigurations), ctx, limit, page)
 }
 
+// LoadTrustedDevice mocks base method.
+func (m *MockStorage) LoadTrustedDevice(ctx context.Context, id uuid.UUID) (*model.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadTrustedDevice", ctx, id)
+	ret0, _ := ret[0].(*model.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadTrustedDevice indicates an expected call of LoadTrustedDevice.
+func (mr *MockStorageMockRecorder) LoadTrustedDevice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTrustedDevice", reflect.TypeOf((*MockStorage)(nil).LoadTrustedDevice), ctx, id)
+}
+
+// LoadTrustedDevices mocks base method.
+func (m *MockStorage) LoadTrustedDevices(ctx context.Context, username string, now time.Time) ([]model.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadTrustedDevices", ctx, username, now)
+	ret0, _ := ret[0].([]model.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadTrustedDevices indicates an expected call of LoadTrustedDevices.
+func (mr *MockStorageMockRecorder) LoadTrustedDevices(ctx, username, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTrustedDevices", reflect.TypeOf((*MockStorage)(nil).LoadTrustedDevices), ctx, username, now)
+}
+
 // LoadUserInfo mocks base method.
 func (m *MockStorage) LoadUserInfo(ctx context.Context, username string) (model.UserInfo, error) {
 	m.ctrl.T.Helper()
@@ -797,6 +1033,20 @@ func (mr *MockStorageMockRecorder) LoadWebAuthnUserByUserID(ctx, rpid, userID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebAuthnUserByUserID", reflect.TypeOf((*MockStorage)(nil).LoadWebAuthnUserByUserID), ctx, rpid, userID)
 }
 
+// RegenerateSessionData mocks base method.
+func (m *MockStorage) RegenerateSessionData(ctx context.Context, id, newID string, expires sql.NullTime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateSessionData", ctx, id, newID, expires)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegenerateSessionData indicates an expected call of RegenerateSessionData.
+func (mr *MockStorageMockRecorder) RegenerateSessionData(ctx, id, newID, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateSessionData", reflect.TypeOf((*MockStorage)(nil).RegenerateSessionData), ctx, id, newID, expires)
+}
+
 // RevokeBannedIP mocks base method.
 func (m *MockStorage) RevokeBannedIP(ctx context.Context, id int, expired time.Time) error {
 	m.ctrl.T.Helper()
@@ -895,6 +1145,20 @@ func (mr *MockStorageMockRecorder) RevokeOneTimeCode(ctx, id, ip any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).RevokeOneTimeCode), ctx, id, ip)
 }
 
+// RevokeTrustedDevice mocks base method.
+func (m *MockStorage) RevokeTrustedDevice(ctx context.Context, username string, id uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTrustedDevice", ctx, username, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTrustedDevice indicates an expected call of RevokeTrustedDevice.
+func (mr *MockStorageMockRecorder) RevokeTrustedDevice(ctx, username, id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTrustedDevice", reflect.TypeOf((*MockStorage)(nil).RevokeTrustedDevice), ctx, username, id, revokedAt)
+}
+
 // Rollback mocks base method.
 func (m *MockStorage) Rollback(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -909,6 +1173,34 @@ func (mr *MockStorageMockRecorder) Rollback(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockStorage)(nil).Rollback), ctx)
 }
 
+// SaveAuthenticationUser mocks base method.
+func (m *MockStorage) SaveAuthenticationUser(ctx context.Context, user model.AuthenticationUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuthenticationUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuthenticationUser indicates an expected call of SaveAuthenticationUser.
+func (mr *MockStorageMockRecorder) SaveAuthenticationUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuthenticationUser", reflect.TypeOf((*MockStorage)(nil).SaveAuthenticationUser), ctx, user)
+}
+
+// SaveAuthenticationUserGroups mocks base method.
+func (m *MockStorage) SaveAuthenticationUserGroups(ctx context.Context, username string, groups []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuthenticationUserGroups", ctx, username, groups)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuthenticationUserGroups indicates an expected call of SaveAuthenticationUserGroups.
+func (mr *MockStorageMockRecorder) SaveAuthenticationUserGroups(ctx, username, groups any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuthenticationUserGroups", reflect.TypeOf((*MockStorage)(nil).SaveAuthenticationUserGroups), ctx, username, groups)
+}
+
 // SaveBannedIP mocks base method.
 func (m *MockStorage) SaveBannedIP(ctx context.Context, ban *model.BannedIP) error {
 	m.ctrl.T.Helper()
@@ -1093,6 +1385,20 @@ func (mr *MockStorageMockRecorder) SaveOneTimeCode(ctx, code any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOneTimeCode", reflect.TypeOf((*MockStorage)(nil).SaveOneTimeCode), ctx, code)
 }
 
+// SavePasswordHistory mocks base method.
+func (m *MockStorage) SavePasswordHistory(ctx context.Context, history model.PasswordHistory, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePasswordHistory", ctx, history, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePasswordHistory indicates an expected call of SavePasswordHistory.
+func (mr *MockStorageMockRecorder) SavePasswordHistory(ctx, history, keep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePasswordHistory", reflect.TypeOf((*MockStorage)(nil).SavePasswordHistory), ctx, history, keep)
+}
+
 // SavePreferred2FAMethod mocks base method.
 func (m *MockStorage) SavePreferred2FAMethod(ctx context.Context, username, method string) error {
 	m.ctrl.T.Helper()
@@ -1121,6 +1427,20 @@ func (mr *MockStorageMockRecorder) SavePreferredDuoDevice(ctx, device any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).SavePreferredDuoDevice), ctx, device)
 }
 
+// SaveSessionData mocks base method.
+func (m *MockStorage) SaveSessionData(ctx context.Context, id string, data []byte, expires sql.NullTime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSessionData", ctx, id, data, expires)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSessionData indicates an expected call of SaveSessionData.
+func (mr *MockStorageMockRecorder) SaveSessionData(ctx, id, data, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionData", reflect.TypeOf((*MockStorage)(nil).SaveSessionData), ctx, id, data, expires)
+}
+
 // SaveTOTPConfiguration mocks base method.
 func (m *MockStorage) SaveTOTPConfiguration(ctx context.Context, config model.TOTPConfiguration) error {
 	m.ctrl.T.Helper()
@@ -1149,6 +1469,20 @@ func (mr *MockStorageMockRecorder) SaveTOTPHistory(ctx, username, step any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPHistory", reflect.TypeOf((*MockStorage)(nil).SaveTOTPHistory), ctx, username, step)
 }
 
+// SaveTrustedDevice mocks base method.
+func (m *MockStorage) SaveTrustedDevice(ctx context.Context, device model.TrustedDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTrustedDevice", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTrustedDevice indicates an expected call of SaveTrustedDevice.
+func (mr *MockStorageMockRecorder) SaveTrustedDevice(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTrustedDevice", reflect.TypeOf((*MockStorage)(nil).SaveTrustedDevice), ctx, device)
+}
+
 // SaveUserOpaqueIdentifier mocks base method.
 func (m *MockStorage) SaveUserOpaqueIdentifier(ctx context.Context, subject model.UserOpaqueIdentifier) error {
 	m.ctrl.T.Helper()
@@ -1352,6 +1686,34 @@ func (mr *MockStorageMockRecorder) StartupCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockStorage)(nil).StartupCheck))
 }
 
+// UpdateAuthenticationUser mocks base method.
+func (m *MockStorage) UpdateAuthenticationUser(ctx context.Context, user model.AuthenticationUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthenticationUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthenticationUser indicates an expected call of UpdateAuthenticationUser.
+func (mr *MockStorageMockRecorder) UpdateAuthenticationUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthenticationUser", reflect.TypeOf((*MockStorage)(nil).UpdateAuthenticationUser), ctx, user)
+}
+
+// UpdateAuthenticationUserPassword mocks base method.
+func (m *MockStorage) UpdateAuthenticationUserPassword(ctx context.Context, username string, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthenticationUserPassword", ctx, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthenticationUserPassword indicates an expected call of UpdateAuthenticationUserPassword.
+func (mr *MockStorageMockRecorder) UpdateAuthenticationUserPassword(ctx, username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthenticationUserPassword", reflect.TypeOf((*MockStorage)(nil).UpdateAuthenticationUserPassword), ctx, username, password)
+}
+
 // UpdateOAuth2DeviceCodeSession mocks base method.
 func (m *MockStorage) UpdateOAuth2DeviceCodeSession(ctx context.Context, session *model.OAuth2DeviceCodeSession) error {
 	m.ctrl.T.Helper()
@@ -1408,6 +1770,20 @@ func (mr *MockStorageMockRecorder) UpdateTOTPConfigurationSignIn(ctx, id, lastUs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTPConfigurationSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTOTPConfigurationSignIn), ctx, id, lastUsedAt)
 }
 
+// UpdateTrustedDeviceLastUsed mocks base method.
+func (m *MockStorage) UpdateTrustedDeviceLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrustedDeviceLastUsed", ctx, id, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTrustedDeviceLastUsed indicates an expected call of UpdateTrustedDeviceLastUsed.
+func (mr *MockStorageMockRecorder) UpdateTrustedDeviceLastUsed(ctx, id, lastUsedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrustedDeviceLastUsed", reflect.TypeOf((*MockStorage)(nil).UpdateTrustedDeviceLastUsed), ctx, id, lastUsedAt)
+}
+
 // UpdateWebAuthnCredentialDescription mocks base method.
 func (m *MockStorage) UpdateWebAuthnCredentialDescription(ctx context.Context, username string, credentialID int, description string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuthenticationUser represents a user row for the SQL authentication backend in the storage provider.
+type AuthenticationUser struct {
+	ID          int            `db:"id"`
+	Created     time.Time      `db:"created_at"`
+	Updated     time.Time      `db:"updated_at"`
+	Username    string         `db:"username"`
+	DisplayName string         `db:"display_name"`
+	Email       sql.NullString `db:"email"`
+	Password    string         `db:"password"`
+	Disabled    bool           `db:"disabled"`
+}
@@ -31,6 +31,10 @@ const (
 	semverRegexpGroupPreRelease = "PreRelease"
 )
 
+const (
+	trustedDeviceUserAgentMaxLength = 512
+)
+
 // JSON Schema format strings.
 const (
 	FormatJSONSchemaIdentifier         = "https://www.authelia.com/schemas/%s/json-schema/%s.json"
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// PasswordHistory represents a previously used password digest of a user in the storage provider.
+type PasswordHistory struct {
+	ID       int       `db:"id"`
+	Created  time.Time `db:"created_at"`
+	Username string    `db:"username"`
+	Password string    `db:"password"`
+}
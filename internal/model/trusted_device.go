@@ -0,0 +1,64 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// NewTrustedDevice returns a new TrustedDevice for the given user which remains trusted for the given lifespan.
+func NewTrustedDevice(ctx Context, username, userAgent string, lifespan time.Duration) (device *TrustedDevice, err error) {
+	var publicID uuid.UUID
+
+	if publicID, err = uuid.NewRandomFromReader(ctx.GetRandom()); err != nil {
+		return nil, fmt.Errorf("failed to generate public id: %w", err)
+	}
+
+	if len(userAgent) > trustedDeviceUserAgentMaxLength {
+		userAgent = strings.ToValidUTF8(userAgent[:trustedDeviceUserAgentMaxLength], "")
+	}
+
+	now := ctx.GetClock().Now()
+
+	return &TrustedDevice{
+		PublicID:  publicID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(lifespan),
+		Username:  username,
+		IP:        NewIP(ctx.RemoteIP()),
+		UserAgent: userAgent,
+	}, nil
+}
+
+// TrustedDevice represents a device a user has chosen to trust after completing the second factor on it.
+type TrustedDevice struct {
+	ID         int          `db:"id"`
+	PublicID   uuid.UUID    `db:"public_id"`
+	CreatedAt  time.Time    `db:"created_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	ExpiresAt  time.Time    `db:"expires_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+	Username   string       `db:"username"`
+	IP         IP           `db:"ip"`
+	UserAgent  string       `db:"user_agent"`
+}
+
+// IsTrusted returns true if the device has neither been revoked nor expired at the given time.
+func (d *TrustedDevice) IsTrusted(now time.Time) (trusted bool) {
+	return !d.RevokedAt.Valid && now.Before(d.ExpiresAt)
+}
+
+// ToClaims returns the claims of the signed token stored in the cookie which identifies the device.
+func (d *TrustedDevice) ToClaims(issuer string) (claims *jwt.RegisteredClaims) {
+	return &jwt.RegisteredClaims{
+		ID:        d.PublicID.String(),
+		Issuer:    issuer,
+		Subject:   d.Username,
+		IssuedAt:  jwt.NewNumericDate(d.CreatedAt),
+		ExpiresAt: jwt.NewNumericDate(d.ExpiresAt),
+	}
+}
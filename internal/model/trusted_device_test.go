@@ -0,0 +1,99 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/random"
+)
+
+func TestNewTrustedDevice(t *testing.T) {
+	now := time.Unix(1000000000, 0)
+
+	ctx := &TestContext{
+		Context: context.Background(),
+		ip:      net.ParseIP("127.0.0.1"),
+		clock:   clock.NewFixed(now),
+		random:  random.NewMathematical(),
+	}
+
+	device, err := NewTrustedDevice(ctx, "john", "Test/1.0", time.Hour)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, device.PublicID)
+	assert.Equal(t, "john", device.Username)
+	assert.Equal(t, "Test/1.0", device.UserAgent)
+	assert.Equal(t, NewIP(net.ParseIP("127.0.0.1")), device.IP)
+	assert.Equal(t, now, device.CreatedAt)
+	assert.Equal(t, now.Add(time.Hour), device.ExpiresAt)
+	assert.False(t, device.LastUsedAt.Valid)
+	assert.False(t, device.RevokedAt.Valid)
+
+	device, err = NewTrustedDevice(ctx, "john", strings.Repeat("a", 511)+"é", time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Repeat("a", 511), device.UserAgent)
+}
+
+func TestTrustedDevice_IsTrusted(t *testing.T) {
+	now := time.Unix(1000000000, 0)
+
+	testCases := []struct {
+		name     string
+		have     TrustedDevice
+		expected bool
+	}{
+		{
+			"ShouldBeTrusted",
+			TrustedDevice{ExpiresAt: now.Add(time.Minute)},
+			true,
+		},
+		{
+			"ShouldNotBeTrustedWhenExpired",
+			TrustedDevice{ExpiresAt: now},
+			false,
+		},
+		{
+			"ShouldNotBeTrustedWhenRevoked",
+			TrustedDevice{ExpiresAt: now.Add(time.Minute), RevokedAt: sql.NullTime{Time: now, Valid: true}},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.IsTrusted(now))
+		})
+	}
+}
+
+func TestTrustedDevice_ToClaims(t *testing.T) {
+	now := time.Unix(1000000000, 0)
+
+	ctx := &TestContext{
+		Context: context.Background(),
+		ip:      net.ParseIP("127.0.0.1"),
+		clock:   clock.NewFixed(now),
+		random:  random.NewMathematical(),
+	}
+
+	device, err := NewTrustedDevice(ctx, "john", "Test/1.0", time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, &jwt.RegisteredClaims{
+		ID:        device.PublicID.String(),
+		Issuer:    "https://auth.example.com",
+		Subject:   "john",
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}, device.ToClaims("https://auth.example.com"))
+}
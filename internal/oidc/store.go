@@ -466,6 +466,28 @@ func (s *Store) revokeSessionByRequestID(ctx context.Context, sessionType storag
 	return nil
 }
 
+// RevokeUserOAuth2Sessions revokes every access token and refresh token session which has not already been revoked for
+// all subjects belonging to the provided username, returning the number of sessions which were revoked.
+func RevokeUserOAuth2Sessions(ctx context.Context, provider storage.Provider, username string) (revoked int, err error) {
+	var signatures []string
+
+	for _, sessionType := range []storage.OAuth2SessionType{storage.OAuth2SessionTypeRefreshToken, storage.OAuth2SessionTypeAccessToken} {
+		if signatures, err = provider.LoadOAuth2SessionSignaturesByUsername(ctx, sessionType, username); err != nil {
+			return revoked, err
+		}
+
+		for _, signature := range signatures {
+			if err = provider.RevokeOAuth2Session(ctx, sessionType, signature); err != nil {
+				return revoked, fmt.Errorf("error revoking oauth2 %s session for user '%s': %w", sessionType, username, err)
+			}
+
+			revoked++
+		}
+	}
+
+	return revoked, nil
+}
+
 var (
 	_ oauthelia2.PARStorage              = (*Store)(nil)
 	_ oauthelia2.ClientManager           = (*Store)(nil)
@@ -886,3 +886,68 @@ func (s *StoreSuite) TestGetDeviceCodeSessionByUserCode() {
 		assert.NotNil(t, request)
 	})
 }
+
+func TestRevokeUserOAuth2Sessions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(mock *mocks.MockStorage)
+		expected int
+		err      string
+	}{
+		{
+			"ShouldRevokeAllSessions",
+			func(mock *mocks.MockStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "john").Return([]string{"r1", "r2"}, nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "r1").Return(nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "r2").Return(nil),
+					mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "john").Return([]string{"a1"}, nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "a1").Return(nil),
+				)
+			},
+			3,
+			"",
+		},
+		{
+			"ShouldErrOnLoad",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "john").Return(nil, fmt.Errorf("load error"))
+			},
+			0,
+			"load error",
+		},
+		{
+			"ShouldErrOnRevoke",
+			func(mock *mocks.MockStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2SessionSignaturesByUsername(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "john").Return([]string{"r1", "r2"}, nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "r1").Return(nil),
+					mock.EXPECT().RevokeOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "r2").Return(fmt.Errorf("revoke error")),
+				)
+			},
+			1,
+			"error revoking oauth2 refresh token session for user 'john': revoke error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mock := mocks.NewMockStorage(ctrl)
+
+			tc.setup(mock)
+
+			revoked, err := oidc.RevokeUserOAuth2Sessions(context.Background(), mock, "john")
+
+			assert.Equal(t, tc.expected, revoked)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -211,6 +211,11 @@ func handlerMain(config *schema.Configuration, providers middlewares.Providers)
 		WithPostMiddlewares(middlewares.RequireElevated).
 		Build()
 
+	middlewarePasswordChange := middlewares.NewBridgeBuilder(*config, providers).
+		WithPreMiddlewares(middlewares.SecurityHeadersBase, middlewares.SecurityHeadersNoStore, middlewares.SecurityHeadersCSPNone).
+		WithPostMiddlewares(middlewares.RequirePasswordChangeOrElevated).
+		Build()
+
 	r.HEAD("/api/health", middlewareAPI(handlers.HealthGET))
 	r.GET("/api/health", middlewareAPI(handlers.HealthGET))
 
@@ -267,7 +272,7 @@ func handlerMain(config *schema.Configuration, providers middlewares.Providers)
 	}
 
 	if !config.AuthenticationBackend.PasswordChange.Disable {
-		r.POST("/api/change-password", middlewareElevated1FA(handlers.ChangePasswordPOST))
+		r.POST("/api/change-password", middlewarePasswordChange(handlers.ChangePasswordPOST))
 	}
 
 	r.GET("/api/user/info", middleware1FA(handlers.UserInfoGET))
@@ -290,6 +295,21 @@ func handlerMain(config *schema.Configuration, providers middlewares.Providers)
 
 	r.DELETE("/api/user/session/elevation/{id}", middlewareAPI(handlers.UserSessionElevateDELETE))
 
+	r.GET("/api/user/sessions", middleware1FA(handlers.UserSessionsGET))
+	r.DELETE("/api/user/sessions", middlewareElevated1FA(handlers.UserSessionsDELETE))
+	r.DELETE("/api/user/sessions/{sessionID}", middlewareElevated1FA(handlers.UserSessionDELETE))
+
+	if config.Session.TrustedDevices != nil {
+		r.GET("/api/user/trusted-devices", middleware1FA(handlers.UserTrustedDevicesGET))
+		r.POST("/api/user/trusted-devices", middleware1FA(handlers.UserTrustedDevicesPOST))
+		r.DELETE("/api/user/trusted-devices/{deviceID}", middleware1FA(handlers.UserTrustedDeviceDELETE))
+	}
+
+	if len(config.Session.Administrators) != 0 {
+		r.GET("/api/admin/sessions/{username}", middlewareElevated1FA(handlers.AdminSessionsGET))
+		r.DELETE("/api/admin/sessions/{username}", middlewareElevated1FA(handlers.AdminSessionsDELETE))
+	}
+
 	if !config.TOTP.Disable {
 		middlewareRateLimitTOTP := middlewares.NewBridgeBuilder(*config, providers).
 			WithPreMiddlewares(middlewares.SecurityHeadersBase, middlewares.SecurityHeadersNoStore, middlewares.SecurityHeadersCSPNone).
@@ -230,6 +230,8 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 		EndpointsTOTP:           !config.TOTP.Disable,
 		EndpointsDuo:            !config.DuoAPI.Disable,
 		EndpointsOpenIDConnect:  config.IdentityProviders.OIDC != nil,
+		EndpointsAdminSessions:  len(config.Session.Administrators) != 0,
+		EndpointsTrustedDevices: config.Session.TrustedDevices != nil,
 		EndpointsAuthz:          config.Server.Endpoints.Authz,
 	}
 
@@ -266,6 +268,8 @@ type TemplatedFileOptions struct {
 	EndpointsTOTP           bool
 	EndpointsDuo            bool
 	EndpointsOpenIDConnect  bool
+	EndpointsAdminSessions  bool
+	EndpointsTrustedDevices bool
 
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
@@ -331,6 +335,8 @@ func (options *TemplatedFileOptions) OpenAPIData(base, baseURL, domain, nonce st
 		TOTP:           options.EndpointsTOTP,
 		Duo:            options.EndpointsDuo,
 		OpenIDConnect:  options.EndpointsOpenIDConnect,
+		AdminSessions:  options.EndpointsAdminSessions,
+		TrustedDevices: options.EndpointsTrustedDevices,
 		EndpointsAuthz: options.EndpointsAuthz,
 	}
 }
@@ -368,6 +374,8 @@ type TemplatedFileOpenAPIData struct {
 	TOTP           bool
 	Duo            bool
 	OpenIDConnect  bool
+	AdminSessions  bool
+	TrustedDevices bool
 
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
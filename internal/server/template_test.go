@@ -62,7 +62,7 @@ func TestShouldTemplateOpenAPI(t *testing.T) {
 		},
 	}
 
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 	opts := NewTemplatedFileOptions(&mock.Ctx.Configuration)
 
@@ -157,7 +157,7 @@ func TestServeTemplatedFile(t *testing.T) {
 				},
 			}
 
-			mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+			mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 			opts := NewTemplatedFileOptions(&mock.Ctx.Configuration)
 
@@ -384,7 +384,7 @@ func TestETagRootURL(t *testing.T) {
 				},
 			}
 
-			mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+			mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil)
 
 			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
 			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedHost, "auth.example.com")
@@ -396,7 +396,7 @@ func TestETagRootURL(t *testing.T) {
 
 				firstMock.Ctx.Configuration.Server = schema.DefaultServerConfiguration
 				firstMock.Ctx.Configuration.Session = mock.Ctx.Configuration.Session
-				firstMock.Ctx.Providers.SessionProvider = session.NewProvider(firstMock.Ctx.Configuration.Session, nil)
+				firstMock.Ctx.Providers.SessionProvider = session.NewProvider(firstMock.Ctx.Configuration.Session, nil, nil)
 				firstMock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
 				firstMock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedHost, "auth.example.com")
 				firstMock.Ctx.Request.Header.Set("X-Forwarded-URI", "/api/openapi.yml")
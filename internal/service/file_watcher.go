@@ -18,7 +18,17 @@ func ProvisionUsersFileWatcher(ctx Context) (service Provider, err error) {
 	providers := ctx.GetProviders()
 
 	if config.AuthenticationBackend.File != nil && config.AuthenticationBackend.File.Watch {
-		provider, ok := providers.UserProvider.(*authentication.FileUserProvider)
+		var (
+			provider *authentication.FileUserProvider
+			ok       bool
+		)
+
+		switch p := providers.UserProvider.(type) {
+		case *authentication.ChainUserProvider:
+			provider, ok = p.Backend(authentication.BackendFile).(*authentication.FileUserProvider)
+		default:
+			provider, ok = p.(*authentication.FileUserProvider)
+		}
 
 		if !ok {
 			return nil, errors.New("error occurred asserting user provider")
@@ -32,6 +42,33 @@ func ProvisionUsersFileWatcher(ctx Context) (service Provider, err error) {
 	return service, nil
 }
 
+// ProvisionAccessControlFileWatcher returns a Provider which watches the configuration for changes and reloads the
+// access control rules.
+func ProvisionAccessControlFileWatcher(ctx Context) (service Provider, err error) {
+	config := ctx.GetConfiguration()
+
+	if !config.AccessControl.Watch {
+		return nil, nil
+	}
+
+	providers := ctx.GetProviders()
+
+	if providers.Authorizer == nil {
+		return nil, errors.New("error occurred asserting authorizer")
+	}
+
+	switch paths := ctx.GetConfigurationPaths(); len(paths) {
+	case 1:
+		if service, err = NewFileWatcher("access_control", paths[0], providers.Authorizer, ctx.GetLogger()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("error initializing access control file watcher: exactly one configuration file or directory must be specified but %d were specified", len(paths))
+	}
+
+	return service, nil
+}
+
 // NewFileWatcher creates a new FileWatcher with the appropriate logger etc.
 func NewFileWatcher(name, path string, reload ReloadableProvider, log *logrus.Entry) (service *FileWatcher, err error) {
 	if path == "" {
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -95,6 +96,79 @@ func TestProvisionUsersFileWatcher(t *testing.T) {
 	assert.Equal(t, "watcher", watcher.ServiceType())
 
 	watcher.Shutdown()
+
+	ctx.Providers.UserProvider = authentication.NewChainUserProvider(
+		[]schema.AuthenticationBackendChain{{Backend: authentication.BackendFile}},
+		map[string]authentication.UserProvider{},
+	)
+
+	watcher, err = provision(ctx)
+	assert.EqualError(t, err, "error occurred asserting user provider")
+	assert.Nil(t, watcher)
+
+	ctx.Providers.UserProvider = authentication.NewChainUserProvider(
+		[]schema.AuthenticationBackendChain{{Backend: authentication.BackendFile}},
+		map[string]authentication.UserProvider{
+			authentication.BackendFile: authentication.NewFileUserProvider(config.AuthenticationBackend.File),
+		},
+	)
+
+	watcher, err = provision(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, watcher)
+
+	watcher.Shutdown()
+}
+
+func TestProvisionAccessControlFileWatcher(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(dir, "configuration.yml"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	config := &schema.Configuration{}
+
+	provision := ProvisionAccessControlFileWatcher
+
+	ctx := &testCtx{
+		Context:       context.Background(),
+		Configuration: config,
+		Providers:     middlewares.NewProvidersBasic(),
+		Logger:        logrus.NewEntry(logging.Logger()),
+	}
+
+	watcher, err := provision(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, watcher)
+
+	config.AccessControl.Watch = true
+
+	watcher, err = provision(ctx)
+	assert.EqualError(t, err, "error occurred asserting authorizer")
+	assert.Nil(t, watcher)
+
+	ctx.Providers.Authorizer = authorization.NewAuthorizer(config)
+
+	watcher, err = provision(ctx)
+	assert.EqualError(t, err, "error initializing access control file watcher: exactly one configuration file or directory must be specified but 0 were specified")
+	assert.Nil(t, watcher)
+
+	ctx.ConfigurationPaths = []string{filepath.Join(dir, "configuration.yml"), filepath.Join(dir, "other.yml")}
+
+	watcher, err = provision(ctx)
+	assert.EqualError(t, err, "error initializing access control file watcher: exactly one configuration file or directory must be specified but 2 were specified")
+	assert.Nil(t, watcher)
+
+	ctx.ConfigurationPaths = []string{filepath.Join(dir, "configuration.yml")}
+
+	watcher, err = provision(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, watcher)
+	assert.Equal(t, "access_control", watcher.ServiceName())
+	assert.Equal(t, "watcher", watcher.ServiceType())
+
+	watcher.Shutdown()
 }
 
 func TestNewFileWatcher(t *testing.T) {
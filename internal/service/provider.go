@@ -36,6 +36,7 @@ func GetProvisioners() []Provisioner {
 		ProvisionServer,
 		ProvisionServerMetrics,
 		ProvisionUsersFileWatcher,
+		ProvisionAccessControlFileWatcher,
 		ProvisionLoggingSignal,
 		ProvisionGarbageCollector,
 	}
@@ -200,9 +200,10 @@ func (l *testListener) Addr() net.Addr {
 }
 
 type testCtx struct {
-	Configuration *schema.Configuration
-	Providers     middlewares.Providers
-	Logger        *logrus.Entry
+	Configuration      *schema.Configuration
+	ConfigurationPaths []string
+	Providers          middlewares.Providers
+	Logger             *logrus.Entry
 
 	context.Context
 }
@@ -211,6 +212,10 @@ func (c *testCtx) GetConfiguration() *schema.Configuration {
 	return c.Configuration
 }
 
+func (c *testCtx) GetConfigurationPaths() (paths []string) {
+	return c.ConfigurationPaths
+}
+
 func (c *testCtx) GetProviders() middlewares.Providers {
 	return c.Providers
 }
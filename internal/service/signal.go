@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"os"
 	"os/signal"
 	"sync"
@@ -11,18 +12,43 @@ import (
 	"github.com/authelia/authelia/v4/internal/logging"
 )
 
-// ProvisionLoggingSignal returns a Provider which reopens the log file when the relevant signal is received.
+// ProvisionLoggingSignal returns a Provider which reopens the log file and the access control decision audit log file
+// when the relevant signal is received.
 func ProvisionLoggingSignal(ctx Context) (service Provider, err error) {
 	config := ctx.GetConfiguration()
 
-	if config == nil || len(config.Log.FilePath) == 0 {
+	if config == nil {
 		return nil, nil
 	}
 
+	var actions []func() (err error)
+
+	if len(config.Log.FilePath) != 0 {
+		actions = append(actions, logging.Reopen)
+	}
+
+	if auditor := ctx.GetProviders().AuditLogger; auditor != nil {
+		actions = append(actions, auditor.Reopen)
+	}
+
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	reopen := func() (err error) {
+		errs := make([]error, 0, len(actions))
+
+		for _, action := range actions {
+			errs = append(errs, action())
+		}
+
+		return errors.Join(errs...)
+	}
+
 	return &Signal{
 		name:    "log-reload",
 		signals: []os.Signal{syscall.SIGHUP},
-		action:  logging.Reopen,
+		action:  reopen,
 		log:     ctx.GetLogger().WithFields(map[string]any{logFieldService: serviceTypeSignal, serviceTypeSignal: "log-reload"}),
 		notify:  make(chan os.Signal, 1),
 		quit:    make(chan struct{}),
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -13,14 +14,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/random"
 )
 
 type mockServiceCtx struct {
 	ctx       context.Context
 	config    *schema.Configuration
+	paths     []string
 	logger    *logrus.Entry
 	providers middlewares.Providers
 }
@@ -37,6 +41,10 @@ func (m *mockServiceCtx) GetConfiguration() *schema.Configuration {
 	return m.config
 }
 
+func (m *mockServiceCtx) GetConfigurationPaths() (paths []string) {
+	return m.paths
+}
+
 func (m *mockServiceCtx) Deadline() (deadline time.Time, ok bool) {
 	return m.ctx.Deadline()
 }
@@ -244,6 +252,68 @@ func TestLogReopenFiles(t *testing.T) {
 	assert.Equal(t, 2, len(entries))
 }
 
+func TestLogReopenAuditLog(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "audit.log")
+
+	auditor := authorization.NewAuditLogger(schema.AccessControlAuditLog{FilePath: path, SampleRate: 1}, random.NewMathematical())
+	require.NoError(t, auditor.Open())
+
+	defer auditor.Close()
+
+	ctx := newMockServiceCtx()
+	ctx.providers.AuditLogger = auditor
+
+	service, err := ProvisionLoggingSignal(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, service)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- service.Run()
+	}()
+
+	defer service.Shutdown()
+
+	require.NoError(t, auditor.Log(authorization.AuditDecision{Policy: "one_factor"}))
+
+	// Simulate a log rotation which moves the current file aside before the signal is sent.
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "audit.log.1")))
+
+	service.(*Signal).notify <- syscall.SIGHUP
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, auditor.Log(authorization.AuditDecision{Policy: "two_factor"}))
+
+	rotated, err := os.ReadFile(filepath.Join(dir, "audit.log.1"))
+	require.NoError(t, err)
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(rotated), `"policy":"one_factor"`)
+	assert.NotContains(t, string(rotated), `"policy":"two_factor"`)
+	assert.Contains(t, string(current), `"policy":"two_factor"`)
+}
+
+func TestSvcSignalLogReOpenFuncAuditLog(t *testing.T) {
+	ctx := newMockServiceCtx()
+	ctx.providers.AuditLogger = authorization.NewAuditLogger(schema.AccessControlAuditLog{FilePath: filepath.Join(t.TempDir(), "audit.log")}, random.NewMathematical())
+
+	service, err := ProvisionLoggingSignal(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, service)
+
+	assert.Equal(t, "log-reload", service.ServiceName())
+}
+
 func TestSignalRunShouldNotReturnStaleActionError(t *testing.T) {
 	called := make(chan struct{}, 1)
 
@@ -14,6 +14,7 @@ type Context interface {
 	GetLogger() *logrus.Entry
 	GetProviders() middlewares.Providers
 	GetConfiguration() *schema.Configuration
+	GetConfigurationPaths() (paths []string)
 
 	context.Context
 }
@@ -44,3 +45,7 @@ func (c *runContext) GetProviders() middlewares.Providers {
 func (c *runContext) GetConfiguration() *schema.Configuration {
 	return c.base.GetConfiguration()
 }
+
+func (c *runContext) GetConfigurationPaths() (paths []string) {
+	return c.base.GetConfigurationPaths()
+}
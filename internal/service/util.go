@@ -79,6 +79,12 @@ func Run(ctx Context, provisioners ...Provisioner) (err error) {
 		log.WithError(err).Error("Error occurred closing database connections")
 	}
 
+	if auditor := ctx.GetProviders().AuditLogger; auditor != nil {
+		if err = auditor.Close(); err != nil {
+			log.WithError(err).Error("Error occurred closing the access control decision audit log")
+		}
+	}
+
 	if err = group.Wait(); err != nil {
 		log.WithError(err).Error("Error occurred waiting for shutdown")
 	}
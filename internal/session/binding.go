@@ -0,0 +1,102 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewBinding returns the *Binding of a client given the session binding configuration, the remote IP of the client,
+// and the user agent of the client. It returns nil if the session binding configuration is nil.
+func NewBinding(config *schema.SessionBinding, ip net.IP, userAgent []byte) *Binding {
+	if config == nil {
+		return nil
+	}
+
+	binding := &Binding{}
+
+	if ip != nil {
+		var network *net.IPNet
+
+		if ip4 := ip.To4(); ip4 != nil {
+			network = &net.IPNet{IP: ip4.Mask(net.CIDRMask(config.IPv4Prefix, net.IPv4len*8)), Mask: net.CIDRMask(config.IPv4Prefix, net.IPv4len*8)}
+		} else {
+			network = &net.IPNet{IP: ip.Mask(net.CIDRMask(config.IPv6Prefix, net.IPv6len*8)), Mask: net.CIDRMask(config.IPv6Prefix, net.IPv6len*8)}
+		}
+
+		binding.Network = network.String()
+	}
+
+	if !config.DisableUserAgent {
+		sum := sha256.Sum256(userAgent)
+
+		binding.UserAgentHash = hex.EncodeToString(sum[:])
+	}
+
+	return binding
+}
+
+// Binding is the fingerprint of the client which established an authenticated session. It consists of the network
+// prefix of the remote IP and a hash of the user agent of the client.
+type Binding struct {
+	Network       string
+	UserAgentHash string
+}
+
+// Equal returns true if the other *Binding has the same network and user agent hash.
+func (b *Binding) Equal(other *Binding) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+
+	return b.Network == other.Network && b.UserAgentHash == other.UserAgentHash
+}
+
+// ValidateBinding validates the binding of the user session against the binding of the client which made the request
+// when session binding is configured. An authenticated session which isn't bound yet, for example because it was
+// established before session binding was configured, is bound to the client. If the binding doesn't match the session is
+// destroyed or reset so the user has to authenticate again depending on the configured action, and the mismatch return
+// value is true. The returned user session must be used for the remainder of the request.
+func ValidateBinding(manager Manager, config *schema.SessionBinding, userSession UserSession, binding *Binding) (updated UserSession, mismatch bool, err error) {
+	switch {
+	case config == nil:
+		return userSession, false, nil
+	case userSession.Binding == nil:
+		if userSession.IsAnonymous() {
+			return userSession, false, nil
+		}
+
+		userSession.Binding = binding
+
+		if err = manager.SaveSession(userSession); err != nil {
+			return userSession, false, fmt.Errorf("error saving the session binding: %w", err)
+		}
+
+		return userSession, false, nil
+	case userSession.Binding.Equal(binding):
+		return userSession, false, nil
+	}
+
+	updated = manager.NewDefaultUserSession()
+
+	switch config.Action {
+	case schema.SessionBindingActionReauthenticate:
+		updated.Username = userSession.Username
+		updated.DisplayName = userSession.DisplayName
+		updated.Emails = userSession.Emails
+		updated.KeepMeLoggedIn = userSession.KeepMeLoggedIn
+	default:
+		if err = manager.DestroySession(); err != nil {
+			err = fmt.Errorf("error destroying the session: %w", err)
+		}
+	}
+
+	if serr := manager.SaveSession(updated); serr != nil && err == nil {
+		err = fmt.Errorf("error saving the session: %w", serr)
+	}
+
+	return updated, true, err
+}
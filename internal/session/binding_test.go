@@ -0,0 +1,129 @@
+package session
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewBinding(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    *schema.SessionBinding
+		ip        net.IP
+		userAgent []byte
+		expected  *Binding
+	}{
+		{
+			"ShouldReturnNilWithoutConfiguration",
+			nil,
+			net.ParseIP("192.168.0.1"),
+			[]byte("Test/1.0"),
+			nil,
+		},
+		{
+			"ShouldMaskIPv4",
+			&schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64},
+			net.ParseIP("192.168.0.123"),
+			[]byte("Test/1.0"),
+			&Binding{Network: "192.168.0.0/24", UserAgentHash: "894e460cec90e5f2e9a38a167f8e4baa65a5cf673f3dc1f2714cdb02aaa95411"},
+		},
+		{
+			"ShouldMaskIPv6",
+			&schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64, DisableUserAgent: true},
+			net.ParseIP("2001:db8:1:2:3:4:5:6"),
+			[]byte("Test/1.0"),
+			&Binding{Network: "2001:db8:1:2::/64"},
+		},
+		{
+			"ShouldHandleNilIP",
+			&schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64, DisableUserAgent: true},
+			nil,
+			[]byte("Test/1.0"),
+			&Binding{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewBinding(tc.config, tc.ip, tc.userAgent)
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestBindingEqual(t *testing.T) {
+	config := &schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64}
+
+	a := NewBinding(config, net.ParseIP("192.168.0.1"), []byte("Test/1.0"))
+
+	assert.True(t, a.Equal(NewBinding(config, net.ParseIP("192.168.0.254"), []byte("Test/1.0"))))
+	assert.False(t, a.Equal(NewBinding(config, net.ParseIP("192.168.1.1"), []byte("Test/1.0"))))
+	assert.False(t, a.Equal(NewBinding(config, net.ParseIP("192.168.0.1"), []byte("Test/2.0"))))
+	assert.False(t, a.Equal(nil))
+
+	var b *Binding
+
+	assert.True(t, b.Equal(nil))
+	assert.False(t, b.Equal(a))
+}
+
+func TestValidateBinding(t *testing.T) {
+	bound := NewBinding(&schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64}, net.ParseIP("192.168.0.1"), []byte("Test/1.0"))
+	other := NewBinding(&schema.SessionBinding{IPv4Prefix: 24, IPv6Prefix: 64}, net.ParseIP("10.0.0.1"), []byte("Test/1.0"))
+
+	testCases := []struct {
+		name             string
+		config           *schema.SessionBinding
+		username         string
+		have, binding    *Binding
+		expectedMismatch bool
+		expectedUsername string
+		expectedBinding  *Binding
+	}{
+		{"ShouldIgnoreWithoutConfiguration", nil, testUsername, bound, other, false, testUsername, bound},
+		{"ShouldRetainMatchingSession", &schema.SessionBinding{}, testUsername, bound, bound, false, testUsername, bound},
+		{"ShouldBindUnboundSession", &schema.SessionBinding{}, testUsername, nil, other, false, testUsername, other},
+		{"ShouldNotBindAnonymousSession", &schema.SessionBinding{}, "", nil, other, false, "", nil},
+		{"ShouldDestroyMismatchedSession", &schema.SessionBinding{Action: schema.SessionBindingActionDestroy}, testUsername, bound, other, true, "", nil},
+		{"ShouldReauthenticateMismatchedSession", &schema.SessionBinding{Action: schema.SessionBindingActionReauthenticate}, testUsername, bound, other, true, testUsername, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := newTestSession()
+			require.NoError(t, err)
+
+			manager := NewEncapsulatedSession(provider, &fasthttp.RequestCtx{})
+
+			userSession, err := manager.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = tc.username
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = tc.username != ""
+			userSession.Binding = tc.have
+
+			require.NoError(t, manager.SaveSession(userSession))
+
+			updated, mismatch, err := ValidateBinding(manager, tc.config, userSession, tc.binding)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedMismatch, mismatch)
+			assert.Equal(t, tc.expectedUsername, updated.Username)
+			assert.Equal(t, tc.expectedBinding, updated.Binding)
+			assert.Equal(t, tc.expectedMismatch, updated.IsAnonymous() && tc.username != "")
+
+			stored, err := manager.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedUsername, stored.Username)
+			assert.Equal(t, tc.expectedBinding, stored.Binding)
+		})
+	}
+}
@@ -12,6 +12,17 @@ const (
 )
 
 const (
-	userSessionStorerKey = "UserSession"
-	randomSessionChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_!#$%^*"
+	userSessionStorerKey      = "UserSession"
+	userSessionIndexStorerKey = "UserSessionIndex"
+	userSessionIndexedKey     = "UserSessionIndexed"
+	userSessionIndexIDPrefix  = "user-session-index;"
+	randomSessionChars        = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_!#$%^*"
 )
+
+// updateMaxAttempts is the number of times a session backend attempts to atomically update the data of a session before
+// giving up when the data is concurrently modified.
+const updateMaxAttempts = 10
+
+// userSessionIndexTouchInterval is the longest interval between updates of the record of a session in the user
+// session index when neither the session ID nor the authentication level of the session changed.
+const userSessionIndexTouchInterval = time.Minute * 5
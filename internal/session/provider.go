@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/fasthttp/session/v2"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 // Provider contains a list of domain sessions.
@@ -15,12 +17,14 @@ type Provider struct {
 	sessions    map[string]*Session
 	backend     session.Provider
 	backendName string
+	index       *UserSessionIndex
 	errStartup  error
 }
 
-// NewProvider instantiate a session provider given a configuration.
-func NewProvider(config schema.Session, certPool *x509.CertPool) *Provider {
-	name, p, s, err := NewSessionProvider(config, certPool)
+// NewProvider instantiate a session provider given a configuration. The storage.SessionDataProvider is only used by the
+// storage session provider.
+func NewProvider(config schema.Session, certPool *x509.CertPool, store storage.SessionDataProvider) *Provider {
+	name, p, s, err := NewSessionProvider(config, certPool, store)
 	if err != nil {
 		return &Provider{errStartup: fmt.Errorf("error initializing session backend: %w", err)}
 	}
@@ -29,6 +33,7 @@ func NewProvider(config schema.Session, certPool *x509.CertPool) *Provider {
 		sessions:    map[string]*Session{},
 		backend:     p,
 		backendName: name,
+		index:       NewUserSessionIndex(p, s, getUserSessionIndexExpiration(config)),
 	}
 
 	var (
@@ -45,6 +50,7 @@ func NewProvider(config schema.Session, certPool *x509.CertPool) *Provider {
 		provider.sessions[dconfig.Domain] = &Session{
 			Config:        dconfig,
 			sessionHolder: holder,
+			index:         provider.index,
 		}
 	}
 
@@ -93,3 +99,27 @@ func (p *Provider) Get(domain string) (*Session, error) {
 
 	return s, nil
 }
+
+// UserSessionIndex returns the per-user session index which is shared by all session cookie domains.
+func (p *Provider) UserSessionIndex() *UserSessionIndex {
+	return p.index
+}
+
+// GarbageCollection performs the garbage collection of the session backend if the backend requires it.
+func (p *Provider) GarbageCollection(ctx context.Context) (err error) {
+	if collector, ok := p.backend.(garbageCollector); ok {
+		return collector.GarbageCollection(ctx)
+	}
+
+	return nil
+}
+
+// GarbageCollectionFrequency returns the garbage collection frequency of the session backend, which is zero if the
+// backend expires the sessions itself.
+func (p *Provider) GarbageCollectionFrequency(ctx context.Context) (frequency time.Duration) {
+	if collector, ok := p.backend.(garbageCollector); ok {
+		return collector.GarbageCollectionFrequency(ctx)
+	}
+
+	return 0
+}
@@ -8,13 +8,14 @@ import (
 	"strings"
 
 	"github.com/fasthttp/session/v2"
-	"github.com/fasthttp/session/v2/providers/redis"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/session/memory"
+	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -91,13 +92,19 @@ func NewProviderConfigAndSession(config schema.SessionCookie, providerName strin
 	return c, p, nil
 }
 
-// NewSessionProvider returns the name, provider, and serializer for the given session configuration.
-func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name string, provider session.Provider, serializer Serializer, err error) {
+// NewSessionProvider returns the name, provider, and serializer for the given session configuration. The
+// storage.SessionDataProvider is only used by the storage session provider.
+func NewSessionProvider(config schema.Session, certPool *x509.CertPool, store storage.SessionDataProvider) (name string, provider session.Provider, serializer Serializer, err error) {
 	switch {
 	case config.Redis != nil:
 		serializer = NewEncryptingSerializer(config.Secret)
 
-		var tlsConfig *tls.Config
+		var (
+			tlsConfig *tls.Config
+			client    redis.UniversalClient
+		)
+
+		redis.SetLogger(logging.LoggerCtxPrintf(logrus.TraceLevel))
 
 		if config.Redis.TLS != nil {
 			tlsConfig = utils.NewTLSConfig(config.Redis.TLS, certPool)
@@ -119,8 +126,7 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 
 			name = "redis-sentinel"
 
-			provider, err = redis.NewFailover(redis.FailoverConfig{
-				Logger:           logging.LoggerCtxPrintf(logrus.TraceLevel),
+			client = redis.NewFailoverClient(&redis.FailoverOptions{
 				MasterName:       config.Redis.HighAvailability.SentinelName,
 				SentinelAddrs:    addrs,
 				DialTimeout:      config.Redis.Timeout,
@@ -136,7 +142,6 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 				MinIdleConns:     config.Redis.MinimumIdleConnections,
 				ConnMaxIdleTime:  300,
 				TLSConfig:        tlsConfig,
-				KeyPrefix:        "authelia-session",
 			})
 		} else {
 			name = "redis"
@@ -151,8 +156,7 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 				addr = fmt.Sprintf("%s:%d", config.Redis.Host, config.Redis.Port)
 			}
 
-			provider, err = redis.New(redis.Config{
-				Logger:          logging.LoggerCtxPrintf(logrus.TraceLevel),
+			client = redis.NewClient(&redis.Options{
 				Network:         network,
 				Addr:            addr,
 				DialTimeout:     config.Redis.Timeout,
@@ -164,9 +168,18 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 				MinIdleConns:    config.Redis.MinimumIdleConnections,
 				ConnMaxIdleTime: 300,
 				TLSConfig:       tlsConfig,
-				KeyPrefix:       "authelia-session",
 			})
 		}
+
+		provider, err = NewRedisProvider(client, "authelia-session")
+	case config.Storage != nil:
+		if store == nil {
+			return "", nil, nil, fmt.Errorf("the storage session provider requires a storage provider")
+		}
+
+		name = "storage"
+		serializer = NewEncryptingSerializer(config.Secret)
+		provider = NewStorageProvider(*config.Storage, store)
 	default:
 		name = "memory"
 		provider, err = memory.New(memory.Config{})
@@ -25,7 +25,7 @@ func newTestSession() (*Session, error) {
 		},
 	}
 
-	provider := NewProvider(config, nil)
+	provider := NewProvider(config, nil, nil)
 
 	return provider.Get(testDomain)
 }
@@ -349,7 +349,7 @@ func TestStartupCheckShouldSucceedForMemoryBackend(t *testing.T) {
 		},
 	}
 
-	provider := NewProvider(config, nil)
+	provider := NewProvider(config, nil, nil)
 
 	assert.NoError(t, provider.StartupCheck())
 }
@@ -373,7 +373,7 @@ func TestStartupCheckShouldSucceedForMemoryBackendWithMultipleDomains(t *testing
 		},
 	}
 
-	provider := NewProvider(config, nil)
+	provider := NewProvider(config, nil, nil)
 
 	assert.NoError(t, provider.StartupCheck())
 	assert.Len(t, provider.sessions, 2)
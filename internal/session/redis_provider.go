@@ -0,0 +1,133 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fasthttp/session/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisProvider returns a new *RedisProvider which persists the session data in redis using the provided client and
+// key prefix. The client connection is checked before the provider is returned.
+func NewRedisProvider(client redis.UniversalClient, prefix string) (provider *RedisProvider, err error) {
+	if err = client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("redis connection error: %w", err)
+	}
+
+	return &RedisProvider{client: client, prefix: prefix}, nil
+}
+
+// RedisProvider is a session.Provider which persists the session data in redis using the same key layout as the
+// upstream redis session provider. Unlike the upstream provider it supports atomically updating the data of a session
+// which is required so the user session index is consistent when it's shared by multiple instances.
+type RedisProvider struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// Get returns the data of the given session id.
+func (p *RedisProvider) Get(id []byte) (data []byte, err error) {
+	if data, err = p.client.Get(context.Background(), p.key(id)).Bytes(); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save saves the session data and expiration for the given session id.
+func (p *RedisProvider) Save(id, data []byte, expiration time.Duration) (err error) {
+	return p.client.Set(context.Background(), p.key(id), data, expiration).Err()
+}
+
+// Regenerate updates the session id and expiration of the session with the given current session id.
+func (p *RedisProvider) Regenerate(id, newID []byte, expiration time.Duration) (err error) {
+	ctx := context.Background()
+	key, newKey := p.key(id), p.key(newID)
+
+	var exists int64
+
+	if exists, err = p.client.Exists(ctx, key).Result(); err != nil || exists == 0 {
+		return err
+	}
+
+	if err = p.client.Rename(ctx, key, newKey).Err(); err != nil {
+		return err
+	}
+
+	return p.client.Expire(ctx, newKey, expiration).Err()
+}
+
+// Update atomically replaces the data of the given session id with the result of the function. The function is called
+// again with the latest data if the session was concurrently modified.
+func (p *RedisProvider) Update(id []byte, expiration time.Duration, fn func(data []byte) (updated []byte, err error)) (err error) {
+	ctx := context.Background()
+	key := p.key(id)
+
+	txn := func(tx *redis.Tx) (err error) {
+		var data []byte
+
+		if data, err = tx.Get(ctx, key).Bytes(); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+
+		if data, err = fn(data); err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if len(data) == 0 {
+				return pipe.Del(ctx, key).Err()
+			}
+
+			return pipe.Set(ctx, key, data, expiration).Err()
+		})
+
+		return err
+	}
+
+	for range updateMaxAttempts {
+		if err = p.client.Watch(ctx, txn, key); !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("error updating the session data: the data was concurrently modified %d times", updateMaxAttempts)
+}
+
+// Destroy destroys the session with the given session id.
+func (p *RedisProvider) Destroy(id []byte) (err error) {
+	return p.client.Del(context.Background(), p.key(id)).Err()
+}
+
+// Count returns the total number of sessions.
+func (p *RedisProvider) Count() (count int) {
+	keys, err := p.client.Keys(context.Background(), p.key([]byte("*"))).Result()
+	if err != nil {
+		return 0
+	}
+
+	return len(keys)
+}
+
+// NeedGC indicates if the session library should perform the garbage collection which is never the case as redis
+// expires the keys itself.
+func (p *RedisProvider) NeedGC() bool {
+	return false
+}
+
+// GC is a no-op as redis expires the keys itself.
+func (p *RedisProvider) GC() error {
+	return nil
+}
+
+func (p *RedisProvider) key(id []byte) string {
+	return p.prefix + ":" + string(id)
+}
+
+var (
+	_ session.Provider = (*RedisProvider)(nil)
+	_ updateProvider   = (*RedisProvider)(nil)
+)
@@ -15,6 +15,7 @@ type Session struct {
 	Config schema.SessionCookie
 
 	sessionHolder *session.Session
+	index         *UserSessionIndex
 }
 
 // NewDefaultUserSession returns a new default UserSession for this session provider.
@@ -70,11 +71,48 @@ func (p *Session) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession)
 
 	store.Set(userSessionStorerKey, userSessionJSON)
 
+	// The store is reset when it's saved so the ID must be copied beforehand.
+	id := string(store.GetSessionID())
+
+	var record *UserSessionRecord
+
+	if p.index != nil && userSession.Username != "" {
+		record = &UserSessionRecord{
+			ID:           id,
+			CookieDomain: p.Config.Domain,
+			RemoteIP:     requestRemoteIP(ctx).String(),
+			UserAgent:    string(ctx.UserAgent()),
+			AMR:          userSession.AuthenticationMethodRefs.MarshalRFC8176(),
+		}
+
+		// The index is only updated when the session ID, user, or authentication level changes, or periodically to
+		// refresh the last activity, rather than every time the session is saved.
+		if indexed, ok := p.index.NeedsTouch(store.Get(userSessionIndexedKey), userSession.Username, *record); ok {
+			store.Set(userSessionIndexedKey, indexed)
+		} else {
+			record = nil
+		}
+	}
+
 	if err = p.sessionHolder.Save(ctx, store); err != nil {
 		return err
 	}
 
-	return nil
+	if record == nil {
+		return nil
+	}
+
+	return p.index.Touch(userSession.Username, *record)
+}
+
+// GetSessionID returns the session ID of the request, or an empty string if the request has no session cookie.
+func (p *Session) GetSessionID(ctx *fasthttp.RequestCtx) (id string) {
+	return string(ctx.Request.Header.Cookie(p.Config.Name))
+}
+
+// UserSessionIndex returns the per-user session index which is shared by all session cookie domains.
+func (p *Session) UserSessionIndex() *UserSessionIndex {
+	return p.index
 }
 
 // RegenerateSession regenerate a session ID.
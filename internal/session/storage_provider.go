@@ -0,0 +1,129 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fasthttp/session/v2"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// NewStorageProvider returns a new *StorageProvider which persists the session data using the provided
+// storage.SessionDataProvider.
+func NewStorageProvider(config schema.SessionStorage, provider storage.SessionDataProvider) *StorageProvider {
+	return &StorageProvider{
+		config:   config,
+		provider: provider,
+		clock:    clock.New(),
+	}
+}
+
+// StorageProvider is a session.Provider which persists the session data in the storage backend. The data is expected
+// to be encrypted by the serializer before it reaches this provider. Expired sessions are removed by the garbage
+// collector rather than by the session library so the NeedGC method always returns false.
+type StorageProvider struct {
+	config   schema.SessionStorage
+	provider storage.SessionDataProvider
+	clock    clock.Provider
+}
+
+// Get returns the data of the given session id.
+func (p *StorageProvider) Get(id []byte) (data []byte, err error) {
+	return p.provider.LoadSessionData(context.Background(), string(id))
+}
+
+// Save saves the session data and expiration for the given session id.
+func (p *StorageProvider) Save(id, data []byte, expiration time.Duration) (err error) {
+	return p.provider.SaveSessionData(context.Background(), string(id), data, p.expires(expiration))
+}
+
+// Regenerate updates the session id and expiration of the session with the given current session id.
+func (p *StorageProvider) Regenerate(id, newID []byte, expiration time.Duration) (err error) {
+	return p.provider.RegenerateSessionData(context.Background(), string(id), string(newID), p.expires(expiration))
+}
+
+// Update atomically replaces the data of the given session id with the result of the function. The function is called
+// again with the latest data if the session was concurrently modified.
+func (p *StorageProvider) Update(id []byte, expiration time.Duration, fn func(data []byte) (updated []byte, err error)) (err error) {
+	ctx := context.Background()
+
+	var (
+		data, updated []byte
+		swapped       bool
+	)
+
+	for range updateMaxAttempts {
+		if data, err = p.provider.LoadSessionData(ctx, string(id)); err != nil {
+			return err
+		}
+
+		if updated, err = fn(data); err != nil {
+			return err
+		}
+
+		if len(data) == 0 {
+			data = nil
+		}
+
+		if len(updated) == 0 {
+			updated = nil
+		}
+
+		if swapped, err = p.provider.CompareAndSwapSessionData(ctx, string(id), data, updated, p.expires(expiration)); err != nil || swapped {
+			return err
+		}
+	}
+
+	return fmt.Errorf("error updating the session data: the data was concurrently modified %d times", updateMaxAttempts)
+}
+
+// Destroy destroys the session with the given session id.
+func (p *StorageProvider) Destroy(id []byte) (err error) {
+	return p.provider.DeleteSessionData(context.Background(), string(id))
+}
+
+// Count returns the total number of sessions which have not expired.
+func (p *StorageProvider) Count() (count int) {
+	count, _ = p.provider.CountSessionData(context.Background())
+
+	return count
+}
+
+// NeedGC indicates if the session library should perform the garbage collection which is never the case as expired
+// sessions are removed by GarbageCollection.
+func (p *StorageProvider) NeedGC() bool {
+	return false
+}
+
+// GC is a no-op, see GarbageCollection.
+func (p *StorageProvider) GC() error {
+	return nil
+}
+
+// GarbageCollection removes the expired sessions from the storage backend.
+func (p *StorageProvider) GarbageCollection(ctx context.Context) (err error) {
+	return p.provider.DeleteExpiredSessionData(ctx, p.clock.Now())
+}
+
+// GarbageCollectionFrequency returns the configured garbage collection interval.
+func (p *StorageProvider) GarbageCollectionFrequency(_ context.Context) (frequency time.Duration) {
+	return p.config.GarbageCollectionInterval
+}
+
+func (p *StorageProvider) expires(expiration time.Duration) sql.NullTime {
+	if expiration <= 0 {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: p.clock.Now().Add(expiration), Valid: true}
+}
+
+var (
+	_ session.Provider = (*StorageProvider)(nil)
+	_ updateProvider   = (*StorageProvider)(nil)
+)
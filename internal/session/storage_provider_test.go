@@ -0,0 +1,240 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+type testSessionDataProvider struct {
+	data    map[string][]byte
+	expires map[string]sql.NullTime
+	now     time.Time
+}
+
+func newTestSessionDataProvider(now time.Time) *testSessionDataProvider {
+	return &testSessionDataProvider{data: map[string][]byte{}, expires: map[string]sql.NullTime{}, now: now}
+}
+
+func (p *testSessionDataProvider) expired(id string, now time.Time) bool {
+	return p.expires[id].Valid && !p.expires[id].Time.After(now)
+}
+
+func (p *testSessionDataProvider) LoadSessionData(_ context.Context, id string) (data []byte, err error) {
+	if p.expired(id, p.now) {
+		return nil, nil
+	}
+
+	return p.data[id], nil
+}
+
+func (p *testSessionDataProvider) SaveSessionData(_ context.Context, id string, data []byte, expires sql.NullTime) (err error) {
+	p.data[id], p.expires[id] = data, expires
+
+	return nil
+}
+
+func (p *testSessionDataProvider) CompareAndSwapSessionData(_ context.Context, id string, old, data []byte, expires sql.NullTime) (swapped bool, err error) {
+	current, _ := p.LoadSessionData(context.Background(), id)
+
+	if !bytes.Equal(current, old) {
+		return false, nil
+	}
+
+	if data == nil {
+		delete(p.data, id)
+		delete(p.expires, id)
+	} else {
+		p.data[id], p.expires[id] = data, expires
+	}
+
+	return true, nil
+}
+
+func (p *testSessionDataProvider) RegenerateSessionData(_ context.Context, id, newID string, expires sql.NullTime) (err error) {
+	if data, ok := p.data[id]; ok {
+		p.data[newID], p.expires[newID] = data, expires
+
+		delete(p.data, id)
+		delete(p.expires, id)
+	}
+
+	return nil
+}
+
+func (p *testSessionDataProvider) DeleteSessionData(_ context.Context, id string) (err error) {
+	delete(p.data, id)
+	delete(p.expires, id)
+
+	return nil
+}
+
+func (p *testSessionDataProvider) DeleteExpiredSessionData(_ context.Context, now time.Time) (err error) {
+	for id := range p.data {
+		if p.expired(id, now) {
+			delete(p.data, id)
+			delete(p.expires, id)
+		}
+	}
+
+	return nil
+}
+
+func (p *testSessionDataProvider) CountSessionData(_ context.Context) (count int, err error) {
+	for id := range p.data {
+		if !p.expired(id, p.now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func TestStorageProvider(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	store := newTestSessionDataProvider(now)
+
+	provider := NewStorageProvider(schema.SessionStorage{GarbageCollectionInterval: time.Minute}, store)
+	provider.clock = clock.NewFixed(now)
+
+	assert.False(t, provider.NeedGC())
+	assert.NoError(t, provider.GC())
+	assert.Equal(t, time.Minute, provider.GarbageCollectionFrequency(context.Background()))
+
+	require.NoError(t, provider.Save([]byte("one"), []byte("data"), time.Hour))
+	require.NoError(t, provider.Save([]byte("two"), []byte("data"), 0))
+
+	assert.Equal(t, sql.NullTime{Time: now.Add(time.Hour), Valid: true}, store.expires["one"])
+	assert.Equal(t, sql.NullTime{}, store.expires["two"])
+	assert.Equal(t, 2, provider.Count())
+
+	require.NoError(t, provider.Regenerate([]byte("one"), []byte("three"), time.Minute))
+
+	data, err := provider.Get([]byte("one"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = provider.Get([]byte("three"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	provider.clock = clock.NewFixed(now.Add(time.Hour))
+
+	require.NoError(t, provider.GarbageCollection(context.Background()))
+
+	assert.NotContains(t, store.data, "three")
+	assert.Contains(t, store.data, "two")
+
+	require.NoError(t, provider.Destroy([]byte("two")))
+
+	assert.Equal(t, 0, provider.Count())
+}
+
+func TestStorageProviderUpdate(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	store := newTestSessionDataProvider(now)
+
+	provider := NewStorageProvider(schema.SessionStorage{}, store)
+	provider.clock = clock.NewFixed(now)
+
+	require.NoError(t, provider.Update([]byte("one"), time.Hour, func(data []byte) ([]byte, error) {
+		assert.Nil(t, data)
+
+		return []byte("a"), nil
+	}))
+
+	assert.Equal(t, []byte("a"), store.data["one"])
+	assert.Equal(t, sql.NullTime{Time: now.Add(time.Hour), Valid: true}, store.expires["one"])
+
+	calls := 0
+
+	require.NoError(t, provider.Update([]byte("one"), time.Hour, func(data []byte) ([]byte, error) {
+		calls++
+
+		if calls == 1 {
+			store.data["one"] = []byte("b")
+		}
+
+		return append(data, 'c'), nil
+	}))
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []byte("bc"), store.data["one"])
+
+	require.NoError(t, provider.Update([]byte("one"), time.Hour, func(data []byte) ([]byte, error) {
+		return nil, nil
+	}))
+
+	assert.NotContains(t, store.data, "one")
+
+	assert.EqualError(t, provider.Update([]byte("one"), time.Hour, func(data []byte) ([]byte, error) {
+		store.data["one"] = append(data, 'd')
+
+		return []byte("e"), nil
+	}), "error updating the session data: the data was concurrently modified 10 times")
+
+	assert.EqualError(t, provider.Update([]byte("one"), time.Hour, func(data []byte) ([]byte, error) {
+		return nil, fmt.Errorf("bad data")
+	}), "bad data")
+}
+
+func TestShouldUseStorageSessionProvider(t *testing.T) {
+	config := schema.Session{
+		Secret:  "a_secret",
+		Storage: &schema.SessionStorage{GarbageCollectionInterval: time.Minute * 5},
+		Cookies: []schema.SessionCookie{
+			{
+				SessionCookieCommon: schema.SessionCookieCommon{
+					Name:       testName,
+					Expiration: testExpiration,
+				},
+				Domain: testDomain,
+			},
+		},
+	}
+
+	store := newTestSessionDataProvider(time.Now())
+
+	provider := NewProvider(config, nil, store)
+
+	require.NoError(t, provider.StartupCheck())
+	assert.Equal(t, "storage", provider.backendName)
+	assert.Equal(t, time.Minute*5, provider.GarbageCollectionFrequency(context.Background()))
+
+	domain, err := provider.Get(testDomain)
+	require.NoError(t, err)
+
+	ctx := &fasthttp.RequestCtx{}
+
+	userSession, err := domain.GetSession(ctx)
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+
+	require.NoError(t, domain.SaveSession(ctx, userSession))
+
+	raw, ok := store.data[domain.GetSessionID(ctx)]
+	require.True(t, ok)
+	assert.NotContains(t, string(raw), testUsername)
+
+	userSession, err = domain.GetSession(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testUsername, userSession.Username)
+
+	provider = NewProvider(config, nil, nil)
+
+	assert.EqualError(t, provider.StartupCheck(), "error initializing session backend: the storage session provider requires a storage provider")
+	assert.Equal(t, time.Duration(0), NewProvider(schema.Session{}, nil, nil).GarbageCollectionFrequency(context.Background()))
+}
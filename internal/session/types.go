@@ -1,11 +1,13 @@
 package session
 
 import (
+	"context"
 	"net"
 	"time"
 
 	"github.com/fasthttp/session/v2"
 	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 )
@@ -16,10 +18,26 @@ type ProviderConfig struct {
 	providerName string
 }
 
+// garbageCollector is implemented by session backends which require the expired sessions to be periodically removed.
+type garbageCollector interface {
+	GarbageCollection(ctx context.Context) (err error)
+	GarbageCollectionFrequency(ctx context.Context) (frequency time.Duration)
+}
+
+// updateProvider is implemented by session backends which can atomically update the data of a session. The function
+// receives the current data and returns the new data, and returning empty data removes the session. The function may be
+// called more than once if the data is concurrently modified.
+type updateProvider interface {
+	Update(id []byte, expiration time.Duration, fn func(data []byte) (updated []byte, err error)) (err error)
+}
+
 // UserSession is the structure representing the session of a user.
 type UserSession struct {
 	CookieDomain string
 
+	// Binding is the fingerprint of the client which established the session when session binding is configured.
+	Binding *Binding
+
 	Username    string
 	DisplayName string
 	// TODO(c.michaud): move groups out of the session.
@@ -34,6 +52,13 @@ type UserSession struct {
 
 	AuthenticationMethodRefs authorization.AuthenticationMethodsReferences
 
+	// TrustedDeviceID is the public ID of the device the first factor was completed from when the user has chosen to
+	// trust it, or nil if the device isn't trusted.
+	TrustedDeviceID *uuid.UUID
+
+	// TrustedDeviceRefreshTTL is the time after which the device is checked again to ensure it's still trusted.
+	TrustedDeviceRefreshTTL time.Time
+
 	// WebAuthn holds the session registration data for this session.
 	WebAuthn *WebAuthn
 	TOTP     *TOTP
@@ -42,6 +67,14 @@ type UserSession struct {
 	// while doing the query actually updating the password.
 	PasswordResetUsername *string
 
+	// PasswordChangeRequired is set to true when the user has passed the first factor but must change their password
+	// before the session is considered authenticated.
+	PasswordChangeRequired bool
+
+	// PasswordGraceLoginsRemaining is the number of grace logins the backend reported as remaining for the expired
+	// password of the user during the first factor, or nil if the backend did not report any.
+	PasswordGraceLoginsRemaining *int
+
 	RefreshTTL time.Time
 
 	Elevations Elevations
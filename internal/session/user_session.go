@@ -21,10 +21,15 @@ func (s *UserSession) IsAnonymous() bool {
 	return s.AuthenticationLevel(false) == authentication.NotAuthenticated
 }
 
+// IsTrustedDevice returns true if the first factor was completed from a device the user has chosen to trust.
+func (s *UserSession) IsTrustedDevice() bool {
+	return s.TrustedDeviceID != nil
+}
+
 // AuthenticationLevel returns the authentication.Level for this session.
 func (s *UserSession) AuthenticationLevel(passkey2FA bool) authentication.Level {
 	switch {
-	case s.Username == "":
+	case s.Username == "", s.PasswordChangeRequired:
 		return authentication.NotAuthenticated
 	case s.AuthenticationMethodRefs.FactorPossession() && s.AuthenticationMethodRefs.FactorKnowledge():
 		return authentication.TwoFactor
@@ -0,0 +1,363 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/session/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewUserSessionIndex returns a new *UserSessionIndex which stores the index of each user in the provided backend. The
+// serializer is used to encode the index, and if it's nil the index is encoded the same way as the session data of the
+// memory backend. The expiration is the longest lifetime of any session cookie.
+func NewUserSessionIndex(backend session.Provider, serializer Serializer, expiration time.Duration) *UserSessionIndex {
+	index := &UserSessionIndex{
+		backend:    backend,
+		encodeDict: session.MSGPEncode,
+		decodeDict: session.MSGPDecode,
+		expiration: expiration,
+		clock:      clock.New(),
+	}
+
+	if serializer != nil {
+		index.encodeDict, index.decodeDict = serializer.Encode, serializer.Decode
+	}
+
+	return index
+}
+
+// UserSessionIndex is a per-user index of the session IDs known to the session backend. The index of each user is kept
+// in the session backend itself so the same index is shared by every instance using the same redis or storage backend.
+type UserSessionIndex struct {
+	backend    session.Provider
+	encodeDict func(src session.Dict) (data []byte, err error)
+	decodeDict func(dst *session.Dict, src []byte) (err error)
+	expiration time.Duration
+	clock      clock.Provider
+
+	// mu serializes the updates of the index when the backend doesn't support atomic updates.
+	mu sync.Mutex
+}
+
+// UserSessionRecord is the metadata of an individual session in the UserSessionIndex.
+type UserSessionRecord struct {
+	ID           string    `json:"id"`
+	CookieDomain string    `json:"cookie_domain"`
+	RemoteIP     string    `json:"remote_ip"`
+	UserAgent    string    `json:"user_agent"`
+	Created      time.Time `json:"created"`
+	LastActivity time.Time `json:"last_activity"`
+	AMR          []string  `json:"amr"`
+}
+
+// PublicID returns the public identifier of the session.
+func (r UserSessionRecord) PublicID() string {
+	return NewUserSessionRecordPublicID(r.ID)
+}
+
+// NewUserSessionRecordPublicID returns an identifier for the session ID which can safely be shared with the user as
+// unlike the session ID it can't be used as the session cookie value.
+func NewUserSessionRecordPublicID(id string) string {
+	sum := sha256.Sum256([]byte(id))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// Touch adds the record to the index of the user or updates the existing record with the same ID while preserving the
+// time it was created. Records which have not been active for longer than the longest session lifetime are removed.
+func (i *UserSessionIndex) Touch(username string, record UserSessionRecord) (err error) {
+	now := i.clock.Now().UTC()
+
+	record.Created, record.LastActivity = now, now
+
+	return i.update(username, func(records []UserSessionRecord) (updated []UserSessionRecord, err error) {
+		updated = []UserSessionRecord{record}
+
+		for _, r := range records {
+			switch {
+			case r.ID == record.ID:
+				updated[0].Created = r.Created
+			case i.expiration > 0 && now.Sub(r.LastActivity) > i.expiration:
+				continue
+			default:
+				updated = append(updated, r)
+			}
+		}
+
+		return updated, nil
+	})
+}
+
+// NeedsTouch returns true if the record must be touched given the marker value stored in the session when the record
+// was last touched, along with the new marker value which should be stored in the session. The record must be touched
+// when the session ID, user, or authentication methods differ from the marker or when the marker is older than the
+// touch interval.
+func (i *UserSessionIndex) NeedsTouch(value any, username string, record UserSessionRecord) (marker []byte, touch bool) {
+	now := i.clock.Now()
+
+	current := strings.Join([]string{record.ID, username, strings.Join(record.AMR, ",")}, ";")
+
+	marker = []byte(strconv.FormatInt(now.Unix(), 10) + ";" + current)
+
+	raw, ok := value.([]byte)
+	if !ok {
+		return marker, true
+	}
+
+	timestamp, previous, found := strings.Cut(string(raw), ";")
+	if !found || previous != current {
+		return marker, true
+	}
+
+	interval := userSessionIndexTouchInterval
+
+	if i.expiration > 0 {
+		interval = min(interval, i.expiration/2)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(unix, 0)) >= interval {
+		return marker, true
+	}
+
+	return nil, false
+}
+
+// List returns the records of the user ordered by the most recent activity. Records of sessions which no longer exist
+// in the session backend, for example because they expired or the user logged out, are removed from the index.
+func (i *UserSessionIndex) List(username string) (records []UserSessionRecord, err error) {
+	if records, err = i.load(username); err != nil {
+		return nil, err
+	}
+
+	var (
+		data    []byte
+		exists  []UserSessionRecord
+		missing = map[string]bool{}
+	)
+
+	for _, record := range records {
+		if data, err = i.backend.Get([]byte(record.ID)); err != nil {
+			return nil, fmt.Errorf("error checking the session '%s' of user '%s': %w", record.PublicID(), username, err)
+		}
+
+		if len(data) == 0 {
+			missing[record.ID] = true
+
+			continue
+		}
+
+		exists = append(exists, record)
+	}
+
+	if len(missing) != 0 {
+		if err = i.update(username, func(records []UserSessionRecord) (updated []UserSessionRecord, err error) {
+			for _, record := range records {
+				if !missing[record.ID] {
+					updated = append(updated, record)
+				}
+			}
+
+			return updated, nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(exists, func(a, b int) bool {
+		return exists[a].LastActivity.After(exists[b].LastActivity)
+	})
+
+	return exists, nil
+}
+
+// Revoke destroys every session of the user for which the filter returns true and removes them from the index. The
+// revoked records are returned.
+func (i *UserSessionIndex) Revoke(username string, filter func(record UserSessionRecord) bool) (revoked []UserSessionRecord, err error) {
+	err = i.update(username, func(records []UserSessionRecord) (remaining []UserSessionRecord, err error) {
+		// The index may be updated more than once if it's concurrently modified so the result of any previous
+		// attempt must be discarded. Destroying a session is idempotent.
+		revoked, remaining = nil, make([]UserSessionRecord, 0, len(records))
+
+		for _, record := range records {
+			if !filter(record) {
+				remaining = append(remaining, record)
+
+				continue
+			}
+
+			if err = i.backend.Destroy([]byte(record.ID)); err != nil {
+				return nil, fmt.Errorf("error destroying the session '%s' of user '%s': %w", record.PublicID(), username, err)
+			}
+
+			revoked = append(revoked, record)
+		}
+
+		return remaining, nil
+	})
+
+	return revoked, err
+}
+
+// update applies the function to the records of the user and saves the result. The update is atomic when the session
+// backend supports it which is required when the backend is shared by multiple instances. Otherwise the update is only
+// atomic within this instance which is sufficient for the memory backend as it can't be shared.
+func (i *UserSessionIndex) update(username string, fn func(records []UserSessionRecord) (updated []UserSessionRecord, err error)) (err error) {
+	if backend, ok := i.backend.(updateProvider); ok {
+		if err = backend.Update(userSessionIndexID(username), i.expiration, func(data []byte) (updated []byte, err error) {
+			var records []UserSessionRecord
+
+			if records, err = i.decode(username, data); err != nil {
+				return nil, err
+			}
+
+			if records, err = fn(records); err != nil {
+				return nil, err
+			}
+
+			return i.encode(username, records)
+		}); err != nil {
+			return fmt.Errorf("error updating the session index of user '%s': %w", username, err)
+		}
+
+		return nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var records []UserSessionRecord
+
+	if records, err = i.load(username); err != nil {
+		return err
+	}
+
+	if records, err = fn(records); err != nil {
+		return err
+	}
+
+	return i.save(username, records)
+}
+
+func (i *UserSessionIndex) load(username string) (records []UserSessionRecord, err error) {
+	var data []byte
+
+	if data, err = i.backend.Get(userSessionIndexID(username)); err != nil {
+		return nil, fmt.Errorf("error loading the session index of user '%s': %w", username, err)
+	}
+
+	return i.decode(username, data)
+}
+
+func (i *UserSessionIndex) save(username string, records []UserSessionRecord) (err error) {
+	id := userSessionIndexID(username)
+
+	var data []byte
+
+	if data, err = i.encode(username, records); err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err = i.backend.Destroy(id); err != nil {
+			return fmt.Errorf("error removing the session index of user '%s': %w", username, err)
+		}
+
+		return nil
+	}
+
+	if err = i.backend.Save(id, data, i.expiration); err != nil {
+		return fmt.Errorf("error saving the session index of user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+func (i *UserSessionIndex) decode(username string, data []byte) (records []UserSessionRecord, err error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	dict := session.Dict{KV: map[string]any{}}
+
+	if err = i.decodeDict(&dict, data); err != nil {
+		return nil, fmt.Errorf("error decoding the session index of user '%s': %w", username, err)
+	}
+
+	raw, ok := dict.KV[userSessionIndexStorerKey].([]byte)
+	if !ok {
+		return nil, nil
+	}
+
+	if err = json.Unmarshal(raw, &records); err != nil {
+		return nil, fmt.Errorf("error decoding the session index of user '%s': %w", username, err)
+	}
+
+	return records, nil
+}
+
+// encode returns the encoded records of the user, or nil if there are no records.
+func (i *UserSessionIndex) encode(username string, records []UserSessionRecord) (data []byte, err error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var raw []byte
+
+	if raw, err = json.Marshal(records); err != nil {
+		return nil, fmt.Errorf("error encoding the session index of user '%s': %w", username, err)
+	}
+
+	if data, err = i.encodeDict(session.Dict{KV: map[string]any{userSessionIndexStorerKey: raw}}); err != nil {
+		return nil, fmt.Errorf("error encoding the session index of user '%s': %w", username, err)
+	}
+
+	return data, nil
+}
+
+// userSessionIndexID returns the backend ID of the index for the given username. The prefix contains a semicolon which
+// is not permitted in a cookie value, so a crafted session cookie can never be used to read or overwrite an index.
+func userSessionIndexID(username string) []byte {
+	return []byte(userSessionIndexIDPrefix + username)
+}
+
+// getUserSessionIndexExpiration returns the longest lifetime of any of the configured session cookies.
+func getUserSessionIndexExpiration(config schema.Session) (expiration time.Duration) {
+	for _, cookie := range config.Cookies {
+		if cookie.Expiration > expiration {
+			expiration = cookie.Expiration
+		}
+
+		if !cookie.DisableRememberMe && cookie.RememberMe > expiration {
+			expiration = cookie.RememberMe
+		}
+	}
+
+	return expiration
+}
+
+// requestRemoteIP returns the first X-Forwarded-For value of the request, or the remote address of the connection if
+// the header is absent or invalid.
+func requestRemoteIP(ctx *fasthttp.RequestCtx) net.IP {
+	if header := ctx.Request.Header.Peek(fasthttp.HeaderXForwardedFor); len(header) != 0 {
+		ips := strings.SplitN(string(header), ",", 2)
+
+		if ip := net.ParseIP(strings.TrimSpace(ips[0])); ip != nil {
+			return ip
+		}
+	}
+
+	return ctx.RemoteIP()
+}
@@ -0,0 +1,210 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fasthttp/session/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/session/memory"
+)
+
+func TestUserSessionIndex(t *testing.T) {
+	testCases := []struct {
+		name       string
+		serializer Serializer
+		backend    func(t *testing.T) session.Provider
+	}{
+		{"ShouldHandleUnencryptedIndex", nil, newTestUserSessionIndexMemoryBackend},
+		{"ShouldHandleEncryptedIndex", NewEncryptingSerializer("a_secret"), newTestUserSessionIndexMemoryBackend},
+		{"ShouldHandleAtomicBackend", NewEncryptingSerializer("a_secret"), func(t *testing.T) session.Provider {
+			return NewStorageProvider(schema.SessionStorage{}, newTestSessionDataProvider(time.Unix(1000, 0)))
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := tc.backend(t)
+
+			index := NewUserSessionIndex(backend, tc.serializer, time.Hour)
+
+			fixed := clock.NewFixed(time.Unix(1000, 0))
+			index.clock = fixed
+
+			require.NoError(t, backend.Save([]byte("one"), []byte("data"), time.Hour))
+			require.NoError(t, backend.Save([]byte("two"), []byte("data"), time.Hour))
+
+			require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "one", RemoteIP: "192.168.0.1"}))
+
+			fixed.Set(time.Unix(2000, 0))
+
+			require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "two", RemoteIP: "192.168.0.2"}))
+			require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "expired"}))
+
+			fixed.Set(time.Unix(3000, 0))
+
+			require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "one", RemoteIP: "192.168.0.3"}))
+
+			records, err := index.List(testUsername)
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+
+			assert.Equal(t, "one", records[0].ID)
+			assert.Equal(t, "192.168.0.3", records[0].RemoteIP)
+			assert.Equal(t, time.Unix(1000, 0).UTC(), records[0].Created)
+			assert.Equal(t, time.Unix(3000, 0).UTC(), records[0].LastActivity)
+			assert.Equal(t, "two", records[1].ID)
+
+			records, err = index.List("jane")
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+
+			revoked, err := index.Revoke(testUsername, func(record UserSessionRecord) bool {
+				return record.ID != "one"
+			})
+			require.NoError(t, err)
+			require.Len(t, revoked, 1)
+			assert.Equal(t, "two", revoked[0].ID)
+
+			data, err := backend.Get([]byte("two"))
+			assert.NoError(t, err)
+			assert.Nil(t, data)
+
+			records, err = index.List(testUsername)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			assert.Equal(t, "one", records[0].ID)
+		})
+	}
+}
+
+func TestUserSessionIndexNeedsTouch(t *testing.T) {
+	index := NewUserSessionIndex(nil, nil, time.Hour)
+	index.clock = clock.NewFixed(time.Unix(1000, 0))
+
+	record := UserSessionRecord{ID: "one", AMR: []string{"pwd", "otp"}}
+
+	testCases := []struct {
+		name     string
+		value    any
+		username string
+		record   UserSessionRecord
+		expected bool
+	}{
+		{"ShouldTouchWithoutMarker", nil, testUsername, record, true},
+		{"ShouldTouchWithInvalidMarker", []byte("abc"), testUsername, record, true},
+		{"ShouldTouchWithInvalidTimestamp", []byte("abc;one;john;pwd,otp"), testUsername, record, true},
+		{"ShouldNotTouchUnchanged", []byte("900;one;john;pwd,otp"), testUsername, record, false},
+		{"ShouldTouchAfterInterval", []byte("700;one;john;pwd,otp"), testUsername, record, true},
+		{"ShouldTouchWhenIDChanged", []byte("900;two;john;pwd,otp"), testUsername, record, true},
+		{"ShouldTouchWhenUserChanged", []byte("900;one;jane;pwd,otp"), testUsername, record, true},
+		{"ShouldTouchWhenMethodsChanged", []byte("900;one;john;pwd"), testUsername, record, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			marker, touch := index.NeedsTouch(tc.value, tc.username, tc.record)
+
+			assert.Equal(t, tc.expected, touch)
+
+			if tc.expected {
+				assert.Equal(t, []byte("1000;one;john;pwd,otp"), marker)
+			} else {
+				assert.Nil(t, marker)
+			}
+		})
+	}
+}
+
+func TestUserSessionRecordPublicID(t *testing.T) {
+	record := UserSessionRecord{ID: "abc"}
+
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223", record.PublicID())
+	assert.Equal(t, record.PublicID(), NewUserSessionRecordPublicID("abc"))
+}
+
+func TestShouldIndexAuthenticatedSessions(t *testing.T) {
+	provider, err := newTestSession()
+	require.NoError(t, err)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetUserAgent("Test/1.0")
+	ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "10.0.0.1, 10.0.0.2")
+
+	userSession, err := provider.GetSession(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	records, err := provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	records, err = provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, provider.GetSessionID(ctx), records[0].ID)
+	assert.Equal(t, testDomain, records[0].CookieDomain)
+	assert.Equal(t, "10.0.0.1", records[0].RemoteIP)
+	assert.Equal(t, "Test/1.0", records[0].UserAgent)
+	assert.Equal(t, []string{"pwd"}, records[0].AMR)
+
+	ctx.Request.Header.SetUserAgent("Test/2.0")
+
+	userSession, err = provider.GetSession(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	records, err = provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, "Test/1.0", records[0].UserAgent)
+
+	userSession.AuthenticationMethodRefs.TOTP = true
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	records, err = provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, "Test/2.0", records[0].UserAgent)
+	assert.Equal(t, []string{"pwd", "otp", "mfa"}, records[0].AMR)
+
+	require.NoError(t, provider.DestroySession(ctx))
+
+	records, err = provider.UserSessionIndex().List(testUsername)
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+}
+
+func TestGetUserSessionIndexExpiration(t *testing.T) {
+	config := schema.Session{
+		Cookies: []schema.SessionCookie{
+			{SessionCookieCommon: schema.SessionCookieCommon{Expiration: time.Hour, RememberMe: time.Hour * 24, DisableRememberMe: true}},
+			{SessionCookieCommon: schema.SessionCookieCommon{Expiration: time.Hour * 2, RememberMe: time.Hour * 12}},
+		},
+	}
+
+	assert.Equal(t, time.Hour*12, getUserSessionIndexExpiration(config))
+}
+
+func newTestUserSessionIndexMemoryBackend(t *testing.T) session.Provider {
+	backend, err := memory.New(memory.Config{})
+	require.NoError(t, err)
+
+	return backend
+}
@@ -205,6 +205,19 @@ func TestUserSession_AuthenticationLevel(t *testing.T) {
 			true,
 			authentication.NotAuthenticated,
 		},
+		{
+			"ShouldHandlePasswordChangeRequired",
+			&UserSession{
+				Username:               "john",
+				PasswordChangeRequired: true,
+				AuthenticationMethodRefs: authorization.AuthenticationMethodsReferences{
+					KnowledgeBasedAuthentication: true,
+					UsernameAndPassword:          true,
+				},
+			},
+			false,
+			authentication.NotAuthenticated,
+		},
 	}
 
 	for _, tc := range testCases {
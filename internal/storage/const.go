@@ -2,22 +2,28 @@ package storage
 
 import (
 	"regexp"
+	"time"
 )
 
 const (
-	tableAuthenticationLogs   = "authentication_logs"
-	tableBannedUser           = "banned_user"
-	tableBannedIP             = "banned_ip"
-	tableCachedData           = "cached_data"
-	tableDuoDevices           = "duo_devices"
-	tableIdentityVerification = "identity_verification"
-	tableOneTimeCode          = "one_time_code"
-	tableTOTPConfigurations   = "totp_configurations"
-	tableTOTPHistory          = "totp_history"
-	tableUserOpaqueIdentifier = "user_opaque_identifier"
-	tableUserPreferences      = "user_preferences"
-	tableWebAuthnCredentials  = "webauthn_credentials" //nolint:gosec // This is a table name, not a credential.
-	tableWebAuthnUsers        = "webauthn_users"
+	tableAuthenticationLogs       = "authentication_logs"
+	tableAuthenticationUsers      = "authentication_users"
+	tableAuthenticationUserGroups = "authentication_user_groups"
+	tableBannedUser               = "banned_user"
+	tableBannedIP                 = "banned_ip"
+	tableCachedData               = "cached_data"
+	tableDuoDevices               = "duo_devices"
+	tableIdentityVerification     = "identity_verification"
+	tableOneTimeCode              = "one_time_code"
+	tablePasswordHistory          = "password_history"
+	tableSessionData              = "session_data"
+	tableTOTPConfigurations       = "totp_configurations"
+	tableTOTPHistory              = "totp_history"
+	tableTrustedDevices           = "trusted_devices"
+	tableUserOpaqueIdentifier     = "user_opaque_identifier"
+	tableUserPreferences          = "user_preferences"
+	tableWebAuthnCredentials      = "webauthn_credentials" //nolint:gosec // This is a table name, not a credential.
+	tableWebAuthnUsers            = "webauthn_users"
 
 	tableOAuth2BlacklistedJTI          = "oauth2_blacklisted_jti"
 	tableOAuth2ConsentSession          = "oauth2_consent_session"
@@ -122,3 +128,10 @@ var (
 const (
 	na = "N/A"
 )
+
+const (
+	// trustedDevicesRetention is the period expired or revoked trusted devices are kept before they're deleted.
+	trustedDevicesRetention = time.Hour * 24 * 7
+
+	trustedDevicesGarbageCollectionFrequency = time.Hour
+)
@@ -8,6 +8,9 @@ var (
 	// ErrNoAuthenticationLogs error thrown when no matching authentication logs have been found in DB.
 	ErrNoAuthenticationLogs = errors.New("no matching authentication logs found")
 
+	// ErrNoAuthenticationUser error thrown when no matching authentication user has been found in DB.
+	ErrNoAuthenticationUser = errors.New("no matching authentication user found")
+
 	// ErrNoCurrentBans is an error which indicates no bans were found.
 	ErrNoCurrentBans = errors.New("no current bans found")
 
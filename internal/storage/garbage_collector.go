@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+)
+
+// NewTrustedDevicesGarbageCollector returns a new *TrustedDevicesGarbageCollector.
+func NewTrustedDevicesGarbageCollector(provider TrustedDeviceProvider, clock clock.Provider) *TrustedDevicesGarbageCollector {
+	return &TrustedDevicesGarbageCollector{provider: provider, clock: clock}
+}
+
+// TrustedDevicesGarbageCollector periodically deletes the trusted devices which expired or were revoked more than
+// the retention period ago. The retention period allows recently revoked or expired devices to still be audited.
+type TrustedDevicesGarbageCollector struct {
+	provider TrustedDeviceProvider
+	clock    clock.Provider
+}
+
+// GarbageCollection deletes the trusted devices which expired or were revoked before the retention period.
+func (gc *TrustedDevicesGarbageCollector) GarbageCollection(ctx context.Context) (err error) {
+	return gc.provider.DeleteExpiredTrustedDevices(ctx, gc.clock.Now().Add(-trustedDevicesRetention))
+}
+
+// GarbageCollectionFrequency returns the frequency the garbage collection should be performed.
+func (gc *TrustedDevicesGarbageCollector) GarbageCollectionFrequency(_ context.Context) (frequency time.Duration) {
+	return trustedDevicesGarbageCollectionFrequency
+}
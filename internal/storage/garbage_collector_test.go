@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestTrustedDevicesGarbageCollector(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	gc := NewTrustedDevicesGarbageCollector(provider, clock.NewFixed(now))
+
+	assert.Equal(t, time.Hour, gc.GarbageCollectionFrequency(ctx))
+
+	testCases := []struct {
+		name     string
+		created  time.Time
+		lifespan time.Duration
+		revoked  time.Time
+		expected bool
+	}{
+		{"ShouldKeepTrustedDevice", now.Add(-time.Hour), time.Hour * 24, time.Time{}, true},
+		{"ShouldKeepRecentlyExpiredDevice", now.Add(-time.Hour * 48), time.Hour * 24, time.Time{}, true},
+		{"ShouldKeepRecentlyRevokedDevice", now.Add(-time.Hour), time.Hour * 24, now, true},
+		{"ShouldDeleteExpiredDevice", now.Add(-trustedDevicesRetention - time.Hour*48), time.Hour * 24, time.Time{}, false},
+		{"ShouldDeleteRevokedDevice", now.Add(-trustedDevicesRetention - time.Hour), time.Hour * 24 * 30, now.Add(-trustedDevicesRetention - time.Minute), false},
+	}
+
+	ids := make([]uuid.UUID, len(testCases))
+
+	for i, tc := range testCases {
+		ids[i] = uuid.Must(uuid.NewRandom())
+
+		require.NoError(t, provider.SaveTrustedDevice(ctx, model.TrustedDevice{
+			PublicID:  ids[i],
+			CreatedAt: tc.created,
+			ExpiresAt: tc.created.Add(tc.lifespan),
+			Username:  "john",
+			IP:        model.NewIP(net.ParseIP("127.0.0.1")),
+			UserAgent: "Test/1.0",
+		}))
+
+		if !tc.revoked.IsZero() {
+			require.NoError(t, provider.RevokeTrustedDevice(ctx, "john", ids[i], tc.revoked))
+		}
+	}
+
+	require.NoError(t, gc.GarbageCollection(ctx))
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.LoadTrustedDevice(ctx, ids[i])
+
+			if tc.expected {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, sql.ErrNoRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS authentication_user_groups;
+DROP TABLE IF EXISTS authentication_users;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS session_data (
+    id SERIAL CONSTRAINT session_data_pkey PRIMARY KEY,
+    session_id VARCHAR(150) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    data BYTEA NOT NULL
+);
+
+CREATE UNIQUE INDEX session_data_session_id_key ON session_data (session_id);
+CREATE INDEX session_data_expires_at_idx ON session_data (expires_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS trusted_devices (
+    id SERIAL CONSTRAINT trusted_devices_pkey PRIMARY KEY,
+    public_id CHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    ip VARCHAR(39) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL
+);
+
+CREATE UNIQUE INDEX trusted_devices_public_id_key ON trusted_devices (public_id);
+CREATE INDEX trusted_devices_username_idx ON trusted_devices (username);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS authentication_users (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    email VARCHAR(255) NULL DEFAULT NULL,
+    password VARCHAR(512) NOT NULL,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX authentication_users_username_key ON authentication_users (username);
+CREATE INDEX authentication_users_email_idx ON authentication_users (email);
+
+CREATE TABLE IF NOT EXISTS authentication_user_groups (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(100) NOT NULL,
+    group_name VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX authentication_user_groups_lookup_key ON authentication_user_groups (username, group_name);
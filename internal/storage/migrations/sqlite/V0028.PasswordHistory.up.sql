@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS password_history (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(512) NOT NULL
+);
+
+CREATE INDEX password_history_lookup_idx ON password_history (username, created_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS session_data (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    session_id VARCHAR(150) NOT NULL,
+    expires_at DATETIME NULL DEFAULT NULL,
+    data BLOB NOT NULL
+);
+
+CREATE UNIQUE INDEX session_data_session_id_key ON session_data (session_id);
+CREATE INDEX session_data_expires_at_idx ON session_data (expires_at);
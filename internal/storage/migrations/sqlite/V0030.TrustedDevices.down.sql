@@ -0,0 +1 @@
+DROP TABLE IF EXISTS trusted_devices;
@@ -240,6 +240,10 @@ type Provider interface {
 	// LoadOAuth2Session loads an OAuth2.0 session from the storage provider.
 	LoadOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (session *model.OAuth2Session, err error)
 
+	// LoadOAuth2SessionSignaturesByUsername loads the signatures of all OAuth2.0 sessions which have not been revoked
+	// from the storage provider given the username the subject of the session belongs to.
+	LoadOAuth2SessionSignaturesByUsername(ctx context.Context, sessionType OAuth2SessionType, username string) (signatures []string, err error)
+
 	/*
 		Implementation for OAuth2.0 Device Code Sessions.
 	*/
@@ -331,6 +335,10 @@ type Provider interface {
 
 	RegulatorProvider
 	CachedDataProvider
+	AuthenticationUserProvider
+	PasswordHistoryProvider
+	SessionDataProvider
+	TrustedDeviceProvider
 }
 
 // CachedDataProvider is the storage provider interface for cached data.
@@ -388,3 +396,99 @@ type RegulatorProvider interface {
 	// RevokeBannedIP revokes an ip ban in the database.
 	RevokeBannedIP(ctx context.Context, id int, expired time.Time) (err error)
 }
+
+// AuthenticationUserProvider is the storage provider interface for the users of the SQL authentication backend.
+type AuthenticationUserProvider interface {
+	// LoadAuthenticationUser loads an authentication backend user from the storage provider given a username.
+	LoadAuthenticationUser(ctx context.Context, username string) (user *model.AuthenticationUser, err error)
+
+	// LoadAuthenticationUserByEmail loads an authentication backend user from the storage provider given an email.
+	LoadAuthenticationUserByEmail(ctx context.Context, email string) (user *model.AuthenticationUser, err error)
+
+	// LoadAuthenticationUsers loads a page of authentication backend users from the storage provider.
+	LoadAuthenticationUsers(ctx context.Context, limit, page int) (users []model.AuthenticationUser, err error)
+
+	// SaveAuthenticationUser saves a new authentication backend user to the storage provider.
+	SaveAuthenticationUser(ctx context.Context, user model.AuthenticationUser) (err error)
+
+	// UpdateAuthenticationUser updates an existing authentication backend user in the storage provider.
+	UpdateAuthenticationUser(ctx context.Context, user model.AuthenticationUser) (err error)
+
+	// UpdateAuthenticationUserPassword updates the password digest of an authentication backend user in the storage
+	// provider.
+	UpdateAuthenticationUserPassword(ctx context.Context, username, password string) (err error)
+
+	// DeleteAuthenticationUser deletes an authentication backend user from the storage provider.
+	DeleteAuthenticationUser(ctx context.Context, username string) (err error)
+
+	// LoadAuthenticationUserGroups loads the groups of an authentication backend user from the storage provider.
+	LoadAuthenticationUserGroups(ctx context.Context, username string) (groups []string, err error)
+
+	// LoadAuthenticationGroups loads every distinct group assigned to an authentication backend user from the storage
+	// provider.
+	LoadAuthenticationGroups(ctx context.Context) (groups []string, err error)
+
+	// SaveAuthenticationUserGroups replaces the groups of an authentication backend user in the storage provider.
+	SaveAuthenticationUserGroups(ctx context.Context, username string, groups []string) (err error)
+}
+
+// PasswordHistoryProvider is the storage provider interface for the password history of users.
+type PasswordHistoryProvider interface {
+	// LoadPasswordHistory loads the most recent password history entries of a user from the storage provider.
+	LoadPasswordHistory(ctx context.Context, username string, limit int) (history []model.PasswordHistory, err error)
+
+	// SavePasswordHistory saves a password history entry to the storage provider, retaining only the most recent
+	// entries for the user up to the value of keep.
+	SavePasswordHistory(ctx context.Context, history model.PasswordHistory, keep int) (err error)
+}
+
+// SessionDataProvider is the storage provider interface for the data of the storage session provider.
+type SessionDataProvider interface {
+	// LoadSessionData loads the data of a session from the storage provider given the session id. If the session does
+	// not exist or has expired the data is nil.
+	LoadSessionData(ctx context.Context, id string) (data []byte, err error)
+
+	// SaveSessionData saves the data of a session to the storage provider replacing any existing data for the session.
+	// If the expires value is not valid the session does not expire.
+	SaveSessionData(ctx context.Context, id string, data []byte, expires sql.NullTime) (err error)
+
+	// CompareAndSwapSessionData replaces the data of a session with the new data only if the current data is equal to
+	// the old data. A nil old value means the session must not exist, and a nil new value deletes the session.
+	CompareAndSwapSessionData(ctx context.Context, id string, old, data []byte, expires sql.NullTime) (swapped bool, err error)
+
+	// RegenerateSessionData changes the id of an existing session in the storage provider.
+	RegenerateSessionData(ctx context.Context, id, newID string, expires sql.NullTime) (err error)
+
+	// DeleteSessionData deletes the data of a session from the storage provider given the session id.
+	DeleteSessionData(ctx context.Context, id string) (err error)
+
+	// DeleteExpiredSessionData deletes the data of every session in the storage provider which expired before the
+	// provided time.
+	DeleteExpiredSessionData(ctx context.Context, now time.Time) (err error)
+
+	// CountSessionData returns the number of sessions in the storage provider which have not expired.
+	CountSessionData(ctx context.Context) (count int, err error)
+}
+
+// TrustedDeviceProvider is the storage provider interface for the devices users have chosen to trust.
+type TrustedDeviceProvider interface {
+	// SaveTrustedDevice saves a new trusted device to the storage provider.
+	SaveTrustedDevice(ctx context.Context, device model.TrustedDevice) (err error)
+
+	// LoadTrustedDevice loads a trusted device from the storage provider given the public id.
+	LoadTrustedDevice(ctx context.Context, id uuid.UUID) (device *model.TrustedDevice, err error)
+
+	// LoadTrustedDevices loads the trusted devices of a user from the storage provider which have neither been revoked
+	// nor expired at the given time.
+	LoadTrustedDevices(ctx context.Context, username string, now time.Time) (devices []model.TrustedDevice, err error)
+
+	// UpdateTrustedDeviceLastUsed updates the time a trusted device was last used to skip the second factor.
+	UpdateTrustedDeviceLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) (err error)
+
+	// RevokeTrustedDevice revokes a trusted device of a user in the storage provider given the public id.
+	RevokeTrustedDevice(ctx context.Context, username string, id uuid.UUID, revokedAt time.Time) (err error)
+
+	// DeleteExpiredTrustedDevices deletes every trusted device in the storage provider which expired or was revoked
+	// before the given time.
+	DeleteExpiredTrustedDevices(ctx context.Context, before time.Time) (err error)
+}
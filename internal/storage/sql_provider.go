@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/x509"
@@ -66,6 +67,42 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectAuthenticationLogsRegulationRecordsByUsername: fmt.Sprintf(queryFmtSelectAuthenticationLogsRegulationRecordsByUsername, tableAuthenticationLogs),
 		sqlSelectAuthenticationLogsRegulationRecordsByRemoteIP: fmt.Sprintf(queryFmtSelectAuthenticationLogsRegulationRecordsByRemoteIP, tableAuthenticationLogs),
 
+		sqlSelectAuthenticationUser:         fmt.Sprintf(queryFmtSelectAuthenticationUser, tableAuthenticationUsers),
+		sqlSelectAuthenticationUserByEmail:  fmt.Sprintf(queryFmtSelectAuthenticationUserByEmail, tableAuthenticationUsers),
+		sqlSelectAuthenticationUsers:        fmt.Sprintf(queryFmtSelectAuthenticationUsers, tableAuthenticationUsers),
+		sqlInsertAuthenticationUser:         fmt.Sprintf(queryFmtInsertAuthenticationUser, tableAuthenticationUsers),
+		sqlUpdateAuthenticationUser:         fmt.Sprintf(queryFmtUpdateAuthenticationUser, tableAuthenticationUsers),
+		sqlUpdateAuthenticationUserPassword: fmt.Sprintf(queryFmtUpdateAuthenticationUserPassword, tableAuthenticationUsers),
+		sqlDeleteAuthenticationUser:         fmt.Sprintf(queryFmtDeleteAuthenticationUser, tableAuthenticationUsers),
+
+		sqlSelectAuthenticationUserGroups: fmt.Sprintf(queryFmtSelectAuthenticationUserGroups, tableAuthenticationUserGroups),
+		sqlSelectAuthenticationGroups:     fmt.Sprintf(queryFmtSelectAuthenticationGroups, tableAuthenticationUserGroups),
+		sqlInsertAuthenticationUserGroup:  fmt.Sprintf(queryFmtInsertAuthenticationUserGroup, tableAuthenticationUserGroups),
+		sqlDeleteAuthenticationUserGroups: fmt.Sprintf(queryFmtDeleteAuthenticationUserGroups, tableAuthenticationUserGroups),
+
+		sqlSelectPasswordHistory:       fmt.Sprintf(queryFmtSelectPasswordHistory, tablePasswordHistory),
+		sqlInsertPasswordHistory:       fmt.Sprintf(queryFmtInsertPasswordHistory, tablePasswordHistory),
+		sqlDeletePasswordHistoryExcess: fmt.Sprintf(queryFmtDeletePasswordHistoryExcess, tablePasswordHistory, tablePasswordHistory),
+
+		sqlSelectSessionData:        fmt.Sprintf(queryFmtSelectSessionData, tableSessionData),
+		sqlUpsertSessionData:        fmt.Sprintf(queryFmtUpsertSessionData, tableSessionData),
+		sqlUpdateSessionDataID:      fmt.Sprintf(queryFmtUpdateSessionDataID, tableSessionData),
+		sqlDeleteSessionData:        fmt.Sprintf(queryFmtDeleteSessionData, tableSessionData),
+		sqlDeleteExpiredSessionData: fmt.Sprintf(queryFmtDeleteExpiredSessionData, tableSessionData),
+		sqlCountSessionData:         fmt.Sprintf(queryFmtCountSessionData, tableSessionData),
+
+		sqlInsertSessionDataIfAbsent:    fmt.Sprintf(queryFmtInsertSessionDataIfAbsent, tableSessionData),
+		sqlUpdateSessionDataIfUnchanged: fmt.Sprintf(queryFmtUpdateSessionDataIfUnchanged, tableSessionData),
+		sqlDeleteSessionDataIfUnchanged: fmt.Sprintf(queryFmtDeleteSessionDataIfUnchanged, tableSessionData),
+		sqlDeleteExpiredSessionDataByID: fmt.Sprintf(queryFmtDeleteExpiredSessionDataByID, tableSessionData),
+
+		sqlInsertTrustedDevice:         fmt.Sprintf(queryFmtInsertTrustedDevice, tableTrustedDevices),
+		sqlSelectTrustedDevice:         fmt.Sprintf(queryFmtSelectTrustedDevice, tableTrustedDevices),
+		sqlSelectTrustedDevices:        fmt.Sprintf(queryFmtSelectTrustedDevices, tableTrustedDevices),
+		sqlUpdateTrustedDeviceLastUsed: fmt.Sprintf(queryFmtUpdateTrustedDeviceLastUsed, tableTrustedDevices),
+		sqlRevokeTrustedDevice:         fmt.Sprintf(queryFmtRevokeTrustedDevice, tableTrustedDevices),
+		sqlDeleteExpiredTrustedDevices: fmt.Sprintf(queryFmtDeleteExpiredTrustedDevices, tableTrustedDevices),
+
 		sqlInsertBannedUser:         fmt.Sprintf(queryFmtInsertBannedUser, tableBannedUser),
 		sqlSelectBannedUser:         fmt.Sprintf(queryFmtSelectBannedUser, tableBannedUser),
 		sqlSelectBannedUserByID:     fmt.Sprintf(queryFmtSelectBannedUserByID, tableBannedUser),
@@ -155,6 +192,7 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 
 		sqlInsertOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
 		sqlSelectOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlSelectOAuth2AccessTokenSessionSignatures:      fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2AccessTokenSession, tableUserOpaqueIdentifier),
 		sqlRevokeOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AccessTokenSession),
 		sqlRevokeOAuth2AccessTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
 		sqlDeactivateOAuth2AccessTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AccessTokenSession),
@@ -190,6 +228,7 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 
 		sqlInsertOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2RefreshTokenSession),
 		sqlSelectOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSessionSignatures:      fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2RefreshTokenSession, tableUserOpaqueIdentifier),
 		sqlRevokeOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2RefreshTokenSession),
 		sqlRevokeOAuth2RefreshTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
 		sqlDeactivateOAuth2RefreshTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2RefreshTokenSession),
@@ -228,6 +267,47 @@ type SQLProvider struct {
 	sqlSelectAuthenticationLogsRegulationRecordsByUsername string
 	sqlSelectAuthenticationLogsRegulationRecordsByRemoteIP string
 
+	// Table: authentication_users.
+	sqlSelectAuthenticationUser         string
+	sqlSelectAuthenticationUserByEmail  string
+	sqlSelectAuthenticationUsers        string
+	sqlInsertAuthenticationUser         string
+	sqlUpdateAuthenticationUser         string
+	sqlUpdateAuthenticationUserPassword string
+	sqlDeleteAuthenticationUser         string
+
+	// Table: authentication_user_groups.
+	sqlSelectAuthenticationUserGroups string
+	sqlSelectAuthenticationGroups     string
+	sqlInsertAuthenticationUserGroup  string
+	sqlDeleteAuthenticationUserGroups string
+
+	// Table: password_history.
+	sqlSelectPasswordHistory       string
+	sqlInsertPasswordHistory       string
+	sqlDeletePasswordHistoryExcess string
+
+	// Table: session_data.
+	sqlSelectSessionData        string
+	sqlUpsertSessionData        string
+	sqlUpdateSessionDataID      string
+	sqlDeleteSessionData        string
+	sqlDeleteExpiredSessionData string
+	sqlCountSessionData         string
+
+	sqlInsertSessionDataIfAbsent    string
+	sqlUpdateSessionDataIfUnchanged string
+	sqlDeleteSessionDataIfUnchanged string
+	sqlDeleteExpiredSessionDataByID string
+
+	// Table: trusted_devices.
+	sqlInsertTrustedDevice         string
+	sqlSelectTrustedDevice         string
+	sqlSelectTrustedDevices        string
+	sqlUpdateTrustedDeviceLastUsed string
+	sqlRevokeTrustedDevice         string
+	sqlDeleteExpiredTrustedDevices string
+
 	// Table: banned_user.
 	sqlInsertBannedUser         string
 	sqlSelectBannedUser         string
@@ -352,6 +432,7 @@ type SQLProvider struct {
 	// Table: oauth2_access_token_session.
 	sqlInsertOAuth2AccessTokenSession                string
 	sqlSelectOAuth2AccessTokenSession                string
+	sqlSelectOAuth2AccessTokenSessionSignatures      string
 	sqlRevokeOAuth2AccessTokenSession                string
 	sqlRevokeOAuth2AccessTokenSessionByRequestID     string
 	sqlDeactivateOAuth2AccessTokenSession            string
@@ -382,6 +463,7 @@ type SQLProvider struct {
 	// Table: oauth2_refresh_token_session.
 	sqlInsertOAuth2RefreshTokenSession                string
 	sqlSelectOAuth2RefreshTokenSession                string
+	sqlSelectOAuth2RefreshTokenSessionSignatures      string
 	sqlRevokeOAuth2RefreshTokenSession                string
 	sqlRevokeOAuth2RefreshTokenSessionByRequestID     string
 	sqlDeactivateOAuth2RefreshTokenSession            string
@@ -1459,6 +1541,27 @@ func (p *SQLProvider) LoadOAuth2Session(ctx context.Context, sessionType OAuth2S
 	return session, nil
 }
 
+// LoadOAuth2SessionSignaturesByUsername loads the signatures of all OAuth2.0 sessions which have not been revoked from
+// the storage provider given the username the subject of the session belongs to.
+func (p *SQLProvider) LoadOAuth2SessionSignaturesByUsername(ctx context.Context, sessionType OAuth2SessionType, username string) (signatures []string, err error) {
+	var query string
+
+	switch sessionType {
+	case OAuth2SessionTypeAccessToken:
+		query = p.sqlSelectOAuth2AccessTokenSessionSignatures
+	case OAuth2SessionTypeRefreshToken:
+		query = p.sqlSelectOAuth2RefreshTokenSessionSignatures
+	default:
+		return nil, fmt.Errorf("error selecting oauth2 session signatures for user '%s': unsupported oauth2 session type '%s'", username, sessionType)
+	}
+
+	if err = p.db.SelectContext(ctx, &signatures, query, username); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 %s session signatures for user '%s': %w", sessionType, username, err)
+	}
+
+	return signatures, nil
+}
+
 // SaveOAuth2DeviceCodeSession saves an OAuth2.0 Device Code session to the storage provider.
 func (p *SQLProvider) SaveOAuth2DeviceCodeSession(ctx context.Context, session *model.OAuth2DeviceCodeSession) (err error) {
 	if session.Session, err = utils.Encrypt(session.Session, p.aad.Get(OAuth2SessionTypeDeviceAuthorizeCode.AAD(), columnSessionData, session.Signature), p.keys.encryption); err != nil {
@@ -1890,6 +1993,411 @@ func (p *SQLProvider) DeleteCachedData(ctx context.Context, name string) (err er
 	return nil
 }
 
+// LoadAuthenticationUser loads an authentication backend user from the storage provider given a username.
+func (p *SQLProvider) LoadAuthenticationUser(ctx context.Context, username string) (user *model.AuthenticationUser, err error) {
+	user = &model.AuthenticationUser{}
+
+	if err = p.db.GetContext(ctx, user, p.sqlSelectAuthenticationUser, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationUser
+		}
+
+		return nil, fmt.Errorf("error selecting authentication user '%s': %w", username, err)
+	}
+
+	return user, nil
+}
+
+// LoadAuthenticationUserByEmail loads an authentication backend user from the storage provider given an email. If
+// more than one user has the email an error is returned.
+func (p *SQLProvider) LoadAuthenticationUserByEmail(ctx context.Context, email string) (user *model.AuthenticationUser, err error) {
+	var users []model.AuthenticationUser
+
+	if err = p.db.SelectContext(ctx, &users, p.sqlSelectAuthenticationUserByEmail, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationUser
+		}
+
+		return nil, fmt.Errorf("error selecting authentication user with email '%s': %w", email, err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, ErrNoAuthenticationUser
+	case 1:
+		return &users[0], nil
+	default:
+		return nil, fmt.Errorf("error selecting authentication user with email '%s': multiple users have this email", email)
+	}
+}
+
+// LoadAuthenticationUsers loads a page of authentication backend users from the storage provider.
+func (p *SQLProvider) LoadAuthenticationUsers(ctx context.Context, limit, page int) (users []model.AuthenticationUser, err error) {
+	users = make([]model.AuthenticationUser, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &users, p.sqlSelectAuthenticationUsers, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting authentication users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SaveAuthenticationUser saves a new authentication backend user to the storage provider.
+func (p *SQLProvider) SaveAuthenticationUser(ctx context.Context, user model.AuthenticationUser) (err error) {
+	now := time.Now()
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertAuthenticationUser, now, now, user.Username, user.DisplayName, user.Email, user.Password, user.Disabled); err != nil {
+		return fmt.Errorf("error inserting authentication user '%s': %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateAuthenticationUser updates the display name, email, and disabled status of an authentication backend user in
+// the storage provider.
+func (p *SQLProvider) UpdateAuthenticationUser(ctx context.Context, user model.AuthenticationUser) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateAuthenticationUser, time.Now(), user.DisplayName, user.Email, user.Disabled, user.Username); err != nil {
+		return fmt.Errorf("error updating authentication user '%s': %w", user.Username, err)
+	}
+
+	if err = checkSingleUpdateResult(result); err != nil {
+		return fmt.Errorf("error updating authentication user '%s': %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateAuthenticationUserPassword updates the password digest of an authentication backend user in the storage
+// provider.
+func (p *SQLProvider) UpdateAuthenticationUserPassword(ctx context.Context, username, password string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateAuthenticationUserPassword, time.Now(), password, username); err != nil {
+		return fmt.Errorf("error updating password for authentication user '%s': %w", username, err)
+	}
+
+	if err = checkSingleUpdateResult(result); err != nil {
+		return fmt.Errorf("error updating password for authentication user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// DeleteAuthenticationUser deletes an authentication backend user and their group memberships from the storage
+// provider.
+func (p *SQLProvider) DeleteAuthenticationUser(ctx context.Context, username string) (err error) {
+	var tx SQLXTx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to delete authentication user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteAuthenticationUserGroups, username); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error deleting groups for authentication user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteAuthenticationUser, username); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error deleting authentication user '%s': %w", username, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to delete authentication user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// LoadAuthenticationUserGroups loads the groups of an authentication backend user from the storage provider.
+func (p *SQLProvider) LoadAuthenticationUserGroups(ctx context.Context, username string) (groups []string, err error) {
+	groups = []string{}
+
+	if err = p.db.SelectContext(ctx, &groups, p.sqlSelectAuthenticationUserGroups, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{}, nil
+		}
+
+		return nil, fmt.Errorf("error selecting groups for authentication user '%s': %w", username, err)
+	}
+
+	return groups, nil
+}
+
+// LoadAuthenticationGroups loads every distinct group assigned to an authentication backend user from the storage
+// provider.
+func (p *SQLProvider) LoadAuthenticationGroups(ctx context.Context) (groups []string, err error) {
+	groups = []string{}
+
+	if err = p.db.SelectContext(ctx, &groups, p.sqlSelectAuthenticationGroups); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{}, nil
+		}
+
+		return nil, fmt.Errorf("error selecting authentication groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// SaveAuthenticationUserGroups replaces the groups of an authentication backend user in the storage provider.
+func (p *SQLProvider) SaveAuthenticationUserGroups(ctx context.Context, username string, groups []string) (err error) {
+	var tx SQLXTx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to save groups for authentication user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteAuthenticationUserGroups, username); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error deleting groups for authentication user '%s': %w", username, err)
+	}
+
+	for _, group := range groups {
+		if _, err = tx.ExecContext(ctx, p.sqlInsertAuthenticationUserGroup, username, group); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("error inserting group '%s' for authentication user '%s': %w", group, username, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to save groups for authentication user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// LoadPasswordHistory loads the most recent password history entries of a user from the storage provider.
+func (p *SQLProvider) LoadPasswordHistory(ctx context.Context, username string, limit int) (history []model.PasswordHistory, err error) {
+	history = make([]model.PasswordHistory, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &history, p.sqlSelectPasswordHistory, username, limit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []model.PasswordHistory{}, nil
+		}
+
+		return nil, fmt.Errorf("error selecting password history for user '%s': %w", username, err)
+	}
+
+	return history, nil
+}
+
+// SavePasswordHistory saves a password history entry to the storage provider and removes any entries for the user
+// beyond the most recent number specified by keep.
+func (p *SQLProvider) SavePasswordHistory(ctx context.Context, history model.PasswordHistory, keep int) (err error) {
+	var tx SQLXTx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to save password history for user '%s': %w", history.Username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlInsertPasswordHistory, history.Created, history.Username, history.Password); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error inserting password history for user '%s': %w", history.Username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeletePasswordHistoryExcess, history.Username, history.Username, keep); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error deleting excess password history for user '%s': %w", history.Username, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to save password history for user '%s': %w", history.Username, err)
+	}
+
+	return nil
+}
+
+// LoadSessionData loads the data of a session from the storage provider given the session id.
+func (p *SQLProvider) LoadSessionData(ctx context.Context, id string) (data []byte, err error) {
+	if err = p.db.GetContext(ctx, &data, p.sqlSelectSessionData, id, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting session data: %w", err)
+	}
+
+	return data, nil
+}
+
+// SaveSessionData saves the data of a session to the storage provider replacing any existing data for the session.
+func (p *SQLProvider) SaveSessionData(ctx context.Context, id string, data []byte, expires sql.NullTime) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertSessionData, id, expires, data); err != nil {
+		return fmt.Errorf("error upserting session data: %w", err)
+	}
+
+	return nil
+}
+
+// CompareAndSwapSessionData replaces the data of a session with the new data only if the current data is equal to the
+// old data. A nil old value means the session must not exist, and a nil new value deletes the session. The swapped
+// value is false if the current data was not equal to the old data.
+func (p *SQLProvider) CompareAndSwapSessionData(ctx context.Context, id string, old, data []byte, expires sql.NullTime) (swapped bool, err error) {
+	var result sql.Result
+
+	switch {
+	case old == nil && data == nil:
+		return true, nil
+	case old == nil:
+		if _, err = p.db.ExecContext(ctx, p.sqlDeleteExpiredSessionDataByID, id, time.Now()); err != nil {
+			return false, fmt.Errorf("error deleting expired session data: %w", err)
+		}
+
+		if result, err = p.db.ExecContext(ctx, p.sqlInsertSessionDataIfAbsent, id, expires, data); err != nil {
+			return false, fmt.Errorf("error inserting session data: %w", err)
+		}
+	case data == nil:
+		if result, err = p.db.ExecContext(ctx, p.sqlDeleteSessionDataIfUnchanged, id, old); err != nil {
+			return false, fmt.Errorf("error deleting session data: %w", err)
+		}
+	default:
+		if result, err = p.db.ExecContext(ctx, p.sqlUpdateSessionDataIfUnchanged, expires, data, id, old); err != nil {
+			return false, fmt.Errorf("error updating session data: %w", err)
+		}
+
+		// Some database engines only count the rows which were changed so an update which doesn't change the data is
+		// always considered successful.
+		if bytes.Equal(old, data) {
+			return true, nil
+		}
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error checking the result of the session data swap: %w", err)
+	}
+
+	return affected != 0, nil
+}
+
+// RegenerateSessionData changes the id of an existing session in the storage provider.
+func (p *SQLProvider) RegenerateSessionData(ctx context.Context, id, newID string, expires sql.NullTime) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateSessionDataID, newID, expires, id); err != nil {
+		return fmt.Errorf("error updating session data id: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSessionData deletes the data of a session from the storage provider given the session id.
+func (p *SQLProvider) DeleteSessionData(ctx context.Context, id string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteSessionData, id); err != nil {
+		return fmt.Errorf("error deleting session data: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessionData deletes the data of every session in the storage provider which expired before the
+// provided time.
+func (p *SQLProvider) DeleteExpiredSessionData(ctx context.Context, now time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteExpiredSessionData, now); err != nil {
+		return fmt.Errorf("error deleting expired session data: %w", err)
+	}
+
+	return nil
+}
+
+// CountSessionData returns the number of sessions in the storage provider which have not expired.
+func (p *SQLProvider) CountSessionData(ctx context.Context) (count int, err error) {
+	if err = p.db.GetContext(ctx, &count, p.sqlCountSessionData, time.Now()); err != nil {
+		return 0, fmt.Errorf("error counting session data: %w", err)
+	}
+
+	return count, nil
+}
+
+// SaveTrustedDevice saves a new trusted device to the storage provider.
+func (p *SQLProvider) SaveTrustedDevice(ctx context.Context, device model.TrustedDevice) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertTrustedDevice,
+		device.PublicID, device.CreatedAt, device.ExpiresAt, device.Username, device.IP, device.UserAgent); err != nil {
+		return fmt.Errorf("error inserting trusted device for user '%s' with public id '%s': %w", device.Username, device.PublicID, err)
+	}
+
+	return nil
+}
+
+// LoadTrustedDevice loads a trusted device from the storage provider given the public id.
+func (p *SQLProvider) LoadTrustedDevice(ctx context.Context, id uuid.UUID) (device *model.TrustedDevice, err error) {
+	device = &model.TrustedDevice{}
+
+	if err = p.db.GetContext(ctx, device, p.sqlSelectTrustedDevice, id); err != nil {
+		return nil, fmt.Errorf("error selecting trusted device with public id '%s': %w", id, err)
+	}
+
+	return device, nil
+}
+
+// LoadTrustedDevices loads the trusted devices of a user from the storage provider which have neither been revoked
+// nor expired at the given time.
+func (p *SQLProvider) LoadTrustedDevices(ctx context.Context, username string, now time.Time) (devices []model.TrustedDevice, err error) {
+	devices = []model.TrustedDevice{}
+
+	if err = p.db.SelectContext(ctx, &devices, p.sqlSelectTrustedDevices, username, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []model.TrustedDevice{}, nil
+		}
+
+		return nil, fmt.Errorf("error selecting trusted devices for user '%s': %w", username, err)
+	}
+
+	return devices, nil
+}
+
+// UpdateTrustedDeviceLastUsed updates the time a trusted device was last used to skip the second factor.
+func (p *SQLProvider) UpdateTrustedDeviceLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateTrustedDeviceLastUsed, lastUsedAt, id); err != nil {
+		return fmt.Errorf("error updating trusted device with public id '%s': %w", id, err)
+	}
+
+	return nil
+}
+
+// RevokeTrustedDevice revokes a trusted device of a user in the storage provider given the public id.
+func (p *SQLProvider) RevokeTrustedDevice(ctx context.Context, username string, id uuid.UUID, revokedAt time.Time) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRevokeTrustedDevice, revokedAt, id, username); err != nil {
+		return fmt.Errorf("error revoking trusted device for user '%s' with public id '%s': %w", username, id, err)
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error revoking trusted device for user '%s' with public id '%s': %w", username, id, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("error revoking trusted device for user '%s' with public id '%s': %w", username, id, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// DeleteExpiredTrustedDevices deletes every trusted device in the storage provider which expired or was revoked before
+// the provided time.
+func (p *SQLProvider) DeleteExpiredTrustedDevices(ctx context.Context, before time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteExpiredTrustedDevices, before, before); err != nil {
+		return fmt.Errorf("error deleting expired trusted devices: %w", err)
+	}
+
+	return nil
+}
+
 var (
 	_ Provider = (*SQLProvider)(nil)
 )
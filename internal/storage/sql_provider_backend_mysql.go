@@ -34,6 +34,9 @@ func NewMySQLProvider(config *schema.Configuration, caCertPool *x509.CertPool) (
 
 	provider.sqlFmtRenameTable = queryFmtMySQLRenameTable
 
+	// MySQL doesn't support the ON CONFLICT operation but has an INSERT IGNORE statement instead.
+	provider.sqlInsertSessionDataIfAbsent = fmt.Sprintf(queryFmtInsertSessionDataIfAbsentMySQL, tableSessionData)
+
 	return provider, nil
 }
 
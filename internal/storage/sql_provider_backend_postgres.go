@@ -46,6 +46,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlUpsertOAuth2BlacklistedJTI = fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTIPostgreSQL, tableOAuth2BlacklistedJTI)
 	provider.sqlInsertOAuth2ConsentPreConfiguration = fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfigurationPostgreSQL, tableOAuth2ConsentPreConfiguration)
 	provider.sqlUpsertCachedData = fmt.Sprintf(queryFmtUpsertCachedDataPostgreSQL, tableCachedData)
+	provider.sqlUpsertSessionData = fmt.Sprintf(queryFmtUpsertSessionDataPostgreSQL, tableSessionData)
 
 	// PostgreSQL requires rebinding of any query that contains a '?' placeholder to use the '$#' notation placeholders.
 	provider.sqlFmtRenameTable = provider.db.Rebind(provider.sqlFmtRenameTable)
@@ -101,6 +102,39 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlSelectAuthenticationLogsRegulationRecordsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationLogsRegulationRecordsByUsername)
 	provider.sqlSelectAuthenticationLogsRegulationRecordsByRemoteIP = provider.db.Rebind(provider.sqlSelectAuthenticationLogsRegulationRecordsByRemoteIP)
 
+	provider.sqlSelectAuthenticationUser = provider.db.Rebind(provider.sqlSelectAuthenticationUser)
+	provider.sqlSelectAuthenticationUserByEmail = provider.db.Rebind(provider.sqlSelectAuthenticationUserByEmail)
+	provider.sqlSelectAuthenticationUsers = provider.db.Rebind(provider.sqlSelectAuthenticationUsers)
+	provider.sqlInsertAuthenticationUser = provider.db.Rebind(provider.sqlInsertAuthenticationUser)
+	provider.sqlUpdateAuthenticationUser = provider.db.Rebind(provider.sqlUpdateAuthenticationUser)
+	provider.sqlUpdateAuthenticationUserPassword = provider.db.Rebind(provider.sqlUpdateAuthenticationUserPassword)
+	provider.sqlDeleteAuthenticationUser = provider.db.Rebind(provider.sqlDeleteAuthenticationUser)
+
+	provider.sqlSelectAuthenticationUserGroups = provider.db.Rebind(provider.sqlSelectAuthenticationUserGroups)
+	provider.sqlInsertAuthenticationUserGroup = provider.db.Rebind(provider.sqlInsertAuthenticationUserGroup)
+	provider.sqlDeleteAuthenticationUserGroups = provider.db.Rebind(provider.sqlDeleteAuthenticationUserGroups)
+
+	provider.sqlSelectPasswordHistory = provider.db.Rebind(provider.sqlSelectPasswordHistory)
+	provider.sqlInsertPasswordHistory = provider.db.Rebind(provider.sqlInsertPasswordHistory)
+	provider.sqlDeletePasswordHistoryExcess = provider.db.Rebind(provider.sqlDeletePasswordHistoryExcess)
+
+	provider.sqlSelectSessionData = provider.db.Rebind(provider.sqlSelectSessionData)
+	provider.sqlUpdateSessionDataID = provider.db.Rebind(provider.sqlUpdateSessionDataID)
+	provider.sqlInsertSessionDataIfAbsent = provider.db.Rebind(provider.sqlInsertSessionDataIfAbsent)
+	provider.sqlUpdateSessionDataIfUnchanged = provider.db.Rebind(provider.sqlUpdateSessionDataIfUnchanged)
+	provider.sqlDeleteSessionDataIfUnchanged = provider.db.Rebind(provider.sqlDeleteSessionDataIfUnchanged)
+	provider.sqlDeleteExpiredSessionDataByID = provider.db.Rebind(provider.sqlDeleteExpiredSessionDataByID)
+	provider.sqlDeleteSessionData = provider.db.Rebind(provider.sqlDeleteSessionData)
+	provider.sqlDeleteExpiredSessionData = provider.db.Rebind(provider.sqlDeleteExpiredSessionData)
+	provider.sqlCountSessionData = provider.db.Rebind(provider.sqlCountSessionData)
+
+	provider.sqlInsertTrustedDevice = provider.db.Rebind(provider.sqlInsertTrustedDevice)
+	provider.sqlSelectTrustedDevice = provider.db.Rebind(provider.sqlSelectTrustedDevice)
+	provider.sqlSelectTrustedDevices = provider.db.Rebind(provider.sqlSelectTrustedDevices)
+	provider.sqlUpdateTrustedDeviceLastUsed = provider.db.Rebind(provider.sqlUpdateTrustedDeviceLastUsed)
+	provider.sqlRevokeTrustedDevice = provider.db.Rebind(provider.sqlRevokeTrustedDevice)
+	provider.sqlDeleteExpiredTrustedDevices = provider.db.Rebind(provider.sqlDeleteExpiredTrustedDevices)
+
 	provider.sqlInsertBannedUser = provider.db.Rebind(provider.sqlInsertBannedUser)
 	provider.sqlSelectBannedUser = provider.db.Rebind(provider.sqlSelectBannedUser)
 	provider.sqlSelectBannedUserByID = provider.db.Rebind(provider.sqlSelectBannedUserByID)
@@ -139,6 +173,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSession)
 	provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID)
 	provider.sqlSelectOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSession)
+	provider.sqlSelectOAuth2AccessTokenSessionSignatures = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSessionSignatures)
 
 	provider.sqlInsertOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2AuthorizeCodeSession)
 	provider.sqlRevokeOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlRevokeOAuth2AuthorizeCodeSession)
@@ -179,6 +214,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSession)
 	provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID)
 	provider.sqlSelectOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSession)
+	provider.sqlSelectOAuth2RefreshTokenSessionSignatures = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSessionSignatures)
 
 	provider.sqlSelectOAuth2BlacklistedJTI = provider.db.Rebind(provider.sqlSelectOAuth2BlacklistedJTI)
 
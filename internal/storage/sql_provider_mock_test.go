@@ -1182,6 +1182,14 @@ func TestSQLProviderOAuth2SessionUnknownType(t *testing.T) {
 			},
 			expectErr: "error selecting oauth2 session: unknown oauth2 session type 'invalid'",
 		},
+		{
+			name: "ShouldErrLoadOAuth2SessionSignaturesByUsernameUnknownType",
+			invoke: func(p *storage.SQLProvider) error {
+				_, err := p.LoadOAuth2SessionSignaturesByUsername(context.Background(), storage.OAuth2SessionType(-1), "john")
+				return err
+			},
+			expectErr: "error selecting oauth2 session signatures for user 'john': unsupported oauth2 session type 'invalid'",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -498,6 +498,12 @@ const (
 		active, revoked, form_data, session_data)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
+	queryFmtSelectOAuth2SessionSignaturesByUsername = `
+		SELECT s.signature
+		FROM %s s
+		INNER JOIN %s u ON u.identifier = s.subject
+		WHERE u.username = ? AND s.revoked = FALSE;`
+
 	queryFmtRevokeOAuth2Session = `
 		UPDATE %s
 		SET revoked = TRUE
@@ -637,3 +643,175 @@ const (
 		SELECT id, service, sector_id, username, identifier
 		FROM %s;`
 )
+
+const (
+	queryFmtSelectAuthenticationUser = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		WHERE username = ?;`
+
+	queryFmtSelectAuthenticationUserByEmail = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		WHERE email = ?;`
+
+	queryFmtSelectAuthenticationUsers = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		ORDER BY username ASC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtInsertAuthenticationUser = `
+		INSERT INTO %s (created_at, updated_at, username, display_name, email, password, disabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateAuthenticationUser = `
+		UPDATE %s
+		SET updated_at = ?, display_name = ?, email = ?, disabled = ?
+		WHERE username = ?;`
+
+	queryFmtUpdateAuthenticationUserPassword = `
+		UPDATE %s
+		SET updated_at = ?, password = ?
+		WHERE username = ?;`
+
+	queryFmtDeleteAuthenticationUser = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtSelectAuthenticationUserGroups = `
+		SELECT group_name
+		FROM %s
+		WHERE username = ?
+		ORDER BY group_name ASC;`
+
+	queryFmtSelectAuthenticationGroups = `
+		SELECT DISTINCT group_name
+		FROM %s
+		ORDER BY group_name ASC;`
+
+	queryFmtInsertAuthenticationUserGroup = `
+		INSERT INTO %s (username, group_name)
+		VALUES (?, ?);`
+
+	queryFmtDeleteAuthenticationUserGroups = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtSelectPasswordHistory = `
+		SELECT id, created_at, username, password
+		FROM %s
+		WHERE username = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?;`
+
+	queryFmtInsertPasswordHistory = `
+		INSERT INTO %s (created_at, username, password)
+		VALUES (?, ?, ?);`
+
+	queryFmtDeletePasswordHistoryExcess = `
+		DELETE FROM %s
+		WHERE username = ? AND id NOT IN (
+			SELECT id FROM (
+				SELECT id
+				FROM %s
+				WHERE username = ?
+				ORDER BY created_at DESC, id DESC
+				LIMIT ?
+			) AS recent
+		);`
+)
+
+const (
+	queryFmtSelectSessionData = `
+		SELECT data
+		FROM %s
+		WHERE session_id = ? AND (expires_at IS NULL OR expires_at > ?);`
+
+	queryFmtUpsertSessionData = `
+		REPLACE INTO %s (session_id, expires_at, data)
+		VALUES (?, ?, ?);`
+
+	queryFmtUpsertSessionDataPostgreSQL = `
+		INSERT INTO %s (session_id, expires_at, data)
+		VALUES ($1, $2, $3)
+			ON CONFLICT (session_id)
+			DO UPDATE SET expires_at = $2, data = $3;`
+
+	queryFmtInsertSessionDataIfAbsent = `
+		INSERT INTO %s (session_id, expires_at, data)
+		VALUES (?, ?, ?)
+			ON CONFLICT (session_id)
+			DO NOTHING;`
+
+	queryFmtInsertSessionDataIfAbsentMySQL = `
+		INSERT IGNORE INTO %s (session_id, expires_at, data)
+		VALUES (?, ?, ?);`
+
+	queryFmtUpdateSessionDataIfUnchanged = `
+		UPDATE %s
+		SET expires_at = ?, data = ?
+		WHERE session_id = ? AND data = ?;`
+
+	queryFmtDeleteSessionDataIfUnchanged = `
+		DELETE FROM %s
+		WHERE session_id = ? AND data = ?;`
+
+	queryFmtDeleteExpiredSessionDataByID = `
+		DELETE FROM %s
+		WHERE session_id = ? AND expires_at IS NOT NULL AND expires_at <= ?;`
+
+	queryFmtUpdateSessionDataID = `
+		UPDATE %s
+		SET session_id = ?, expires_at = ?
+		WHERE session_id = ?;`
+
+	queryFmtDeleteSessionData = `
+		DELETE FROM %s
+		WHERE session_id = ?;`
+
+	queryFmtDeleteExpiredSessionData = `
+		DELETE FROM %s
+		WHERE expires_at IS NOT NULL AND expires_at <= ?;`
+
+	queryFmtCountSessionData = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE expires_at IS NULL OR expires_at > ?;`
+)
+
+const (
+	queryFmtInsertTrustedDevice = `
+		INSERT INTO %s (public_id, created_at, expires_at, username, ip, user_agent)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectTrustedDevice = `
+		SELECT id, public_id, created_at, last_used_at, expires_at, revoked_at, username, ip, user_agent
+		FROM %s
+		WHERE public_id = ?;`
+
+	queryFmtSelectTrustedDevices = `
+		SELECT id, public_id, created_at, last_used_at, expires_at, revoked_at, username, ip, user_agent
+		FROM %s
+		WHERE username = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY created_at DESC, id DESC;`
+
+	queryFmtUpdateTrustedDeviceLastUsed = `
+		UPDATE %s
+		SET last_used_at = ?
+		WHERE public_id = ?;`
+
+	queryFmtRevokeTrustedDevice = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE public_id = ? AND username = ? AND revoked_at IS NULL;`
+
+	queryFmtDeleteExpiredTrustedDevices = `
+		DELETE FROM %s
+		WHERE expires_at <= ? OR (revoked_at IS NOT NULL AND revoked_at <= ?);`
+)
@@ -722,6 +722,389 @@ func TestSQLProviderCachedData(t *testing.T) {
 	})
 }
 
+func TestSQLProviderAuthenticationUser(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	t.Run("ShouldSaveAndLoadUser", func(t *testing.T) {
+		require.NoError(t, provider.SaveAuthenticationUser(ctx, model.AuthenticationUser{
+			Username:    "john",
+			DisplayName: "John Doe",
+			Email:       sql.NullString{Valid: true, String: "john.doe@authelia.com"},
+			Password:    "$plaintext$password",
+		}))
+
+		user, err := provider.LoadAuthenticationUser(ctx, "john")
+
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, "John Doe", user.DisplayName)
+		assert.Equal(t, "$plaintext$password", user.Password)
+		assert.False(t, user.Disabled)
+
+		user, err = provider.LoadAuthenticationUserByEmail(ctx, "john.doe@authelia.com")
+
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, "john", user.Username)
+	})
+
+	t.Run("ShouldReturnErrNoAuthenticationUserForUnknown", func(t *testing.T) {
+		user, err := provider.LoadAuthenticationUser(ctx, "unknown")
+
+		assert.ErrorIs(t, err, ErrNoAuthenticationUser)
+		assert.Nil(t, user)
+
+		user, err = provider.LoadAuthenticationUserByEmail(ctx, "unknown@authelia.com")
+
+		assert.ErrorIs(t, err, ErrNoAuthenticationUser)
+		assert.Nil(t, user)
+	})
+
+	t.Run("ShouldNotSaveDuplicateUser", func(t *testing.T) {
+		assert.Error(t, provider.SaveAuthenticationUser(ctx, model.AuthenticationUser{Username: "john", Password: "$plaintext$password"}))
+	})
+
+	t.Run("ShouldUpdateUser", func(t *testing.T) {
+		require.NoError(t, provider.UpdateAuthenticationUser(ctx, model.AuthenticationUser{
+			Username:    "john",
+			DisplayName: "Johnny",
+			Disabled:    true,
+		}))
+
+		user, err := provider.LoadAuthenticationUser(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Johnny", user.DisplayName)
+		assert.False(t, user.Email.Valid)
+		assert.True(t, user.Disabled)
+
+		assert.ErrorIs(t, provider.UpdateAuthenticationUser(ctx, model.AuthenticationUser{Username: "unknown"}), ErrNoRowsAffected)
+	})
+
+	t.Run("ShouldUpdateUserPassword", func(t *testing.T) {
+		require.NoError(t, provider.UpdateAuthenticationUserPassword(ctx, "john", "$plaintext$example"))
+
+		user, err := provider.LoadAuthenticationUser(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Equal(t, "$plaintext$example", user.Password)
+
+		assert.ErrorIs(t, provider.UpdateAuthenticationUserPassword(ctx, "unknown", "$plaintext$example"), ErrNoRowsAffected)
+	})
+
+	t.Run("ShouldSaveAndLoadGroups", func(t *testing.T) {
+		groups, err := provider.LoadAuthenticationUserGroups(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+
+		require.NoError(t, provider.SaveAuthenticationUserGroups(ctx, "john", []string{"dev", "admins"}))
+
+		groups, err = provider.LoadAuthenticationUserGroups(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins", "dev"}, groups)
+
+		require.NoError(t, provider.SaveAuthenticationUserGroups(ctx, "john", []string{"dev"}))
+
+		groups, err = provider.LoadAuthenticationUserGroups(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev"}, groups)
+	})
+
+	t.Run("ShouldLoadUsers", func(t *testing.T) {
+		require.NoError(t, provider.SaveAuthenticationUser(ctx, model.AuthenticationUser{Username: "harry", Password: "$plaintext$password"}))
+
+		users, err := provider.LoadAuthenticationUsers(ctx, 10, 0)
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "harry", users[0].Username)
+		assert.Equal(t, "john", users[1].Username)
+	})
+
+	t.Run("ShouldLoadDistinctGroups", func(t *testing.T) {
+		require.NoError(t, provider.SaveAuthenticationUserGroups(ctx, "harry", []string{"dev", "users"}))
+
+		groups, err := provider.LoadAuthenticationGroups(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev", "users"}, groups)
+	})
+
+	t.Run("ShouldDeleteUser", func(t *testing.T) {
+		require.NoError(t, provider.DeleteAuthenticationUser(ctx, "john"))
+
+		_, err := provider.LoadAuthenticationUser(ctx, "john")
+
+		assert.ErrorIs(t, err, ErrNoAuthenticationUser)
+
+		groups, err := provider.LoadAuthenticationUserGroups(ctx, "john")
+
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
+
+func TestSQLProviderPasswordHistory(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	t.Run("ShouldLoadEmptyHistory", func(t *testing.T) {
+		history, err := provider.LoadPasswordHistory(ctx, "john", 5)
+
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("ShouldSaveAndPruneHistory", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+
+		for i, password := range []string{"$plaintext$one", "$plaintext$two", "$plaintext$three"} {
+			require.NoError(t, provider.SavePasswordHistory(ctx, model.PasswordHistory{
+				Created:  now.Add(time.Duration(i) * time.Minute),
+				Username: "john",
+				Password: password,
+			}, 2))
+		}
+
+		require.NoError(t, provider.SavePasswordHistory(ctx, model.PasswordHistory{Created: now, Username: "harry", Password: "$plaintext$one"}, 2))
+
+		history, err := provider.LoadPasswordHistory(ctx, "john", 5)
+
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, "$plaintext$three", history[0].Password)
+		assert.Equal(t, "$plaintext$two", history[1].Password)
+
+		history, err = provider.LoadPasswordHistory(ctx, "john", 1)
+
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "$plaintext$three", history[0].Password)
+
+		history, err = provider.LoadPasswordHistory(ctx, "harry", 5)
+
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "harry", history[0].Username)
+	})
+}
+
+func TestSQLProviderSessionData(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	data, err := provider.LoadSessionData(ctx, "abc")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	now := time.Now()
+
+	require.NoError(t, provider.SaveSessionData(ctx, "abc", []byte("one"), sql.NullTime{Time: now.Add(time.Hour), Valid: true}))
+	require.NoError(t, provider.SaveSessionData(ctx, "abc", []byte("two"), sql.NullTime{Time: now.Add(time.Hour), Valid: true}))
+	require.NoError(t, provider.SaveSessionData(ctx, "forever", []byte("three"), sql.NullTime{}))
+	require.NoError(t, provider.SaveSessionData(ctx, "expired", []byte("four"), sql.NullTime{Time: now.Add(-time.Minute), Valid: true}))
+
+	data, err = provider.LoadSessionData(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("two"), data)
+
+	data, err = provider.LoadSessionData(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("three"), data)
+
+	data, err = provider.LoadSessionData(ctx, "expired")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	count, err := provider.CountSessionData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, provider.RegenerateSessionData(ctx, "abc", "xyz", sql.NullTime{Time: now.Add(time.Hour), Valid: true}))
+
+	data, err = provider.LoadSessionData(ctx, "abc")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = provider.LoadSessionData(ctx, "xyz")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("two"), data)
+
+	require.NoError(t, provider.DeleteExpiredSessionData(ctx, now))
+
+	data, err = provider.LoadSessionData(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("three"), data)
+
+	require.NoError(t, provider.DeleteExpiredSessionData(ctx, now.Add(time.Hour*2)))
+	require.NoError(t, provider.DeleteSessionData(ctx, "forever"))
+
+	count, err = provider.CountSessionData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSQLProviderCompareAndSwapSessionData(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	now := time.Now()
+	expires := sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+
+	swapped, err := provider.CompareAndSwapSessionData(ctx, "abc", nil, []byte("one"), expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", nil, []byte("two"), expires)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", []byte("two"), []byte("three"), expires)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", []byte("one"), []byte("two"), expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", []byte("two"), []byte("two"), expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	data, err := provider.LoadSessionData(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("two"), data)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", []byte("one"), nil, expires)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "abc", []byte("two"), nil, expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	data, err = provider.LoadSessionData(ctx, "abc")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, provider.SaveSessionData(ctx, "expired", []byte("four"), sql.NullTime{Time: now.Add(-time.Minute), Valid: true}))
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "expired", nil, []byte("five"), expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	data, err = provider.LoadSessionData(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("five"), data)
+
+	swapped, err = provider.CompareAndSwapSessionData(ctx, "none", nil, nil, expires)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+}
+
+func TestSQLProviderTrustedDevices(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	devices, err := provider.LoadTrustedDevices(ctx, "john", now)
+	require.NoError(t, err)
+	assert.Len(t, devices, 0)
+
+	newDevice := func(username string, created time.Time, lifespan time.Duration) model.TrustedDevice {
+		id, err := uuid.NewRandom()
+		require.NoError(t, err)
+
+		return model.TrustedDevice{
+			PublicID:  id,
+			CreatedAt: created,
+			ExpiresAt: created.Add(lifespan),
+			Username:  username,
+			IP:        model.NewIP(net.ParseIP("127.0.0.1")),
+			UserAgent: "Test/1.0",
+		}
+	}
+
+	one := newDevice("john", now.Add(-time.Hour), time.Hour*24)
+	two := newDevice("john", now.Add(-time.Minute), time.Hour*24)
+	expired := newDevice("john", now.Add(-time.Hour*48), time.Hour*24)
+	other := newDevice("fred", now, time.Hour*24)
+
+	for _, device := range []model.TrustedDevice{one, two, expired, other} {
+		require.NoError(t, provider.SaveTrustedDevice(ctx, device))
+	}
+
+	devices, err = provider.LoadTrustedDevices(ctx, "john", now)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, two.PublicID, devices[0].PublicID)
+	assert.Equal(t, one.PublicID, devices[1].PublicID)
+
+	device, err := provider.LoadTrustedDevice(ctx, one.PublicID)
+	require.NoError(t, err)
+	assert.Equal(t, "john", device.Username)
+	assert.Equal(t, "Test/1.0", device.UserAgent)
+	assert.Equal(t, "127.0.0.1", device.IP.String())
+	assert.False(t, device.LastUsedAt.Valid)
+	assert.True(t, device.IsTrusted(now))
+
+	require.NoError(t, provider.UpdateTrustedDeviceLastUsed(ctx, one.PublicID, now))
+
+	device, err = provider.LoadTrustedDevice(ctx, one.PublicID)
+	require.NoError(t, err)
+	assert.True(t, device.LastUsedAt.Valid)
+	assert.Equal(t, now.Unix(), device.LastUsedAt.Time.Unix())
+
+	assert.ErrorIs(t, provider.RevokeTrustedDevice(ctx, "fred", one.PublicID, now), sql.ErrNoRows)
+	require.NoError(t, provider.RevokeTrustedDevice(ctx, "john", one.PublicID, now))
+	assert.ErrorIs(t, provider.RevokeTrustedDevice(ctx, "john", one.PublicID, now), sql.ErrNoRows)
+
+	device, err = provider.LoadTrustedDevice(ctx, one.PublicID)
+	require.NoError(t, err)
+	assert.False(t, device.IsTrusted(now))
+
+	devices, err = provider.LoadTrustedDevices(ctx, "john", now)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, two.PublicID, devices[0].PublicID)
+
+	_, err = provider.LoadTrustedDevice(ctx, uuid.Must(uuid.NewRandom()))
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, provider.DeleteExpiredTrustedDevices(ctx, now.Add(-time.Hour)))
+
+	_, err = provider.LoadTrustedDevice(ctx, expired.PublicID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = provider.LoadTrustedDevice(ctx, one.PublicID)
+	assert.NoError(t, err)
+
+	require.NoError(t, provider.DeleteExpiredTrustedDevices(ctx, now))
+
+	_, err = provider.LoadTrustedDevice(ctx, one.PublicID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	for _, device := range []model.TrustedDevice{two, other} {
+		_, err = provider.LoadTrustedDevice(ctx, device.PublicID)
+		assert.NoError(t, err)
+	}
+}
+
 func TestSQLProviderOAuth2ConsentSession(t *testing.T) {
 	provider := newTestSQLiteProviderWithEncryption(t)
 	require.NoError(t, provider.StartupCheck())
@@ -843,6 +1226,51 @@ func TestSQLProviderOAuth2Session(t *testing.T) {
 	})
 }
 
+func TestSQLProviderOAuth2SessionSignaturesByUsername(t *testing.T) {
+	provider := newTestSQLiteProviderWithEncryption(t)
+	require.NoError(t, provider.StartupCheck())
+
+	ctx := context.Background()
+
+	john, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	harry, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SaveUserOpaqueIdentifier(ctx, model.UserOpaqueIdentifier{Service: "openid", SectorID: "", Username: "john", Identifier: john}))
+	require.NoError(t, provider.SaveUserOpaqueIdentifier(ctx, model.UserOpaqueIdentifier{Service: "openid", SectorID: "example.com", Username: "harry", Identifier: harry}))
+
+	for signature, subject := range map[string]uuid.UUID{"sig-john-1": john, "sig-john-2": john, "sig-harry": harry} {
+		require.NoError(t, provider.SaveOAuth2Session(ctx, OAuth2SessionTypeRefreshToken, model.OAuth2Session{
+			ChallengeID: model.MustNullUUID(model.NewRandomNullUUID()),
+			RequestID:   "req-" + signature,
+			ClientID:    "test-client",
+			Signature:   signature,
+			Subject:     sql.NullString{Valid: true, String: subject.String()},
+			Active:      true,
+			Session:     []byte("{}"),
+		}))
+	}
+
+	signatures, err := provider.LoadOAuth2SessionSignaturesByUsername(ctx, OAuth2SessionTypeRefreshToken, "john")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"sig-john-1", "sig-john-2"}, signatures)
+
+	require.NoError(t, provider.RevokeOAuth2Session(ctx, OAuth2SessionTypeRefreshToken, "sig-john-1"))
+
+	signatures, err = provider.LoadOAuth2SessionSignaturesByUsername(ctx, OAuth2SessionTypeRefreshToken, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sig-john-2"}, signatures)
+
+	signatures, err = provider.LoadOAuth2SessionSignaturesByUsername(ctx, OAuth2SessionTypeAccessToken, "john")
+	require.NoError(t, err)
+	assert.Empty(t, signatures)
+
+	_, err = provider.LoadOAuth2SessionSignaturesByUsername(ctx, OAuth2SessionTypeAuthorizeCode, "john")
+	assert.EqualError(t, err, "error selecting oauth2 session signatures for user 'john': unsupported oauth2 session type 'authorization code'")
+}
+
 func TestSQLProviderOAuth2DeviceCodeSession(t *testing.T) {
 	provider := newTestSQLiteProviderWithEncryption(t)
 	require.NoError(t, provider.StartupCheck())
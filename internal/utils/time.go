@@ -146,6 +146,27 @@ func matchParseTimeStringWithLayouts(input string, layouts []string) (index int,
 	return -998, time.UnixMilli(0), fmt.Errorf("failed to find a suitable time layout for time '%s'", input)
 }
 
+// ParseTimeOfDay parses a time of day in the 24-hour 'HH:MM' format and returns the number of minutes since midnight.
+// The value '24:00' is accepted and represents the end of the day.
+func ParseTimeOfDay(input string) (minutes int, err error) {
+	hourStr, minuteStr, found := strings.Cut(input, ":")
+	if !found || len(hourStr) != 2 || len(minuteStr) != 2 {
+		return 0, fmt.Errorf("time of day '%s' must be in the 'HH:MM' format", input)
+	}
+
+	var hour, minute int
+
+	if hour, err = strconv.Atoi(hourStr); err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("time of day '%s' has an invalid hour value", input)
+	}
+
+	if minute, err = strconv.Atoi(minuteStr); err != nil || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("time of day '%s' has an invalid minute value", input)
+	}
+
+	return hour*60 + minute, nil
+}
+
 // UnixNanoTimeToMicrosoftNTEpoch converts a unix timestamp in nanosecond format to win32 epoch format.
 func UnixNanoTimeToMicrosoftNTEpoch(nano int64) (t uint64) {
 	if nano >= 0 {
@@ -154,3 +175,12 @@ func UnixNanoTimeToMicrosoftNTEpoch(nano int64) (t uint64) {
 
 	return timeUnixEpochAsMicrosoftNTEpoch
 }
+
+// MicrosoftNTEpochToTime converts a win32 epoch format timestamp to a time.Time.
+func MicrosoftNTEpochToTime(t uint64) time.Time {
+	if t <= timeUnixEpochAsMicrosoftNTEpoch {
+		return time.Unix(0, 0).UTC()
+	}
+
+	return time.Unix(0, int64(t-timeUnixEpochAsMicrosoftNTEpoch)*100).UTC()
+}
@@ -191,6 +191,12 @@ func TestShouldConvertKnownUnixNanoTimeToKnownWin32Epoch(t *testing.T) {
 	assert.Equal(t, timeUnixEpochAsMicrosoftNTEpoch, UnixNanoTimeToMicrosoftNTEpoch(-1))
 }
 
+func TestShouldConvertKnownWin32EpochToKnownTime(t *testing.T) {
+	assert.Equal(t, time.Unix(1626234411, 0).UTC(), MicrosoftNTEpochToTime(132707080110000000))
+	assert.Equal(t, time.Unix(0, 0).UTC(), MicrosoftNTEpochToTime(timeUnixEpochAsMicrosoftNTEpoch))
+	assert.Equal(t, time.Unix(0, 0).UTC(), MicrosoftNTEpochToTime(0))
+}
+
 func TestParseTimeString(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -468,3 +474,37 @@ func TestStandardizeDurationString_FailsOnFirstAtoiError(t *testing.T) {
 
 	assert.Contains(t, err.Error(), `parsing "999999999999999999999999999999999999999999999999999999999999999999999"`)
 }
+
+func TestParseTimeOfDay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected int
+		err      string
+	}{
+		{"ShouldParseMidnight", "00:00", 0, ""},
+		{"ShouldParseMorning", "08:30", 510, ""},
+		{"ShouldParseEvening", "18:00", 1080, ""},
+		{"ShouldParseEndOfDay", "24:00", 1440, ""},
+		{"ShouldNotParseWithoutSeparator", "0800", 0, "time of day '0800' must be in the 'HH:MM' format"},
+		{"ShouldNotParseSingleDigitHour", "8:00", 0, "time of day '8:00' must be in the 'HH:MM' format"},
+		{"ShouldNotParseInvalidHour", "25:00", 0, "time of day '25:00' has an invalid hour value"},
+		{"ShouldNotParseNonNumericHour", "ab:00", 0, "time of day 'ab:00' has an invalid hour value"},
+		{"ShouldNotParseInvalidMinute", "10:60", 0, "time of day '10:60' has an invalid minute value"},
+		{"ShouldNotParseAfterEndOfDay", "24:01", 0, "time of day '24:01' has an invalid minute value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseTimeOfDay(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
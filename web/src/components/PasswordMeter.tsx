@@ -161,6 +161,11 @@ const PasswordMeter = function (props: Props) {
                     </AlertDescription>
                 </Alert>
             )}
+            {props.policy?.breached && (
+                <p className="mt-1 text-[0.7rem] text-left text-muted-foreground">
+                    {translate("Passwords which have appeared in a data breach are not allowed")}
+                </p>
+            )}
         </div>
     );
 };
@@ -1,5 +1,7 @@
 export const IndexRoute: string = "/";
 export const AuthenticatedRoute: string = "/authenticated";
+export const ChangePasswordRoute: string = "/change-password";
+export const ReauthenticateRoute: string = "/reauthenticate";
 
 export const SecondFactorRoute: string = "/2fa";
 export const SecondFactorPasswordSubRoute: string = "/password";
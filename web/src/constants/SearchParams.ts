@@ -8,6 +8,10 @@ export const RedirectionRestoreURL: string = "xrd";
 
 export const RequestMethod: string = "rm";
 
+export const AuthenticationMethodsReferences: string = "amr";
+
+export const MaxAge: string = "max_age";
+
 export const Flow: string = "flow";
 
 export const FlowID: string = "flow_id";
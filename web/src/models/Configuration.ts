@@ -4,6 +4,7 @@ export interface Configuration {
     available_methods: Set<SecondFactorMethod>;
     password_change_disabled: boolean;
     password_reset_disabled: boolean;
+    trusted_devices_enabled: boolean;
 }
 
 export interface SecuritySettingsConfiguration {
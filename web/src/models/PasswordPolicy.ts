@@ -13,4 +13,5 @@ export interface PasswordPolicyConfiguration {
     require_lowercase: boolean;
     require_number: boolean;
     require_special: boolean;
+    breached?: boolean;
 }
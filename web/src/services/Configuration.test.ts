@@ -1,6 +1,6 @@
 import { SecondFactorMethod } from "@models/Methods";
 import { Get } from "@services/Client";
-import { getConfiguration } from "@services/Configuration";
+import { getConfiguration, getMethodsSatisfyingAMR } from "@services/Configuration";
 import { toSecondFactorMethod } from "@services/UserInfo";
 
 vi.mock("@services/Api", () => ({
@@ -18,6 +18,7 @@ it("gets configuration and transforms available methods", async () => {
         available_methods: ["totp", "webauthn"],
         password_change_disabled: false,
         password_reset_disabled: true,
+        trusted_devices_enabled: true,
     });
     (toSecondFactorMethod as any).mockImplementation((m: string) =>
         m === "totp" ? SecondFactorMethod.TOTP : SecondFactorMethod.WebAuthn,
@@ -30,4 +31,21 @@ it("gets configuration and transforms available methods", async () => {
     expect(result.available_methods.has(SecondFactorMethod.WebAuthn)).toBe(true);
     expect(result.password_change_disabled).toBe(false);
     expect(result.password_reset_disabled).toBe(true);
+    expect(result.trusted_devices_enabled).toBe(true);
+});
+
+it("filters available methods to those satisfying the required amr values", () => {
+    const methods = new Set([SecondFactorMethod.TOTP, SecondFactorMethod.WebAuthn, SecondFactorMethod.MobilePush]);
+
+    expect(getMethodsSatisfyingAMR(methods, ["hwk"])).toEqual(new Set([SecondFactorMethod.WebAuthn]));
+    expect(getMethodsSatisfyingAMR(methods, ["otp", "sms"])).toEqual(
+        new Set([SecondFactorMethod.TOTP, SecondFactorMethod.MobilePush]),
+    );
+    expect(getMethodsSatisfyingAMR(methods, ["mfa"])).toEqual(methods);
+});
+
+it("returns all available methods when none satisfy the required amr values", () => {
+    const methods = new Set([SecondFactorMethod.TOTP]);
+
+    expect(getMethodsSatisfyingAMR(methods, ["hwk"])).toEqual(methods);
 });
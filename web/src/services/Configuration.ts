@@ -1,4 +1,5 @@
 import { Configuration } from "@models/Configuration";
+import { SecondFactorMethod } from "@models/Methods";
 import { ConfigurationPath } from "@services/Api";
 import { Get } from "@services/Client";
 import { Method2FA, toSecondFactorMethod } from "@services/UserInfo";
@@ -7,6 +8,7 @@ interface ConfigurationPayload {
     available_methods: Method2FA[];
     password_change_disabled: boolean;
     password_reset_disabled: boolean;
+    trusted_devices_enabled: boolean;
 }
 
 export async function getConfiguration(): Promise<Configuration> {
@@ -16,5 +18,40 @@ export async function getConfiguration(): Promise<Configuration> {
         available_methods: new Set(config.available_methods.map(toSecondFactorMethod)),
         password_change_disabled: config.password_change_disabled,
         password_reset_disabled: config.password_reset_disabled,
+        trusted_devices_enabled: config.trusted_devices_enabled,
     };
 }
+
+/**
+ * Filters the available second factor methods to those which satisfy at least one of the RFC8176 Authentication
+ * Method Reference values required by an access control rule. If none of the available methods can satisfy the
+ * values then all of the available methods are returned.
+ */
+export function getMethodsSatisfyingAMR(methods: Set<SecondFactorMethod>, amr: string[]): Set<SecondFactorMethod> {
+    const satisfying = new Set<SecondFactorMethod>();
+
+    for (const value of amr) {
+        switch (value) {
+            case "otp":
+                satisfying.add(SecondFactorMethod.TOTP);
+                break;
+            case "sms":
+            case "mca":
+                satisfying.add(SecondFactorMethod.MobilePush);
+                break;
+            case "pop":
+            case "hwk":
+            case "swk":
+            case "user":
+            case "pin":
+                satisfying.add(SecondFactorMethod.WebAuthn);
+                break;
+            case "mfa":
+                return methods;
+        }
+    }
+
+    const filtered = new Set([...methods].filter((method) => satisfying.has(method)));
+
+    return filtered.size === 0 ? methods : filtered;
+}
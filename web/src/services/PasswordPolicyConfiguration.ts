@@ -11,6 +11,7 @@ interface PasswordPolicyConfigurationPayload {
     require_lowercase: boolean;
     require_number: boolean;
     require_special: boolean;
+    breached?: boolean;
 }
 
 export type ModePasswordPolicy = "disabled" | "standard" | "zxcvbn";
@@ -12,6 +12,8 @@ export interface AutheliaState {
     authentication_level: AuthenticationLevel;
     factor_knowledge: boolean;
     default_redirection_url?: string;
+    password_change_required?: boolean;
+    password_grace_logins_remaining?: number;
 }
 
 export async function getState(): Promise<AutheliaState> {
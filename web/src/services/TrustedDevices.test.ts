@@ -0,0 +1,37 @@
+import { DeleteWithOptionalResponse, GetWithOptionalData, PostWithOptionalResponse } from "@services/Client";
+import { getUserTrustedDevices, revokeUserTrustedDevice, trustCurrentDevice } from "@services/TrustedDevices";
+
+vi.mock("@services/Api", () => ({
+    UserTrustedDevicesPath: "/user/trusted-devices",
+}));
+vi.mock("@services/Client", () => ({
+    DeleteWithOptionalResponse: vi.fn(),
+    GetWithOptionalData: vi.fn(),
+    PostWithOptionalResponse: vi.fn(),
+}));
+
+it("returns trusted devices when present", async () => {
+    const devices = [{ current: true, id: "1" }];
+    (GetWithOptionalData as any).mockResolvedValue(devices);
+    const result = await getUserTrustedDevices();
+    expect(GetWithOptionalData).toHaveBeenCalledWith("/user/trusted-devices");
+    expect(result).toEqual(devices);
+});
+
+it("returns empty array when null", async () => {
+    (GetWithOptionalData as any).mockResolvedValue(null);
+    const result = await getUserTrustedDevices();
+    expect(result).toEqual([]);
+});
+
+it("trusts the current device", async () => {
+    (PostWithOptionalResponse as any).mockResolvedValue(undefined);
+    await trustCurrentDevice();
+    expect(PostWithOptionalResponse).toHaveBeenCalledWith("/user/trusted-devices");
+});
+
+it("revokes a trusted device", async () => {
+    (DeleteWithOptionalResponse as any).mockResolvedValue(undefined);
+    await revokeUserTrustedDevice("abc");
+    expect(DeleteWithOptionalResponse).toHaveBeenCalledWith("/user/trusted-devices/abc");
+});
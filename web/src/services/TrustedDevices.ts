@@ -0,0 +1,30 @@
+import { UserTrustedDevicesPath } from "@services/Api";
+import { DeleteWithOptionalResponse, GetWithOptionalData, PostWithOptionalResponse } from "@services/Client";
+
+export interface TrustedDevice {
+    id: string;
+    remote_ip: string;
+    user_agent: string;
+    created: string;
+    last_used?: string;
+    expires: string;
+    current: boolean;
+}
+
+export async function getUserTrustedDevices(): Promise<TrustedDevice[]> {
+    const res = await GetWithOptionalData<null | TrustedDevice[]>(UserTrustedDevicesPath);
+
+    if (res === null) {
+        return [];
+    }
+
+    return res;
+}
+
+export async function trustCurrentDevice() {
+    return PostWithOptionalResponse(UserTrustedDevicesPath);
+}
+
+export async function revokeUserTrustedDevice(id: string) {
+    return DeleteWithOptionalResponse(`${UserTrustedDevicesPath}/${id}`);
+}
@@ -0,0 +1,28 @@
+import { useTranslation } from "react-i18next";
+
+import LoginLayout from "@layouts/LoginLayout";
+import { UserInfo } from "@models/UserInfo";
+import PasswordForm from "@views/LoginPortal/SecondFactor/PasswordForm";
+
+export interface Props {
+    userInfo: UserInfo;
+
+    onAuthenticationSuccess: (_redirectURL: string | undefined) => void;
+}
+
+const ReauthenticateForm = function (props: Props) {
+    const { t: translate } = useTranslation();
+
+    return (
+        <LoginLayout
+            id={"reauthenticate-stage"}
+            title={`${translate("Hi")} ${props.userInfo.display_name}`}
+            subtitle={translate("Enter your password to confirm your identity")}
+            userInfo={props.userInfo}
+        >
+            <PasswordForm reauthenticate onAuthenticationSuccess={props.onAuthenticationSuccess} />
+        </LoginLayout>
+    );
+};
+
+export default ReauthenticateForm;
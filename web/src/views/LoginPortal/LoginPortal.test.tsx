@@ -4,6 +4,7 @@ import { MemoryRouter } from "react-router-dom";
 import { useLocalStorageMethodContext } from "@contexts/LocalStorageMethodContext";
 import { useNotifications } from "@contexts/NotificationsContext";
 import { useConfiguration } from "@hooks/Configuration";
+import { useQueryParam } from "@hooks/QueryParam";
 import { useRouterNavigate } from "@hooks/RouterNavigate";
 import { useAutheliaState } from "@hooks/State";
 import { useUserInfoPOST } from "@hooks/UserInfo";
@@ -16,6 +17,7 @@ vi.mock("react-i18next", () => ({
 vi.mock("@constants/Routes", () => ({
     AuthenticatedRoute: "/authenticated",
     IndexRoute: "/",
+    ReauthenticateRoute: "/reauthenticate",
     SecondFactorPasswordSubRoute: "/password",
     SecondFactorPushSubRoute: "/push",
     SecondFactorRoute: "/2fa",
@@ -36,7 +38,7 @@ vi.mock("@contexts/NotificationsContext", () => ({
 }));
 
 vi.mock("@hooks/QueryParam", () => ({
-    useQueryParam: () => null,
+    useQueryParam: vi.fn(),
 }));
 
 vi.mock("@hooks/Redirector", () => ({
@@ -71,6 +73,10 @@ vi.mock("@views/LoginPortal/FirstFactor/FirstFactorForm", () => ({
     default: () => <div data-testid="first-factor-form" />,
 }));
 
+vi.mock("@views/LoginPortal/FirstFactor/ReauthenticateForm", () => ({
+    default: () => <div data-testid="reauthenticate-form" />,
+}));
+
 vi.mock("@views/LoginPortal/SecondFactor/SecondFactorForm", () => ({
     default: () => <div data-testid="second-factor-form" />,
 }));
@@ -108,6 +114,7 @@ beforeEach(() => {
     vi.mocked(useAutheliaState).mockReturnValue([undefined, vi.fn(), false, undefined]);
     vi.mocked(useConfiguration).mockReturnValue([undefined, vi.fn(), false, undefined]);
     vi.mocked(useUserInfoPOST).mockReturnValue([undefined, vi.fn(), false, undefined]);
+    vi.mocked(useQueryParam).mockReturnValue(undefined);
     mockNavigate.mockClear();
     mockCreateErrorNotification.mockClear();
 });
@@ -149,7 +156,12 @@ it("OneFactor with no 2FA methods navigates to /authenticated", async () => {
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set(), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set(),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -173,6 +185,44 @@ it("OneFactor with no 2FA methods navigates to /authenticated", async () => {
     expect(mockNavigate).toHaveBeenNthCalledWith(1, "/authenticated", false);
 });
 
+it("OneFactor with max_age and no 2FA methods navigates to /reauthenticate", async () => {
+    vi.mocked(useQueryParam).mockImplementation((param: string) => (param === "max_age" ? "300" : undefined));
+    vi.mocked(useAutheliaState).mockReturnValue([
+        { authentication_level: 1, factor_knowledge: true, username: "test" },
+        vi.fn(),
+        false,
+        undefined,
+    ]);
+    vi.mocked(useConfiguration).mockReturnValue([
+        {
+            available_methods: new Set(),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
+        vi.fn(),
+        false,
+        undefined,
+    ]);
+    vi.mocked(useUserInfoPOST).mockReturnValue([
+        { display_name: "test", emails: [], has_duo: false, has_totp: false, has_webauthn: false, method: 1 },
+        vi.fn(),
+        false,
+        undefined,
+    ]);
+
+    render(
+        <MemoryRouter>
+            <LoginPortal {...defaultProps} />
+        </MemoryRouter>,
+    );
+
+    await waitFor(() => {
+        expect(mockNavigate).toHaveBeenCalledTimes(1);
+    });
+    expect(mockNavigate).toHaveBeenNthCalledWith(1, "/reauthenticate");
+});
+
 it("OneFactor with TOTP preferred navigates to /2fa/totp", async () => {
     vi.mocked(useAutheliaState).mockReturnValue([
         { authentication_level: 1, factor_knowledge: true, username: "test" },
@@ -181,7 +231,12 @@ it("OneFactor with TOTP preferred navigates to /2fa/totp", async () => {
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set([1]), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set([1]),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -213,7 +268,12 @@ it("OneFactor with WebAuthn preferred navigates to /2fa/webauthn", async () => {
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set([2]), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set([2]),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -245,7 +305,12 @@ it("OneFactor with MobilePush preferred navigates to /2fa/push", async () => {
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set([3]), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set([3]),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -277,7 +342,12 @@ it("OneFactor with factor_knowledge false navigates to /2fa/password", async ()
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set([1]), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set([1]),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -309,7 +379,12 @@ it("localStorageMethod overrides userInfo.method", async () => {
         undefined,
     ]);
     vi.mocked(useConfiguration).mockReturnValue([
-        { available_methods: new Set([1, 2]), password_change_disabled: false, password_reset_disabled: false },
+        {
+            available_methods: new Set([1, 2]),
+            password_change_disabled: false,
+            password_reset_disabled: false,
+            trusted_devices_enabled: false,
+        },
         vi.fn(),
         false,
         undefined,
@@ -1,18 +1,20 @@
-import { Fragment, ReactNode, lazy, useCallback, useEffect, useState } from "react";
+import { Fragment, ReactNode, lazy, useCallback, useEffect, useMemo, useRef, useState } from "react";
 
 import { useTranslation } from "react-i18next";
 import { Route, Routes, useLocation } from "react-router-dom";
 
 import {
     AuthenticatedRoute,
+    ChangePasswordRoute,
     IndexRoute,
+    ReauthenticateRoute,
     SecondFactorPasswordSubRoute,
     SecondFactorPushSubRoute,
     SecondFactorRoute,
     SecondFactorTOTPSubRoute,
     SecondFactorWebAuthnSubRoute,
 } from "@constants/Routes";
-import { RedirectionURL } from "@constants/SearchParams";
+import { AuthenticationMethodsReferences, MaxAge, RedirectionURL } from "@constants/SearchParams";
 import { useLocalStorageMethodContext } from "@contexts/LocalStorageMethodContext";
 import { useNotifications } from "@contexts/NotificationsContext";
 import { useConfiguration } from "@hooks/Configuration";
@@ -22,12 +24,15 @@ import { useRouterNavigate } from "@hooks/RouterNavigate";
 import { useAutheliaState } from "@hooks/State";
 import { useUserInfoPOST } from "@hooks/UserInfo";
 import { SecondFactorMethod } from "@models/Methods";
+import { getMethodsSatisfyingAMR } from "@services/Configuration";
 import { checkSafeRedirection } from "@services/SafeRedirection";
 import { AuthenticationLevel } from "@services/State";
 import LoadingPage from "@views/LoadingPage/LoadingPage";
 
 const AuthenticatedView = lazy(() => import("@views/LoginPortal/AuthenticatedView/AuthenticatedView"));
+const ChangePasswordDialog = lazy(() => import("@views/Settings/Security/ChangePasswordDialog"));
 const FirstFactorForm = lazy(() => import("@views/LoginPortal/FirstFactor/FirstFactorForm"));
+const ReauthenticateForm = lazy(() => import("@views/LoginPortal/FirstFactor/ReauthenticateForm"));
 const SecondFactorForm = lazy(() => import("@views/LoginPortal/SecondFactor/SecondFactorForm"));
 
 export interface Props {
@@ -44,7 +49,9 @@ const RedirectionErrorMessage =
 const LoginPortal = function (props: Props) {
     const location = useLocation();
     const redirectionURL = useQueryParam(RedirectionURL);
-    const { createErrorNotification } = useNotifications();
+    const requiredAMR = useQueryParam(AuthenticationMethodsReferences);
+    const maxAge = useQueryParam(MaxAge);
+    const { createErrorNotification, createWarnNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
     const redirector = useRedirector();
@@ -57,6 +64,26 @@ const LoginPortal = function (props: Props) {
 
     const navigate = useRouterNavigate();
 
+    // The required AMR values and the max age are only present when the user must authenticate again to access the
+    // resource which means an authenticated session must not be redirected back to it or it would be redirected
+    // straight back here.
+    const stepUp =
+        (!!requiredAMR || !!maxAge) &&
+        state !== undefined &&
+        state.authentication_level >= AuthenticationLevel.OneFactor &&
+        !broadcastRedirect;
+
+    const stepUpConfiguration = useMemo(() => {
+        if (!configuration || !requiredAMR) {
+            return configuration;
+        }
+
+        return {
+            ...configuration,
+            available_methods: getMethodsSatisfyingAMR(configuration.available_methods, requiredAMR.split(",")),
+        };
+    }, [configuration, requiredAMR]);
+
     useEffect(() => {
         fetchState();
     }, [fetchState]);
@@ -68,6 +95,23 @@ const LoginPortal = function (props: Props) {
         }
     }, [state, fetchUserInfo, fetchConfiguration]);
 
+    const graceLoginsNotified = useRef(false);
+
+    useEffect(() => {
+        if (state?.password_grace_logins_remaining === undefined || graceLoginsNotified.current) {
+            return;
+        }
+
+        graceLoginsNotified.current = true;
+
+        createWarnNotification(
+            translate(
+                "Your password has expired and you have {{count}} grace logins remaining, please change your password",
+                { count: state.password_grace_logins_remaining },
+            ),
+        );
+    }, [state, createWarnNotification, translate]);
+
     useEffect(() => {
         if (fetchStateError) {
             createErrorNotification(translate("There was an issue retrieving the current user state"));
@@ -92,9 +136,10 @@ const LoginPortal = function (props: Props) {
         }
 
         const shouldRedirect =
-            (configuration?.available_methods?.size === 0 &&
-                state!.authentication_level >= AuthenticationLevel.OneFactor) ||
-            state!.authentication_level === AuthenticationLevel.TwoFactor ||
+            (!stepUp &&
+                ((configuration?.available_methods?.size === 0 &&
+                    state!.authentication_level >= AuthenticationLevel.OneFactor) ||
+                    state!.authentication_level === AuthenticationLevel.TwoFactor)) ||
             broadcastRedirect;
 
         if (!shouldRedirect) {
@@ -113,17 +158,38 @@ const LoginPortal = function (props: Props) {
         }
 
         return true;
-    }, [redirectionURL, configuration, state, broadcastRedirect, redirector, createErrorNotification, translate]);
+    }, [
+        redirectionURL,
+        configuration,
+        state,
+        stepUp,
+        broadcastRedirect,
+        redirector,
+        createErrorNotification,
+        translate,
+    ]);
 
     const handleAuthenticationNavigation = useCallback(() => {
-        if (state!.authentication_level === AuthenticationLevel.Unauthenticated) {
+        if (state!.password_change_required) {
+            navigate(ChangePasswordRoute);
+        } else if (state!.authentication_level === AuthenticationLevel.Unauthenticated) {
             setFirstFactorDisabled(false);
             navigate(IndexRoute);
-        } else if (state!.authentication_level >= AuthenticationLevel.OneFactor && userInfo && configuration) {
-            if (configuration.available_methods.size === 0) {
-                navigate(AuthenticatedRoute, false);
+        } else if (state!.authentication_level >= AuthenticationLevel.OneFactor && userInfo && stepUpConfiguration) {
+            if (stepUpConfiguration.available_methods.size === 0) {
+                // Without a second factor the max age can only be satisfied by performing the first factor again,
+                // otherwise the user would be sent back here as soon as they're redirected to the resource.
+                if (stepUp && maxAge) {
+                    navigate(ReauthenticateRoute);
+                } else {
+                    navigate(AuthenticatedRoute, false);
+                }
             } else {
-                const method = localStorageMethod || userInfo.method;
+                let method = localStorageMethod || userInfo.method;
+
+                if (!stepUpConfiguration.available_methods.has(method)) {
+                    method = [...stepUpConfiguration.available_methods][0];
+                }
 
                 if (!state!.factor_knowledge) {
                     navigate(`${SecondFactorRoute}${SecondFactorPasswordSubRoute}`);
@@ -136,7 +202,7 @@ const LoginPortal = function (props: Props) {
                 }
             }
         }
-    }, [state, userInfo, configuration, navigate, localStorageMethod]);
+    }, [state, userInfo, stepUpConfiguration, stepUp, maxAge, navigate, localStorageMethod]);
 
     useEffect(() => {
         (async function () {
@@ -208,12 +274,12 @@ const LoginPortal = function (props: Props) {
             <Route
                 path={`${SecondFactorRoute}/*`}
                 element={
-                    state && userInfo && configuration ? (
+                    state && userInfo && stepUpConfiguration ? (
                         <SecondFactorForm
-                            authenticationLevel={state.authentication_level}
+                            authenticationLevel={stepUp ? AuthenticationLevel.OneFactor : state.authentication_level}
                             factorKnowledge={state.factor_knowledge}
                             userInfo={userInfo}
-                            configuration={configuration}
+                            configuration={stepUpConfiguration}
                             duoSelfEnrollment={props.duoSelfEnrollment}
                             onMethodChanged={() => fetchUserInfo()}
                             onAuthenticationSuccess={handleAuthSuccess}
@@ -221,7 +287,23 @@ const LoginPortal = function (props: Props) {
                     ) : null
                 }
             />
+            <Route
+                path={ReauthenticateRoute}
+                element={
+                    userInfo ? (
+                        <ReauthenticateForm userInfo={userInfo} onAuthenticationSuccess={handleAuthSuccess} />
+                    ) : null
+                }
+            />
             <Route path={AuthenticatedRoute} element={userInfo ? <AuthenticatedView userInfo={userInfo} /> : null} />
+            <Route
+                path={ChangePasswordRoute}
+                element={
+                    state?.password_change_required ? (
+                        <ChangePasswordDialog username={state.username} open={true} setClosed={() => fetchState()} />
+                    ) : null
+                }
+            />
         </Routes>
     );
 };
@@ -25,8 +25,10 @@ vi.mock("@hooks/OpenIDConnect", () => ({
 }));
 
 const mockPostSecondFactor = vi.fn();
+const mockPostFirstFactorReauthenticate = vi.fn();
 
 vi.mock("@services/Password", () => ({
+    postFirstFactorReauthenticate: (...args: any[]) => mockPostFirstFactorReauthenticate(...args),
     postSecondFactor: (...args: any[]) => mockPostSecondFactor(...args),
 }));
 
@@ -42,6 +44,7 @@ it("renders the password form", () => {
 
 beforeEach(() => {
     mockPostSecondFactor.mockReset();
+    mockPostFirstFactorReauthenticate.mockReset();
 });
 
 it("shows error when submitting empty password", async () => {
@@ -54,3 +57,21 @@ it("shows error when submitting empty password", async () => {
     expect(mockPostSecondFactor).not.toHaveBeenCalled();
     expect(screen.getByLabelText(/Password/)).toHaveAttribute("aria-invalid", "true");
 });
+
+it("reauthenticates the first factor when requested", async () => {
+    const onAuthenticationSuccess = vi.fn();
+
+    mockPostFirstFactorReauthenticate.mockResolvedValue({ redirect: "https://app.example.com" });
+
+    render(<PasswordForm reauthenticate onAuthenticationSuccess={onAuthenticationSuccess} />);
+
+    fireEvent.change(screen.getByLabelText(/Password/), { target: { value: "password" } });
+
+    await act(async () => {
+        fireEvent.click(screen.getByText("Authenticate"));
+    });
+
+    expect(mockPostSecondFactor).not.toHaveBeenCalled();
+    expect(mockPostFirstFactorReauthenticate).toHaveBeenCalledWith("password", null, null, null, null, null);
+    expect(onAuthenticationSuccess).toHaveBeenCalledWith("https://app.example.com");
+});
@@ -8,14 +8,17 @@ import { Button } from "@components/UI/Button";
 import { Input } from "@components/UI/Input";
 import { Label } from "@components/UI/Label";
 import { Spinner } from "@components/UI/Spinner";
-import { RedirectionURL } from "@constants/SearchParams";
+import { RedirectionURL, RequestMethod } from "@constants/SearchParams";
 import { useNotifications } from "@contexts/NotificationsContext";
 import { useFlow } from "@hooks/Flow";
 import { useQueryParam } from "@hooks/QueryParam";
 import { IsCapsLockModified } from "@services/CapsLock";
-import { postSecondFactor } from "@services/Password";
+import { postFirstFactorReauthenticate, postSecondFactor } from "@services/Password";
 
 export interface Props {
+    // Whether the password re-authenticates the first factor rather than performing the knowledge based second factor.
+    reauthenticate?: boolean;
+
     onAuthenticationSuccess: (_redirectURL: string | undefined) => void;
 }
 
@@ -24,6 +27,7 @@ const PasswordForm = function (props: Props) {
     const { t: translate } = useTranslation(["portal", "settings"]);
 
     const redirectionURL = useQueryParam(RedirectionURL);
+    const requestMethod = useQueryParam(RequestMethod);
     const { flow, id: flowID, subflow } = useFlow();
 
     const [loading, setLoading] = useState(false);
@@ -56,7 +60,9 @@ const PasswordForm = function (props: Props) {
         setLoading(true);
 
         try {
-            const res = await postSecondFactor(password, redirectionURL, flowID, flow, subflow);
+            const res = props.reauthenticate
+                ? await postFirstFactorReauthenticate(password, redirectionURL, requestMethod, flowID, flow, subflow)
+                : await postSecondFactor(password, redirectionURL, flowID, flow, subflow);
             props.onAuthenticationSuccess(res ? res.redirect : undefined);
         } catch (err) {
             console.error(err);
@@ -65,7 +71,18 @@ const PasswordForm = function (props: Props) {
             setLoading(false);
             focusPassword();
         }
-    }, [createErrorNotification, focusPassword, password, props, redirectionURL, translate, flowID, flow, subflow]);
+    }, [
+        createErrorNotification,
+        focusPassword,
+        password,
+        props,
+        redirectionURL,
+        requestMethod,
+        translate,
+        flowID,
+        flow,
+        subflow,
+    ]);
 
     const handlePasswordKeyDown = useCallback(
         (event: KeyboardEvent<HTMLInputElement>) => {
@@ -47,6 +47,10 @@ vi.mock("@services/UserInfo", () => ({
     setPreferred2FAMethod: vi.fn(),
 }));
 
+vi.mock("@services/TrustedDevices", () => ({
+    trustCurrentDevice: vi.fn(),
+}));
+
 vi.mock("@layouts/LoginLayout", () => ({
     default: (props: any) => <div data-testid="login-layout">{props.children}</div>,
 }));
@@ -113,3 +117,25 @@ it("does not render methods button when only one method available", () => {
     );
     expect(screen.queryByText("Methods")).not.toBeInTheDocument();
 });
+
+it("does not render the trust device checkbox when trusted devices are disabled", () => {
+    render(
+        <MemoryRouter>
+            <SecondFactorForm {...defaultProps} />
+        </MemoryRouter>,
+    );
+    expect(screen.queryByText("Remember this device")).not.toBeInTheDocument();
+});
+
+it("renders the trust device checkbox when trusted devices are enabled", () => {
+    const trustedDevicesProps = {
+        ...defaultProps,
+        configuration: { ...defaultProps.configuration, trusted_devices_enabled: true },
+    };
+    render(
+        <MemoryRouter>
+            <SecondFactorForm {...trustedDevicesProps} />
+        </MemoryRouter>,
+    );
+    expect(screen.getByText("Remember this device")).toBeInTheDocument();
+});
@@ -7,6 +7,8 @@ import { Route, Routes } from "react-router-dom";
 import LogoutButton from "@components/LogoutButton";
 import SwitchUserButton from "@components/SwitchUserButton";
 import { Button } from "@components/UI/Button";
+import { Checkbox } from "@components/UI/Checkbox";
+import { Label } from "@components/UI/Label";
 import {
     SecondFactorPasswordSubRoute,
     SecondFactorPushSubRoute,
@@ -24,6 +26,7 @@ import { Configuration } from "@models/Configuration";
 import { SecondFactorMethod } from "@models/Methods";
 import { UserInfo } from "@models/UserInfo";
 import { AuthenticationLevel } from "@services/State";
+import { trustCurrentDevice } from "@services/TrustedDevices";
 import { setPreferred2FAMethod } from "@services/UserInfo";
 import MethodSelectionDialog from "@views/LoginPortal/SecondFactor/MethodSelectionDialog";
 
@@ -52,6 +55,7 @@ const SecondFactorForm = function (props: Props) {
     const { createErrorNotification } = useNotifications();
 
     const [methodSelectionOpen, setMethodSelectionOpen] = useState(false);
+    const [trustDevice, setTrustDevice] = useState(false);
     const stateWebAuthnSupported = browserSupportsWebAuthn();
 
     const handleMethodSelectionClick = () => {
@@ -78,6 +82,23 @@ const SecondFactorForm = function (props: Props) {
         }
     };
 
+    const handleAuthenticationSuccess = (redirectURL: string | undefined) => {
+        if (!trustDevice) {
+            props.onAuthenticationSuccess(redirectURL);
+
+            return;
+        }
+
+        trustCurrentDevice()
+            .catch((err) => {
+                console.error(err);
+                createErrorNotification(translate("There was an issue trusting this device"));
+            })
+            .finally(() => props.onAuthenticationSuccess(redirectURL));
+    };
+
+    const showTrustDevice = props.configuration.trusted_devices_enabled;
+
     const showMethods = props.factorKnowledge && props.configuration.available_methods.size > 1;
 
     return (
@@ -121,7 +142,7 @@ const SecondFactorForm = function (props: Props) {
                                 <PasswordMethod
                                     id="password-method"
                                     authenticationLevel={props.authenticationLevel}
-                                    onAuthenticationSuccess={props.onAuthenticationSuccess}
+                                    onAuthenticationSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
@@ -137,7 +158,7 @@ const SecondFactorForm = function (props: Props) {
                                         navigate(`${SettingsRoute}${SettingsTwoFactorAuthenticationSubRoute}`);
                                     }}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
@@ -153,7 +174,7 @@ const SecondFactorForm = function (props: Props) {
                                         navigate(`${SettingsRoute}${SettingsTwoFactorAuthenticationSubRoute}`);
                                     }}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
@@ -167,12 +188,24 @@ const SecondFactorForm = function (props: Props) {
                                     registered={props.userInfo.has_duo}
                                     onSelectionClick={props.onMethodChanged}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
                     </Routes>
                 </div>
+                {showTrustDevice ? (
+                    <div className="flex items-center gap-2">
+                        <Checkbox
+                            id="trust-device-checkbox"
+                            checked={trustDevice}
+                            onCheckedChange={(checked) => setTrustDevice(checked === true)}
+                        />
+                        <Label htmlFor="trust-device-checkbox" className="text-base">
+                            {translate("Remember this device")}
+                        </Label>
+                    </div>
+                ) : null}
             </div>
         </LoginLayout>
     );
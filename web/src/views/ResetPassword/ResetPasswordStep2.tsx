@@ -126,6 +126,14 @@ const ResetPasswordStep2 = function () {
                 createErrorNotification(
                     translate("Your supplied password does not meet the password policy requirements"),
                 );
+            } else if ((err as Error).message.includes("data breach")) {
+                createErrorNotification(translate("Your supplied password has appeared in a data breach"));
+            } else if ((err as Error).message.includes("used recently")) {
+                createErrorNotification(translate("Your supplied password has been used recently"));
+            } else if ((err as Error).message.includes("too short")) {
+                createErrorNotification(translate("Your supplied password is too short"));
+            } else if ((err as Error).message.includes("too recently")) {
+                createErrorNotification(translate("Your password was changed too recently to be changed again"));
             } else {
                 createErrorNotification(translate("There was an issue resetting the password"));
             }
@@ -135,17 +135,35 @@ const ChangePasswordDialog = (props: Props) => {
             setSubmitting(false);
             if (axios.isAxiosError(err) && err.response) {
                 switch (err.response.status) {
-                    case 400: // Bad Request - Weak Password
+                    case 400: // Bad Request - Weak, Breached, or Reused Password
                         setNewPasswordError(true);
                         setRepeatNewPasswordError(true);
-                        createErrorNotification(
-                            translate("Your supplied password does not meet the password policy requirements"),
-                        );
+                        if (err.response.data?.message?.includes("used recently")) {
+                            createErrorNotification(translate("Your supplied password has been used recently"));
+                        } else if (err.response.data?.message?.includes("data breach")) {
+                            createErrorNotification(translate("Your supplied password has appeared in a data breach"));
+                        } else if (err.response.data?.message?.includes("too short")) {
+                            createErrorNotification(translate("Your supplied password is too short"));
+                        } else if (err.response.data?.message?.includes("too recently")) {
+                            setNewPasswordError(false);
+                            setRepeatNewPasswordError(false);
+                            createErrorNotification(
+                                translate("Your password was changed too recently to be changed again"),
+                            );
+                        } else {
+                            createErrorNotification(
+                                translate("Your supplied password does not meet the password policy requirements"),
+                            );
+                        }
                         break;
 
-                    case 401: // Unauthorized - Incorrect Password
+                    case 401: // Unauthorized - Incorrect or Expired Password
                         setOldPasswordError(true);
-                        createErrorNotification(translate("Incorrect password"));
+                        if (err.response.data?.message?.includes("has expired")) {
+                            createErrorNotification(translate("Your password has expired"));
+                        } else {
+                            createErrorNotification(translate("Incorrect password"));
+                        }
                         break;
 
                     case 500: // Internal Server Error
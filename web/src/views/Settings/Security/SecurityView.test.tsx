@@ -42,6 +42,10 @@ vi.mock("@views/Settings/Security/ChangePasswordDialog", () => ({
     default: () => <div data-testid="change-password-dialog" />,
 }));
 
+vi.mock("@views/Settings/Security/TrustedDevicesPanel", () => ({
+    default: () => <div data-testid="trusted-devices-panel" />,
+}));
+
 it("renders user info and change password button", () => {
     render(<SecurityView />);
     expect(screen.getByText(/John Doe/)).toBeInTheDocument();
@@ -54,3 +58,8 @@ it("renders dialogs", () => {
     expect(screen.getByTestId("second-factor-dialog")).toBeInTheDocument();
     expect(screen.getByTestId("change-password-dialog")).toBeInTheDocument();
 });
+
+it("does not render the trusted devices panel when trusted devices are disabled", () => {
+    render(<SecurityView />);
+    expect(screen.queryByTestId("trusted-devices-panel")).not.toBeInTheDocument();
+});
@@ -13,6 +13,7 @@ import { UserSessionElevation, getUserSessionElevation } from "@services/UserSes
 import IdentityVerificationDialog from "@views/Settings/Common/IdentityVerificationDialog";
 import SecondFactorDialog from "@views/Settings/Common/SecondFactorDialog";
 import ChangePasswordDialog from "@views/Settings/Security/ChangePasswordDialog";
+import TrustedDevicesPanel from "@views/Settings/Security/TrustedDevicesPanel";
 
 interface PasswordChangeButtonProps {
     configuration: Configuration | undefined;
@@ -234,6 +235,11 @@ const SettingsView = function () {
                                 handleChangePassword={handleChangePassword}
                             />
                         </div>
+                        {configuration?.trusted_devices_enabled ? (
+                            <div className="p-2 md:p-6">
+                                <TrustedDevicesPanel />
+                            </div>
+                        ) : null}
                     </div>
                 </Card>
             </div>
@@ -0,0 +1,99 @@
+import { fireEvent, render, screen, waitFor } from "@testing-library/react";
+
+import { getUserTrustedDevices, revokeUserTrustedDevice } from "@services/TrustedDevices";
+import TrustedDevicesPanel from "@views/Settings/Security/TrustedDevicesPanel";
+
+vi.mock("react-i18next", () => ({
+    useTranslation: () => ({ t: (key: string) => key }),
+}));
+
+vi.mock("@hooks/RelativeTimeString", () => ({
+    useRelativeTime: () => "2 days ago",
+}));
+
+const mockCreateErrorNotification = vi.fn();
+const mockCreateSuccessNotification = vi.fn();
+
+vi.mock("@contexts/NotificationsContext", () => ({
+    useNotifications: () => ({
+        createErrorNotification: mockCreateErrorNotification,
+        createSuccessNotification: mockCreateSuccessNotification,
+    }),
+}));
+
+vi.mock("@services/TrustedDevices", () => ({
+    getUserTrustedDevices: vi.fn(),
+    revokeUserTrustedDevice: vi.fn(),
+}));
+
+const devices = [
+    {
+        created: "2024-01-01T00:00:00Z",
+        current: true,
+        expires: "2024-02-01T00:00:00Z",
+        id: "a",
+        remote_ip: "127.0.0.1",
+        user_agent: "Test/1.0",
+    },
+    {
+        created: "2024-01-01T00:00:00Z",
+        current: false,
+        expires: "2024-02-01T00:00:00Z",
+        id: "b",
+        last_used: "2024-01-02T00:00:00Z",
+        remote_ip: "192.168.0.1",
+        user_agent: "Other/1.0",
+    },
+];
+
+beforeEach(() => {
+    vi.mocked(getUserTrustedDevices).mockReset();
+    vi.mocked(revokeUserTrustedDevice).mockReset();
+    mockCreateErrorNotification.mockReset();
+    mockCreateSuccessNotification.mockReset();
+});
+
+it("renders the trusted devices", async () => {
+    vi.mocked(getUserTrustedDevices).mockResolvedValue(devices);
+
+    render(<TrustedDevicesPanel />);
+
+    expect(await screen.findByText("Test/1.0")).toBeInTheDocument();
+    expect(screen.getByText("This device")).toBeInTheDocument();
+    expect(screen.getByText("Other/1.0")).toBeInTheDocument();
+    expect(screen.getByText("192.168.0.1")).toBeInTheDocument();
+});
+
+it("renders a message when there are no trusted devices", async () => {
+    vi.mocked(getUserTrustedDevices).mockResolvedValue([]);
+
+    render(<TrustedDevicesPanel />);
+
+    expect(await screen.findByText("There are no trusted devices")).toBeInTheDocument();
+});
+
+it("revokes a trusted device and refreshes the list", async () => {
+    vi.mocked(getUserTrustedDevices).mockResolvedValue(devices);
+    vi.mocked(revokeUserTrustedDevice).mockResolvedValue(undefined);
+
+    render(<TrustedDevicesPanel />);
+
+    fireEvent.click((await screen.findAllByRole("button", { name: "Revoke this device" }))[0]);
+
+    await waitFor(() => expect(revokeUserTrustedDevice).toHaveBeenCalledWith("a"));
+    await waitFor(() =>
+        expect(mockCreateSuccessNotification).toHaveBeenCalledWith("The trusted device has been revoked"),
+    );
+    await waitFor(() => expect(getUserTrustedDevices).toHaveBeenCalledTimes(2));
+});
+
+it("shows an error notification when retrieving the trusted devices fails", async () => {
+    vi.spyOn(console, "error").mockImplementation(() => {});
+    vi.mocked(getUserTrustedDevices).mockRejectedValue(new Error("failed"));
+
+    render(<TrustedDevicesPanel />);
+
+    await waitFor(() =>
+        expect(mockCreateErrorNotification).toHaveBeenCalledWith("There was an issue retrieving the trusted devices"),
+    );
+});
@@ -0,0 +1,64 @@
+import { useCallback, useEffect, useState } from "react";
+
+import { Monitor } from "lucide-react";
+import { useTranslation } from "react-i18next";
+
+import { useNotifications } from "@contexts/NotificationsContext";
+import { TrustedDevice, getUserTrustedDevices, revokeUserTrustedDevice } from "@services/TrustedDevices";
+import CredentialItem from "@views/Settings/TwoFactorAuthentication/CredentialItem";
+
+const TrustedDevicesPanel = function () {
+    const { t: translate } = useTranslation("settings");
+    const { createErrorNotification, createSuccessNotification } = useNotifications();
+
+    const [devices, setDevices] = useState<TrustedDevice[]>();
+
+    const handleRefresh = useCallback(() => {
+        getUserTrustedDevices()
+            .then(setDevices)
+            .catch((err) => {
+                console.error(err);
+                createErrorNotification(translate("There was an issue retrieving the trusted devices"));
+            });
+    }, [createErrorNotification, translate]);
+
+    const handleRevoke = (device: TrustedDevice) => {
+        revokeUserTrustedDevice(device.id)
+            .then(() => {
+                createSuccessNotification(translate("The trusted device has been revoked"));
+            })
+            .catch((err) => {
+                console.error(err);
+                createErrorNotification(translate("There was an issue revoking the trusted device"));
+            })
+            .finally(handleRefresh);
+    };
+
+    useEffect(() => {
+        handleRefresh();
+    }, [handleRefresh]);
+
+    return (
+        <div className="flex flex-col gap-2 w-full">
+            <h6 className="text-lg font-medium">{translate("Trusted Devices")}</h6>
+            {devices !== undefined && devices.length === 0 ? (
+                <p className="text-sm text-muted-foreground">{translate("There are no trusted devices")}</p>
+            ) : null}
+            {devices?.map((device, index) => (
+                <CredentialItem
+                    key={device.id}
+                    id={`trusted-device-${index}`}
+                    icon={<Monitor />}
+                    description={device.user_agent}
+                    qualifier={device.current ? translate("This device") : device.remote_ip}
+                    created_at={new Date(device.created)}
+                    last_used_at={device.last_used ? new Date(device.last_used) : undefined}
+                    tooltipDelete={translate("Revoke this device")}
+                    handleDelete={() => handleRevoke(device)}
+                />
+            ))}
+        </div>
+    );
+};
+
+export default TrustedDevicesPanel;